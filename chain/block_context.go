@@ -10,6 +10,13 @@ import (
 
 // newTestChainBlockContext obtains a new vm.BlockContext that is tailored to provide data from a TestChain.
 func newTestChainBlockContext(testChain *TestChain, header *types.Header) vm.BlockContext {
+	// The EVM determines whether the Merge has occurred by whether a PREVRANDAO value is provided. Before it,
+	// block.difficulty is provided instead.
+	var random *common.Hash
+	if !testChain.testChainConfig.IsPreMerge() {
+		random = &header.MixDigest
+	}
+
 	return vm.BlockContext{
 		CanTransfer: core.CanTransfer,
 		Transfer:    core.Transfer,
@@ -25,6 +32,6 @@ func newTestChainBlockContext(testChain *TestChain, header *types.Header) vm.Blo
 		Difficulty:  new(big.Int).Set(header.Difficulty),
 		BaseFee:     new(big.Int).Set(testChain.Head().Header.BaseFee),
 		GasLimit:    header.GasLimit,
-		Random:      &header.MixDigest,
+		Random:      random,
 	}
 }
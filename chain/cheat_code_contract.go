@@ -3,6 +3,7 @@ package chain
 import (
 	"encoding/binary"
 	"fmt"
+	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/logging"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,6 +28,10 @@ type CheatCodeContract struct {
 
 	// abi refers to the cheat code contract's ABI definition.
 	abi abi.ABI
+
+	// disabledMethods describes a table of methodId (function selectors) to the names of cheat code methods which were
+	// disabled. Calls to these methods revert with a message indicating they are disabled.
+	disabledMethods map[uint32]string
 }
 
 // cheatCodeMethod defines the method information for a given precompiledContract.
@@ -56,17 +61,27 @@ type cheatCodeRawReturnData struct {
 
 // getCheatCodeProviders obtains a cheatCodeTracer (used to power cheat code analysis) and associated CheatCodeContract
 // objects linked to the tracer (providing on-chain callable methods as an entry point). These objects are attached to
-// the TestChain to enable cheat code functionality.
-// Returns the tracer and associated pre-compile contracts, or an error, if one occurred.
-func getCheatCodeProviders() (*cheatCodeTracer, []*CheatCodeContract, error) {
+// the TestChain to enable cheat code functionality. The provided amount of mock ERC20 token pre-compiles are included.
+// Standard cheat code methods whose names are provided in disabledCheatcodes are not registered, and if callerAllowlist is
+// not empty, only the addresses it contains may invoke standard cheat code methods.
+// Returns the tracer and associated pre-compile contracts, or an error, if one occurred (e.g. a disabled cheat code name
+// did not match any standard cheat code method).
+func getCheatCodeProviders(mockERC20TokenCount int, disabledCheatcodes []string, callerAllowlist []common.Address) (*cheatCodeTracer, []*CheatCodeContract, error) {
 	// Create a cheat code tracer and attach it to the chain.
 	tracer := newCheatCodeTracer()
+	tracer.callerAllowlist = callerAllowlist
 
 	// Obtain our standard cheat code pre-compile
 	stdCheatCodeContract, err := getStandardCheatCodeContract(tracer)
 	if err != nil {
 		return nil, nil, err
 	}
+	for _, disabledCheatcode := range disabledCheatcodes {
+		err = stdCheatCodeContract.disableMethod(disabledCheatcode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid disabled cheat code in the chain configuration: %v", err)
+		}
+	}
 
 	// Obtain the console.log pre-compile
 	consoleCheatCodeContract, err := getConsoleLogCheatCodeContract(tracer)
@@ -74,18 +89,29 @@ func getCheatCodeProviders() (*cheatCodeTracer, []*CheatCodeContract, error) {
 		return nil, nil, err
 	}
 
+	// Obtain the mock ERC20 token pre-compiles
+	cheatCodeContracts := []*CheatCodeContract{stdCheatCodeContract, consoleCheatCodeContract}
+	for i := 0; i < mockERC20TokenCount; i++ {
+		mockERC20CheatCodeContract, err := getMockERC20CheatCodeContract(tracer, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		cheatCodeContracts = append(cheatCodeContracts, mockERC20CheatCodeContract)
+	}
+
 	// Return the tracer and precompiles
-	return tracer, []*CheatCodeContract{stdCheatCodeContract, consoleCheatCodeContract}, nil
+	return tracer, cheatCodeContracts, nil
 }
 
 // newCheatCodeContract returns a new precompiledContract which uses the attached cheatCodeTracer for execution
 // context.
 func newCheatCodeContract(tracer *cheatCodeTracer, address common.Address, name string) *CheatCodeContract {
 	return &CheatCodeContract{
-		name:       name,
-		address:    address,
-		tracer:     tracer,
-		methodInfo: make(map[uint32]*cheatCodeMethod),
+		name:            name,
+		address:         address,
+		tracer:          tracer,
+		methodInfo:      make(map[uint32]*cheatCodeMethod),
+		disabledMethods: make(map[uint32]string),
 		abi: abi.ABI{
 			Constructor: abi.Method{},
 			Methods:     make(map[string]abi.Method),
@@ -147,6 +173,26 @@ func (c *CheatCodeContract) addMethod(name string, inputs abi.Arguments, outputs
 	c.abi.Methods[method.Sig] = method
 }
 
+// disableMethod removes all methods with the provided name (including overloads) from the precompiled contract, such
+// that calling them reverts with a message indicating they are disabled.
+// Returns an error if the contract has no method with the provided name.
+func (c *CheatCodeContract) disableMethod(name string) error {
+	disabled := false
+	for methodId, methodInfo := range c.methodInfo {
+		if methodInfo.method.Name != name {
+			continue
+		}
+		delete(c.methodInfo, methodId)
+		delete(c.abi.Methods, methodInfo.method.Sig)
+		c.disabledMethods[methodId] = name
+		disabled = true
+	}
+	if !disabled {
+		return fmt.Errorf("%v has no cheat code named %q to disable", c.name, name)
+	}
+	return nil
+}
+
 // RequiredGas determines the amount of gas necessary to execute the pre-compile with the given input data.
 // Returns the gas cost.
 func (c *CheatCodeContract) RequiredGas(input []byte) uint64 {
@@ -164,6 +210,11 @@ func (c *CheatCodeContract) Run(input []byte) ([]byte, error) {
 	// Obtain the method identifier as an uint32
 	methodId := binary.LittleEndian.Uint32(input[:4])
 
+	// If the method was disabled, revert with a message indicating so.
+	if name, disabled := c.disabledMethods[methodId]; disabled {
+		return abiutils.EncodeSolidityRevertErrorString(fmt.Sprintf("%v is disabled in the chain configuration", name)), vm.ErrExecutionReverted
+	}
+
 	// Ensure we have a method definition that matches our selector.
 	methodInfo, methodInfoExists := c.methodInfo[methodId]
 	if !methodInfoExists || methodId != binary.LittleEndian.Uint32(methodInfo.method.ID) {
@@ -176,6 +227,15 @@ func (c *CheatCodeContract) Run(input []byte) ([]byte, error) {
 		return []byte{}, vm.ErrExecutionReverted
 	}
 
+	// Ensure the caller is permitted to invoke cheat codes, then record the cheat code usage, so it can be reported
+	// later. Console logging and mock tokens are not considered cheat codes.
+	if c.address == StandardCheatcodeContractAddress {
+		if caller := c.tracer.CurrentCallFrame().vmCaller; !c.tracer.isCallerAllowed(caller) {
+			return abiutils.EncodeSolidityRevertErrorString(fmt.Sprintf("cheat code caller %v is not in the caller allowlist", caller.Hex())), vm.ErrExecutionReverted
+		}
+		c.tracer.recordCheatCodeUsed(methodInfo.method.Name)
+	}
+
 	// Call the registered method handler.
 	outputValues, rawReturnData := methodInfo.handler(c.tracer, inputValues)
 
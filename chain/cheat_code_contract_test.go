@@ -0,0 +1,25 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheatCodeProvidersDisabledCheatcodes tests that standard cheat code methods can be disabled by name, including
+// all of their overloads, and that names which do not match any standard cheat code method are rejected.
+func TestCheatCodeProvidersDisabledCheatcodes(t *testing.T) {
+	// Disable a cheat code with overloads, and ensure none of them remain.
+	_, cheatCodeContracts, err := getCheatCodeProviders(0, []string{"prank"}, nil)
+	assert.NoError(t, err)
+	stdCheatCodeContract := cheatCodeContracts[0]
+	assert.Equal(t, StandardCheatcodeContractAddress, stdCheatCodeContract.Address())
+	for _, method := range stdCheatCodeContract.Abi().Methods {
+		assert.NotEqual(t, "prank", method.Name)
+	}
+	assert.Len(t, stdCheatCodeContract.disabledMethods, 4)
+
+	// Disabling a cheat code which does not exist is an error.
+	_, _, err = getCheatCodeProviders(0, []string{"prank", "notACheatcode"}, nil)
+	assert.ErrorContains(t, err, "notACheatcode")
+}
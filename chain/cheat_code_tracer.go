@@ -1,14 +1,19 @@
 package chain
 
 import (
+	"crypto/ecdsa"
 	"math/big"
+	"reflect"
 
 	"github.com/crytic/medusa/chain/types"
+	"github.com/crytic/medusa/utils/reflectionutils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/exp/slices"
 )
 
 // cheatCodeTracer represents an EVM.Logger which tracks and patches EVM execution state to enable extended
@@ -30,6 +35,51 @@ type cheatCodeTracer struct {
 	// results stores the tracer output after a transaction has concluded.
 	results *cheatCodeTracerResults
 
+	// namedSnapshots maps names provided to the saveState cheat code to the keys of stateSnapshots they refer to. As
+	// snapshots are only valid within the transaction that created them, this is reset at the start of each one.
+	namedSnapshots map[string]int
+
+	// stateSnapshots maps the ids returned by the snapshot cheat code (or taken by the saveState cheat code) to the
+	// state snapshot ids they currently refer to, for those which can still be reverted to. Snapshots are removed once
+	// they are deleted, or invalidated by a revert to an earlier snapshot or of the call frame which took them. This is
+	// reset at the start of each transaction.
+	stateSnapshots map[int]int
+
+	// storageWriteRecorders describes the active storage write recorders, which each map storage slots written since
+	// the recorder was added to the value the slot held before it was first written. Recorders are added and removed in
+	// call frame order, so the most recently added recorder is the first to be removed.
+	storageWriteRecorders []map[cheatCodeStorageSlot]common.Hash
+
+	// stateDiffRecorder describes the storage slots written since the startStateDiffRecording cheat code was invoked.
+	// This is nil if no state diff is being recorded. As recording is scoped to the transaction which started it, this
+	// is reset at the start of each one.
+	stateDiffRecorder *storageDiffRecorder
+
+	// mockedCalls maps addresses to the calls to them which were mocked by the mockCall cheat code, in the order they
+	// were mocked. As mocks are scoped to the transaction which created them, this is reset at the start of each one.
+	mockedCalls map[common.Address][]*cheatCodeMockedCall
+
+	// cooledAccounts maps addresses provided to the cool cheat code to the access list state they were reset to. As
+	// the access list is scoped to a transaction, this is reset at the start of each one.
+	cooledAccounts map[common.Address]*cheatCodeCooledAccount
+
+	// gasMeteringPaused indicates whether the pauseGasMetering cheat code paused gas metering, in which case each call
+	// frame's gas is held at the amount it had when it first executed while paused. This is reset at the start of each
+	// transaction.
+	gasMeteringPaused bool
+
+	// fileLineOffsets maps the resolved paths of files read by the readLine cheat code to the index of the next line to
+	// return from them. This is host state, so it persists across transactions until the file is written.
+	fileLineOffsets map[string]int
+
+	// rememberedKeys maps addresses to the private keys provided to the rememberKey cheat code for them. Keys are not
+	// chain state, so they persist across transactions.
+	rememberedKeys map[common.Address]*ecdsa.PrivateKey
+
+	// callerAllowlist describes the addresses which are permitted to invoke cheat codes. If empty, any address may
+	// invoke cheat codes.
+	callerAllowlist []common.Address
+
 	// nativeTracer is the underlying tracer interface that the cheatcode tracer follows
 	nativeTracer *TestChainTracer
 }
@@ -59,27 +109,139 @@ type cheatCodeTracerCallFrame struct {
 	// The hooks are executed as a stack (to support revert operations).
 	onChainRevertRestoreHooks types.GenericHookFuncs
 
+	// onNextOpcodeHooks describes hooks which will be executed the next time this call frame executes an instruction.
+	// This allows the result of a call this frame made to be patched once it has been pushed to the stack.
+	// The hooks are executed as a queue.
+	onNextOpcodeHooks types.GenericHookFuncs
+
+	// onNextLogHooks describes hooks which will be executed the next time this call frame emits a log itself. The
+	// emitted log is the last entry in vmLogs when they are executed.
+	// The hooks are executed as a queue.
+	onNextLogHooks types.GenericHookFuncs
+
+	// expectedEmits describes logs which an expectEmit cheat code invoked from this call frame expects the next call
+	// frame it enters to emit, in order.
+	expectedEmits []*cheatCodeExpectedEmit
+
+	// expectRevertPending indicates whether an expectRevert cheat code was invoked from this call frame, and is waiting
+	// for the next call frame it enters to exit.
+	expectRevertPending bool
+
+	// expectReturnPending indicates whether an expectReturn cheat code was invoked from this call frame, and is waiting
+	// for the next call frame it enters to exit.
+	expectReturnPending bool
+
+	// expectNonceIncreasePending indicates whether an expectNonceIncrease cheat code was invoked from this call frame,
+	// and is waiting for the next call frame it enters to exit.
+	expectNonceIncreasePending bool
+
+	// expectEmitSequencePending indicates whether an expectEmitSequence cheat code was invoked from this call frame, and
+	// is waiting for the next call frame it enters.
+	expectEmitSequencePending bool
+
+	// activePrank describes the prank started by a startPrank cheat code invoked from this call frame, which is applied
+	// to every call frame it enters until stopPrank is invoked or this call frame exits. This is nil if no prank is
+	// active.
+	activePrank *cheatCodePrank
+
+	// nextCallPrank describes the prank set by a prank cheat code invoked from this call frame, which is applied to the
+	// next call frame it enters. This is nil once it has been applied, or if no prank was set.
+	nextCallPrank *cheatCodePrank
+
+	// mockedReturnData describes the data the current call frame should return instead of executing its code, as it
+	// matched a mocked call. This is nil if the call frame was not mocked, or once its code has been replaced.
+	mockedReturnData []byte
+
+	// pausedGas describes the amount of gas this call frame is held at while gas metering is paused. This is nil if
+	// gas metering is not paused, or the call frame has not executed an instruction since it was paused.
+	pausedGas *uint64
+
+	// childGasUsed describes the amount of gas used by the last call frame this call frame entered, once it exited.
+	childGasUsed uint64
+
+	// vmAddress describes the address the current call frame was entered at (set on entry).
+	vmAddress common.Address
+	// vmCaller describes the address which entered the current call frame, i.e. its msg.sender (set on entry).
+	vmCaller common.Address
+	// vmCallType describes the type of call which entered the current call frame, e.g. a delegatecall (set on entry).
+	vmCallType vm.OpCode
 	// vmPc describes the current call frame's program counter.
 	vmPc uint64
 	// vmOp describes the current call frame's last instruction executed.
 	vmOp vm.OpCode
+	// vmCost describes the gas cost charged for the current call frame's last instruction executed.
+	vmCost uint64
 	// vmScope describes the current call frame's scope context.
 	vmScope tracing.OpContext
 	// vmReturnData describes the current call frame's return data (set on exit).
 	vmReturnData []byte
 	// vmErr describes the current call frame's returned error (set on exit), nil if no error.
 	vmErr error
+	// vmLogs describes the logs emitted by the current call frame, and by the call frames it entered which did not
+	// revert, in the order they were emitted.
+	vmLogs []*coretypes.Log
+}
+
+// cheatCodeStorageSlot describes a storage slot of a given account.
+type cheatCodeStorageSlot struct {
+	// account describes the address of the account which holds the storage slot.
+	account common.Address
+
+	// slot describes the key of the storage slot.
+	slot common.Hash
+}
+
+// cheatCodePrank describes the values a prank patches into the call frames it is applied to.
+type cheatCodePrank struct {
+	// sender describes the address to use as msg.sender.
+	sender common.Address
+
+	// origin describes the address to use as tx.origin, or nil if it should not be patched.
+	origin *common.Address
+
+	// skipDelegateCalls describes whether call frames entered with a delegatecall are skipped, in which case the prank
+	// is applied to the next call frame which was not.
+	skipDelegateCalls bool
+
+	// spoofDelegateCallContext describes whether call frames entered with a delegatecall execute in the context of the
+	// sender, as if the sender had made the delegatecall. The call frame's address (address(this)) is patched to the
+	// sender, so its storage is read from and written to the sender's account.
+	spoofDelegateCallContext bool
+}
+
+// appliesTo indicates whether the prank applies to the provided call frame. This is false if there is no prank, or it
+// skips delegatecalls and the call frame was entered with one.
+func (p *cheatCodePrank) appliesTo(prankCallFrame *cheatCodeTracerCallFrame) bool {
+	return p != nil && !(prankCallFrame.vmCallType == vm.DELEGATECALL && p.skipDelegateCalls)
+}
+
+// cheatCodeCooledAccount describes the access list state an account was reset to by the cool cheat code.
+type cheatCodeCooledAccount struct {
+	// accountCooled indicates whether the account was warm when it was cooled, and has not been accessed since.
+	accountCooled bool
+
+	// accessedSlots describes the storage slots of the account which were accessed since it was cooled.
+	accessedSlots map[common.Hash]struct{}
 }
 
 // cheatCodeTracerResults holds the hooks that need to be executed when the chain reverts.
 type cheatCodeTracerResults struct {
 	// onChainRevertHooks describes hooks which are to be executed when the chain reverts.
 	onChainRevertHooks types.GenericHookFuncs
+
+	// cheatCodesUsed describes the names of cheat code methods invoked during the transaction, without duplicates.
+	cheatCodesUsed []string
+
+	// assumptionFailed describes whether an assume cheat code was invoked with a false condition during the transaction.
+	assumptionFailed bool
 }
 
 // newCheatCodeTracer creates a cheatCodeTracer and returns it.
 func newCheatCodeTracer() *cheatCodeTracer {
-	tracer := &cheatCodeTracer{}
+	tracer := &cheatCodeTracer{
+		fileLineOffsets: make(map[string]int),
+		rememberedKeys:  make(map[common.Address]*ecdsa.PrivateKey),
+	}
 	innerTracer := &tracers.Tracer{
 		Hooks: &tracing.Hooks{
 			OnTxStart: tracer.OnTxStart,
@@ -106,6 +268,12 @@ func (t *cheatCodeTracer) bindToChain(chain *TestChain) {
 	t.chain = chain
 }
 
+// isCallerAllowed indicates whether the provided address is permitted to invoke cheat codes. Any address is permitted if
+// no caller allowlist was configured.
+func (t *cheatCodeTracer) isCallerAllowed(caller common.Address) bool {
+	return len(t.callerAllowlist) == 0 || slices.Contains(t.callerAllowlist, caller)
+}
+
 // PreviousCallFrame returns the previous call frame of the current EVM execution, or nil if there is no previous.
 func (t *cheatCodeTracer) PreviousCallFrame() *cheatCodeTracerCallFrame {
 	if len(t.callFrames) < 2 {
@@ -122,6 +290,154 @@ func (t *cheatCodeTracer) CurrentCallFrame() *cheatCodeTracerCallFrame {
 	return t.callFrames[t.callDepth]
 }
 
+// recordCheatCodeUsed records that the cheat code method with the provided name was invoked during the current
+// transaction.
+func (t *cheatCodeTracer) recordCheatCodeUsed(name string) {
+	// If we are not tracing a transaction or have already recorded this cheat code, there is nothing to do.
+	if t.results == nil || slices.Contains(t.results.cheatCodesUsed, name) {
+		return
+	}
+	t.results.cheatCodesUsed = append(t.results.cheatCodesUsed, name)
+}
+
+// recordStorageWrite records that the provided storage slot is about to be written, in every active storage write
+// recorder, as well as the active state diff recorder, which has not yet recorded a write to it.
+func (t *cheatCodeTracer) recordStorageWrite(account common.Address, slot common.Hash) {
+	storageSlot := cheatCodeStorageSlot{account: account, slot: slot}
+	for _, recorder := range t.storageWriteRecorders {
+		if _, recorded := recorder[storageSlot]; !recorded {
+			recorder[storageSlot] = t.chain.State().GetState(account, slot)
+		}
+	}
+	if t.stateDiffRecorder != nil {
+		t.stateDiffRecorder.recordWrite(t.chain.State(), account, slot)
+	}
+}
+
+// mockedReturnData obtains the data which a call with the provided parameters should return, if it matches a call
+// mocked by the mockCall cheat code. If several mocked calls match, the one with the longest calldata is used.
+// Returns the mocked return data, or nil if the call is not mocked.
+func (t *cheatCodeTracer) mockedReturnData(to common.Address, input []byte, value *big.Int) []byte {
+	var matchedMock *cheatCodeMockedCall
+	for _, mock := range t.mockedCalls[to] {
+		if mock.matches(input, value) && (matchedMock == nil || len(mock.calldata) >= len(matchedMock.calldata)) {
+			matchedMock = mock
+		}
+	}
+	if matchedMock == nil {
+		return nil
+	}
+	return matchedMock.returnData
+}
+
+// applyPrank patches the msg.sender of the provided call frame, and optionally the tx.origin, with the values of the
+// provided prank, adding hooks to restore the original values when the call frame is exited. If the call frame was
+// entered with a delegatecall and the prank spoofs delegatecall contexts, the call frame's address is patched as well.
+// The call frame must have executed an instruction, so that its scope is available.
+// Returns true if the prank was applied, or false if it was nil or does not apply to call frames entered like this one.
+func (t *cheatCodeTracer) applyPrank(prankCallFrame *cheatCodeTracerCallFrame, prank *cheatCodePrank) bool {
+	// If there is no prank, or it does not apply to this call frame, skip it.
+	if !prank.appliesTo(prankCallFrame) {
+		return false
+	}
+	isDelegateCall := prankCallFrame.vmCallType == vm.DELEGATECALL
+
+	// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+	scopeContext := prankCallFrame.vmScope.(*vm.ScopeContext)
+	originalSender := scopeContext.Caller()
+	scopeContext.Contract.CallerAddress = prank.sender
+	prankCallFrame.onFrameExitRestoreHooks.Push(func() {
+		scopeContext.Contract.CallerAddress = originalSender
+	})
+
+	// The address a contract executes as is unexported, so we patch it through reflection. Every instruction which
+	// reads the address or accesses storage obtains it from the contract, so this executes the delegated code in the
+	// context of the sender.
+	if isDelegateCall && prank.spoofDelegateCallContext {
+		selfField := reflect.ValueOf(scopeContext.Contract).Elem().FieldByName("self")
+		originalSelf := reflectionutils.GetField(selfField)
+		reflectionutils.SetField(selfField, vm.AccountRef(prank.sender))
+		prankCallFrame.onFrameExitRestoreHooks.Push(func() {
+			reflectionutils.SetField(selfField, originalSelf)
+		})
+	}
+
+	// The origin is shared by the whole transaction, so it is also observed by any call frames this one enters.
+	if prank.origin != nil {
+		txContext := t.chain.pendingTxContext
+		originalOrigin := txContext.Origin
+		txContext.Origin = *prank.origin
+		prankCallFrame.onFrameExitRestoreHooks.Push(func() {
+			txContext.Origin = originalOrigin
+		})
+	}
+	return true
+}
+
+// chargeCooledAccess charges the cold access surcharge for an instruction about to execute in the provided scope, if
+// it is the first to access an account or storage slot cooled by the cool cheat code since. The EVM already charged
+// the instruction the provided cost, treating anything in the access list as warm, so only the difference is charged.
+func (t *cheatCodeTracer) chargeCooledAccess(op vm.OpCode, cost uint64, scope tracing.OpContext) {
+	// Determine the account or storage slot the instruction accesses, along with the surcharge for a cold access.
+	stack := scope.StackData()
+	var account common.Address
+	var slot *common.Hash
+	var surcharge uint64
+	switch op {
+	case vm.BALANCE, vm.EXTCODESIZE, vm.EXTCODECOPY, vm.EXTCODEHASH:
+		account = stack[len(stack)-1].Bytes20()
+		surcharge = params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		account = stack[len(stack)-2].Bytes20()
+		surcharge = params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
+	case vm.SLOAD:
+		account = scope.Address()
+		slot = new(common.Hash)
+		*slot = stack[len(stack)-1].Bytes32()
+		// If the slot was not in the access list, the EVM already charged it as cold.
+		if cost == params.WarmStorageReadCostEIP2929 {
+			surcharge = params.ColdSloadCostEIP2929 - params.WarmStorageReadCostEIP2929
+		}
+	case vm.SSTORE:
+		account = scope.Address()
+		slot = new(common.Hash)
+		*slot = stack[len(stack)-1].Bytes32()
+		// If the slot was not in the access list, the EVM already charged it as cold, on top of the cost of the write.
+		if cost == params.WarmStorageReadCostEIP2929 || cost == params.SstoreResetGasEIP2200-params.ColdSloadCostEIP2929 ||
+			cost == params.SstoreSetGasEIP2200 {
+			surcharge = params.ColdSloadCostEIP2929
+		}
+	default:
+		return
+	}
+
+	// If the account was not cooled, or this access was already charged as cold, there is nothing to do.
+	cooledAccount, cooled := t.cooledAccounts[account]
+	if !cooled {
+		return
+	}
+	if slot == nil {
+		if !cooledAccount.accountCooled {
+			return
+		}
+		cooledAccount.accountCooled = false
+	} else {
+		if _, accessed := cooledAccount.accessedSlots[*slot]; accessed {
+			return
+		}
+		cooledAccount.accessedSlots[*slot] = struct{}{}
+	}
+
+	// Charge the surcharge to the call frame, leaving it without gas if it cannot afford it.
+	// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+	contract := scope.(*vm.ScopeContext).Contract
+	if contract.Gas < surcharge {
+		contract.Gas = 0
+	} else {
+		contract.Gas -= surcharge
+	}
+}
+
 // OnTxStart is called upon the start of transaction execution, as defined by tracers.Tracer.
 func (t *cheatCodeTracer) OnTxStart(vm *tracing.VMContext, tx *coretypes.Transaction, from common.Address) {
 	// Reset our capture state
@@ -129,7 +445,16 @@ func (t *cheatCodeTracer) OnTxStart(vm *tracing.VMContext, tx *coretypes.Transac
 	t.callFrames = make([]*cheatCodeTracerCallFrame, 0)
 	t.results = &cheatCodeTracerResults{
 		onChainRevertHooks: nil,
+		cheatCodesUsed:     nil,
 	}
+	t.namedSnapshots = make(map[string]int)
+	t.stateSnapshots = make(map[int]int)
+	t.storageWriteRecorders = nil
+	t.stateDiffRecorder = nil
+	t.mockedCalls = make(map[common.Address][]*cheatCodeMockedCall)
+	t.cooledAccounts = make(map[common.Address]*cheatCodeCooledAccount)
+	t.gasMeteringPaused = false
+
 	// Store our evm reference
 	t.evmContext = vm
 }
@@ -146,7 +471,12 @@ func (t *cheatCodeTracer) OnEnter(depth int, typ byte, from common.Address, to c
 	var callFrameData *cheatCodeTracerCallFrame
 	if isTopLevelFrame {
 		// Create our call frame struct to track data for this initial entry call frame.
-		callFrameData = &cheatCodeTracerCallFrame{}
+		callFrameData = &cheatCodeTracerCallFrame{
+			mockedReturnData: t.mockedReturnData(to, input, value),
+			vmAddress:        to,
+			vmCaller:         from,
+			vmCallType:       vm.OpCode(typ),
+		}
 	} else {
 		// We haven't updated our call depth yet, so obtain the "previous" call frame (current for now)
 		previousCallFrame := t.CurrentCallFrame()
@@ -155,6 +485,10 @@ func (t *cheatCodeTracer) OnEnter(depth int, typ byte, from common.Address, to c
 		// We forward our "next frame hooks" to this frame, then clear them from the previous frame.
 		callFrameData = &cheatCodeTracerCallFrame{
 			onFrameExitRestoreHooks: previousCallFrame.onNextFrameExitRestoreHooks,
+			mockedReturnData:        t.mockedReturnData(to, input, value),
+			vmAddress:               to,
+			vmCaller:                from,
+			vmCallType:              vm.OpCode(typ),
 		}
 		previousCallFrame.onNextFrameExitRestoreHooks = nil
 
@@ -171,8 +505,10 @@ func (t *cheatCodeTracer) OnEnter(depth int, typ byte, from common.Address, to c
 
 // OnExit is called after a call to finalize tracing completes for the top of a call frame, as defined by tracers.Tracer.
 func (t *cheatCodeTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
-	// Execute all current call frame exit hooks
+	// Set the exiting call frame's results, so they are available to exit hooks, then execute them
 	exitingCallFrame := t.callFrames[t.callDepth]
+	exitingCallFrame.vmReturnData = output
+	exitingCallFrame.vmErr = err
 	exitingCallFrame.onFrameExitRestoreHooks.Execute(false, true)
 
 	var parentCallFrame *cheatCodeTracerCallFrame
@@ -180,8 +516,9 @@ func (t *cheatCodeTracer) OnExit(depth int, output []byte, gasUsed uint64, err e
 		// If this is the top-level call frame, execute all of its exit hooks
 		exitingCallFrame.onTopFrameExitRestoreHooks.Execute(false, true)
 	} else {
-		// If not, retrieve the parent call frame
+		// If not, retrieve the parent call frame and record the gas this call frame used
 		parentCallFrame = t.callFrames[t.callDepth-1]
+		parentCallFrame.childGasUsed = gasUsed
 	}
 
 	// We're exiting the current frame, so remove our frame data.
@@ -193,9 +530,10 @@ func (t *cheatCodeTracer) OnExit(depth int, output []byte, gasUsed uint64, err e
 		t.results.onChainRevertHooks = append(t.results.onChainRevertHooks, exitingCallFrame.onChainRevertRestoreHooks...)
 		return
 	} else if err == nil {
-		// Propagate hooks up to the parent call frame
+		// Propagate hooks and emitted logs up to the parent call frame
 		parentCallFrame.onTopFrameExitRestoreHooks = append(parentCallFrame.onTopFrameExitRestoreHooks, exitingCallFrame.onTopFrameExitRestoreHooks...)
 		parentCallFrame.onChainRevertRestoreHooks = append(parentCallFrame.onChainRevertRestoreHooks, exitingCallFrame.onChainRevertRestoreHooks...)
+		parentCallFrame.vmLogs = append(parentCallFrame.vmLogs, exitingCallFrame.vmLogs...)
 	} else {
 		// We hit an error, so a revert occurred before this tx was committed.
 		exitingCallFrame.onChainRevertRestoreHooks.Execute(false, true)
@@ -209,15 +547,93 @@ func (t *cheatCodeTracer) OnExit(depth int, output []byte, gasUsed uint64, err e
 func (t *cheatCodeTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
 	// Set our current frame information.
 	currentCallFrame := t.CurrentCallFrame()
+	firstInstruction := currentCallFrame.vmScope == nil
 	currentCallFrame.vmPc = pc
 	currentCallFrame.vmOp = vm.OpCode(op)
+	currentCallFrame.vmCost = cost
 	currentCallFrame.vmScope = scope
 	currentCallFrame.vmReturnData = rData
 	currentCallFrame.vmErr = err
 
+	// If this call frame was mocked, replace its code so it returns the mocked data.
+	if currentCallFrame.mockedReturnData != nil {
+		// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+		scope.(*vm.ScopeContext).Contract.Code = mockedCallCode(currentCallFrame.mockedReturnData)
+		currentCallFrame.mockedReturnData = nil
+	}
+
+	// Execute any hooks waiting for this call frame to execute its next instruction.
+	currentCallFrame.onNextOpcodeHooks.Execute(true, true)
+
+	// If this instruction accesses an account or storage slot cooled by the cool cheat code, charge it as cold.
+	if err == nil && len(t.cooledAccounts) > 0 {
+		t.chargeCooledAccess(vm.OpCode(op), cost, scope)
+	}
+
+	// If gas metering is paused, hold this call frame at the gas it had when it first executed while paused, which
+	// refunds the cost of this instruction.
+	if t.gasMeteringPaused {
+		if currentCallFrame.pausedGas == nil {
+			currentCallFrame.pausedGas = &gas
+		}
+		// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+		scope.(*vm.ScopeContext).Contract.Gas = *currentCallFrame.pausedGas
+	}
+
 	// We execute our entered next frame hooks here (from our previous call frame), as we now have scope information.
+	// If the previous call frame started a prank or set one for its next call, we apply them first, the latter taking
+	// precedence.
 	if t.callDepth > 0 {
-		t.callFrames[t.callDepth-1].onNextFrameEnterHooks.Execute(true, true)
+		previousCallFrame := t.callFrames[t.callDepth-1]
+		if firstInstruction {
+			t.applyPrank(currentCallFrame, previousCallFrame.activePrank)
+			if t.applyPrank(currentCallFrame, previousCallFrame.nextCallPrank) {
+				previousCallFrame.nextCallPrank = nil
+			}
+		}
+		previousCallFrame.onNextFrameEnterHooks.Execute(true, true)
+	}
+
+	// If this instruction writes to storage, record the value it overwrites for any active storage write recorders.
+	if err == nil && op == byte(vm.SSTORE) && (len(t.storageWriteRecorders) > 0 || t.stateDiffRecorder != nil) {
+		stack := scope.StackData()
+		t.recordStorageWrite(scope.Address(), stack[len(stack)-1].Bytes32())
+	}
+
+	// If this instruction emits a log, capture it from the stack and memory. We only record it once the next
+	// instruction executes, as the log operation may still fail.
+	if err == nil && op >= byte(vm.LOG0) && op <= byte(vm.LOG4) {
+		emittedLog := captureLog(scope, int(op-byte(vm.LOG0)))
+		currentCallFrame.onNextOpcodeHooks.Push(func() {
+			currentCallFrame.vmLogs = append(currentCallFrame.vmLogs, emittedLog)
+			currentCallFrame.onNextLogHooks.Execute(true, true)
+		})
+	}
+}
+
+// captureLog constructs the log emitted by a LOG instruction with the provided topic count, which is about to execute
+// in the provided scope. Memory which has not yet been expanded for the instruction reads as zero.
+// Returns the log which the instruction emits.
+func captureLog(scope tracing.OpContext, topicCount int) *coretypes.Log {
+	// Obtain the memory offset and size of the log data, followed by its topics, from the top of the stack.
+	stack := scope.StackData()
+	offset := stack[len(stack)-1].Uint64()
+	size := stack[len(stack)-2].Uint64()
+	topics := make([]common.Hash, topicCount)
+	for i := 0; i < topicCount; i++ {
+		topics[i] = stack[len(stack)-3-i].Bytes32()
+	}
+
+	// Copy the log data from memory.
+	data := make([]byte, size)
+	memory := scope.MemoryData()
+	if offset < uint64(len(memory)) {
+		copy(data, memory[offset:])
+	}
+	return &coretypes.Log{
+		Address: scope.Address(),
+		Topics:  topics,
+		Data:    data,
 	}
 }
 
@@ -227,4 +643,10 @@ func (t *cheatCodeTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope t
 func (t *cheatCodeTracer) CaptureTxEndSetAdditionalResults(results *types.MessageResults) {
 	// Add our revert operations we collected for this transaction.
 	results.OnRevertHookFuncs = append(results.OnRevertHookFuncs, t.results.onChainRevertHooks...)
+
+	// Add the cheat codes which were used in this transaction.
+	results.CheatCodesUsed = append(results.CheatCodesUsed, t.results.cheatCodesUsed...)
+
+	// Signal whether an assumption failed, so the transaction is discarded.
+	results.AssumptionFailed = results.AssumptionFailed || t.results.assumptionFailed
 }
@@ -5,8 +5,21 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 )
 
+// The hardforks which a TestChainConfig may select, in the order they were activated.
+const (
+	ForkLondon   = "london"
+	ForkParis    = "paris"
+	ForkShanghai = "shanghai"
+	ForkCancun   = "cancun"
+)
+
 // TestChainConfig represents the chain configuration.
 type TestChainConfig struct {
+	// Fork describes the hardfork whose rules the chain should follow. Forks preceding Paris (the Merge) use pre-Merge
+	// semantics, where block.difficulty is provided in place of block.prevrandao. If empty, the latest supported fork
+	// is used.
+	Fork string `json:"fork"`
+
 	// CodeSizeCheckDisabled indicates whether code size checks should be disabled in the EVM. This allows for code
 	// size to be disabled without disabling the entire EIP it was introduced.
 	CodeSizeCheckDisabled bool `json:"codeSizeCheckDisabled"`
@@ -17,6 +30,10 @@ type TestChainConfig struct {
 	// SkipAccountChecks skips account pre-checks like nonce validation and disallowing non-EOA tx senders (this is done in eth_call, for instance).
 	SkipAccountChecks bool `json:"skipAccountChecks"`
 
+	// MockERC20TokenCount describes the amount of mock ERC20 tokens that should be pre-deployed on the chain. The
+	// tokens are implemented as pre-compiles and require cheat codes to be enabled.
+	MockERC20TokenCount int `json:"mockERC20TokenCount"`
+
 	// ContractAddressOverrides describes contracts that are going to be deployed at deterministic addresses
 	ContractAddressOverrides map[common.Hash]common.Address `json:"contractAddressOverrides,omitempty"`
 }
@@ -29,6 +46,31 @@ type CheatCodeConfig struct {
 	// EnableFFI describes whether the FFI cheat code should be enabled. Enablement allows for arbitrary code execution
 	// on the tester's machine
 	EnableFFI bool `json:"enableFFI"`
+
+	// EnableEnvAccess describes whether the env cheat codes should be enabled. Enablement allows tests to read
+	// environment variables from the tester's machine
+	EnableEnvAccess bool `json:"enableEnvAccess"`
+
+	// EnableFileAccess describes whether the file cheat codes should be enabled. Enablement allows tests to read and
+	// write files on the tester's machine, within FileAccessRoot
+	EnableFileAccess bool `json:"enableFileAccess"`
+
+	// FileAccessRoot describes the directory which the file cheat codes are restricted to. Relative paths provided to
+	// them are resolved against it. If empty, the working directory is used.
+	FileAccessRoot string `json:"fileAccessRoot"`
+
+	// DisabledCheatcodes describes the names of standard cheat code methods (e.g. "etch") which should not be
+	// available. Calling a disabled cheat code reverts.
+	DisabledCheatcodes []string `json:"disabledCheatcodes"`
+
+	// CallerAllowlist describes the addresses which are permitted to invoke cheat codes. Invocations originating from
+	// any other address revert. If empty, any address may invoke cheat codes.
+	CallerAllowlist []string `json:"callerAllowlist"`
+}
+
+// IsPreMerge indicates whether the configured fork precedes Paris (the Merge).
+func (t *TestChainConfig) IsPreMerge() bool {
+	return t.Fork == ForkLondon
 }
 
 // GetVMConfigExtensions derives a vm.ConfigExtensions from the provided TestChainConfig.
@@ -5,12 +5,19 @@ package config
 func DefaultTestChainConfig() (*TestChainConfig, error) {
 	// Create a default config and return it.
 	config := &TestChainConfig{
+		Fork:                  ForkCancun,
 		CodeSizeCheckDisabled: true,
 		CheatCodeConfig: CheatCodeConfig{
-			CheatCodesEnabled: true,
-			EnableFFI:         false,
+			CheatCodesEnabled:  true,
+			EnableFFI:          false,
+			EnableEnvAccess:    false,
+			EnableFileAccess:   false,
+			FileAccessRoot:     "",
+			DisabledCheatcodes: []string{},
+			CallerAllowlist:    []string{},
 		},
-		SkipAccountChecks: true,
+		SkipAccountChecks:   true,
+		MockERC20TokenCount: 0,
 	}
 
 	// Return the generated configuration.
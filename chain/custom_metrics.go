@@ -0,0 +1,61 @@
+package chain
+
+import (
+	"math/big"
+	"sync"
+
+	"golang.org/x/exp/maps"
+)
+
+// CustomMetrics describes named counters and gauges recorded by contracts through the incrementCounter and setGauge
+// cheat codes. It is safe for concurrent use, so a single instance can aggregate the values recorded across chains.
+type CustomMetrics struct {
+	// counters maps counter names to the amount of times they were incremented.
+	counters map[string]uint64
+
+	// gauges maps gauge names to the value they were last set to.
+	gauges map[string]*big.Int
+
+	// lock provides thread synchronization to prevent concurrent access errors.
+	lock sync.Mutex
+}
+
+// NewCustomMetrics creates an empty CustomMetrics and returns it.
+func NewCustomMetrics() *CustomMetrics {
+	return &CustomMetrics{
+		counters: make(map[string]uint64),
+		gauges:   make(map[string]*big.Int),
+	}
+}
+
+// IncrementCounter increments the counter with the provided name, creating it if it does not exist.
+func (m *CustomMetrics) IncrementCounter(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.counters[name]++
+}
+
+// SetGauge sets the gauge with the provided name to the provided value, creating it if it does not exist.
+func (m *CustomMetrics) SetGauge(name string, value *big.Int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.gauges[name] = new(big.Int).Set(value)
+}
+
+// Counters returns a copy of the counters recorded so far, mapping their names to their values.
+func (m *CustomMetrics) Counters() map[string]uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return maps.Clone(m.counters)
+}
+
+// Gauges returns a copy of the gauges recorded so far, mapping their names to their values.
+func (m *CustomMetrics) Gauges() map[string]*big.Int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	gauges := make(map[string]*big.Int, len(m.gauges))
+	for name, value := range m.gauges {
+		gauges[name] = new(big.Int).Set(value)
+	}
+	return gauges
+}
@@ -0,0 +1,270 @@
+package chain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MockERC20BaseAddress is the address of the first mock ERC20 token contract. Each additional mock token is installed
+// at the subsequent address.
+var MockERC20BaseAddress = common.HexToAddress("0x00000000000000000000000000000000000E2C20")
+
+// The following storage slots mirror the layout of a simple Solidity ERC20 implementation, so mock token state can
+// be inspected with the standard `load` cheat code:
+//   - slot 0: totalSupply
+//   - slot 1: mapping(address => uint256) balances
+//   - slot 2: mapping(address => mapping(address => uint256)) allowances
+var (
+	mockERC20TotalSupplySlot = common.BigToHash(big.NewInt(0))
+	mockERC20BalancesSlot    = common.BigToHash(big.NewInt(1))
+	mockERC20AllowancesSlot  = common.BigToHash(big.NewInt(2))
+)
+
+// MockERC20Address obtains the address of the mock ERC20 token contract with the provided index.
+func MockERC20Address(index int) common.Address {
+	return common.BigToAddress(new(big.Int).Add(MockERC20BaseAddress.Big(), big.NewInt(int64(index))))
+}
+
+// isMockERC20Address indicates whether the provided address is that of one of the provided number of mock ERC20 token
+// contracts.
+func isMockERC20Address(address common.Address, tokenCount int) bool {
+	index := new(big.Int).Sub(address.Big(), MockERC20BaseAddress.Big())
+	return index.Sign() >= 0 && index.Cmp(big.NewInt(int64(tokenCount))) < 0
+}
+
+// mockERC20BalanceSlot obtains the storage slot holding the balance of the provided account.
+func mockERC20BalanceSlot(account common.Address) common.Hash {
+	return crypto.Keccak256Hash(common.LeftPadBytes(account.Bytes(), 32), mockERC20BalancesSlot.Bytes())
+}
+
+// mockERC20AllowanceSlot obtains the storage slot holding the amount the provided spender may spend on behalf of the
+// provided owner.
+func mockERC20AllowanceSlot(owner common.Address, spender common.Address) common.Hash {
+	ownerSlot := crypto.Keccak256Hash(common.LeftPadBytes(owner.Bytes(), 32), mockERC20AllowancesSlot.Bytes())
+	return crypto.Keccak256Hash(common.LeftPadBytes(spender.Bytes(), 32), ownerSlot.Bytes())
+}
+
+// getMockERC20CheatCodeContract obtains a CheatCodeContract which implements a standard mock ERC20 token, with
+// additional unrestricted mint and burn methods so balances can be set by a test harness.
+// Returns the precompiled contract, or an error if one occurs.
+func getMockERC20CheatCodeContract(tracer *cheatCodeTracer, index int) (*CheatCodeContract, error) {
+	// Create a new precompile to add methods to.
+	address := MockERC20Address(index)
+	contract := newCheatCodeContract(tracer, address, fmt.Sprintf("MockERC20_%d", index))
+	name := fmt.Sprintf("Mock Token %d", index)
+	symbol := fmt.Sprintf("MOCK%d", index)
+
+	// Define some basic ABI argument types
+	typeAddress, err := abi.NewType("address", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	typeUint8, err := abi.NewType("uint8", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	typeUint256, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	typeString, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	typeBool, err := abi.NewType("bool", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Define our event signatures for token transfers and approvals.
+	transferEventId := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalEventId := crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+	// Define helpers to read and write token state.
+	getValue := func(tracer *cheatCodeTracer, slot common.Hash) *big.Int {
+		return tracer.chain.State().GetState(address, slot).Big()
+	}
+	setValue := func(tracer *cheatCodeTracer, slot common.Hash, value *big.Int) {
+		tracer.chain.State().SetState(address, slot, common.BigToHash(value))
+	}
+	emitLog := func(tracer *cheatCodeTracer, eventId common.Hash, from common.Address, to common.Address, value *big.Int) {
+		tracer.chain.State().AddLog(&coretypes.Log{
+			Address: address,
+			Topics:  []common.Hash{eventId, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    common.LeftPadBytes(value.Bytes(), 32),
+		})
+	}
+
+	// Define a helper to obtain the caller of the token. As the token is a pre-compile, it never executes an instruction
+	// for pranks to be applied on, so we apply any prank started by the call frame which called it here, with a prank
+	// set for its next call taking precedence and being consumed by this call.
+	getCaller := func(tracer *cheatCodeTracer) common.Address {
+		tokenCallFrame := tracer.CurrentCallFrame()
+		callerFrame := tracer.PreviousCallFrame()
+		if callerFrame == nil {
+			return tokenCallFrame.vmCaller
+		}
+		if prank := callerFrame.nextCallPrank; prank.appliesTo(tokenCallFrame) {
+			callerFrame.nextCallPrank = nil
+			return prank.sender
+		}
+		if prank := callerFrame.activePrank; prank.appliesTo(tokenCallFrame) {
+			return prank.sender
+		}
+		return tokenCallFrame.vmCaller
+	}
+
+	// Define a helper to move balances between accounts.
+	transfer := func(tracer *cheatCodeTracer, from common.Address, to common.Address, value *big.Int) *cheatCodeRawReturnData {
+		fromBalance := getValue(tracer, mockERC20BalanceSlot(from))
+		if fromBalance.Cmp(value) < 0 {
+			return cheatCodeRevertData([]byte("ERC20: transfer amount exceeds balance"))
+		}
+		setValue(tracer, mockERC20BalanceSlot(from), new(big.Int).Sub(fromBalance, value))
+		setValue(tracer, mockERC20BalanceSlot(to), new(big.Int).Add(getValue(tracer, mockERC20BalanceSlot(to)), value))
+		emitLog(tracer, transferEventId, from, to, value)
+		return nil
+	}
+
+	// name: Returns the name of the token.
+	contract.addMethod(
+		"name", abi.Arguments{}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return []any{name}, nil
+		},
+	)
+
+	// symbol: Returns the symbol of the token.
+	contract.addMethod(
+		"symbol", abi.Arguments{}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return []any{symbol}, nil
+		},
+	)
+
+	// decimals: Returns the number of decimals used by the token.
+	contract.addMethod(
+		"decimals", abi.Arguments{}, abi.Arguments{{Type: typeUint8}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return []any{uint8(18)}, nil
+		},
+	)
+
+	// totalSupply: Returns the amount of tokens in existence.
+	contract.addMethod(
+		"totalSupply", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return []any{getValue(tracer, mockERC20TotalSupplySlot)}, nil
+		},
+	)
+
+	// balanceOf: Returns the amount of tokens owned by an account.
+	contract.addMethod(
+		"balanceOf", abi.Arguments{{Type: typeAddress}}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			account := inputs[0].(common.Address)
+			return []any{getValue(tracer, mockERC20BalanceSlot(account))}, nil
+		},
+	)
+
+	// allowance: Returns the amount of tokens a spender may spend on behalf of an owner.
+	contract.addMethod(
+		"allowance", abi.Arguments{{Type: typeAddress}, {Type: typeAddress}}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			owner := inputs[0].(common.Address)
+			spender := inputs[1].(common.Address)
+			return []any{getValue(tracer, mockERC20AllowanceSlot(owner, spender))}, nil
+		},
+	)
+
+	// transfer: Moves tokens from the caller to the recipient.
+	contract.addMethod(
+		"transfer", abi.Arguments{{Type: typeAddress}, {Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			caller := getCaller(tracer)
+			if rawReturnData := transfer(tracer, caller, inputs[0].(common.Address), inputs[1].(*big.Int)); rawReturnData != nil {
+				return nil, rawReturnData
+			}
+			return []any{true}, nil
+		},
+	)
+
+	// approve: Sets the amount of tokens a spender may spend on behalf of the caller.
+	contract.addMethod(
+		"approve", abi.Arguments{{Type: typeAddress}, {Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			caller := getCaller(tracer)
+			spender := inputs[0].(common.Address)
+			value := inputs[1].(*big.Int)
+			setValue(tracer, mockERC20AllowanceSlot(caller, spender), value)
+			emitLog(tracer, approvalEventId, caller, spender, value)
+			return []any{true}, nil
+		},
+	)
+
+	// transferFrom: Moves tokens from an owner to a recipient using the caller's allowance.
+	contract.addMethod(
+		"transferFrom", abi.Arguments{{Type: typeAddress}, {Type: typeAddress}, {Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			caller := getCaller(tracer)
+			from := inputs[0].(common.Address)
+			to := inputs[1].(common.Address)
+			value := inputs[2].(*big.Int)
+
+			// Spend the caller's allowance. A maximum allowance is treated as infinite and is not decreased.
+			allowance := getValue(tracer, mockERC20AllowanceSlot(from, caller))
+			if allowance.Cmp(abi.MaxUint256) != 0 {
+				if allowance.Cmp(value) < 0 {
+					return nil, cheatCodeRevertData([]byte("ERC20: insufficient allowance"))
+				}
+				setValue(tracer, mockERC20AllowanceSlot(from, caller), new(big.Int).Sub(allowance, value))
+			}
+			if rawReturnData := transfer(tracer, from, to, value); rawReturnData != nil {
+				return nil, rawReturnData
+			}
+			return []any{true}, nil
+		},
+	)
+
+	// mint: Creates tokens and assigns them to an account. This is unrestricted so harnesses can fuzz balances.
+	contract.addMethod(
+		"mint", abi.Arguments{{Type: typeAddress}, {Type: typeUint256}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			to := inputs[0].(common.Address)
+			value := inputs[1].(*big.Int)
+			totalSupply := new(big.Int).Add(getValue(tracer, mockERC20TotalSupplySlot), value)
+			if totalSupply.Cmp(abi.MaxUint256) > 0 {
+				return nil, cheatCodeRevertData([]byte("ERC20: total supply overflow"))
+			}
+			setValue(tracer, mockERC20TotalSupplySlot, totalSupply)
+			setValue(tracer, mockERC20BalanceSlot(to), new(big.Int).Add(getValue(tracer, mockERC20BalanceSlot(to)), value))
+			emitLog(tracer, transferEventId, common.Address{}, to, value)
+			return nil, nil
+		},
+	)
+
+	// burn: Destroys tokens owned by an account. This is unrestricted so harnesses can fuzz balances.
+	contract.addMethod(
+		"burn", abi.Arguments{{Type: typeAddress}, {Type: typeUint256}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			from := inputs[0].(common.Address)
+			value := inputs[1].(*big.Int)
+			balance := getValue(tracer, mockERC20BalanceSlot(from))
+			if balance.Cmp(value) < 0 {
+				return nil, cheatCodeRevertData([]byte("ERC20: burn amount exceeds balance"))
+			}
+			setValue(tracer, mockERC20BalanceSlot(from), new(big.Int).Sub(balance, value))
+			setValue(tracer, mockERC20TotalSupplySlot, new(big.Int).Sub(getValue(tracer, mockERC20TotalSupplySlot), value))
+			emitLog(tracer, transferEventId, from, common.Address{}, value)
+			return nil, nil
+		},
+	)
+
+	// Return our precompile contract information.
+	return contract, nil
+}
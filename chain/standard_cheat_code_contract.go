@@ -1,21 +1,32 @@
 package chain
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/crytic/medusa/chain/config"
+	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/utils"
+	"github.com/crytic/medusa/utils/reflectionutils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
+	"golang.org/x/exp/slices"
 )
 
 // StandardCheatcodeContractAddress is the address for the standard cheatcode contract
@@ -35,10 +46,18 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 	if err != nil {
 		return nil, err
 	}
+	typeAddressSlice, err := abi.NewType("address[]", "", nil)
+	if err != nil {
+		return nil, err
+	}
 	typeBytes, err := abi.NewType("bytes", "", nil)
 	if err != nil {
 		return nil, err
 	}
+	typeBytes4, err := abi.NewType("bytes4", "", nil)
+	if err != nil {
+		return nil, err
+	}
 	typeBytes32, err := abi.NewType("bytes32", "", nil)
 	if err != nil {
 		return nil, err
@@ -47,6 +66,10 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 	if err != nil {
 		return nil, err
 	}
+	typeUint32, err := abi.NewType("uint32", "", nil)
+	if err != nil {
+		return nil, err
+	}
 	typeUint64, err := abi.NewType("uint64", "", nil)
 	if err != nil {
 		return nil, err
@@ -71,6 +94,15 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 	if err != nil {
 		return nil, err
 	}
+	typeStorageDiffSlice, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
+		{Name: "account", Type: "address"},
+		{Name: "slot", Type: "bytes32"},
+		{Name: "previousValue", Type: "bytes32"},
+		{Name: "newValue", Type: "bytes32"},
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Warp: Sets VM timestamp. Note that this _permanently_ updates the block timestamp for the remainder of the
 	// chain's lifecycle.
@@ -142,6 +174,24 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// GetBlockNumber: Gets the block number of the block currently being executed.
+	contract.addMethod(
+		"getBlockNumber", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			blockNumber := new(big.Int).Set(tracer.chain.pendingBlockContext.BlockNumber)
+			return []any{blockNumber}, nil
+		},
+	)
+
+	// GetBlockTimestamp: Gets the timestamp of the block currently being executed.
+	contract.addMethod(
+		"getBlockTimestamp", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			blockTimestamp := new(big.Int).SetUint64(tracer.chain.pendingBlockContext.Time)
+			return []any{blockTimestamp}, nil
+		},
+	)
+
 	// Fee: Update the base bee. Note that this _permanently_ updates the base fee for the remainder of the
 	// chain's lifecycle
 	contract.addMethod(
@@ -172,13 +222,24 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
-	// Difficulty: Updates difficulty
-	// TODO: Make changes to difficulty permanent and make it revert for post-Paris EVM versions
+	// Difficulty: Updates difficulty, or prevrandao for post-Merge forks
+	// TODO: Make changes to difficulty permanent
 	contract.addMethod(
 		"difficulty", abi.Arguments{{Type: typeUint256}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
 			// Maintain our changes until the transaction exits.
 			spoofedDifficulty := inputs[0].(*big.Int)
+
+			// Before the Merge, block.difficulty uses opDifficulty, which reads the difficulty directly.
+			if tracer.chain.testChainConfig.IsPreMerge() {
+				originalDifficulty := tracer.chain.pendingBlockContext.Difficulty
+				tracer.chain.pendingBlockContext.Difficulty = new(big.Int).Set(spoofedDifficulty)
+				tracer.CurrentCallFrame().onTopFrameExitRestoreHooks.Push(func() {
+					tracer.chain.pendingBlockContext.Difficulty = originalDifficulty
+				})
+				return nil, nil
+			}
+
 			spoofedDifficultyHash := common.BigToHash(spoofedDifficulty)
 			originalRandom := tracer.chain.pendingBlockContext.Random
 
@@ -238,6 +299,15 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// GetChainId: Gets VM chain ID
+	contract.addMethod(
+		"getChainId", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			chainId := new(big.Int).Set(tracer.chain.pendingBlockChainConfig.ChainID)
+			return []any{chainId}, nil
+		},
+	)
+
 	// Store: Sets a storage slot value in a given account.
 	contract.addMethod(
 		"store", abi.Arguments{{Type: typeAddress}, {Type: typeBytes32}, {Type: typeBytes32}}, abi.Arguments{},
@@ -245,11 +315,36 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 			account := inputs[0].(common.Address)
 			slot := inputs[1].([32]byte)
 			value := inputs[2].([32]byte)
+			tracer.recordStorageWrite(account, slot)
 			tracer.chain.State().SetState(account, slot, value)
 			return nil, nil
 		},
 	)
 
+	// StartStateDiffRecording: Begins recording the storage slots written from now on, until the end of the current
+	// transaction or until stopAndReturnStateDiff is called.
+	contract.addMethod(
+		"startStateDiffRecording", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			tracer.stateDiffRecorder = newStorageDiffRecorder()
+			return nil, nil
+		},
+	)
+
+	// StopAndReturnStateDiff: Stops recording storage slot writes and returns each storage slot whose value changed
+	// since startStateDiffRecording was called, along with its previous and new values, in the order first written.
+	contract.addMethod(
+		"stopAndReturnStateDiff", abi.Arguments{}, abi.Arguments{{Type: typeStorageDiffSlice}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			recorder := tracer.stateDiffRecorder
+			if recorder == nil {
+				return nil, cheatCodeRevertData([]byte("stopAndReturnStateDiff: state diff recording was not started"))
+			}
+			tracer.stateDiffRecorder = nil
+			return []any{recorder.storageDiffs(tracer.chain.State())}, nil
+		},
+	)
+
 	// Load: Loads a storage slot value from a given account.
 	contract.addMethod(
 		"load", abi.Arguments{{Type: typeAddress}, {Type: typeBytes32}}, abi.Arguments{{Type: typeBytes32}},
@@ -261,6 +356,30 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// StoreTransient: Sets a transient storage slot value in a given account. Transient storage changes are journaled,
+	// so they are reverted if the caller reverts, and cleared at the end of the transaction.
+	contract.addMethod(
+		"storeTransient", abi.Arguments{{Type: typeAddress}, {Type: typeBytes32}, {Type: typeBytes32}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			account := inputs[0].(common.Address)
+			slot := inputs[1].([32]byte)
+			value := inputs[2].([32]byte)
+			tracer.chain.State().SetTransientState(account, slot, value)
+			return nil, nil
+		},
+	)
+
+	// LoadTransient: Loads a transient storage slot value from a given account.
+	contract.addMethod(
+		"loadTransient", abi.Arguments{{Type: typeAddress}, {Type: typeBytes32}}, abi.Arguments{{Type: typeBytes32}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			account := inputs[0].(common.Address)
+			slot := inputs[1].([32]byte)
+			value := tracer.chain.State().GetTransientState(account, slot)
+			return []any{value}, nil
+		},
+	)
+
 	// Etch: Sets the code for a given account.
 	contract.addMethod(
 		"etch", abi.Arguments{{Type: typeAddress}, {Type: typeBytes}}, abi.Arguments{},
@@ -272,6 +391,20 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// SetCodeMarker: Sets minimal non-empty code for a given account, so it is treated as a contract by code size checks.
+	// Accounts which already have code are left unchanged.
+	contract.addMethod(
+		"setCodeMarker", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// We use the same single byte of code that cheat code contracts are deployed with.
+			account := inputs[0].(common.Address)
+			if tracer.chain.State().GetCodeSize(account) == 0 {
+				tracer.chain.State().SetCode(account, []byte{0xFF})
+			}
+			return nil, nil
+		},
+	)
+
 	// Deal: Sets the balance for a given account.
 	contract.addMethod(
 		"deal", abi.Arguments{{Type: typeAddress}, {Type: typeUint256}}, abi.Arguments{},
@@ -295,6 +428,40 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// GetBlockCount: Gets the number of blocks which have been committed to the chain.
+	contract.addMethod(
+		"getBlockCount", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			blockCount := new(big.Int).SetInt64(int64(len(tracer.chain.CommittedBlocks())))
+			return []any{blockCount}, nil
+		},
+	)
+
+	// GetDeployedAddress: Gets the address a contract with the provided name was deployed to while setting up the chain.
+	contract.addMethod(
+		"getDeployedAddress", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeAddress}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			name := inputs[0].(string)
+			address, ok := tracer.chain.DeployedContractAddresses[name]
+			if !ok {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("getDeployedAddress: no contract named %q was deployed", name)))
+			}
+			return []any{address}, nil
+		},
+	)
+
+	// GetMockERC20Tokens: Gets the addresses of the mock ERC20 tokens pre-deployed on the chain.
+	contract.addMethod(
+		"getMockERC20Tokens", abi.Arguments{}, abi.Arguments{{Type: typeAddressSlice}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			tokens := make([]common.Address, tracer.chain.testChainConfig.MockERC20TokenCount)
+			for i := 0; i < len(tokens); i++ {
+				tokens[i] = MockERC20Address(i)
+			}
+			return []any{tokens}, nil
+		},
+	)
+
 	// SetNonce: Sets the nonce for a given account.
 	contract.addMethod(
 		"setNonce", abi.Arguments{{Type: typeAddress}, {Type: typeUint64}}, abi.Arguments{},
@@ -310,232 +477,890 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 	contract.addMethod(
 		"prank", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			// Obtain the caller frame. This is a pre-compile, so we want to add an event to the frame which called us,
-			// so when it enters the next frame in its scope, we trigger the prank.
-			cheatCodeCallerFrame := tracer.PreviousCallFrame()
-			cheatCodeCallerFrame.onNextFrameEnterHooks.Push(func() {
-				// We entered the scope we want to prank, store the original value, patch, and add a hook to restore it
-				// when this frame is exited.
-				prankCallFrame := tracer.CurrentCallFrame()
-				// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
-				scopeContext := prankCallFrame.vmScope.(*vm.ScopeContext)
-				original := scopeContext.Caller()
-				scopeContext.Contract.CallerAddress = inputs[0].(common.Address)
-				prankCallFrame.onFrameExitRestoreHooks.Push(func() {
-					scopeContext.Contract.CallerAddress = original
-				})
-			})
+			tracer.PreviousCallFrame().nextCallPrank = newCheatCodePrank(inputs[0], nil, nil)
 			return nil, nil
 		},
 	)
 
-	// PrankHere: Sets the msg.sender within caller EVM scope until it is exited.
+	// Prank: Sets the msg.sender within the next EVM call scope created by the caller. If the delegateCall flag is set,
+	// a delegatecall executes in the context of the sender, otherwise delegatecalls are skipped.
 	contract.addMethod(
-		"prankHere", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
+		"prank", abi.Arguments{{Type: typeAddress}, {Type: typeBool}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			// Obtain the caller frame. This is a pre-compile, so we want to add an event to the frame which called us,
-			// to disable the cheat code on exit
-			cheatCodeCallerFrame := tracer.PreviousCallFrame()
-
-			// Store the original value, patch, and add a hook to restore it when this frame is exited.
-			// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
-			scopeContext := cheatCodeCallerFrame.vmScope.(*vm.ScopeContext)
-			original := scopeContext.Caller()
-			scopeContext.Contract.CallerAddress = inputs[0].(common.Address)
-			cheatCodeCallerFrame.onFrameExitRestoreHooks.Push(func() {
-				scopeContext.Contract.CallerAddress = original
-			})
+			tracer.PreviousCallFrame().nextCallPrank = newCheatCodePrank(inputs[0], nil, inputs[1])
 			return nil, nil
 		},
 	)
 
-	// snapshot: Takes a snapshot of the current state of the evm and returns the id associated with the snapshot
+	// Prank: Sets the msg.sender and tx.origin within the next EVM call scope created by the caller.
 	contract.addMethod(
-		"snapshot", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		"prank", abi.Arguments{{Type: typeAddress}, {Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			snapshotID := tracer.chain.State().Snapshot()
-
-			return []any{snapshotID}, nil
+			tracer.PreviousCallFrame().nextCallPrank = newCheatCodePrank(inputs[0], inputs[1], nil)
+			return nil, nil
 		},
 	)
 
-	// revertTo(uint256): Revert the state of the evm to a previous snapshot. Takes the snapshot id to revert to.
+	// Prank: Sets the msg.sender and tx.origin within the next EVM call scope created by the caller. If the
+	// delegateCall flag is set, a delegatecall executes in the context of the sender, otherwise delegatecalls are
+	// skipped.
 	contract.addMethod(
-		"revertTo", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		"prank", abi.Arguments{{Type: typeAddress}, {Type: typeAddress}, {Type: typeBool}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			snapshotID := inputs[0].(*big.Int)
-			tracer.chain.State().RevertToSnapshot(int(snapshotID.Int64()))
-
-			return []any{true}, nil
+			tracer.PreviousCallFrame().nextCallPrank = newCheatCodePrank(inputs[0], inputs[1], inputs[2])
+			return nil, nil
 		},
 	)
 
-	// FFI: Run arbitrary command on base OS
+	// StartPrank: Sets the msg.sender within every EVM call scope created by the caller, until stopPrank is called or
+	// the caller's scope is exited.
 	contract.addMethod(
-		"ffi", abi.Arguments{{Type: typeStringSlice}}, abi.Arguments{{Type: typeBytes}},
+		"startPrank", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			// Ensure FFI is enabled (this allows arbitrary code execution, so we expect it to be explicitly enabled).
-			if !tracer.chain.testChainConfig.CheatCodeConfig.EnableFFI {
-				// Make sure there is at least a command to run
-				return nil, cheatCodeRevertData([]byte("ffi is not enabled in the chain configuration"))
-			}
-
-			// command is cmdAndInputs[0] and args are cmdAndInputs[1:]
-			cmdAndInputs := inputs[0].([]string)
-
-			var command string
-			var args []string
-
-			if len(cmdAndInputs) < 1 {
-				// Make sure there is at least a command to run
-				return nil, cheatCodeRevertData([]byte("ffi: no command was provided"))
-			} else if len(cmdAndInputs) == 1 {
-				// It is possible there are no arguments provided
-				command = cmdAndInputs[0]
-			} else {
-				// Both a command and arguments have been provided
-				command = cmdAndInputs[0]
-				args = cmdAndInputs[1:]
-			}
-
-			// Create our command
-			cmd := exec.Command(command, args...)
-
-			// Execute it and grab the output
-			stdout, _, combined, err := utils.RunCommandWithOutputAndError(cmd)
-			if err != nil {
-				errorMsg := fmt.Sprintf("ffi: cmd failed with the following error: %v\nOutput: %v", err, string(combined))
-				return nil, cheatCodeRevertData([]byte(errorMsg))
-			}
-
-			// Attempt to hex decode the output
-			hexOut, err := hex.DecodeString(strings.TrimPrefix(string(stdout), "0x"))
-			if err != nil {
-				// Return the byte array as itself if hex decoding does not work
-				return []any{stdout}, nil
-			}
-
-			// Hex decoding worked, so return that
-			return []any{hexOut}, nil
+			tracer.PreviousCallFrame().activePrank = newCheatCodePrank(inputs[0], nil, nil)
+			return nil, nil
 		},
 	)
 
-	// addr: Compute the address for a given private key
-	contract.addMethod("addr", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeAddress}},
+	// StartPrank: Sets the msg.sender within every EVM call scope created by the caller, until stopPrank is called or
+	// the caller's scope is exited. If the delegateCall flag is set, delegatecalls execute in the context of the sender,
+	// otherwise they are skipped.
+	contract.addMethod(
+		"startPrank", abi.Arguments{{Type: typeAddress}, {Type: typeBool}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			// Get the private key object
-			privateKey, err := utils.GetPrivateKey(inputs[0].(*big.Int).Bytes())
-			if err != nil {
-				errorMessage := "addr: " + err.Error()
-				return nil, cheatCodeRevertData([]byte(errorMessage))
-			}
-
-			// Get ECDSA public key
-			publicKey := privateKey.Public().(*ecdsa.PublicKey)
-
-			// Get associated address
-			addr := crypto.PubkeyToAddress(*publicKey)
-
-			return []any{addr}, nil
+			tracer.PreviousCallFrame().activePrank = newCheatCodePrank(inputs[0], nil, inputs[1])
+			return nil, nil
 		},
 	)
 
-	// sign: Sign a digest given some private key
-	contract.addMethod("sign", abi.Arguments{{Type: typeUint256}, {Type: typeBytes32}},
-		abi.Arguments{{Type: typeUint8}, {Type: typeBytes32}, {Type: typeBytes32}},
+	// StartPrank: Sets the msg.sender and tx.origin within every EVM call scope created by the caller, until stopPrank
+	// is called or the caller's scope is exited.
+	contract.addMethod(
+		"startPrank", abi.Arguments{{Type: typeAddress}, {Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			// Get the private key object
-			privateKey, err := utils.GetPrivateKey(inputs[0].(*big.Int).Bytes())
-			if err != nil {
-				errorMessage := "sign: " + err.Error()
-				return nil, cheatCodeRevertData([]byte(errorMessage))
-			}
-
-			// Sign digest
-			digest := inputs[1].([32]byte)
-			sig, err := crypto.Sign(digest[:], privateKey)
-			if err != nil {
-				return nil, cheatCodeRevertData([]byte("sign: malformed input to signature algorithm"))
-			}
-
-			// `r` and `s` have to be [32]byte arrays
-			var r [32]byte
-			var s [32]byte
-			copy(r[:], sig[:32])
-			copy(s[:], sig[32:64])
-
-			// Need to add 27 to the `v` value for ecrecover to work
-			v := sig[64] + 27
-
-			return []any{v, r, s}, nil
+			tracer.PreviousCallFrame().activePrank = newCheatCodePrank(inputs[0], inputs[1], nil)
+			return nil, nil
 		},
 	)
 
-	// toString(address): Convert address to string
-	contract.addMethod("toString", abi.Arguments{{Type: typeAddress}}, abi.Arguments{{Type: typeString}},
+	// StartPrank: Sets the msg.sender and tx.origin within every EVM call scope created by the caller, until stopPrank
+	// is called or the caller's scope is exited. If the delegateCall flag is set, delegatecalls execute in the context
+	// of the sender, otherwise they are skipped.
+	contract.addMethod(
+		"startPrank", abi.Arguments{{Type: typeAddress}, {Type: typeAddress}, {Type: typeBool}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			addr := inputs[0].(common.Address)
-			return []any{addr.String()}, nil
+			tracer.PreviousCallFrame().activePrank = newCheatCodePrank(inputs[0], inputs[1], inputs[2])
+			return nil, nil
 		},
 	)
 
-	// toString(bool): Convert bool to string
-	contract.addMethod("toString", abi.Arguments{{Type: typeBool}}, abi.Arguments{{Type: typeString}},
+	// StopPrank: Stops the prank started by startPrank in the caller EVM scope.
+	contract.addMethod(
+		"stopPrank", abi.Arguments{}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			b := inputs[0].(bool)
-			return []any{strconv.FormatBool(b)}, nil
+			tracer.PreviousCallFrame().activePrank = nil
+			return nil, nil
 		},
 	)
 
-	// toString(uint256): Convert uint256 to string
-	contract.addMethod("toString", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeString}},
+	// Broadcast: Provided for compatibility with scripts, where it broadcasts the next call. No transaction is
+	// broadcast here, so this does nothing.
+	contract.addMethod(
+		"broadcast", abi.Arguments{}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			n := inputs[0].(*big.Int)
-			return []any{n.String()}, nil
+			return nil, nil
 		},
 	)
 
-	// toString(int256): Convert int256 to string
-	contract.addMethod("toString", abi.Arguments{{Type: typeInt256}}, abi.Arguments{{Type: typeString}},
+	// Broadcast: Provided for compatibility with scripts. No transaction is broadcast, but the msg.sender and tx.origin
+	// within the next EVM call scope created by the caller are set to the broadcasting address.
+	contract.addMethod(
+		"broadcast", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			n := inputs[0].(*big.Int)
-			return []any{n.String()}, nil
+			tracer.PreviousCallFrame().nextCallPrank = newCheatCodePrank(inputs[0], inputs[0], nil)
+			return nil, nil
 		},
 	)
 
-	// toString(bytes32): Convert bytes32 to string
-	contract.addMethod("toString", abi.Arguments{{Type: typeBytes32}}, abi.Arguments{{Type: typeString}},
+	// StartBroadcast: Provided for compatibility with scripts, where it broadcasts every subsequent call. No
+	// transaction is broadcast here, so this does nothing.
+	contract.addMethod(
+		"startBroadcast", abi.Arguments{}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			b := inputs[0].([32]byte)
-			// Prefix "0x"
-			hexString := "0x" + hex.EncodeToString(b[:])
-
-			return []any{hexString}, nil
+			return nil, nil
 		},
 	)
 
-	// toString(bytes): Convert bytes to string
-	contract.addMethod("toString", abi.Arguments{{Type: typeBytes}}, abi.Arguments{{Type: typeString}},
+	// StartBroadcast: Provided for compatibility with scripts. No transaction is broadcast, but the msg.sender and
+	// tx.origin within every EVM call scope created by the caller are set to the broadcasting address, until
+	// stopBroadcast is called or the caller's scope is exited.
+	contract.addMethod(
+		"startBroadcast", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			b := inputs[0].([]byte)
-			// Prefix "0x"
-			hexString := "0x" + hex.EncodeToString(b)
-
-			return []any{hexString}, nil
+			tracer.PreviousCallFrame().activePrank = newCheatCodePrank(inputs[0], inputs[0], nil)
+			return nil, nil
 		},
 	)
 
-	// parseBytes: Convert string to bytes
-	contract.addMethod("parseBytes", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeBytes}},
+	// StopBroadcast: Provided for compatibility with scripts. Stops the broadcast started by startBroadcast in the
+	// caller EVM scope.
+	contract.addMethod(
+		"stopBroadcast", abi.Arguments{}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			return []any{[]byte(inputs[0].(string))}, nil
+			tracer.PreviousCallFrame().activePrank = nil
+			return nil, nil
 		},
 	)
 
-	// parseBytes32: Convert string to bytes32
-	contract.addMethod("parseBytes32", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeBytes32}},
+	// PrankHere: Sets the msg.sender within caller EVM scope until it is exited.
+	contract.addMethod(
+		"prankHere", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
-			bSlice := []byte(inputs[0].(string))
+			// Obtain the caller frame. This is a pre-compile, so we want to add an event to the frame which called us,
+			// to disable the cheat code on exit
+			cheatCodeCallerFrame := tracer.PreviousCallFrame()
+
+			// Store the original value, patch, and add a hook to restore it when this frame is exited.
+			// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+			scopeContext := cheatCodeCallerFrame.vmScope.(*vm.ScopeContext)
+			original := scopeContext.Caller()
+			scopeContext.Contract.CallerAddress = inputs[0].(common.Address)
+			cheatCodeCallerFrame.onFrameExitRestoreHooks.Push(func() {
+				scopeContext.Contract.CallerAddress = original
+			})
+			return nil, nil
+		},
+	)
+
+	// ExpectRevert: Expects the next call made by the caller EVM scope to revert, suppressing the revert if it does.
+	contract.addMethod(
+		"expectRevert", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return nil, expectRevertOnNextCall(tracer, nil)
+		},
+	)
+
+	// ExpectRevert: Expects the next call made by the caller EVM scope to revert with the provided error selector.
+	contract.addMethod(
+		"expectRevert", abi.Arguments{{Type: typeBytes4}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			selector := inputs[0].([4]byte)
+			return nil, expectRevertOnNextCall(tracer, func(revertData []byte) bool {
+				return len(revertData) >= len(selector) && bytes.Equal(revertData[:len(selector)], selector[:])
+			})
+		},
+	)
+
+	// ExpectRevert: Expects the next call made by the caller EVM scope to revert with the provided revert data. A
+	// revert reason string (encoded as Error(string)) is also matched against the provided data.
+	contract.addMethod(
+		"expectRevert", abi.Arguments{{Type: typeBytes}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			expectedRevertData := inputs[0].([]byte)
+			return nil, expectRevertOnNextCall(tracer, func(revertData []byte) bool {
+				if bytes.Equal(revertData, expectedRevertData) {
+					return true
+				}
+				revertReason := abiutils.GetSolidityRevertErrorString(vm.ErrExecutionReverted, revertData)
+				return revertReason != nil && *revertReason == string(expectedRevertData)
+			})
+		},
+	)
+
+	// ExpectReturn: Expects the next call made by the caller EVM scope to succeed and return the provided data.
+	contract.addMethod(
+		"expectReturn", abi.Arguments{{Type: typeBytes}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return nil, expectReturnOnNextCall(tracer, inputs[0].([]byte))
+		},
+	)
+
+	// ExpectNonceIncrease: Expects the nonce of the provided account to have increased once the next call made by the
+	// caller EVM scope exits.
+	contract.addMethod(
+		"expectNonceIncrease", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return nil, expectNonceIncreaseOnNextCall(tracer, inputs[0].(common.Address))
+		},
+	)
+
+	// ExpectEmit: Expects the next call made by the caller EVM scope to emit the next log the caller emits, checking
+	// all topics and data.
+	contract.addMethod(
+		"expectEmit", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return nil, expectEmitOnNextCall(tracer, &cheatCodeExpectedEmit{
+				checkTopics: [3]bool{true, true, true},
+				checkData:   true,
+			})
+		},
+	)
+
+	// ExpectEmit: Expects the next call made by the caller EVM scope to emit the next log the caller emits, from the
+	// provided emitter address, checking all topics and data.
+	contract.addMethod(
+		"expectEmit", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			emitter := inputs[0].(common.Address)
+			return nil, expectEmitOnNextCall(tracer, &cheatCodeExpectedEmit{
+				checkTopics: [3]bool{true, true, true},
+				checkData:   true,
+				emitter:     &emitter,
+			})
+		},
+	)
+
+	// ExpectEmit: Expects the next call made by the caller EVM scope to emit the next log the caller emits, checking
+	// only the provided topics and data.
+	contract.addMethod(
+		"expectEmit", abi.Arguments{{Type: typeBool}, {Type: typeBool}, {Type: typeBool}, {Type: typeBool}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return nil, expectEmitOnNextCall(tracer, &cheatCodeExpectedEmit{
+				checkTopics: [3]bool{inputs[0].(bool), inputs[1].(bool), inputs[2].(bool)},
+				checkData:   inputs[3].(bool),
+			})
+		},
+	)
+
+	// ExpectEmit: Expects the next call made by the caller EVM scope to emit the next log the caller emits, from the
+	// provided emitter address, checking only the provided topics and data.
+	contract.addMethod(
+		"expectEmit", abi.Arguments{{Type: typeBool}, {Type: typeBool}, {Type: typeBool}, {Type: typeBool}, {Type: typeAddress}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			emitter := inputs[4].(common.Address)
+			return nil, expectEmitOnNextCall(tracer, &cheatCodeExpectedEmit{
+				checkTopics: [3]bool{inputs[0].(bool), inputs[1].(bool), inputs[2].(bool)},
+				checkData:   inputs[3].(bool),
+				emitter:     &emitter,
+			})
+		},
+	)
+
+	// ExpectEmitSequence: Expects the next call made by the caller EVM scope to emit exactly the logs the caller emits
+	// before making it, in the same order, checking all topics and data.
+	contract.addMethod(
+		"expectEmitSequence", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return nil, expectEmitSequenceOnNextCall(tracer)
+		},
+	)
+
+	// MockCall: Mocks calls to the provided address whose calldata begins with the provided calldata, returning the
+	// provided data instead of executing its code for the remainder of the transaction.
+	contract.addMethod(
+		"mockCall", abi.Arguments{{Type: typeAddress}, {Type: typeBytes}, {Type: typeBytes}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			mockCall(tracer, inputs[0].(common.Address), &cheatCodeMockedCall{
+				calldata:   inputs[1].([]byte),
+				returnData: append([]byte{}, inputs[2].([]byte)...),
+			})
+			return nil, nil
+		},
+	)
+
+	// MockCall: Mocks calls to the provided address with the provided value, whose calldata begins with the provided
+	// calldata, returning the provided data instead of executing its code for the remainder of the transaction.
+	contract.addMethod(
+		"mockCall", abi.Arguments{{Type: typeAddress}, {Type: typeUint256}, {Type: typeBytes}, {Type: typeBytes}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			mockCall(tracer, inputs[0].(common.Address), &cheatCodeMockedCall{
+				value:      inputs[1].(*big.Int),
+				calldata:   inputs[2].([]byte),
+				returnData: append([]byte{}, inputs[3].([]byte)...),
+			})
+			return nil, nil
+		},
+	)
+
+	// ClearMockedCalls: Clears all calls mocked by the mockCall cheat code.
+	contract.addMethod(
+		"clearMockedCalls", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// If the transaction reverts, we will restore the original mocked calls
+			originalMockedCalls := tracer.mockedCalls
+			tracer.mockedCalls = make(map[common.Address][]*cheatCodeMockedCall)
+			tracer.CurrentCallFrame().onChainRevertRestoreHooks.Push(func() {
+				tracer.mockedCalls = originalMockedCalls
+			})
+			return nil, nil
+		},
+	)
+
+	// AssertEq, AssertGt, AssertLt, AssertGe, AssertLe: Compare two values, failing the caller EVM scope in the same way
+	// as a failed assert if the comparison does not hold. Equality can be asserted for any supported type, while the
+	// ordering comparisons are only supported for integers.
+	for _, argType := range []abi.Type{typeUint256, typeInt256, typeAddress, typeBool, typeBytes32} {
+		addAssertionMethod(contract, "assertEq", argType, "!=", func(c int) bool { return c == 0 })
+	}
+	for _, argType := range []abi.Type{typeUint256, typeInt256} {
+		addAssertionMethod(contract, "assertGt", argType, "<=", func(c int) bool { return c > 0 })
+		addAssertionMethod(contract, "assertLt", argType, ">=", func(c int) bool { return c < 0 })
+		addAssertionMethod(contract, "assertGe", argType, "<", func(c int) bool { return c >= 0 })
+		addAssertionMethod(contract, "assertLe", argType, ">", func(c int) bool { return c <= 0 })
+	}
+
+	// AssertTrue: Fails the caller EVM scope in the same way as a failed assert if the provided condition is false.
+	contract.addMethod(
+		"assertTrue", abi.Arguments{{Type: typeBool}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			if inputs[0].(bool) {
+				return nil, nil
+			}
+			return nil, failAssertion(tracer, "assertTrue failed")
+		},
+	)
+
+	// Assume: Discards the current transaction if the provided condition is false, reverting the caller EVM scope.
+	contract.addMethod(
+		"assume", abi.Arguments{{Type: typeBool}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			if inputs[0].(bool) {
+				return nil, nil
+			}
+			if tracer.results != nil {
+				tracer.results.assumptionFailed = true
+			}
+			return nil, cheatCodeRevertData([]byte("assume: assumption was not met"))
+		},
+	)
+
+	// Skip: Reverts with revert data marking the test being executed as skipped, if the provided condition is true.
+	contract.addMethod(
+		"skip", abi.Arguments{{Type: typeBool}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			if !inputs[0].(bool) {
+				return nil, nil
+			}
+			return nil, cheatCodeRevertData(skipRevertData)
+		},
+	)
+
+	// AssertReversible: Asserts that the next call made by the caller EVM scope invokes the provided selector, and that
+	// reverting to a snapshot taken before it restores the state it observed.
+	contract.addMethod(
+		"assertReversible", abi.Arguments{{Type: typeBytes4}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			assertReversibleOnNextCall(tracer, inputs[0].([4]byte))
+			return nil, nil
+		},
+	)
+
+	// SetNextCallGas: Sets the exact amount of gas the next call made by the caller EVM scope is provided.
+	contract.addMethod(
+		"setNextCallGas", abi.Arguments{{Type: typeUint256}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// Obtain the gas amount, ensuring it fits within the EVM's gas representation.
+			gasAmount := inputs[0].(*big.Int)
+			if !gasAmount.IsUint64() {
+				return nil, cheatCodeRevertData([]byte("setNextCallGas: gas amount exceeds the maximum of uint64"))
+			}
+			callGas := gasAmount.Uint64()
+
+			// Obtain the caller frame and add an event to it, so when it enters the next frame in its scope, we patch
+			// the gas that frame was provided.
+			cheatCodeCallerFrame := tracer.PreviousCallFrame()
+			cheatCodeCallerFrame.onNextFrameEnterHooks.Push(func() {
+				// The hook runs on the first instruction of the new frame, after its cost was already deducted, so we
+				// deduct it from the amount we provide so the frame is left with exactly the requested gas overall.
+				// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+				gasCallFrame := tracer.CurrentCallFrame()
+				scopeContext := gasCallFrame.vmScope.(*vm.ScopeContext)
+
+				// If the frame is provided less gas than was forwarded to it, return the difference to the caller so
+				// it is not lost once the frame exits.
+				forwardedGas := scopeContext.Contract.Gas + gasCallFrame.vmCost
+				if callGas < forwardedGas {
+					callerScopeContext := cheatCodeCallerFrame.vmScope.(*vm.ScopeContext)
+					callerScopeContext.Contract.Gas += forwardedGas - callGas
+				}
+				if callGas > gasCallFrame.vmCost {
+					scopeContext.Contract.Gas = callGas - gasCallFrame.vmCost
+				} else {
+					scopeContext.Contract.Gas = 0
+				}
+			})
+			return nil, nil
+		},
+	)
+
+	// Cool: Resets an account and its storage slots to cold in the access list of the current transaction, so their
+	// next access is charged as a cold access.
+	contract.addMethod(
+		"cool", abi.Arguments{{Type: typeAddress}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// Accounts cannot be removed from the access list, so we track which were cooled and charge the difference
+			// between a cold and warm access when they are next accessed.
+			account := inputs[0].(common.Address)
+			tracer.cooledAccounts[account] = &cheatCodeCooledAccount{
+				accountCooled: tracer.chain.State().AddressInAccessList(account),
+				accessedSlots: make(map[common.Hash]struct{}),
+			}
+			return nil, nil
+		},
+	)
+
+	// PauseGasMetering: Stops charging gas for instructions executed in the current transaction, until gas metering
+	// is resumed.
+	contract.addMethod(
+		"pauseGasMetering", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// If the caller reverts, its pause is reverted with it, so we restore the previous state on exit.
+			cheatCodeCallerFrame := tracer.PreviousCallFrame()
+			wasPaused := tracer.gasMeteringPaused
+			tracer.gasMeteringPaused = true
+			cheatCodeCallerFrame.onFrameExitRestoreHooks.Push(func() {
+				if cheatCodeCallerFrame.vmErr != nil {
+					tracer.gasMeteringPaused = wasPaused
+				}
+			})
+			return nil, nil
+		},
+	)
+
+	// ResumeGasMetering: Resumes charging gas for instructions executed in the current transaction, after gas metering
+	// was paused.
+	contract.addMethod(
+		"resumeGasMetering", abi.Arguments{}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			if !tracer.gasMeteringPaused {
+				return nil, nil
+			}
+			tracer.gasMeteringPaused = false
+
+			// Call frames which executed while paused are each executing a call, and were charged the gas they forwarded
+			// to it, which was held while paused. When they next execute, we restore them to the gas they were held at,
+			// less the gas their call used since metering was resumed.
+			for _, callFrame := range tracer.callFrames {
+				if callFrame.pausedGas == nil {
+					continue
+				}
+				pausedCallFrame, pausedGas := callFrame, *callFrame.pausedGas
+				pausedCallFrame.pausedGas = nil
+				pausedCallFrame.onNextOpcodeHooks.Push(func() {
+					gas := uint64(0)
+					if pausedCallFrame.childGasUsed < pausedGas {
+						gas = pausedGas - pausedCallFrame.childGasUsed
+					}
+					// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+					pausedCallFrame.vmScope.(*vm.ScopeContext).Contract.Gas = gas
+				})
+			}
+			return nil, nil
+		},
+	)
+
+	// snapshot: Takes a snapshot of the current state of the evm and returns the id associated with the snapshot
+	contract.addMethod(
+		"snapshot", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			snapshotID := tracer.chain.State().Snapshot()
+
+			// If the caller reverts, the snapshot is invalidated along with its changes, so we stop tracking it.
+			stateSnapshots := tracer.stateSnapshots
+			stateSnapshots[snapshotID] = snapshotID
+			tracer.PreviousCallFrame().onChainRevertRestoreHooks.Push(func() {
+				delete(stateSnapshots, snapshotID)
+			})
+
+			return []any{snapshotID}, nil
+		},
+	)
+
+	// revertTo(uint256): Revert the state of the evm to a previous snapshot. Takes the snapshot id to revert to.
+	// Returns false if the snapshot does not exist.
+	contract.addMethod(
+		"revertTo", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			snapshotID := inputs[0].(*big.Int)
+			return []any{revertToStateSnapshot(tracer, snapshotID, false)}, nil
+		},
+	)
+
+	// revertToStateAndDelete(uint256): Revert the state of the evm to a previous snapshot and delete it. Takes the
+	// snapshot id to revert to. Returns false if the snapshot does not exist.
+	contract.addMethod(
+		"revertToStateAndDelete", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			snapshotID := inputs[0].(*big.Int)
+			return []any{revertToStateSnapshot(tracer, snapshotID, true)}, nil
+		},
+	)
+
+	// deleteStateSnapshot(uint256): Delete a previous snapshot, releasing it from the state so it can no longer be
+	// reverted to. Takes the snapshot id to delete. Returns false if the snapshot does not exist.
+	contract.addMethod(
+		"deleteStateSnapshot", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeBool}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			snapshotID := inputs[0].(*big.Int)
+			if !snapshotID.IsInt64() {
+				return []any{false}, nil
+			}
+			stateSnapshotID, ok := tracer.stateSnapshots[int(snapshotID.Int64())]
+			if !ok {
+				return []any{false}, nil
+			}
+			releaseStateSnapshot(tracer.chain.State(), stateSnapshotID)
+			delete(tracer.stateSnapshots, int(snapshotID.Int64()))
+			return []any{true}, nil
+		},
+	)
+
+	// saveState(string): Takes a snapshot of the current state of the evm and associates it with the provided name,
+	// overwriting any snapshot previously saved with it.
+	contract.addMethod(
+		"saveState", abi.Arguments{{Type: typeString}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			name := inputs[0].(string)
+
+			// Release any snapshot previously saved with this name, as it can no longer be restored.
+			if previousID, ok := tracer.namedSnapshots[name]; ok {
+				if stateSnapshotID, ok := tracer.stateSnapshots[previousID]; ok {
+					releaseStateSnapshot(tracer.chain.State(), stateSnapshotID)
+					delete(tracer.stateSnapshots, previousID)
+				}
+			}
+
+			// Named snapshots are tracked alongside those taken by the snapshot cheat code, so that they are
+			// invalidated in the same way when reverting to an earlier snapshot or when the caller reverts.
+			snapshotID := tracer.chain.State().Snapshot()
+			stateSnapshots := tracer.stateSnapshots
+			stateSnapshots[snapshotID] = snapshotID
+			tracer.PreviousCallFrame().onChainRevertRestoreHooks.Push(func() {
+				delete(stateSnapshots, snapshotID)
+			})
+			tracer.namedSnapshots[name] = snapshotID
+			return nil, nil
+		},
+	)
+
+	// restoreState(string): Revert the state of the evm to a snapshot previously saved with the provided name. The
+	// state can be restored more than once. Reverts if no state was saved with the name, or if it was invalidated.
+	contract.addMethod(
+		"restoreState", abi.Arguments{{Type: typeString}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			name := inputs[0].(string)
+			snapshotID, ok := tracer.namedSnapshots[name]
+			if !ok {
+				message := fmt.Sprintf("restoreState: no state was saved with the name %q", name)
+				return nil, cheatCodeRevertData(abiutils.EncodeSolidityRevertErrorString(message))
+			}
+			if !revertToStateSnapshot(tracer, big.NewInt(int64(snapshotID)), false) {
+				delete(tracer.namedSnapshots, name)
+				message := fmt.Sprintf("restoreState: the state saved with the name %q was invalidated by an earlier revert", name)
+				return nil, cheatCodeRevertData(abiutils.EncodeSolidityRevertErrorString(message))
+			}
+			return nil, nil
+		},
+	)
+
+	// GetCoverageCount: Returns the amount of unique program counters covered on the chain so far
+	contract.addMethod(
+		"getCoverageCount", abi.Arguments{}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			if tracer.chain.CoverageCountFunc == nil {
+				return nil, cheatCodeRevertData([]byte("getCoverageCount: coverage is not being collected"))
+			}
+			return []any{new(big.Int).SetUint64(tracer.chain.CoverageCountFunc())}, nil
+		},
+	)
+
+	// IncrementCounter: Increments a named counter, aggregated and reported by the fuzzer
+	contract.addMethod(
+		"incrementCounter", abi.Arguments{{Type: typeString}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			tracer.chain.CustomMetrics.IncrementCounter(inputs[0].(string))
+			return nil, nil
+		},
+	)
+
+	// SetGauge: Sets a named gauge to a value, reported by the fuzzer
+	contract.addMethod(
+		"setGauge", abi.Arguments{{Type: typeString}, {Type: typeUint256}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			tracer.chain.CustomMetrics.SetGauge(inputs[0].(string), inputs[1].(*big.Int))
+			return nil, nil
+		},
+	)
+
+	// FFI: Run arbitrary command on base OS
+	contract.addMethod(
+		"ffi", abi.Arguments{{Type: typeStringSlice}}, abi.Arguments{{Type: typeBytes}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// Ensure FFI is enabled (this allows arbitrary code execution, so we expect it to be explicitly enabled).
+			if !tracer.chain.testChainConfig.CheatCodeConfig.EnableFFI {
+				// Make sure there is at least a command to run
+				return nil, cheatCodeRevertData([]byte("ffi is not enabled in the chain configuration"))
+			}
+
+			// command is cmdAndInputs[0] and args are cmdAndInputs[1:]
+			cmdAndInputs := inputs[0].([]string)
+
+			var command string
+			var args []string
+
+			if len(cmdAndInputs) < 1 {
+				// Make sure there is at least a command to run
+				return nil, cheatCodeRevertData([]byte("ffi: no command was provided"))
+			} else if len(cmdAndInputs) == 1 {
+				// It is possible there are no arguments provided
+				command = cmdAndInputs[0]
+			} else {
+				// Both a command and arguments have been provided
+				command = cmdAndInputs[0]
+				args = cmdAndInputs[1:]
+			}
+
+			// Create our command
+			cmd := exec.Command(command, args...)
+
+			// Execute it and grab the output
+			stdout, _, combined, err := utils.RunCommandWithOutputAndError(cmd)
+			if err != nil {
+				errorMsg := fmt.Sprintf("ffi: cmd failed with the following error: %v\nOutput: %v", err, string(combined))
+				return nil, cheatCodeRevertData([]byte(errorMsg))
+			}
+
+			// Attempt to hex decode the output
+			hexOut, err := hex.DecodeString(strings.TrimPrefix(string(stdout), "0x"))
+			if err != nil {
+				// Return the byte array as itself if hex decoding does not work
+				return []any{stdout}, nil
+			}
+
+			// Hex decoding worked, so return that
+			return []any{hexOut}, nil
+		},
+	)
+
+	// readFile: Read the entire contents of a file within the file access root
+	contract.addMethod(
+		"readFile", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			path, err := resolveFileAccessPath(tracer.chain.testChainConfig.CheatCodeConfig, inputs[0].(string))
+			if err != nil {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("readFile: %v", err)))
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("readFile: %v", err)))
+			}
+			return []any{string(data)}, nil
+		},
+	)
+
+	// writeFile: Write a string to a file within the file access root, replacing its contents if it exists
+	contract.addMethod(
+		"writeFile", abi.Arguments{{Type: typeString}, {Type: typeString}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			path, err := resolveFileAccessPath(tracer.chain.testChainConfig.CheatCodeConfig, inputs[0].(string))
+			if err != nil {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("writeFile: %v", err)))
+			}
+
+			err = os.WriteFile(path, []byte(inputs[1].(string)), 0644)
+			if err != nil {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("writeFile: %v", err)))
+			}
+
+			// The file's contents changed, so readLine should start from its first line again.
+			delete(tracer.fileLineOffsets, path)
+			return nil, nil
+		},
+	)
+
+	// readLine: Read the next line of a file within the file access root, or an empty string once all lines were read
+	contract.addMethod(
+		"readLine", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			path, err := resolveFileAccessPath(tracer.chain.testChainConfig.CheatCodeConfig, inputs[0].(string))
+			if err != nil {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("readLine: %v", err)))
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, cheatCodeRevertData([]byte(fmt.Sprintf("readLine: %v", err)))
+			}
+
+			// Return the line at the current offset for this file and advance it.
+			lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			offset := tracer.fileLineOffsets[path]
+			if len(data) == 0 || offset >= len(lines) {
+				return []any{""}, nil
+			}
+			tracer.fileLineOffsets[path] = offset + 1
+			return []any{strings.TrimSuffix(lines[offset], "\r")}, nil
+		},
+	)
+
+	// addr: Compute the address for a given private key
+	contract.addMethod("addr", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeAddress}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// Get the private key object
+			privateKey, err := utils.GetPrivateKey(inputs[0].(*big.Int).Bytes())
+			if err != nil {
+				errorMessage := "addr: " + err.Error()
+				return nil, cheatCodeRevertData([]byte(errorMessage))
+			}
+
+			// Get ECDSA public key
+			publicKey := privateKey.Public().(*ecdsa.PublicKey)
+
+			// Get associated address
+			addr := crypto.PubkeyToAddress(*publicKey)
+
+			return []any{addr}, nil
+		},
+	)
+
+	// signDigest signs a digest with the provided private key, returning the (v, r, s) signature as cheat code outputs.
+	signDigest := func(privateKey *ecdsa.PrivateKey, digest [32]byte) ([]any, *cheatCodeRawReturnData) {
+		sig, err := crypto.Sign(digest[:], privateKey)
+		if err != nil {
+			return nil, cheatCodeRevertData([]byte("sign: malformed input to signature algorithm"))
+		}
+
+		// `r` and `s` have to be [32]byte arrays
+		var r [32]byte
+		var s [32]byte
+		copy(r[:], sig[:32])
+		copy(s[:], sig[32:64])
+
+		// Need to add 27 to the `v` value for ecrecover to work
+		v := sig[64] + 27
+
+		return []any{v, r, s}, nil
+	}
+
+	// sign: Sign a digest given some private key
+	contract.addMethod("sign", abi.Arguments{{Type: typeUint256}, {Type: typeBytes32}},
+		abi.Arguments{{Type: typeUint8}, {Type: typeBytes32}, {Type: typeBytes32}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// Get the private key object
+			privateKey, err := utils.GetPrivateKey(inputs[0].(*big.Int).Bytes())
+			if err != nil {
+				errorMessage := "sign: " + err.Error()
+				return nil, cheatCodeRevertData([]byte(errorMessage))
+			}
+
+			// Sign digest
+			return signDigest(privateKey, inputs[1].([32]byte))
+		},
+	)
+
+	// sign: Sign a digest with the private key remembered for some address
+	contract.addMethod("sign", abi.Arguments{{Type: typeAddress}, {Type: typeBytes32}},
+		abi.Arguments{{Type: typeUint8}, {Type: typeBytes32}, {Type: typeBytes32}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			privateKey, ok := tracer.rememberedKeys[inputs[0].(common.Address)]
+			if !ok {
+				return nil, cheatCodeRevertData([]byte("sign: no private key was remembered for the address"))
+			}
+			return signDigest(privateKey, inputs[1].([32]byte))
+		},
+	)
+
+	// deriveKey: Derive a private key from a mnemonic at some index of the default Ethereum derivation path
+	contract.addMethod("deriveKey", abi.Arguments{{Type: typeString}, {Type: typeUint32}}, abi.Arguments{{Type: typeUint256}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			privateKey, err := utils.DerivePrivateKey(inputs[0].(string), inputs[1].(uint32))
+			if err != nil {
+				errorMessage := "deriveKey: " + err.Error()
+				return nil, cheatCodeRevertData([]byte(errorMessage))
+			}
+			return []any{new(big.Int).SetBytes(crypto.FromECDSA(privateKey))}, nil
+		},
+	)
+
+	// rememberKey: Remember a private key so it can be used by its address, returning the address
+	contract.addMethod("rememberKey", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeAddress}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			privateKey, err := utils.GetPrivateKey(inputs[0].(*big.Int).Bytes())
+			if err != nil {
+				errorMessage := "rememberKey: " + err.Error()
+				return nil, cheatCodeRevertData([]byte(errorMessage))
+			}
+
+			addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+			tracer.rememberedKeys[addr] = privateKey
+			return []any{addr}, nil
+		},
+	)
+
+	// toString(address): Convert address to string
+	contract.addMethod("toString", abi.Arguments{{Type: typeAddress}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			addr := inputs[0].(common.Address)
+			return []any{addr.String()}, nil
+		},
+	)
+
+	// toChecksumString: Convert address to an EIP-55 checksummed string
+	contract.addMethod("toChecksumString", abi.Arguments{{Type: typeAddress}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			addr := inputs[0].(common.Address)
+			return []any{addr.Hex()}, nil
+		},
+	)
+
+	// toString(bool): Convert bool to string
+	contract.addMethod("toString", abi.Arguments{{Type: typeBool}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			b := inputs[0].(bool)
+			return []any{strconv.FormatBool(b)}, nil
+		},
+	)
+
+	// toString(uint256): Convert uint256 to string
+	contract.addMethod("toString", abi.Arguments{{Type: typeUint256}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			n := inputs[0].(*big.Int)
+			return []any{n.String()}, nil
+		},
+	)
+
+	// toString(int256): Convert int256 to string
+	contract.addMethod("toString", abi.Arguments{{Type: typeInt256}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			n := inputs[0].(*big.Int)
+			return []any{n.String()}, nil
+		},
+	)
+
+	// toString(bytes32): Convert bytes32 to string
+	contract.addMethod("toString", abi.Arguments{{Type: typeBytes32}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			b := inputs[0].([32]byte)
+			// Prefix "0x"
+			hexString := "0x" + hex.EncodeToString(b[:])
+
+			return []any{hexString}, nil
+		},
+	)
+
+	// toString(bytes): Convert bytes to string
+	contract.addMethod("toString", abi.Arguments{{Type: typeBytes}}, abi.Arguments{{Type: typeString}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			b := inputs[0].([]byte)
+			// Prefix "0x"
+			hexString := "0x" + hex.EncodeToString(b)
+
+			return []any{hexString}, nil
+		},
+	)
+
+	// parseBytes: Convert string to bytes
+	contract.addMethod("parseBytes", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeBytes}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			return []any{[]byte(inputs[0].(string))}, nil
+		},
+	)
+
+	// parseBytes32: Convert string to bytes32
+	contract.addMethod("parseBytes32", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeBytes32}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			bSlice := []byte(inputs[0].(string))
 
 			// Use a fixed array and copy the data over
 			var bArray [32]byte
@@ -557,6 +1382,27 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// parseAddress(string, bool): Convert string to address, optionally requiring a valid EIP-55 checksum
+	contract.addMethod("parseAddress", abi.Arguments{{Type: typeString}, {Type: typeBool}}, abi.Arguments{{Type: typeAddress}},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			// If a checksum is not required, we parse the string as we would otherwise.
+			if !inputs[1].(bool) {
+				addr, err := utils.HexStringToAddress(inputs[0].(string))
+				if err != nil {
+					return nil, cheatCodeRevertData([]byte("parseAddress: malformed string"))
+				}
+				return []any{addr}, nil
+			}
+
+			addr, err := utils.ChecksummedHexStringToAddress(inputs[0].(string))
+			if err != nil {
+				errorMessage := "parseAddress: " + err.Error()
+				return nil, cheatCodeRevertData([]byte(errorMessage))
+			}
+			return []any{addr}, nil
+		},
+	)
+
 	// parseUint: Convert string to uint256
 	contract.addMethod("parseUint", abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typeUint256}},
 		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
@@ -593,6 +1439,668 @@ func getStandardCheatCodeContract(tracer *cheatCodeTracer) (*CheatCodeContract,
 		},
 	)
 
+	// addEnvMethods registers a cheat code with the given name which reads an environment variable and parses it into
+	// the given type, along with an envOr overload which returns a provided default if the variable is not set. The
+	// parse function returns the parsed value and a boolean indicating whether parsing succeeded. Reading environment
+	// variables must be enabled in the chain configuration, as it exposes host state to the tests.
+	addEnvMethods := func(name string, typ abi.Type, parse func(value string) (any, bool)) {
+		readEnv := func(tracer *cheatCodeTracer, cheatName string, key string, defaultValue any) ([]any, *cheatCodeRawReturnData) {
+			// Ensure environment access is enabled.
+			if !tracer.chain.testChainConfig.CheatCodeConfig.EnableEnvAccess {
+				return nil, cheatCodeRevertData([]byte("env access is not enabled in the chain configuration"))
+			}
+
+			// Obtain the variable, falling back to the default if one was provided.
+			value, ok := os.LookupEnv(key)
+			if !ok {
+				if defaultValue != nil {
+					return []any{defaultValue}, nil
+				}
+				errorMsg := fmt.Sprintf("%v: environment variable %q is not set", cheatName, key)
+				return nil, cheatCodeRevertData([]byte(errorMsg))
+			}
+
+			// Parse the variable into the requested type.
+			parsed, ok := parse(value)
+			if !ok {
+				errorMsg := fmt.Sprintf("%v: environment variable %q could not be parsed", cheatName, key)
+				return nil, cheatCodeRevertData([]byte(errorMsg))
+			}
+			return []any{parsed}, nil
+		}
+
+		contract.addMethod(name, abi.Arguments{{Type: typeString}}, abi.Arguments{{Type: typ}},
+			func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+				return readEnv(tracer, name, inputs[0].(string), nil)
+			},
+		)
+		contract.addMethod("envOr", abi.Arguments{{Type: typeString}, {Type: typ}}, abi.Arguments{{Type: typ}},
+			func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+				return readEnv(tracer, "envOr", inputs[0].(string), inputs[1])
+			},
+		)
+	}
+
+	// envUint/envAddress/envString/envBytes: Read an environment variable, with envOr variants which fall back to a
+	// provided default if the variable is not set.
+	addEnvMethods("envUint", typeUint256, func(value string) (any, bool) {
+		n, ok := new(big.Int).SetString(value, 0)
+		return n, ok && n.Sign() >= 0 && n.BitLen() <= 256
+	})
+	addEnvMethods("envAddress", typeAddress, func(value string) (any, bool) {
+		addr, err := utils.HexStringToAddress(value)
+		return addr, err == nil
+	})
+	addEnvMethods("envString", typeString, func(value string) (any, bool) {
+		return value, true
+	})
+	addEnvMethods("envBytes", typeBytes, func(value string) (any, bool) {
+		b, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		return b, err == nil
+	})
+
 	// Return our precompile contract information.
 	return contract, nil
 }
+
+// expectRevertOnNextCall installs hooks on the frame which called the expectRevert cheat code, which verify that the
+// next call it makes reverts. If revertDataMatcher is non-nil, the revert data must also satisfy it. If the expectation
+// is met, the revert is suppressed by reporting the call as successful to the caller. Otherwise, the caller frame is
+// failed so the unmet expectation is caught by assertion testing. Calls to other cheat code contracts are skipped.
+// Returns revert data for the cheat code if an expected revert is already pending for the caller, otherwise nil.
+func expectRevertOnNextCall(tracer *cheatCodeTracer, revertDataMatcher func(revertData []byte) bool) *cheatCodeRawReturnData {
+	// Obtain the caller frame. Only one expected revert may be pending for the next call it makes.
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	if cheatCodeCallerFrame.expectRevertPending {
+		return cheatCodeRevertData([]byte("expectRevert: a revert is already expected for the next call"))
+	}
+	cheatCodeCallerFrame.expectRevertPending = true
+
+	// When the next call frame entered by the caller exits, check its result.
+	onNextCallExit(tracer, cheatCodeCallerFrame, func(exitingCallFrame *cheatCodeTracerCallFrame) {
+		cheatCodeCallerFrame.expectRevertPending = false
+
+		// Determine whether the call reverted as expected, then patch the caller once the call result has been
+		// pushed to its stack, before its next instruction executes.
+		expectationMet := exitingCallFrame.vmErr != nil && (revertDataMatcher == nil || revertDataMatcher(exitingCallFrame.vmReturnData))
+		callOp := cheatCodeCallerFrame.vmOp
+		cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+			// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+			scopeContext := cheatCodeCallerFrame.vmScope.(*vm.ScopeContext)
+			if !expectationMet {
+				failCallFrame(scopeContext)
+			} else if callOp == vm.CALL || callOp == vm.CALLCODE || callOp == vm.DELEGATECALL || callOp == vm.STATICCALL {
+				// Calls push a success flag to the stack, which we set to report the call as successful.
+				scopeContext.Stack.Back(0).SetOne()
+			}
+		})
+	})
+	return nil
+}
+
+// expectReturnOnNextCall installs hooks on the frame which called the expectReturn cheat code, which verify that the
+// next call it makes succeeds and returns exactly the provided data. If it does not, the caller frame is failed so the
+// unmet expectation is caught by assertion testing. Calls to other cheat code contracts are skipped.
+// Returns revert data for the cheat code if an expected return is already pending for the caller, otherwise nil.
+func expectReturnOnNextCall(tracer *cheatCodeTracer, expectedReturnData []byte) *cheatCodeRawReturnData {
+	// Obtain the caller frame. Only one expected return may be pending for the next call it makes.
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	if cheatCodeCallerFrame.expectReturnPending {
+		return cheatCodeRevertData([]byte("expectReturn: a return is already expected for the next call"))
+	}
+	cheatCodeCallerFrame.expectReturnPending = true
+
+	// When the next call frame entered by the caller exits, compare its return data.
+	onNextCallExit(tracer, cheatCodeCallerFrame, func(exitingCallFrame *cheatCodeTracerCallFrame) {
+		cheatCodeCallerFrame.expectReturnPending = false
+
+		// If the call reverted or returned different data, fail the caller before its next instruction executes.
+		if exitingCallFrame.vmErr == nil && bytes.Equal(exitingCallFrame.vmReturnData, expectedReturnData) {
+			return
+		}
+		cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+			// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+			failCallFrame(cheatCodeCallerFrame.vmScope.(*vm.ScopeContext))
+		})
+	})
+	return nil
+}
+
+// expectNonceIncreaseOnNextCall installs hooks on the frame which called the expectNonceIncrease cheat code, which
+// verify that the nonce of the provided account has increased once the next call it makes exits. The nonce is read from
+// the state database when the cheat code is invoked and again when the call exits. If it did not increase, the caller
+// frame is failed so the unmet expectation is caught by assertion testing. Calls to other cheat code contracts are
+// skipped.
+// Returns revert data for the cheat code if a nonce increase is already pending for the caller, otherwise nil.
+func expectNonceIncreaseOnNextCall(tracer *cheatCodeTracer, account common.Address) *cheatCodeRawReturnData {
+	// Obtain the caller frame. Only one nonce increase may be pending for the next call it makes.
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	if cheatCodeCallerFrame.expectNonceIncreasePending {
+		return cheatCodeRevertData([]byte("expectNonceIncrease: a nonce increase is already expected for the next call"))
+	}
+	cheatCodeCallerFrame.expectNonceIncreasePending = true
+
+	// Record the nonce before the next call. Creations made by the caller increment its nonce before the created frame
+	// is entered, so we must read it now rather than on entry.
+	originalNonce := tracer.chain.State().GetNonce(account)
+
+	// When the next call frame entered by the caller exits, compare the nonce.
+	onNextCallExit(tracer, cheatCodeCallerFrame, func(*cheatCodeTracerCallFrame) {
+		cheatCodeCallerFrame.expectNonceIncreasePending = false
+
+		// If the nonce did not increase, fail the caller before its next instruction executes. Reverted calls have
+		// their state changes (aside from the nonce increment of a creation's sender) rolled back already.
+		if tracer.chain.State().GetNonce(account) > originalNonce {
+			return
+		}
+		cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+			// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+			failCallFrame(cheatCodeCallerFrame.vmScope.(*vm.ScopeContext))
+		})
+	})
+	return nil
+}
+
+// cheatCodeExpectedEmit describes a log which an expectEmit cheat code expects to be emitted by the next call made by
+// the call frame which invoked it.
+type cheatCodeExpectedEmit struct {
+	// log describes the expected log. This is the next log emitted by the call frame which invoked the cheat code.
+	log *coretypes.Log
+
+	// checkTopics describes whether each of the topics following the first should be checked. The first topic (the
+	// event signature, or the first indexed argument of an anonymous event) is always checked.
+	checkTopics [3]bool
+
+	// checkData describes whether the non-indexed data of the log should be checked.
+	checkData bool
+
+	// emitter describes the address which is expected to emit the log, or nil if any emitter is accepted.
+	emitter *common.Address
+}
+
+// matches checks whether the provided emitted log satisfies the expectation.
+// Returns a boolean indicating whether it does.
+func (e *cheatCodeExpectedEmit) matches(emittedLog *coretypes.Log) bool {
+	if e.emitter != nil && emittedLog.Address != *e.emitter {
+		return false
+	}
+	if len(emittedLog.Topics) != len(e.log.Topics) {
+		return false
+	}
+	for i := range emittedLog.Topics {
+		if i > 0 && !e.checkTopics[i-1] {
+			continue
+		}
+		if emittedLog.Topics[i] != e.log.Topics[i] {
+			return false
+		}
+	}
+	return !e.checkData || bytes.Equal(emittedLog.Data, e.log.Data)
+}
+
+// expectEmitOnNextCall installs hooks on the frame which called the expectEmit cheat code, which capture the next log
+// it emits as the expected log, queueing it alongside any other expected logs. When the next call made by the frame
+// exits, the logs it emitted must contain the queued expected logs in order, otherwise the caller frame is failed so the
+// unmet expectation is caught by assertion testing. Calls to other cheat code contracts are skipped.
+// Returns revert data for the cheat code if a previous expectEmit is still waiting for its expected log, otherwise nil.
+func expectEmitOnNextCall(tracer *cheatCodeTracer, expectedEmit *cheatCodeExpectedEmit) *cheatCodeRawReturnData {
+	// Obtain the caller frame. Each expectEmit must be followed by the log it expects before another is invoked.
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	if len(cheatCodeCallerFrame.onNextLogHooks) > 0 {
+		return cheatCodeRevertData([]byte("expectEmit: the previous expectEmit has not been followed by an expected log"))
+	}
+
+	// Define a hook which checks the expected logs were emitted when the next call frame the caller enters exits.
+	checkCallLogs := func(exitingCallFrame *cheatCodeTracerCallFrame) {
+		// Match the expected logs against the emitted ones, in order. Reverted calls do not emit any logs.
+		expectedEmits := cheatCodeCallerFrame.expectedEmits
+		cheatCodeCallerFrame.expectedEmits = nil
+		matchedCount := 0
+		if exitingCallFrame.vmErr == nil {
+			for _, emittedLog := range exitingCallFrame.vmLogs {
+				if matchedCount < len(expectedEmits) && expectedEmits[matchedCount].matches(emittedLog) {
+					matchedCount++
+				}
+			}
+		}
+
+		// If any expected log was not emitted, fail the caller frame before its next instruction executes.
+		if matchedCount < len(expectedEmits) {
+			cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+				// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+				failCallFrame(cheatCodeCallerFrame.vmScope.(*vm.ScopeContext))
+			})
+		}
+	}
+
+	// The next log emitted by the caller describes the expected log. Once the first expected log is queued, we begin
+	// waiting for the next call to check against.
+	cheatCodeCallerFrame.onNextLogHooks.Push(func() {
+		expectedEmit.log = cheatCodeCallerFrame.vmLogs[len(cheatCodeCallerFrame.vmLogs)-1]
+		cheatCodeCallerFrame.expectedEmits = append(cheatCodeCallerFrame.expectedEmits, expectedEmit)
+		if len(cheatCodeCallerFrame.expectedEmits) == 1 {
+			onNextCallExit(tracer, cheatCodeCallerFrame, checkCallLogs)
+		}
+	})
+	return nil
+}
+
+// expectEmitSequenceOnNextCall installs hooks on the frame which called the expectEmitSequence cheat code, which capture
+// the logs it emits before its next call as the expected sequence. When that call exits, the logs it emitted must match
+// the expected sequence exactly, with no logs missing, added, or reordered. Otherwise, the caller frame is failed so the
+// unmet expectation is caught by assertion testing. Calls to other cheat code contracts are skipped.
+// Returns revert data for the cheat code if an expected sequence is already pending for the caller, otherwise nil.
+func expectEmitSequenceOnNextCall(tracer *cheatCodeTracer) *cheatCodeRawReturnData {
+	// Obtain the caller frame. Only one expected sequence may be pending for the next call it makes.
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	if cheatCodeCallerFrame.expectEmitSequencePending {
+		return cheatCodeRevertData([]byte("expectEmitSequence: the previous expectEmitSequence has not been followed by a call"))
+	}
+	cheatCodeCallerFrame.expectEmitSequencePending = true
+	sequenceStart := len(cheatCodeCallerFrame.vmLogs)
+
+	// Cheat code contracts do not execute instructions, so this hook executes in the next call frame which does.
+	cheatCodeCallerFrame.onNextFrameEnterHooks.Push(func() {
+		cheatCodeCallerFrame.expectEmitSequencePending = false
+
+		// The logs the caller emitted since invoking the cheat code describe the expected sequence.
+		expectedEmits := make([]*cheatCodeExpectedEmit, 0)
+		for _, expectedLog := range cheatCodeCallerFrame.vmLogs[sequenceStart:] {
+			expectedEmits = append(expectedEmits, &cheatCodeExpectedEmit{
+				log:         expectedLog,
+				checkTopics: [3]bool{true, true, true},
+				checkData:   true,
+			})
+		}
+
+		// When the call exits, its logs must match the expected sequence one-to-one. Reverted calls do not emit any logs.
+		calledFrame := tracer.CurrentCallFrame()
+		calledFrame.onFrameExitRestoreHooks.Push(func() {
+			var emittedLogs []*coretypes.Log
+			if calledFrame.vmErr == nil {
+				emittedLogs = calledFrame.vmLogs
+			}
+			matched := len(emittedLogs) == len(expectedEmits)
+			for i := 0; matched && i < len(expectedEmits); i++ {
+				matched = expectedEmits[i].matches(emittedLogs[i])
+			}
+
+			// If the sequence was not matched, fail the caller frame before its next instruction executes.
+			if !matched {
+				cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+					// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+					failCallFrame(cheatCodeCallerFrame.vmScope.(*vm.ScopeContext))
+				})
+			}
+		})
+	})
+	return nil
+}
+
+// revertToStateSnapshot reverts the state to the snapshot with the provided id, taken by the snapshot cheat code. The
+// state releases the snapshot and every snapshot taken after it, so unless the snapshot is to be deleted, a new one is
+// taken in its place so it can be reverted to again.
+// Returns a boolean indicating whether the snapshot exists, in which case the state was reverted.
+func revertToStateSnapshot(tracer *cheatCodeTracer, snapshotID *big.Int, deleteSnapshot bool) bool {
+	if !snapshotID.IsInt64() {
+		return false
+	}
+	id := int(snapshotID.Int64())
+	stateSnapshotID, ok := tracer.stateSnapshots[id]
+	if !ok {
+		return false
+	}
+	tracer.chain.State().RevertToSnapshot(stateSnapshotID)
+
+	// Snapshots taken after this one were released by the revert.
+	for laterID := range tracer.stateSnapshots {
+		if laterID > id {
+			delete(tracer.stateSnapshots, laterID)
+		}
+	}
+	if deleteSnapshot {
+		delete(tracer.stateSnapshots, id)
+		return true
+	}
+
+	// Take the snapshot again. If the caller reverts, it is released along with the caller's changes.
+	stateSnapshots := tracer.stateSnapshots
+	stateSnapshots[id] = tracer.chain.State().Snapshot()
+	tracer.PreviousCallFrame().onChainRevertRestoreHooks.Push(func() {
+		delete(stateSnapshots, id)
+	})
+	return true
+}
+
+// releaseStateSnapshot releases the snapshot with the provided id from the provided state, without reverting to it, so
+// that the state does not accumulate snapshots which can no longer be reverted to. The state only releases snapshots
+// when reverting to them, and does not expose the snapshots it holds, so they are accessed through reflection. Other
+// snapshots are unaffected, as they are looked up by their id. Releasing a snapshot which does not exist does nothing.
+func releaseStateSnapshot(stateDB *state.StateDB, snapshotID int) {
+	revisionsField := reflect.ValueOf(stateDB).Elem().FieldByName("validRevisions")
+	revisions := reflect.ValueOf(reflectionutils.GetField(revisionsField))
+	for i := 0; i < revisions.Len(); i++ {
+		if revisions.Index(i).FieldByName("id").Int() == int64(snapshotID) {
+			remainingRevisions := reflect.AppendSlice(revisions.Slice(0, i), revisions.Slice(i+1, revisions.Len()))
+			reflectionutils.SetField(revisionsField, remainingRevisions.Interface())
+			return
+		}
+	}
+}
+
+// cheatCodeMockedCall describes a call mocked by the mockCall cheat code.
+type cheatCodeMockedCall struct {
+	// value describes the call value which a call must be made with to match the mock, or nil if any value matches.
+	value *big.Int
+
+	// calldata describes the data which the calldata of a call must begin with to match the mock.
+	calldata []byte
+
+	// returnData describes the data which a call matching the mock returns. This is never nil.
+	returnData []byte
+}
+
+// matches checks whether a call with the provided calldata and value matches the mocked call.
+// Returns a boolean indicating whether it does.
+func (m *cheatCodeMockedCall) matches(calldata []byte, value *big.Int) bool {
+	if m.value != nil && (value == nil || m.value.Cmp(value) != 0) {
+		return false
+	}
+	return bytes.HasPrefix(calldata, m.calldata)
+}
+
+// mockCall adds the provided mocked call for the provided address, replacing any existing mock with the same calldata
+// and value. Mocks are reverted if the call frame which invoked the cheat code reverts. If the address has no code, a
+// single instruction is temporarily set as its code for the remainder of the transaction, so that calls to it execute
+// and can be mocked.
+func mockCall(tracer *cheatCodeTracer, address common.Address, mockedCall *cheatCodeMockedCall) {
+	// Add the mock, replacing any mock it supersedes. If the transaction reverts, we restore the original mocks.
+	originalMocks := tracer.mockedCalls[address]
+	mocks := slices.DeleteFunc(slices.Clone(originalMocks), func(mock *cheatCodeMockedCall) bool {
+		sameValue := (mock.value == nil) == (mockedCall.value == nil) && (mock.value == nil || mock.value.Cmp(mockedCall.value) == 0)
+		return sameValue && bytes.Equal(mock.calldata, mockedCall.calldata)
+	})
+	tracer.mockedCalls[address] = append(mocks, mockedCall)
+	cheatCodeCallerFrame := tracer.CurrentCallFrame()
+	cheatCodeCallerFrame.onChainRevertRestoreHooks.Push(func() {
+		if tracer.mockedCalls != nil {
+			tracer.mockedCalls[address] = originalMocks
+		}
+	})
+
+	// If the address has no code, calls to it would not execute any instructions to replace, so we set a JUMPDEST as
+	// its code until the transaction concludes.
+	stateDB := tracer.chain.State()
+	if stateDB.GetCodeSize(address) == 0 {
+		stateDB.SetCode(address, []byte{byte(vm.JUMPDEST)})
+		cheatCodeCallerFrame.onTopFrameExitRestoreHooks.Push(func() {
+			stateDB.SetCode(address, nil)
+		})
+	}
+}
+
+// mockedCallCode constructs code which returns the provided data, to replace the code of a call frame which matched a
+// mocked call. As the call frame's first instruction has already been fetched when its code is replaced, the code
+// begins with enough JUMPDEST instructions to skip any instruction executed in its place.
+// Returns the constructed code.
+func mockedCallCode(returnData []byte) []byte {
+	// The longest instruction is PUSH32, which is 33 bytes long.
+	const instructionSledLength = 33
+	code := bytes.Repeat([]byte{byte(vm.JUMPDEST)}, instructionSledLength)
+
+	// Copy the return data (appended to the code) into memory, then return it. The offset of the return data is only
+	// known once the instructions are constructed, so it is set afterwards.
+	returnDataLength := uint32(len(returnData))
+	code = append(code, byte(vm.PUSH4))
+	code = binary.BigEndian.AppendUint32(code, returnDataLength)
+	code = append(code, byte(vm.PUSH4))
+	returnDataOffsetIndex := len(code)
+	code = binary.BigEndian.AppendUint32(code, 0)
+	code = append(code, byte(vm.PUSH1), 0, byte(vm.CODECOPY))
+	code = append(code, byte(vm.PUSH4))
+	code = binary.BigEndian.AppendUint32(code, returnDataLength)
+	code = append(code, byte(vm.PUSH1), 0, byte(vm.RETURN))
+	binary.BigEndian.PutUint32(code[returnDataOffsetIndex:], uint32(len(code)))
+	return append(code, returnData...)
+}
+
+// assertReversibleOnNextCall installs hooks on the frame which called the assertReversible cheat code, which snapshot
+// the state when the next call frame it enters begins executing, recording the original value of every storage slot
+// written from then on. When that call frame exits, the state is reverted to the snapshot and compared against the
+// recorded values, as well as the block number and timestamp. If the call did not invoke the provided selector, it
+// invalidated the snapshot, or any of its effects remain after reverting, the caller frame is failed so it is caught
+// by assertion testing.
+func assertReversibleOnNextCall(tracer *cheatCodeTracer, selector [4]byte) {
+	// Obtain the caller frame and define how it is failed, before its next instruction executes.
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	failCaller := func() {
+		cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+			// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+			failCallFrame(cheatCodeCallerFrame.vmScope.(*vm.ScopeContext))
+		})
+	}
+
+	// Cheat code contracts do not execute instructions, so this hook executes in the next call frame which does.
+	cheatCodeCallerFrame.onNextFrameEnterHooks.Push(func() {
+		calledFrame := tracer.CurrentCallFrame()
+		scopeContext := calledFrame.vmScope.(*vm.ScopeContext)
+		if !bytes.HasPrefix(scopeContext.Contract.Input, selector[:]) {
+			failCaller()
+			return
+		}
+
+		// Snapshot the state and environment, then begin recording the storage slots written by the call. The snapshot
+		// is tracked with those taken by the snapshot cheat code, so we can tell if the call invalidates it.
+		stateDB := tracer.chain.State()
+		snapshotID := stateDB.Snapshot()
+		stateSnapshots := tracer.stateSnapshots
+		stateSnapshots[snapshotID] = snapshotID
+		originalTime := tracer.chain.pendingBlockContext.Time
+		originalBlockNumber := new(big.Int).Set(tracer.chain.pendingBlockContext.BlockNumber)
+		storageWrites := make(map[cheatCodeStorageSlot]common.Hash)
+		tracer.storageWriteRecorders = append(tracer.storageWriteRecorders, storageWrites)
+
+		calledFrame.onFrameExitRestoreHooks.Push(func() {
+			tracer.storageWriteRecorders = tracer.storageWriteRecorders[:len(tracer.storageWriteRecorders)-1]
+
+			// If the call failed, the EVM has already reverted its changes (and our snapshot along with them). Otherwise,
+			// we revert to our snapshot. If the call reverted to a snapshot taken before ours, ours was invalidated, so
+			// the call's effects cannot be verified to be reversible.
+			if calledFrame.vmErr != nil {
+				delete(stateSnapshots, snapshotID)
+			} else if !revertToStateSnapshot(tracer, big.NewInt(int64(snapshotID)), true) {
+				failCaller()
+				return
+			}
+
+			// Verify no effects of the call remain.
+			reversible := tracer.chain.pendingBlockContext.Time == originalTime &&
+				tracer.chain.pendingBlockContext.BlockNumber.Cmp(originalBlockNumber) == 0
+			for storageSlot, originalValue := range storageWrites {
+				if stateDB.GetState(storageSlot.account, storageSlot.slot) != originalValue {
+					reversible = false
+					break
+				}
+			}
+			if !reversible {
+				failCaller()
+			}
+		})
+	})
+}
+
+// resolveFileAccessPath resolves a path provided to a file cheat code against the file access root described by the
+// provided cheat code configuration. Symbolic links in both the root and the path are resolved before checking that
+// the path lies within the root, so links cannot be used to escape it. If the path does not exist yet (e.g. a file
+// about to be written), its parent directory is resolved instead.
+// Returns the resolved absolute path, or an error if file access is disabled or the path lies outside the root.
+func resolveFileAccessPath(cheatCodeConfig config.CheatCodeConfig, path string) (string, error) {
+	// Ensure file access is enabled.
+	if !cheatCodeConfig.EnableFileAccess {
+		return "", fmt.Errorf("file access is not enabled in the chain configuration")
+	}
+
+	// Resolve the root, defaulting to the working directory.
+	root, err := filepath.Abs(cheatCodeConfig.FileAccessRoot)
+	if err == nil {
+		root, err = filepath.EvalSymlinks(root)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not resolve the file access root: %v", err)
+	}
+
+	// Resolve the path against the root, following any symbolic links. If it does not exist, we resolve its parent
+	// directory instead, unless the path itself is a symbolic link (whose target does not exist), as writing to it
+	// would create its target.
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if _, lstatErr := os.Lstat(path); !os.IsNotExist(err) || lstatErr == nil {
+			return "", fmt.Errorf("could not resolve path %q: %v", path, err)
+		}
+		resolvedPath, err = filepath.EvalSymlinks(filepath.Dir(path))
+		if err != nil {
+			return "", fmt.Errorf("could not resolve path %q: %v", path, err)
+		}
+		resolvedPath = filepath.Join(resolvedPath, filepath.Base(path))
+	}
+
+	// Ensure the resolved path does not escape the root.
+	relPath, err := filepath.Rel(root, resolvedPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside of the file access root", path)
+	}
+	return resolvedPath, nil
+}
+
+// addAssertionMethod adds an assertion cheat code method with the provided name to the provided contract, which takes
+// two arguments of the provided type. The method compares the arguments and, if the provided holds function returns
+// false for the result of the comparison, fails the caller EVM scope in the same way as a failed assert. The provided
+// failure operator is used to describe the arguments when the assertion fails, e.g. "assertEq failed: 3 != 4".
+func addAssertionMethod(contract *CheatCodeContract, name string, argType abi.Type, failureOperator string, holds func(comparison int) bool) {
+	contract.addMethod(
+		name, abi.Arguments{{Type: argType}, {Type: argType}}, abi.Arguments{},
+		func(tracer *cheatCodeTracer, inputs []any) ([]any, *cheatCodeRawReturnData) {
+			if holds(compareAssertionValues(inputs[0], inputs[1])) {
+				return nil, nil
+			}
+			message := fmt.Sprintf("%s failed: %s %s %s", name, formatAssertionValue(inputs[0]), failureOperator, formatAssertionValue(inputs[1]))
+			return nil, failAssertion(tracer, message)
+		},
+	)
+}
+
+// compareAssertionValues compares two decoded values of the same type, as provided to an assertion cheat code.
+// Returns -1 if a is less than b, 0 if they are equal, or 1 if a is greater than b. Values of types without an
+// ordering are compared by their encoding, which is only meaningful to test for equality.
+func compareAssertionValues(a any, b any) int {
+	switch a := a.(type) {
+	case *big.Int:
+		return a.Cmp(b.(*big.Int))
+	case common.Address:
+		return bytes.Compare(a[:], b.(common.Address).Bytes())
+	case [32]byte:
+		b := b.([32]byte)
+		return bytes.Compare(a[:], b[:])
+	case bool:
+		if a == b.(bool) {
+			return 0
+		} else if a {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// formatAssertionValue formats a decoded value provided to an assertion cheat code, to describe a failed assertion.
+// Returns the formatted value.
+func formatAssertionValue(value any) string {
+	switch value := value.(type) {
+	case common.Address:
+		return value.Hex()
+	case [32]byte:
+		return "0x" + hex.EncodeToString(value[:])
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// skipRevertData describes the revert data returned by the skip cheat code, encoded as an Error(string) so that it is
+// decoded like any other revert reason. Callers propagate it when they do not catch the revert, so that test providers
+// can mark the test which was executing as skipped.
+var skipRevertData = abiutils.EncodeSolidityRevertErrorString("skip: test was skipped")
+
+// IsSkipRevertData indicates whether the provided revert data was returned by the skip cheat code, signaling that the
+// test which was executing should be marked as skipped.
+func IsSkipRevertData(returnData []byte) bool {
+	return bytes.Equal(returnData, skipRevertData)
+}
+
+// failAssertion fails the frame which called an assertion cheat code in the same way as a failed assert, so the failure
+// is caught by assertion testing, once the cheat code call returns to it.
+// Returns revert data for the cheat code, which describes the failure using the provided message, so that it is shown
+// in execution traces.
+func failAssertion(tracer *cheatCodeTracer, message string) *cheatCodeRawReturnData {
+	cheatCodeCallerFrame := tracer.PreviousCallFrame()
+	cheatCodeCallerFrame.onNextOpcodeHooks.Push(func() {
+		// We can cast OpContext to ScopeContext because that is the type passed to OnOpcode.
+		failCallFrame(cheatCodeCallerFrame.vmScope.(*vm.ScopeContext))
+	})
+	return cheatCodeRevertData(abiutils.EncodeSolidityRevertErrorString(message))
+}
+
+// onNextCallExit installs a hook on the provided frame which called a cheat code, which executes onExit with the next
+// call frame it enters once that frame exits. Calls to cheat code contracts (e.g. to prank the next call) and mock ERC20
+// tokens are skipped, so onExit executes for the next call to any other contract. If the caller frame reverts before
+// then, its frame data (and this hook) is simply discarded.
+func onNextCallExit(tracer *cheatCodeTracer, cheatCodeCallerFrame *cheatCodeTracerCallFrame, onExit func(exitingCallFrame *cheatCodeTracerCallFrame)) {
+	var checkCallExit func()
+	checkCallExit = func() {
+		// If this was a call to a cheat code contract or mock ERC20 token, wait for the next call.
+		exitingCallFrame := tracer.CurrentCallFrame()
+		if exitingCallFrame.vmAddress == StandardCheatcodeContractAddress || exitingCallFrame.vmAddress == ConsoleLogContractAddress ||
+			isMockERC20Address(exitingCallFrame.vmAddress, tracer.chain.testChainConfig.MockERC20TokenCount) {
+			cheatCodeCallerFrame.onNextFrameExitRestoreHooks.Push(checkCallExit)
+			return
+		}
+		onExit(exitingCallFrame)
+	}
+	cheatCodeCallerFrame.onNextFrameExitRestoreHooks.Push(checkCallExit)
+}
+
+// failCallFrame causes the call frame executing in the provided scope to fail with an invalid opcode error, which is
+// treated as an assertion failure. The code executed by the frame is replaced with INVALID instructions, preserving
+// jump destinations so that an instruction which is about to jump does not fail differently.
+func failCallFrame(scopeContext *vm.ScopeContext) {
+	failingCode := make([]byte, len(scopeContext.Contract.Code))
+	for i, op := range scopeContext.Contract.Code {
+		if vm.OpCode(op) == vm.JUMPDEST {
+			failingCode[i] = op
+		} else {
+			failingCode[i] = byte(vm.INVALID)
+		}
+	}
+	scopeContext.Contract.Code = failingCode
+}
+
+// newCheatCodePrank creates a cheatCodePrank from the provided decoded cheat code inputs. The origin input may be nil
+// if the prank should not patch tx.origin. The delegateCall input may be nil if it was not provided, in which case
+// delegatecalls are pranked without spoofing their context. Otherwise, delegatecalls are skipped if it is false, or
+// executed in the context of the sender if it is true.
+func newCheatCodePrank(sender any, origin any, delegateCall any) *cheatCodePrank {
+	prank := &cheatCodePrank{
+		sender: sender.(common.Address),
+	}
+	if origin != nil {
+		originAddress := origin.(common.Address)
+		prank.origin = &originAddress
+	}
+	if delegateCall != nil {
+		prank.skipDelegateCalls = !delegateCall.(bool)
+		prank.spoofDelegateCallContext = delegateCall.(bool)
+	}
+	return prank
+}
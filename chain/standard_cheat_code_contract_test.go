@@ -0,0 +1,125 @@
+package chain
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/crytic/medusa/chain/config"
+	"github.com/crytic/medusa/utils"
+	"github.com/crytic/medusa/utils/reflectionutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReleaseStateSnapshot tests that releasing state snapshots removes them from the state, so taking and releasing
+// many snapshots uses a bounded amount of memory, while snapshots which were not released can still be reverted to.
+func TestReleaseStateSnapshot(t *testing.T) {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	assert.NoError(t, err)
+
+	// Define a helper to obtain the amount of snapshots held by the state.
+	snapshotCount := func() int {
+		revisionsField := reflect.ValueOf(stateDB).Elem().FieldByName("validRevisions")
+		return reflect.ValueOf(reflectionutils.GetField(revisionsField)).Len()
+	}
+
+	// Take a snapshot we keep, then take and release many snapshots, changing state after each.
+	account := common.HexToAddress("0x1234")
+	baseSnapshotID := stateDB.Snapshot()
+	for i := 0; i < 10_000; i++ {
+		snapshotID := stateDB.Snapshot()
+		stateDB.SetState(account, common.Hash{}, common.BigToHash(big.NewInt(int64(i+1))))
+		releaseStateSnapshot(stateDB, snapshotID)
+		assert.Equal(t, 1, snapshotCount())
+	}
+
+	// Releasing a snapshot which was already released does nothing.
+	releaseStateSnapshot(stateDB, baseSnapshotID+1)
+	assert.Equal(t, 1, snapshotCount())
+
+	// Releasing a snapshot taken between others leaves them intact.
+	middleSnapshotID := stateDB.Snapshot()
+	stateDB.SetState(account, common.Hash{}, common.BigToHash(big.NewInt(1)))
+	lastSnapshotID := stateDB.Snapshot()
+	releaseStateSnapshot(stateDB, middleSnapshotID)
+	assert.Equal(t, 2, snapshotCount())
+	stateDB.RevertToSnapshot(lastSnapshotID)
+
+	// The snapshot we kept can still be reverted to, undoing every change made since.
+	stateDB.RevertToSnapshot(baseSnapshotID)
+	assert.Equal(t, common.Hash{}, stateDB.GetState(account, common.Hash{}))
+	assert.Equal(t, 0, snapshotCount())
+}
+
+// TestResolveFileAccessPath tests that paths provided to file cheat codes are resolved within the file access root,
+// and that paths outside it, symbolic links escaping it, and any path when file access is disabled, are rejected.
+func TestResolveFileAccessPath(t *testing.T) {
+	// Create a file access root with a file and directory in it, next to a directory outside of it.
+	parentDir := t.TempDir()
+	root := filepath.Join(parentDir, "root")
+	outside := filepath.Join(parentDir, "outside")
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "dir"), 0755))
+	assert.NoError(t, os.MkdirAll(outside, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "file.txt"), []byte("data"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644))
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	assert.NoError(t, err)
+	cheatCodeConfig := config.CheatCodeConfig{EnableFileAccess: true, FileAccessRoot: root}
+
+	// Paths within the root are resolved against it, whether or not they exist yet.
+	resolvedPath, err := resolveFileAccessPath(cheatCodeConfig, "file.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(resolvedRoot, "file.txt"), resolvedPath)
+	resolvedPath, err = resolveFileAccessPath(cheatCodeConfig, "dir/new.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(resolvedRoot, "dir", "new.txt"), resolvedPath)
+
+	// Paths outside of the root are rejected, whether relative or absolute.
+	_, err = resolveFileAccessPath(cheatCodeConfig, "../outside/secret.txt")
+	assert.Error(t, err)
+	_, err = resolveFileAccessPath(cheatCodeConfig, filepath.Join(outside, "secret.txt"))
+	assert.Error(t, err)
+	_, err = resolveFileAccessPath(cheatCodeConfig, "dir/../../outside/new.txt")
+	assert.Error(t, err)
+
+	// Paths with files which do not exist in directories which do not exist are rejected.
+	_, err = resolveFileAccessPath(cheatCodeConfig, "missing/new.txt")
+	assert.Error(t, err)
+
+	// Symbolic links which escape the root are rejected, whether they link to a file to be read, a directory to be
+	// written to, or a file which does not exist yet. Creating symbolic links may not be permitted on Windows.
+	if !utils.IsWindowsEnvironment() {
+		assert.NoError(t, os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(root, "file_link.txt")))
+		assert.NoError(t, os.Symlink(outside, filepath.Join(root, "dir_link")))
+		assert.NoError(t, os.Symlink(filepath.Join(outside, "new.txt"), filepath.Join(root, "dangling_link.txt")))
+		assert.NoError(t, os.Symlink(filepath.Join(root, "dir"), filepath.Join(root, "inner_link")))
+		_, err = resolveFileAccessPath(cheatCodeConfig, "file_link.txt")
+		assert.Error(t, err)
+		_, err = resolveFileAccessPath(cheatCodeConfig, "dir_link/new.txt")
+		assert.Error(t, err)
+		_, err = resolveFileAccessPath(cheatCodeConfig, "dangling_link.txt")
+		assert.Error(t, err)
+
+		// Symbolic links within the root are followed.
+		resolvedPath, err = resolveFileAccessPath(cheatCodeConfig, "inner_link/new.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(resolvedRoot, "dir", "new.txt"), resolvedPath)
+
+		// A root which is itself a symbolic link is resolved before checking paths against it.
+		linkedRootConfig := config.CheatCodeConfig{EnableFileAccess: true, FileAccessRoot: filepath.Join(root, "inner_link")}
+		resolvedPath, err = resolveFileAccessPath(linkedRootConfig, "new.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(resolvedRoot, "dir", "new.txt"), resolvedPath)
+	}
+
+	// No path is resolved when file access is disabled.
+	cheatCodeConfig.EnableFileAccess = false
+	_, err = resolveFileAccessPath(cheatCodeConfig, "file.txt")
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/crytic/medusa/chain/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+// stateDiffTracerResultsKey describes the key to use when storing tracer results in call message results, or when
+// querying them.
+const stateDiffTracerResultsKey = "StateDiffTracerResults"
+
+// GetStateDiffTracerResults obtains the storage diffs stored by a StateDiffTracer from message results. This is nil if
+// no storage diffs were recorded by a tracer (e.g. StateDiffTracer was not attached during this message execution).
+func GetStateDiffTracerResults(messageResults *types.MessageResults) []types.StorageDiff {
+	// Try to obtain the results the tracer should've stored.
+	if genericResult, ok := messageResults.AdditionalResults[stateDiffTracerResultsKey]; ok {
+		if castedResult, ok := genericResult.([]types.StorageDiff); ok {
+			return castedResult
+		}
+	}
+
+	// If we could not obtain them, return nil.
+	return nil
+}
+
+// storeCheatCodeMethodID describes the method ID of the store cheat code, which writes to storage without executing an
+// SSTORE instruction.
+var storeCheatCodeMethodID = crypto.Keccak256([]byte("store(address,bytes32,bytes32)"))[:4]
+
+// StateDiffTracer implements TestChainTracer, capturing the storage slots changed by each transaction, along with
+// their values before and after it executed. This includes storage written by the store cheat code.
+type StateDiffTracer struct {
+	// evmContext refers to the last tracing.VMContext captured.
+	evmContext *tracing.VMContext
+
+	// recorder describes the storage slots written during the current transaction.
+	recorder *storageDiffRecorder
+
+	// nativeTracer is the underlying tracer interface that the state diff tracer follows
+	nativeTracer *TestChainTracer
+}
+
+// NewStateDiffTracer creates a StateDiffTracer and returns it.
+func NewStateDiffTracer() *StateDiffTracer {
+	tracer := &StateDiffTracer{}
+	innerTracer := &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: tracer.OnTxStart,
+			OnEnter:   tracer.OnEnter,
+			OnOpcode:  tracer.OnOpcode,
+		},
+	}
+	tracer.nativeTracer = &TestChainTracer{Tracer: innerTracer, CaptureTxEndSetAdditionalResults: tracer.CaptureTxEndSetAdditionalResults}
+
+	return tracer
+}
+
+// NativeTracer returns the underlying TestChainTracer.
+func (t *StateDiffTracer) NativeTracer() *TestChainTracer {
+	return t.nativeTracer
+}
+
+// OnTxStart is called upon the start of transaction execution, as defined by tracers.Tracer.
+func (t *StateDiffTracer) OnTxStart(vm *tracing.VMContext, tx *coretypes.Transaction, from common.Address) {
+	// Reset our tracer state
+	t.recorder = newStorageDiffRecorder()
+
+	// Store our evm reference
+	t.evmContext = vm
+}
+
+// OnEnter is called upon entering of the call frame, as defined by tracers.Tracer.
+func (t *StateDiffTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	// If this is a call to the store cheat code, record the storage slot it is about to write. If the cheat code does
+	// not end up writing to it, the slot's value is unchanged, so it is not reported.
+	if to == StandardCheatcodeContractAddress && len(input) >= 4+3*32 && bytes.Equal(input[:4], storeCheatCodeMethodID) {
+		account := common.BytesToAddress(input[4 : 4+32])
+		slot := common.BytesToHash(input[4+32 : 4+2*32])
+		t.recorder.recordWrite(t.evmContext.StateDB, account, slot)
+	}
+}
+
+// OnOpcode records data from an EVM state update, as defined by tracers.Tracer.
+func (t *StateDiffTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	// If this instruction writes to storage, record the value it overwrites if this is the first write to the slot.
+	if err == nil && op == byte(vm.SSTORE) {
+		stack := scope.StackData()
+		t.recorder.recordWrite(t.evmContext.StateDB, scope.Address(), stack[len(stack)-1].Bytes32())
+	}
+}
+
+// CaptureTxEndSetAdditionalResults can be used to set additional results captured from execution tracing. If this
+// tracer is used during transaction execution (block creation), the results can later be queried from the block.
+// This method will only be called on the added tracer if it implements the extended TestChainTracer interface.
+func (t *StateDiffTracer) CaptureTxEndSetAdditionalResults(results *types.MessageResults) {
+	results.AdditionalResults[stateDiffTracerResultsKey] = t.recorder.storageDiffs(t.evmContext.StateDB)
+}
@@ -0,0 +1,55 @@
+package chain
+
+import (
+	"github.com/crytic/medusa/chain/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+)
+
+// storageDiffRecorder records the storage slots written over a period of execution, along with the value each held
+// before it was first written, so the storage slots changed over that period can be determined.
+type storageDiffRecorder struct {
+	// originalValues maps the storage slots written since recording started to the value they held before they were
+	// first written.
+	originalValues map[cheatCodeStorageSlot]common.Hash
+
+	// writtenSlots describes the storage slots written since recording started, in the order they were first written.
+	writtenSlots []cheatCodeStorageSlot
+}
+
+// newStorageDiffRecorder creates a storageDiffRecorder and returns it.
+func newStorageDiffRecorder() *storageDiffRecorder {
+	return &storageDiffRecorder{
+		originalValues: make(map[cheatCodeStorageSlot]common.Hash),
+		writtenSlots:   make([]cheatCodeStorageSlot, 0),
+	}
+}
+
+// recordWrite records that the provided storage slot is about to be written, capturing its current value from the
+// provided state if this is the first write to it.
+func (r *storageDiffRecorder) recordWrite(stateDB tracing.StateDB, account common.Address, slot common.Hash) {
+	storageSlot := cheatCodeStorageSlot{account: account, slot: slot}
+	if _, recorded := r.originalValues[storageSlot]; !recorded {
+		r.originalValues[storageSlot] = stateDB.GetState(account, slot)
+		r.writtenSlots = append(r.writtenSlots, storageSlot)
+	}
+}
+
+// storageDiffs compares each storage slot written since recording started against its value in the provided state.
+// Slots which were written back to their original value, or whose writes were reverted, are not considered changed.
+// Returns the storage slots which changed, in the order they were first written.
+func (r *storageDiffRecorder) storageDiffs(stateDB tracing.StateDB) []types.StorageDiff {
+	storageDiffs := make([]types.StorageDiff, 0)
+	for _, storageSlot := range r.writtenSlots {
+		newValue := stateDB.GetState(storageSlot.account, storageSlot.slot)
+		if previousValue := r.originalValues[storageSlot]; newValue != previousValue {
+			storageDiffs = append(storageDiffs, types.StorageDiff{
+				Account:       storageSlot.account,
+				Slot:          storageSlot.slot,
+				PreviousValue: previousValue,
+				NewValue:      newValue,
+			})
+		}
+	}
+	return storageDiffs
+}
@@ -0,0 +1,70 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/crytic/medusa/chain/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStorageDiffRecorder tests that the storage diffs recorded describe slots whose values changed in the order they
+// were first written, and that they can be returned by the stopAndReturnStateDiff cheat code.
+func TestStorageDiffRecorder(t *testing.T) {
+	stateDB, err := state.New(coretypes.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	assert.NoError(t, err)
+	account := common.HexToAddress("0x1234")
+	stateDB.SetState(account, common.Hash{1}, common.Hash{1})
+
+	// Write to three slots, restoring one to its original value.
+	recorder := newStorageDiffRecorder()
+	for _, slot := range []common.Hash{{2}, {1}, {3}, {2}} {
+		recorder.recordWrite(stateDB, account, slot)
+		stateDB.SetState(account, slot, common.Hash{4})
+	}
+	stateDB.SetState(account, common.Hash{3}, common.Hash{})
+
+	// Only the slots which changed are reported, with the values they held before they were first written.
+	storageDiffs := recorder.storageDiffs(stateDB)
+	assert.Equal(t, []types.StorageDiff{
+		{Account: account, Slot: common.Hash{2}, PreviousValue: common.Hash{}, NewValue: common.Hash{4}},
+		{Account: account, Slot: common.Hash{1}, PreviousValue: common.Hash{1}, NewValue: common.Hash{4}},
+	}, storageDiffs)
+
+	// The storage diffs can be packed as the return value of the stopAndReturnStateDiff cheat code.
+	_, cheatCodeContracts, err := getCheatCodeProviders(0, nil, nil)
+	assert.NoError(t, err)
+	method := cheatCodeContracts[0].Abi().Methods["stopAndReturnStateDiff()"]
+	_, err = method.Outputs.Pack(storageDiffs)
+	assert.NoError(t, err)
+}
+
+// TestStateDiffTracerStoreCheatCode tests that the StateDiffTracer records storage written by the store cheat code,
+// which does not execute an SSTORE instruction.
+func TestStateDiffTracerStoreCheatCode(t *testing.T) {
+	stateDB, err := state.New(coretypes.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	assert.NoError(t, err)
+	account := common.HexToAddress("0x1234")
+	slot := common.Hash{1}
+	value := common.Hash{2}
+
+	// Trace a transaction which calls the store cheat code, performing the write it would make.
+	tracer := NewStateDiffTracer()
+	tracer.OnTxStart(&tracing.VMContext{StateDB: stateDB}, nil, common.Address{})
+	input := append(append(append([]byte{}, storeCheatCodeMethodID...), common.LeftPadBytes(account.Bytes(), 32)...), slot.Bytes()...)
+	input = append(input, value.Bytes()...)
+	tracer.OnEnter(1, byte(vm.CALL), common.Address{}, StandardCheatcodeContractAddress, input, 0, nil)
+	stateDB.SetState(account, slot, value)
+
+	// The write should be reported as a storage diff.
+	results := &types.MessageResults{AdditionalResults: make(map[string]any)}
+	tracer.CaptureTxEndSetAdditionalResults(results)
+	assert.Equal(t, []types.StorageDiff{
+		{Account: account, Slot: slot, PreviousValue: common.Hash{}, NewValue: value},
+	}, GetStateDiffTracerResults(results))
+}
@@ -45,10 +45,30 @@ type TestChain struct {
 	// the chain ID. This should be set when a new EVM is created by the test chain e.g. using vm.NewEVM.
 	pendingBlockChainConfig *params.ChainConfig
 
+	// pendingTxContext is the vm.TxContext for the transaction currently being executed in the pending block. This is
+	// used by cheatcodes to override the transaction origin. This should be set when a new EVM is created by the test
+	// chain e.g. using vm.NewEVM.
+	pendingTxContext *vm.TxContext
+
 	// BlockGasLimit defines the maximum amount of gas that can be consumed by transactions in a block.
 	// Transactions which push the block gas usage beyond this limit will not be added to a block without error.
 	BlockGasLimit uint64
 
+	// Labels maps addresses to human-readable names which are used to display them in execution traces.
+	Labels map[common.Address]string
+
+	// DeployedContractAddresses maps the names of contracts deployed while setting up the chain to the addresses they
+	// were deployed to.
+	DeployedContractAddresses map[string]common.Address
+
+	// CustomMetrics describes the counters and gauges recorded by the incrementCounter and setGauge cheat codes on this
+	// chain. This may be replaced with an instance shared across chains to aggregate their values.
+	CustomMetrics *CustomMetrics
+
+	// CoverageCountFunc describes a function which returns the amount of unique program counters covered on this chain,
+	// as reported by the getCoverageCount cheat code. This is nil if coverage is not being collected.
+	CoverageCountFunc func() uint64
+
 	// testChainConfig represents the configuration used by this TestChain.
 	testChainConfig *config.TestChainConfig
 
@@ -96,15 +116,6 @@ func NewTestChain(genesisAlloc types.GenesisAlloc, testChainConfig *config.TestC
 		return nil, err
 	}
 
-	// TODO: go-ethereum doesn't set cancun start time for THEIR test `ChainConfig` struct.
-	//   Note: We have our own `TestChainConfig` definition that is different (second argument in this function).
-	//  We should allow the user to provide a go-ethereum `ChainConfig` to do custom fork selection, inside of our
-	//  `TestChainConfig` definition. Or we should wrap it in our own struct to simplify the options and not pollute
-	//  our overall medusa project config.
-	cancunTime := uint64(0)
-	chainConfig.ShanghaiTime = &cancunTime
-	chainConfig.CancunTime = &cancunTime
-
 	// Create our genesis definition with our default chain config.
 	genesisDefinition := &core.Genesis{
 		Config:    chainConfig,
@@ -132,6 +143,20 @@ func NewTestChain(genesisAlloc types.GenesisAlloc, testChainConfig *config.TestC
 		}
 	}
 
+	// Activate the time-based forks up to the configured fork. go-ethereum's test `ChainConfig` activates every
+	// block-based fork up to London, and whether the Merge (Paris) has occurred is determined by the block context.
+	forkTime := uint64(0)
+	switch testChainConfig.Fork {
+	case config.ForkLondon, config.ForkParis:
+	case config.ForkShanghai:
+		chainConfig.ShanghaiTime = &forkTime
+	case config.ForkCancun, "":
+		chainConfig.ShanghaiTime = &forkTime
+		chainConfig.CancunTime = &forkTime
+	default:
+		return nil, fmt.Errorf("unsupported fork %q in the chain configuration", testChainConfig.Fork)
+	}
+
 	// Obtain our VM extensions from our config
 	vmConfigExtensions := testChainConfig.GetVMConfigExtensions()
 
@@ -143,7 +168,11 @@ func NewTestChain(genesisAlloc types.GenesisAlloc, testChainConfig *config.TestC
 	if testChainConfig.CheatCodeConfig.CheatCodesEnabled {
 		// Obtain our cheatcode providers
 		var cheatContracts []*CheatCodeContract
-		cheatTracer, cheatContracts, err = getCheatCodeProviders()
+		callerAllowlist, err := utils.HexStringsToAddresses(testChainConfig.CheatCodeConfig.CallerAllowlist)
+		if err != nil {
+			return nil, err
+		}
+		cheatTracer, cheatContracts, err = getCheatCodeProviders(testChainConfig.MockERC20TokenCount, testChainConfig.CheatCodeConfig.DisabledCheatcodes, callerAllowlist)
 		if err != nil {
 			return nil, err
 		}
@@ -180,18 +209,21 @@ func NewTestChain(genesisAlloc types.GenesisAlloc, testChainConfig *config.TestC
 
 	// Create our instance
 	chain := &TestChain{
-		genesisDefinition:       genesisDefinition,
-		BlockGasLimit:           genesisBlock.Header().GasLimit,
-		blocks:                  []*chainTypes.Block{testChainGenesisBlock},
-		pendingBlock:            nil,
-		db:                      db,
-		state:                   nil,
-		stateDatabase:           stateDatabase,
-		transactionTracerRouter: transactionTracerRouter,
-		callTracerRouter:        callTracerRouter,
-		testChainConfig:         testChainConfig,
-		chainConfig:             genesisDefinition.Config,
-		vmConfigExtensions:      vmConfigExtensions,
+		genesisDefinition:         genesisDefinition,
+		BlockGasLimit:             genesisBlock.Header().GasLimit,
+		blocks:                    []*chainTypes.Block{testChainGenesisBlock},
+		pendingBlock:              nil,
+		db:                        db,
+		state:                     nil,
+		stateDatabase:             stateDatabase,
+		transactionTracerRouter:   transactionTracerRouter,
+		callTracerRouter:          callTracerRouter,
+		testChainConfig:           testChainConfig,
+		chainConfig:               genesisDefinition.Config,
+		vmConfigExtensions:        vmConfigExtensions,
+		Labels:                    make(map[common.Address]string),
+		DeployedContractAddresses: make(map[string]common.Address),
+		CustomMetrics:             NewCustomMetrics(),
 	}
 
 	// Add our internal tracers to this chain.
@@ -233,6 +265,10 @@ func (t *TestChain) Clone(onCreateFunc func(chain *TestChain) error) (*TestChain
 		return nil, err
 	}
 
+	// Copy our address labels and deployed contract addresses so they are not shared across chains.
+	targetChain.Labels = maps.Clone(t.Labels)
+	targetChain.DeployedContractAddresses = maps.Clone(t.DeployedContractAddresses)
+
 	// If we have a provided function for our creation event, execute it now
 	if onCreateFunc != nil {
 		err = onCreateFunc(targetChain)
@@ -483,6 +519,7 @@ func (t *TestChain) CallContract(msg *core.Message, state *state.StateDB, additi
 	// Set our block context and chain config in order for cheatcodes to override what EVM interpreter sees.
 	t.pendingBlockContext = &evm.Context
 	t.pendingBlockChainConfig = evm.ChainConfig()
+	t.pendingTxContext = &evm.TxContext
 
 	// Create a tx from our msg, for hashing/receipt purposes
 	tx := utils.MessageToTransaction(msg)
@@ -647,6 +684,7 @@ func (t *TestChain) PendingBlockAddTx(message *core.Message, additionalTracers .
 	// Set our block context and chain config in order for cheatcodes to override what EVM interpreter sees.
 	t.pendingBlockContext = &evm.Context
 	t.pendingBlockChainConfig = evm.ChainConfig()
+	t.pendingTxContext = &evm.TxContext
 
 	// Apply our transaction
 	var usedGas uint64
@@ -663,8 +701,9 @@ func (t *TestChain) PendingBlockAddTx(message *core.Message, additionalTracers .
 		AdditionalResults: make(map[string]any, 0),
 	}
 
-	// For every tracer we have, we call upon them to set their results for this transaction now.
-	t.transactionTracerRouter.CaptureTxEndSetAdditionalResults(messageResult)
+	// For every tracer we have, including any additional tracers provided for this transaction, we call upon them to
+	// set their results for this transaction now.
+	extendedTracerRouter.CaptureTxEndSetAdditionalResults(messageResult)
 
 	// Update our gas used in the block header
 	t.pendingBlock.Header.GasUsed += receipt.GasUsed
@@ -716,9 +755,10 @@ func (t *TestChain) PendingBlockCommit() error {
 		return err
 	}
 
-	// Discard the test chain's reference to the EVM interpreter's block context and chain config.
+	// Discard the test chain's reference to the EVM interpreter's block context, chain config, and tx context.
 	t.pendingBlockContext = nil
 	t.pendingBlockChainConfig = nil
+	t.pendingTxContext = nil
 
 	// Append our new block to our chain.
 	// Update the block hash since cheatcodes may have changed aspects of the header (e.g. time or number)
@@ -753,6 +793,7 @@ func (t *TestChain) PendingBlockDiscard() error {
 	t.pendingBlock = nil
 	t.pendingBlockContext = nil
 	t.pendingBlockChainConfig = nil
+	t.pendingTxContext = nil
 
 	// Emit our contract change events for the messages reverted
 	err := t.emitContractChangeEvents(true, pendingBlock.MessageResults...)
@@ -799,6 +840,7 @@ func (t *TestChain) emitContractChangeEvents(reverting bool, messageResults ...*
 						Chain:             t,
 						Contract:          deploymentChange.Contract,
 						DynamicDeployment: deploymentChange.DynamicCreation,
+						Deployer:          deploymentChange.Deployer,
 					})
 				} else if deploymentChange.Destroyed {
 					err = t.Events.ContractDeploymentRemovedEventEmitter.Publish(ContractDeploymentsRemovedEvent{
@@ -831,6 +873,7 @@ func (t *TestChain) emitContractChangeEvents(reverting bool, messageResults ...*
 						Chain:             t,
 						Contract:          deploymentChange.Contract,
 						DynamicDeployment: deploymentChange.DynamicCreation,
+						Deployer:          deploymentChange.Deployer,
 					})
 				}
 				if err != nil {
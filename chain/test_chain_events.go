@@ -3,6 +3,7 @@ package chain
 import (
 	"github.com/crytic/medusa/chain/types"
 	"github.com/crytic/medusa/events"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // TestChainEvents defines event emitters for a TestChain.
@@ -97,6 +98,10 @@ type ContractDeploymentsAddedEvent struct {
 	// DynamicDeployment describes whether this contract deployment was dynamic (e.g. `c = new MyContract()`) or was
 	// because of a traditional transaction
 	DynamicDeployment bool
+
+	// Deployer describes the address which deployed the contract: the sender of the transaction, or the contract which
+	// deployed it dynamically.
+	Deployer common.Address
 }
 
 // ContractDeploymentsRemovedEvent describes an event where a contract has become unavailable on the TestChain, either
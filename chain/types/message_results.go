@@ -22,6 +22,14 @@ type MessageResults struct {
 	// ContractDeploymentChanges describes changes made to deployed contracts, such as creation and destruction.
 	ContractDeploymentChanges []DeployedContractBytecodeChange
 
+	// CheatCodesUsed describes the names of the cheat code methods which were invoked while executing this
+	// transaction, in the order they were first used.
+	CheatCodesUsed []string
+
+	// AssumptionFailed describes whether an assume cheat code was invoked with a false condition while executing this
+	// transaction, indicating its inputs should be discarded rather than treated as an executed call.
+	AssumptionFailed bool
+
 	// AdditionalResults represents results of arbitrary types which can be stored by any part of the application,
 	// such as a tracers.
 	AdditionalResults map[string]any
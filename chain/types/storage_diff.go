@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StorageDiff describes a change made to the value of a storage slot of a given account due to state updates (e.g.
+// from a transaction being processed). Its fields match the components of the ABI tuple returned by the
+// stopAndReturnStateDiff cheat code, so it can be packed into it directly.
+type StorageDiff struct {
+	// Account describes the address of the account which holds the storage slot.
+	Account common.Address
+
+	// Slot describes the key of the storage slot which was changed.
+	Slot common.Hash
+
+	// PreviousValue describes the value the storage slot held before the change.
+	PreviousValue common.Hash
+
+	// NewValue describes the value the storage slot held after the change.
+	NewValue common.Hash
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/crytic/medusa/fuzzing/corpus"
+	"github.com/crytic/medusa/logging/colors"
+	"github.com/spf13/cobra"
+)
+
+// corpusCmd represents the command provider for corpus operations
+var corpusCmd = &cobra.Command{
+	Use:   "corpus",
+	Short: "Provides operations on corpus directories",
+	Long:  `Provides operations on corpus directories`,
+}
+
+// corpusDiffCmd represents the command provider for diffing two corpus directories
+var corpusDiffCmd = &cobra.Command{
+	Use:           "diff <a> <b>",
+	Short:         "Reports the call sequences unique to, or shared between, two corpus directories",
+	Long:          `Reports the call sequences unique to, or shared between, two corpus directories, comparing them by fingerprint`,
+	Args:          cmdValidateCorpusDiffArgs,
+	RunE:          cmdRunCorpusDiff,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	// Add the corpus command and its sub-commands to the root command
+	corpusCmd.AddCommand(corpusDiffCmd)
+	rootCmd.AddCommand(corpusCmd)
+}
+
+// cmdValidateCorpusDiffArgs makes sure that exactly two positional arguments, the corpus directories, are provided
+func cmdValidateCorpusDiffArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+		err = fmt.Errorf("corpus diff accepts exactly two positional arguments, the paths to the corpus directories to compare")
+		cmdLogger.Error("Failed to validate args to the corpus diff command", err)
+		return err
+	}
+	return nil
+}
+
+// cmdRunCorpusDiff executes the CLI corpus diff command, loading both corpus directories and reporting the call
+// sequences which are only in the first, only in the second, and in both.
+func cmdRunCorpusDiff(cmd *cobra.Command, args []string) error {
+	// Load both corpora, verifying their directories exist first, as a missing corpus directory is otherwise
+	// treated as an empty corpus.
+	corpora := make([]*corpus.Corpus, len(args))
+	for i, corpusDirectory := range args {
+		if info, err := os.Stat(corpusDirectory); err != nil || !info.IsDir() {
+			err = fmt.Errorf("corpus directory '%v' does not exist", corpusDirectory)
+			cmdLogger.Error("Failed to run the corpus diff command", err)
+			return err
+		}
+		c, err := corpus.NewCorpus(corpusDirectory)
+		if err != nil {
+			cmdLogger.Error("Failed to run the corpus diff command", err)
+			return err
+		}
+		corpora[i] = c
+	}
+
+	// Diff the corpora
+	diff, err := corpus.DiffCorpora(corpora[0], corpora[1])
+	if err != nil {
+		cmdLogger.Error("Failed to run the corpus diff command", err)
+		return err
+	}
+
+	// Report the call sequences unique to each corpus, followed by a summary.
+	for _, entry := range diff.OnlyA {
+		cmdLogger.Info("Only in ", colors.Bold, args[0], colors.Reset, ": ", entry.FileName, " (", entry.Fingerprint.String(), ")")
+	}
+	for _, entry := range diff.OnlyB {
+		cmdLogger.Info("Only in ", colors.Bold, args[1], colors.Reset, ": ", entry.FileName, " (", entry.Fingerprint.String(), ")")
+	}
+	cmdLogger.Info(
+		"Corpus diff summary: ",
+		colors.Bold, len(diff.OnlyA), colors.Reset, " sequence(s) only in ", args[0], ", ",
+		colors.Bold, len(diff.OnlyB), colors.Reset, " sequence(s) only in ", args[1], ", ",
+		colors.Bold, len(diff.Both), colors.Reset, " sequence(s) in both",
+	)
+	return nil
+}
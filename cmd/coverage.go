@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crytic/medusa/cmd/exitcodes"
+	"github.com/crytic/medusa/fuzzing"
+	"github.com/spf13/cobra"
+)
+
+// coverageCmd represents the command provider for coverage operations
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Provides operations on coverage collected by corpus directories",
+	Long:  `Provides operations on coverage collected by corpus directories`,
+}
+
+// coverageMergeCmd represents the command provider for merging the coverage of several corpus directories
+var coverageMergeCmd = &cobra.Command{
+	Use:           "merge <corpus-directory>... --output <directory>",
+	Short:         "Merges the coverage of several corpus directories into combined coverage reports",
+	Long:          `Replays the call sequences of each corpus directory against the current code to measure their coverage, then writes coverage reports describing the combined coverage of all of them`,
+	Args:          cmdValidateCoverageMergeArgs,
+	RunE:          cmdRunCoverageMerge,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	// Add all the flags allowed for the coverage merge command
+	err := addCoverageMergeFlags()
+	if err != nil {
+		cmdLogger.Panic("Failed to initialize the coverage merge command", err)
+	}
+
+	// Add the coverage command and its sub-commands to the root command
+	coverageCmd.AddCommand(coverageMergeCmd)
+	rootCmd.AddCommand(coverageCmd)
+}
+
+// cmdValidateCoverageMergeArgs makes sure that at least one positional argument, a corpus directory, is provided
+func cmdValidateCoverageMergeArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+		err = fmt.Errorf("coverage merge requires at least one positional argument, the paths to the corpus directories to merge")
+		cmdLogger.Error("Failed to validate args to the coverage merge command", err)
+		return err
+	}
+	return nil
+}
+
+// cmdRunCoverageMerge executes the CLI coverage merge command. The project configuration is resolved in the same way
+// as for the fuzz command, after which each corpus directory is replayed to measure its coverage, and the merged
+// coverage is written to the output directory.
+func cmdRunCoverageMerge(cmd *cobra.Command, args []string) error {
+	// Resolve the corpus and output directory paths before changing our working directory, so relative paths are
+	// relative to where the command was invoked from. We also verify the corpus directories exist, as a missing corpus
+	// directory is otherwise treated as an empty corpus.
+	corpusDirectories := make([]string, len(args))
+	for i, corpusDirectory := range args {
+		if info, err := os.Stat(corpusDirectory); err != nil || !info.IsDir() {
+			err = fmt.Errorf("corpus directory '%v' does not exist", corpusDirectory)
+			cmdLogger.Error("Failed to run the coverage merge command", err)
+			return err
+		}
+		absCorpusDirectory, err := filepath.Abs(corpusDirectory)
+		if err != nil {
+			cmdLogger.Error("Failed to run the coverage merge command", err)
+			return err
+		}
+		corpusDirectories[i] = absCorpusDirectory
+	}
+	outputDirectory, err := cmd.Flags().GetString("output")
+	if err != nil {
+		cmdLogger.Error("Failed to run the coverage merge command", err)
+		return err
+	}
+	outputDirectory, err = filepath.Abs(outputDirectory)
+	if err != nil {
+		cmdLogger.Error("Failed to run the coverage merge command", err)
+		return err
+	}
+
+	// Read our project configuration
+	projectConfig, configPath, err := readProjectConfig(cmd)
+	if err != nil {
+		cmdLogger.Error("Failed to run the coverage merge command", err)
+		return err
+	}
+
+	// Update the project configuration given whatever flags were set using the CLI
+	err = updateProjectConfigWithCoverageMergeFlags(cmd, projectConfig)
+	if err != nil {
+		cmdLogger.Error("Failed to run the coverage merge command", err)
+		return err
+	}
+
+	// Change our working directory to the parent directory of the project configuration file, as compilation paths
+	// may be relative to it.
+	err = os.Chdir(filepath.Dir(configPath))
+	if err != nil {
+		cmdLogger.Error("Failed to run the coverage merge command", err)
+		return err
+	}
+
+	// Create our fuzzer, which compiles and sets up the project for replaying the corpora.
+	fuzzer, fuzzErr := fuzzing.NewFuzzer(*projectConfig)
+	if fuzzErr != nil {
+		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+	}
+
+	// Merge the coverage of our corpora.
+	_, fuzzErr = fuzzer.MergeCoverage(corpusDirectories, outputDirectory)
+	if fuzzErr != nil {
+		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/spf13/cobra"
+)
+
+// addCoverageMergeFlags adds the various flags for the coverage merge command
+func addCoverageMergeFlags() error {
+	// Prevent alphabetical sorting of usage message
+	coverageMergeCmd.Flags().SortFlags = false
+
+	// Output directory
+	coverageMergeCmd.Flags().String("output", "", "directory to write the merged coverage reports to")
+	err := coverageMergeCmd.MarkFlagRequired("output")
+	if err != nil {
+		return err
+	}
+
+	// Config file
+	coverageMergeCmd.Flags().String("config", "", "path to config file")
+
+	// Compilation Target
+	coverageMergeCmd.Flags().String("compilation-target", "", TargetFlagDescription)
+
+	// Coverage report formats
+	coverageMergeCmd.Flags().StringSlice("coverage-formats", []string{}, "coverage report formats to write (unless a config file is provided, default is html and lcov)")
+
+	// Logging color
+	coverageMergeCmd.Flags().Bool("no-color", false, "disables colored terminal output")
+	return nil
+}
+
+// updateProjectConfigWithCoverageMergeFlags will update the given projectConfig with any CLI arguments that were
+// provided to the coverage merge command
+func updateProjectConfigWithCoverageMergeFlags(cmd *cobra.Command, projectConfig *config.ProjectConfig) error {
+	var err error
+
+	// If --compilation-target was used
+	if cmd.Flags().Changed("compilation-target") {
+		// Get the new target
+		newTarget, err := cmd.Flags().GetString("compilation-target")
+		if err != nil {
+			return err
+		}
+
+		err = projectConfig.Compilation.SetTarget(newTarget)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Update coverage report formats
+	if cmd.Flags().Changed("coverage-formats") {
+		projectConfig.Fuzzing.CoverageFormats, err = cmd.Flags().GetStringSlice("coverage-formats")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Update logging color mode
+	if cmd.Flags().Changed("no-color") {
+		projectConfig.Logging.NoColor, err = cmd.Flags().GetBool("no-color")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
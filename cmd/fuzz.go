@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -76,66 +77,49 @@ func cmdValidateFuzzArgs(cmd *cobra.Command, args []string) error {
 // #2: If a custom file was provided (--config was used), and we can't find the file, throw an error.
 // #3: If medusa.json can't be found, use the default project configuration.
 func cmdRunFuzz(cmd *cobra.Command, args []string) error {
-	var projectConfig *config.ProjectConfig
-
-	// Check to see if --config flag was used and store the value of --config flag
-	configFlagUsed := cmd.Flags().Changed("config")
-	configPath, err := cmd.Flags().GetString("config")
+	// Read our project configuration
+	projectConfig, configPath, err := readProjectConfig(cmd)
 	if err != nil {
 		cmdLogger.Error("Failed to run the fuzz command", err)
 		return err
 	}
 
-	// If --config was not used, look for `medusa.json` in the current work directory
-	if !configFlagUsed {
-		workingDirectory, err := os.Getwd()
-		if err != nil {
-			cmdLogger.Error("Failed to run the fuzz command", err)
-			return err
-		}
-		configPath = filepath.Join(workingDirectory, DefaultProjectConfigFilename)
+	// Update the project configuration given whatever flags were set using the CLI
+	err = updateProjectConfigWithFuzzFlags(cmd, projectConfig)
+	if err != nil {
+		cmdLogger.Error("Failed to run the fuzz command", err)
+		return err
 	}
 
-	// Check to see if the file exists at configPath
-	_, existenceError := os.Stat(configPath)
-
-	// Possibility #1: File was found
-	if existenceError == nil {
-		// Try to read the configuration file and throw an error if something goes wrong
-		cmdLogger.Info("Reading the configuration file at: ", colors.Bold, configPath, colors.Reset)
-		// Use the default compilation platform if the config file doesn't specify one
-		projectConfig, err = config.ReadProjectConfigFromFile(configPath, DefaultCompilationPlatform)
+	// Resolve the JSON output path before changing our working directory, so relative paths are relative to where
+	// the command was invoked from.
+	jsonOutputPath, err := cmd.Flags().GetString("json-output")
+	if err != nil {
+		cmdLogger.Error("Failed to run the fuzz command", err)
+		return err
+	}
+	if jsonOutputPath != "" {
+		jsonOutputPath, err = filepath.Abs(jsonOutputPath)
 		if err != nil {
 			cmdLogger.Error("Failed to run the fuzz command", err)
 			return err
 		}
 	}
 
-	// Possibility #2: If the --config flag was used, and we couldn't find the file, we'll throw an error
-	if configFlagUsed && existenceError != nil {
+	// Resolve the trace JSON output path in the same way.
+	traceJSONPath, err := cmd.Flags().GetString("trace-json")
+	if err != nil {
 		cmdLogger.Error("Failed to run the fuzz command", err)
-		return existenceError
+		return err
 	}
-
-	// Possibility #3: --config flag was not used and medusa.json was not found, so use the default project config
-	if !configFlagUsed && existenceError != nil {
-		cmdLogger.Warn(fmt.Sprintf("Unable to find the config file at %v, will use the default project configuration for the "+
-			"%v compilation platform instead", configPath, DefaultCompilationPlatform))
-
-		projectConfig, err = config.GetDefaultProjectConfig(DefaultCompilationPlatform)
+	if traceJSONPath != "" {
+		traceJSONPath, err = filepath.Abs(traceJSONPath)
 		if err != nil {
 			cmdLogger.Error("Failed to run the fuzz command", err)
 			return err
 		}
 	}
 
-	// Update the project configuration given whatever flags were set using the CLI
-	err = updateProjectConfigWithFuzzFlags(cmd, projectConfig)
-	if err != nil {
-		cmdLogger.Error("Failed to run the fuzz command", err)
-		return err
-	}
-
 	// Change our working directory to the parent directory of the project configuration file
 	// This is important as when we compile for a given platform, the paths may be relative to wherever the
 	// configuration is supplied from. Providing a file path explicitly is optional anyways, so we _should_
@@ -150,30 +134,208 @@ func cmdRunFuzz(cmd *cobra.Command, args []string) error {
 		cmdLogger.Warn("Disabling coverage may limit efficacy of fuzzing. Consider enabling coverage for better results.")
 	}
 
-	// Create our fuzzing
-	fuzzer, fuzzErr := fuzzing.NewFuzzer(*projectConfig)
+	// If self-check mode is enabled, adjust our configuration so the campaign produces the same results each time it
+	// is run, then run it once before the campaign whose results we report, so the results of both can be compared.
+	selfCheck, err := cmd.Flags().GetBool("self-check")
+	if err != nil {
+		cmdLogger.Error("Failed to run the fuzz command", err)
+		return err
+	}
+	var selfCheckFuzzer *fuzzing.Fuzzer
+	if selfCheck {
+		*projectConfig, err = fuzzing.SelfCheckProjectConfig(*projectConfig)
+		if err != nil {
+			cmdLogger.Error("Failed to run the fuzz command", err)
+			return err
+		}
+		cmdLogger.Info("Self-check mode is enabled, the campaign will be run twice with random seed ", colors.Bold, *projectConfig.Fuzzing.RandomSeed, colors.Reset)
+
+		var fuzzErr error
+		selfCheckFuzzer, fuzzErr = startFuzzer(projectConfig, true)
+		if fuzzErr != nil {
+			return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+		}
+	}
+
+	// Create and start our fuzzer
+	fuzzer, fuzzErr := startFuzzer(projectConfig, selfCheck)
+	if fuzzer == nil {
+		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+	}
+
+	// Write our failed test cases in JSON format if requested
+	if jsonOutputPath != "" {
+		err = writeFailedTestCasesJSON(fuzzer, jsonOutputPath)
+		if err != nil {
+			cmdLogger.Error("Failed to write failed test cases to the JSON output file", err)
+			if fuzzErr == nil {
+				return exitcodes.NewErrorWithExitCode(err, exitcodes.ExitCodeHandledError)
+			}
+		}
+	}
+
+	// Write the execution trace of our last failing call sequence in JSON format if requested
+	if traceJSONPath != "" {
+		err = writeLastFailingTraceJSON(fuzzer, traceJSONPath)
+		if err != nil {
+			cmdLogger.Error("Failed to write the execution trace to the trace JSON output file", err)
+			if fuzzErr == nil {
+				return exitcodes.NewErrorWithExitCode(err, exitcodes.ExitCodeHandledError)
+			}
+		}
+	}
+
 	if fuzzErr != nil {
 		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
 	}
 
+	// If self-check mode is enabled, verify both campaigns produced the same results.
+	if selfCheckFuzzer != nil {
+		err = fuzzer.CompareCampaignResults(selfCheckFuzzer)
+		if err != nil {
+			cmdLogger.Error("Self-check failed, the fuzzing campaign did not produce the same results when run twice", err)
+			return exitcodes.NewErrorWithExitCode(err, exitcodes.ExitCodeHandledError)
+		}
+		cmdLogger.Info("Self-check passed, both runs of the fuzzing campaign produced the same results")
+	}
+
+	// If we have no error and failed test cases, we'll want to return a special exit code
+	if fuzzErr == nil && len(fuzzer.TestCasesWithStatus(fuzzing.TestCaseStatusFailed)) > 0 {
+		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeTestFailed)
+	}
+
+	return fuzzErr
+}
+
+// startFuzzer creates a fuzzer with the provided project configuration and runs a fuzzing campaign with it, stopping it
+// on keyboard interrupts. If selfCheck is true, the campaign is run in self-check mode.
+// Returns the fuzzer (or nil if it could not be created), and any error encountered while creating or running it.
+func startFuzzer(projectConfig *config.ProjectConfig, selfCheck bool) (*fuzzing.Fuzzer, error) {
+	// Create our fuzzing
+	fuzzer, err := fuzzing.NewFuzzer(*projectConfig)
+	if err != nil {
+		return nil, err
+	}
+	if selfCheck {
+		fuzzer.EnableSelfCheck()
+	}
+
 	// Stop our fuzzing on keyboard interrupts
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
+	defer signal.Stop(c)
 	go func() {
 		<-c
 		fuzzer.Stop()
 	}()
 
 	// Start the fuzzing process with our cancellable context.
-	fuzzErr = fuzzer.Start()
-	if fuzzErr != nil {
-		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+	return fuzzer, fuzzer.Start()
+}
+
+// writeFailedTestCasesJSON writes a fuzzing.TestCaseReport for each failed test case of the provided fuzzer to the
+// provided file path, as a JSON array.
+func writeFailedTestCasesJSON(fuzzer *fuzzing.Fuzzer, path string) error {
+	failedTestCases := fuzzer.TestCasesWithStatus(fuzzing.TestCaseStatusFailed)
+	reports := make([]fuzzing.TestCaseReport, len(failedTestCases))
+	for i, testCase := range failedTestCases {
+		reports[i] = fuzzing.NewTestCaseReport(testCase)
 	}
 
-	// If we have no error and failed test cases, we'll want to return a special exit code
-	if fuzzErr == nil && len(fuzzer.TestCasesWithStatus(fuzzing.TestCaseStatusFailed)) > 0 {
-		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeTestFailed)
+	b, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
 	}
+	return os.WriteFile(path, b, 0644)
+}
 
-	return fuzzErr
+// writeLastFailingTraceJSON writes the execution traces of the call sequence of the test case which most recently
+// failed in the provided fuzzer (see fuzzing.Fuzzer.LastFailedTestCase) to the provided file path, as a JSON array with
+// an entry for each call in the sequence. Calls which were not traced (e.g. when traceAll is disabled, only the last
+// call is traced) have a null entry. If no test case failed, no file is written.
+func writeLastFailingTraceJSON(fuzzer *fuzzing.Fuzzer, path string) error {
+	lastFailedTestCase := fuzzer.LastFailedTestCase()
+	if lastFailedTestCase == nil {
+		cmdLogger.Info("No failed test cases were found, so no execution trace was written to the trace JSON output file")
+		return nil
+	}
+	callSequence := lastFailedTestCase.CallSequence()
+	if callSequence == nil {
+		return fmt.Errorf("the last failed test case has no call sequence to write an execution trace for")
+	}
+
+	traces := make([]json.RawMessage, len(*callSequence))
+	for i, callSequenceElement := range *callSequence {
+		if callSequenceElement.ExecutionTrace == nil {
+			traces[i] = json.RawMessage("null")
+			continue
+		}
+		traceJSON, err := callSequenceElement.ExecutionTrace.JSON()
+		if err != nil {
+			return err
+		}
+		traces[i] = traceJSON
+	}
+
+	b, err := json.MarshalIndent(traces, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// readProjectConfig reads the project configuration for a command which supports the --config flag, following the
+// possibilities described for cmdRunFuzz.
+// Returns the project configuration, the path of the configuration file it was (or would have been) read from, or an
+// error if one occurred.
+func readProjectConfig(cmd *cobra.Command) (*config.ProjectConfig, string, error) {
+	var projectConfig *config.ProjectConfig
+
+	// Check to see if --config flag was used and store the value of --config flag
+	configFlagUsed := cmd.Flags().Changed("config")
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, "", err
+	}
+
+	// If --config was not used, look for `medusa.json` in the current work directory
+	if !configFlagUsed {
+		workingDirectory, err := os.Getwd()
+		if err != nil {
+			return nil, "", err
+		}
+		configPath = filepath.Join(workingDirectory, DefaultProjectConfigFilename)
+	}
+
+	// Check to see if the file exists at configPath
+	_, existenceError := os.Stat(configPath)
+
+	// Possibility #1: File was found
+	if existenceError == nil {
+		// Try to read the configuration file and throw an error if something goes wrong
+		cmdLogger.Info("Reading the configuration file at: ", colors.Bold, configPath, colors.Reset)
+		// Use the default compilation platform if the config file doesn't specify one
+		projectConfig, err = config.ReadProjectConfigFromFile(configPath, DefaultCompilationPlatform)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Possibility #2: If the --config flag was used, and we couldn't find the file, we'll throw an error
+	if configFlagUsed && existenceError != nil {
+		return nil, "", existenceError
+	}
+
+	// Possibility #3: --config flag was not used and medusa.json was not found, so use the default project config
+	if !configFlagUsed && existenceError != nil {
+		cmdLogger.Warn(fmt.Sprintf("Unable to find the config file at %v, will use the default project configuration for the "+
+			"%v compilation platform instead", configPath, DefaultCompilationPlatform))
+
+		projectConfig, err = config.GetDefaultProjectConfig(DefaultCompilationPlatform)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return projectConfig, configPath, nil
 }
@@ -36,6 +36,10 @@ func addFuzzFlags() error {
 	fuzzCmd.Flags().Uint64("test-limit", 0,
 		fmt.Sprintf("number of transactions to test before exiting (unless a config file is provided, default is %d). 0 means that test limit is not enforced", defaultConfig.Fuzzing.TestLimit))
 
+	// Random seed
+	fuzzCmd.Flags().Int64("seed", 0,
+		"seed for the fuzzer's random provider, to reproduce a previous campaign (unless a config file is provided, a time-based seed is used)")
+
 	// Tx sequence length
 	fuzzCmd.Flags().Int("seq-len", 0,
 		fmt.Sprintf("maximum transactions to run in sequence (unless a config file is provided, default is %d)", defaultConfig.Fuzzing.CallSequenceLength))
@@ -74,6 +78,15 @@ func addFuzzFlags() error {
 
 	// Run slither and overwrite the cache
 	fuzzCmd.Flags().Bool("use-slither-force", false, "runs slither and overwrite the cached results")
+
+	// JSON output of failed test cases
+	fuzzCmd.Flags().String("json-output", "", "path to a file to write failed test cases to in JSON format on exit")
+
+	// JSON output of the execution trace of the last failing call sequence
+	fuzzCmd.Flags().String("trace-json", "", "path to a file to write the execution trace of the last failing call sequence to in JSON format on exit")
+
+	// Self-check mode
+	fuzzCmd.Flags().Bool("self-check", false, "runs the campaign twice with the same seed and fails if their coverage or failed tests differ")
 	return nil
 }
 
@@ -119,6 +132,15 @@ func updateProjectConfigWithFuzzFlags(cmd *cobra.Command, projectConfig *config.
 		}
 	}
 
+	// Update random seed
+	if cmd.Flags().Changed("seed") {
+		seed, err := cmd.Flags().GetInt64("seed")
+		if err != nil {
+			return err
+		}
+		projectConfig.Fuzzing.RandomSeed = &seed
+	}
+
 	// Update sequence length
 	if cmd.Flags().Changed("seq-len") {
 		projectConfig.Fuzzing.CallSequenceLength, err = cmd.Flags().GetInt("seq-len")
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crytic/medusa/compilation"
+	"github.com/crytic/medusa/compilation/platforms"
+	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/crytic/medusa/fuzzing/executiontracer"
+	"github.com/crytic/medusa/utils/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteLastFailingTraceJSON runs a fuzzing campaign against a harness with a failing assertion test, and ensures
+// the execution traces written for the last failing call sequence can be unmarshalled, with the failing call traced.
+func TestWriteLastFailingTraceJSON(t *testing.T) {
+	contractTestPath := testutils.CopyToTestDirectory(t, "../fuzzing/testdata/contracts/assertions/assert_even_number.sol")
+	testutils.ExecuteInDirectory(t, contractTestPath, func() {
+		// Create a project configuration targeting our harness.
+		compilationConfig, err := compilation.NewCompilationConfigFromPlatformConfig(platforms.NewCryticCompilationConfig(contractTestPath))
+		require.NoError(t, err)
+		projectConfig, err := config.GetDefaultProjectConfig("")
+		require.NoError(t, err)
+		projectConfig.Compilation = compilationConfig
+		projectConfig.Fuzzing.TargetContracts = []string{"TestContract"}
+		projectConfig.Fuzzing.Workers = 1
+		projectConfig.Fuzzing.TestLimit = 10_000
+		projectConfig.Fuzzing.Testing.PropertyTesting.Enabled = false
+		projectConfig.Fuzzing.Testing.OptimizationTesting.Enabled = false
+		projectConfig.Slither.UseSlither = false
+
+		// Run the campaign, which should fail a test, and write the traces of the last failing call sequence.
+		fuzzer, err := startFuzzer(projectConfig, false)
+		require.NoError(t, err)
+		lastFailedTestCase := fuzzer.LastFailedTestCase()
+		require.NotNil(t, lastFailedTestCase)
+		tracePath := filepath.Join(t.TempDir(), "trace.json")
+		err = writeLastFailingTraceJSON(fuzzer, tracePath)
+		require.NoError(t, err)
+
+		// Read the traces back, which should describe each call of the failing call sequence.
+		b, err := os.ReadFile(tracePath)
+		require.NoError(t, err)
+		var traces []*executiontracer.CallFrameJSON
+		err = json.Unmarshal(b, &traces)
+		require.NoError(t, err)
+		require.Len(t, traces, len(*lastFailedTestCase.CallSequence()))
+
+		// Only the failing call was traced, as traceAll is disabled.
+		lastTrace := traces[len(traces)-1]
+		require.NotNil(t, lastTrace)
+		assert.EqualValues(t, "TestContract", lastTrace.CodeContract)
+		assert.EqualValues(t, "setX(uint256)", lastTrace.Method)
+		assert.True(t, lastTrace.Reverted)
+		assert.Contains(t, lastTrace.Result, "assertion failed")
+		for _, trace := range traces[:len(traces)-1] {
+			assert.Nil(t, trace)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crytic/medusa/cmd/exitcodes"
+	"github.com/crytic/medusa/fuzzing"
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// replayCmd represents the command provider for replaying a call sequence
+var replayCmd = &cobra.Command{
+	Use:               "replay <call-sequence-file>",
+	Short:             "Replays a saved call sequence with an execution trace",
+	Long:              `Replays a saved call sequence (e.g. a corpus entry) against the current code, printing its execution trace and whether each test still fails`,
+	Args:              cmdValidateReplayArgs,
+	ValidArgsFunction: cmdValidReplayArgs,
+	RunE:              cmdRunReplay,
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+}
+
+func init() {
+	// Add all the flags allowed for the replay command
+	err := addReplayFlags()
+	if err != nil {
+		cmdLogger.Panic("Failed to initialize the replay command", err)
+	}
+
+	// Add the replay command and its associated flags to the root command
+	rootCmd.AddCommand(replayCmd)
+}
+
+// cmdValidReplayArgs will return which flags and sub-commands are valid for dynamic completion for the replay command
+func cmdValidReplayArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Gather a list of flags that are available to be used in the current command but have not been used yet
+	var unusedFlags []string
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Changed {
+			unusedFlags = append(unusedFlags, "--"+flag.Name)
+		}
+	})
+
+	// If the call sequence file has not been provided yet, allow file completion for it.
+	if len(args) == 0 {
+		return unusedFlags, cobra.ShellCompDirectiveDefault
+	}
+	return unusedFlags, cobra.ShellCompDirectiveNoFileComp
+}
+
+// cmdValidateReplayArgs makes sure that exactly one positional argument, the call sequence file, is provided
+func cmdValidateReplayArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		err = fmt.Errorf("replay accepts exactly one positional argument, the path to a call sequence file")
+		cmdLogger.Error("Failed to validate args to the replay command", err)
+		return err
+	}
+	return nil
+}
+
+// cmdRunReplay executes the CLI replay command. The project configuration is resolved in the same way as for the fuzz
+// command, after which the call sequence file is replayed once against the freshly set up test chain.
+func cmdRunReplay(cmd *cobra.Command, args []string) error {
+	// Resolve the call sequence file path before changing our working directory, so relative paths are relative to
+	// where the command was invoked from.
+	callSequencePath, err := filepath.Abs(args[0])
+	if err != nil {
+		cmdLogger.Error("Failed to run the replay command", err)
+		return err
+	}
+
+	// Read our call sequence, which uses the same format as corpus entries.
+	b, err := os.ReadFile(callSequencePath)
+	if err != nil {
+		cmdLogger.Error("Failed to read the call sequence file", err)
+		return err
+	}
+	var callSequence calls.CallSequence
+	err = json.Unmarshal(b, &callSequence)
+	if err != nil {
+		cmdLogger.Error("Failed to parse the call sequence file", err)
+		return err
+	}
+
+	// Read our project configuration
+	projectConfig, configPath, err := readProjectConfig(cmd)
+	if err != nil {
+		cmdLogger.Error("Failed to run the replay command", err)
+		return err
+	}
+
+	// Update the project configuration given whatever flags were set using the CLI
+	err = updateProjectConfigWithReplayFlags(cmd, projectConfig)
+	if err != nil {
+		cmdLogger.Error("Failed to run the replay command", err)
+		return err
+	}
+
+	// Change our working directory to the parent directory of the project configuration file, as compilation paths
+	// may be relative to it.
+	err = os.Chdir(filepath.Dir(configPath))
+	if err != nil {
+		cmdLogger.Error("Failed to run the replay command", err)
+		return err
+	}
+
+	// Create our fuzzer, which compiles and sets up the project for replaying.
+	fuzzer, fuzzErr := fuzzing.NewFuzzer(*projectConfig)
+	if fuzzErr != nil {
+		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+	}
+
+	// Replay the call sequence.
+	_, fuzzErr = fuzzer.Replay(callSequence)
+	if fuzzErr != nil {
+		return exitcodes.NewErrorWithExitCode(fuzzErr, exitcodes.ExitCodeHandledError)
+	}
+
+	// If any test still fails after replaying, we'll want to return a special exit code
+	if len(fuzzer.TestCasesWithStatus(fuzzing.TestCaseStatusFailed)) > 0 {
+		return exitcodes.NewErrorWithExitCode(nil, exitcodes.ExitCodeTestFailed)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/spf13/cobra"
+)
+
+// addReplayFlags adds the various flags for the replay command
+func addReplayFlags() error {
+	// Prevent alphabetical sorting of usage message
+	replayCmd.Flags().SortFlags = false
+
+	// Config file
+	replayCmd.Flags().String("config", "", "path to config file")
+
+	// Compilation Target
+	replayCmd.Flags().String("compilation-target", "", TargetFlagDescription)
+
+	// Logging color
+	replayCmd.Flags().Bool("no-color", false, "disables colored terminal output")
+	return nil
+}
+
+// updateProjectConfigWithReplayFlags will update the given projectConfig with any CLI arguments that were provided to
+// the replay command
+func updateProjectConfigWithReplayFlags(cmd *cobra.Command, projectConfig *config.ProjectConfig) error {
+	var err error
+
+	// If --compilation-target was used
+	if cmd.Flags().Changed("compilation-target") {
+		// Get the new target
+		newTarget, err := cmd.Flags().GetString("compilation-target")
+		if err != nil {
+			return err
+		}
+
+		err = projectConfig.Compilation.SetTarget(newTarget)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Update logging color mode
+	if cmd.Flags().Changed("no-color") {
+		projectConfig.Logging.NoColor, err = cmd.Flags().GetBool("no-color")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
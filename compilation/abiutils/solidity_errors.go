@@ -84,6 +84,20 @@ func GetSolidityRevertErrorString(returnError error, returnData []byte) *string
 	return nil
 }
 
+// EncodeSolidityRevertErrorString encodes the provided error message as the return data of a revert with an Error, in
+// the same way as Solidity's `revert(string)`.
+// Returns the encoded return data.
+func EncodeSolidityRevertErrorString(errorMessage string) []byte {
+	stringType, _ := abi.NewType("string", "", nil)
+	errorReturnDataAbi := abi.NewMethod("Error", "Error", abi.Function, "", false, false, []abi.Argument{
+		{Name: "", Type: stringType, Indexed: false},
+	}, abi.Arguments{})
+
+	// Pack the error message, which cannot fail for a string, and prefix it with the selector.
+	packedArgs, _ := errorReturnDataAbi.Inputs.Pack(errorMessage)
+	return append(bytes.Clone(errorReturnDataAbi.ID), packedArgs...)
+}
+
 // GetSolidityCustomRevertError obtains a custom Solidity error returned, if one was and could be resolved.
 // Returns the ABI error definition as well as its unpacked values. Or returns nil outputs if a custom error was not
 // emitted, or could not be resolved.
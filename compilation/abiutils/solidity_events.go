@@ -10,8 +10,8 @@ import (
 // Returns the event definition and unpacked event input values, or nil for both if an event definition could not
 // be resolved, or values could not be unpacked.
 func UnpackEventAndValues(contractAbi *abi.ABI, eventLog *coreTypes.Log) (*abi.Event, []any) {
-	// If no ABI was given, no event data can be extracted.
-	if contractAbi == nil {
+	// If no ABI was given, or the event is anonymous and has no topics to identify it, no event data can be extracted.
+	if contractAbi == nil || len(eventLog.Topics) == 0 {
 		return nil, nil
 	}
 
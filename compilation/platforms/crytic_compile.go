@@ -154,6 +154,7 @@ func (c *CryticCompilationConfig) Compile() ([]types.Compilation, string, error)
 		Abi           any    `json:"abi"`
 		Bin           string `json:"bin"`
 		BinRuntime    string `json:"bin-runtime"`
+		StorageLayout any    `json:"storage-layout,omitempty"`
 	}
 	type solcExportData struct {
 		Sources   map[string]solcSourceUnit     `json:"sources"`
@@ -250,6 +251,12 @@ func (c *CryticCompilationConfig) Compile() ([]types.Compilation, string, error)
 				return nil, "", fmt.Errorf("unable to parse runtime bytecode for contract '%s'\n", contractName)
 			}
 
+			// Parse the storage layout if one was exported. It is optional, so we ignore any errors.
+			var storageLayout *types.StorageLayout
+			if contract.StorageLayout != nil {
+				storageLayout, _ = types.ParseStorageLayoutFromInterface(contract.StorageLayout)
+			}
+
 			// Add contract details
 			compilation.SourcePathToArtifact[sourcePath].Contracts[contractName] = types.CompiledContract{
 				Abi:             *contractAbi,
@@ -258,6 +265,7 @@ func (c *CryticCompilationConfig) Compile() ([]types.Compilation, string, error)
 				SrcMapsInit:     contract.SrcMap,
 				SrcMapsRuntime:  contract.SrcMapRuntime,
 				Kind:            contractKinds[contractName],
+				StorageLayout:   storageLayout,
 			}
 		}
 
@@ -67,6 +67,11 @@ func (s *SolcCompilationConfig) SetSolcOutputOptions(v *semver.Version) string {
 		(v.Major() == 0 && v.Minor() == 7 && v.Patch() <= 6) ||
 		(v.Major() == 0 && v.Minor() == 8 && v.Patch() <= 9)
 
+	// storage-layout is only requested for 0.8.0 and above, where it is supported by the combined JSON output
+	if v.Major() > 0 || v.Minor() >= 8 {
+		return "abi,ast,bin,bin-runtime,srcmap,srcmap-runtime,userdoc,devdoc,hashes,storage-layout"
+	}
+
 	// if version is 0.3.0-0.3.6 or 0.4.0-0.4.11 no 'hashes' outputOption
 	if (v.Major() == 0 && v.Minor() == 4 && v.Patch() <= 11) || (v.Major() == 0 && v.Minor() == 3 && v.Patch() <= 6) {
 		return "abi,ast,bin,bin-runtime,srcmap,srcmap-runtime,userdoc,devdoc"
@@ -187,6 +192,16 @@ func (s *SolcCompilationConfig) Compile() ([]types.Compilation, string, error) {
 			return nil, "", fmt.Errorf("unable to parse runtime bytecode for contract '%s'\n", contractName)
 		}
 
+		// Parse our storage layout if one was provided. It is optional, so we ignore any errors.
+		var storageLayout *types.StorageLayout
+		if contractsMap, ok := results["contracts"].(map[string]any); ok {
+			if contractMap, ok := contractsMap[name].(map[string]any); ok {
+				if origStorageLayout, ok := contractMap["storage-layout"]; ok {
+					storageLayout, _ = types.ParseStorageLayoutFromInterface(origStorageLayout)
+				}
+			}
+		}
+
 		// Construct our compiled contract
 		compilation.SourcePathToArtifact[sourcePath].Contracts[contractName] = types.CompiledContract{
 			Abi:             *contractAbi,
@@ -195,6 +210,7 @@ func (s *SolcCompilationConfig) Compile() ([]types.Compilation, string, error) {
 			SrcMapsInit:     contract.Info.SrcMap.(string),
 			SrcMapsRuntime:  contract.Info.SrcMapRuntime,
 			Kind:            contractKinds[contractName],
+			StorageLayout:   storageLayout,
 		}
 	}
 
@@ -31,6 +31,12 @@ type FunctionDefinition struct {
 	// Src is the source file for this AST
 	Src  string `json:"src"`
 	Name string `json:"name,omitempty"`
+	// Kind is the kind of function (function, constructor, fallback, receive, or freeFunction)
+	Kind string `json:"kind,omitempty"`
+	// Visibility is the visibility of the function (external, public, internal, or private)
+	Visibility string `json:"visibility,omitempty"`
+	// Implemented indicates whether the function has a body
+	Implemented bool `json:"implemented"`
 }
 
 func (s FunctionDefinition) GetNodeType() string {
@@ -1,9 +1,13 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 )
 
 // Compilation represents the artifacts of a smart contract compilation.
@@ -55,3 +59,114 @@ func (c *Compilation) CacheSourceCode() error {
 
 	return nil
 }
+
+// GetSourceLocation resolves the source file path and line number that a SourceMapElement refers to. The source code
+// must first be cached with CacheSourceCode for the line number to be resolved.
+// Returns the source path, the line number (1-based), and a boolean indicating whether the location was resolved.
+func (c *Compilation) GetSourceLocation(sourceMapElement *SourceMapElement) (string, int, bool) {
+	// If the element does not map to a known source file (e.g. compiler generated code), we cannot resolve it.
+	sourcePath, ok := c.SourceIdToPath[sourceMapElement.SourceUnitID]
+	if !ok {
+		return "", 0, false
+	}
+
+	// Obtain the source code and verify the element's offset is within its bounds.
+	sourceCode, ok := c.SourceCode[sourcePath]
+	if !ok || sourceMapElement.Offset < 0 || sourceMapElement.Offset > len(sourceCode) {
+		return "", 0, false
+	}
+
+	// Count the lines leading up to the offset to determine the line number.
+	lineNumber := bytes.Count(sourceCode[:sourceMapElement.Offset], []byte("\n")) + 1
+	return sourcePath, lineNumber, true
+}
+
+// GetSourceLines obtains the lines of the cached source code for the provided source path, within the provided range
+// of line numbers (1-based, inclusive). The range is clamped to the lines of the source file. The source code must
+// first be cached with CacheSourceCode for the lines to be resolved.
+// Returns the lines within the range, the line number of the first line returned, and a boolean indicating whether
+// any lines were resolved.
+func (c *Compilation) GetSourceLines(sourcePath string, firstLine int, lastLine int) ([]string, int, bool) {
+	sourceCode, ok := c.SourceCode[sourcePath]
+	if !ok || len(sourceCode) == 0 {
+		return nil, 0, false
+	}
+
+	// Split the source into lines and clamp our range to them.
+	lines := strings.Split(strings.ReplaceAll(string(sourceCode), "\r\n", "\n"), "\n")
+	firstLine = max(firstLine, 1)
+	lastLine = min(lastLine, len(lines))
+	if firstLine > lastLine {
+		return nil, 0, false
+	}
+	return lines[firstLine-1 : lastLine], firstLine, true
+}
+
+// GetDefinitionLocation resolves the source file path and line number at which a contract, or a function within it, is
+// defined, using the AST of each source. If functionName is empty, the location of the contract definition is
+// resolved. If the function is not defined in the contract itself (e.g. it is inherited), the first definition of a
+// function with that name in any contract is used. The source code must first be cached with CacheSourceCode for the
+// line number to be resolved.
+// Returns the source path, the line number (1-based), and a boolean indicating whether the location was resolved.
+func (c *Compilation) GetDefinitionLocation(contractName string, functionName string) (string, int, bool) {
+	// Sort our source paths so the fallback definition is chosen deterministically.
+	sourcePaths := make([]string, 0, len(c.SourcePathToArtifact))
+	for sourcePath := range c.SourcePathToArtifact {
+		sourcePaths = append(sourcePaths, sourcePath)
+	}
+	sort.Strings(sourcePaths)
+
+	var fallbackSourcePath, fallbackSrc string
+	for _, sourcePath := range sourcePaths {
+		// Parse the AST for this source.
+		var ast AST
+		b, err := json.Marshal(c.SourcePathToArtifact[sourcePath].Ast)
+		if err != nil || json.Unmarshal(b, &ast) != nil {
+			continue
+		}
+
+		// Search each contract definition for the requested definition.
+		for _, node := range ast.Nodes {
+			contract, ok := node.(ContractDefinition)
+			if !ok {
+				continue
+			}
+			if functionName == "" {
+				if contract.CanonicalName == contractName {
+					return c.getSourceLineNumber(sourcePath, contract.Src)
+				}
+				continue
+			}
+			for _, subNode := range contract.Nodes {
+				function, ok := subNode.(FunctionDefinition)
+				if !ok || function.Name != functionName {
+					continue
+				}
+				if contract.CanonicalName == contractName {
+					return c.getSourceLineNumber(sourcePath, function.Src)
+				}
+				if fallbackSrc == "" {
+					fallbackSourcePath, fallbackSrc = sourcePath, function.Src
+				}
+			}
+		}
+	}
+
+	// If the contract did not define the function itself, use the first definition we found.
+	if fallbackSrc != "" {
+		return c.getSourceLineNumber(fallbackSourcePath, fallbackSrc)
+	}
+	return "", 0, false
+}
+
+// getSourceLineNumber resolves the line number that the start of an AST node's source range refers to in the given
+// source file.
+// Returns the source path, the line number (1-based), and a boolean indicating whether the location was resolved.
+func (c *Compilation) getSourceLineNumber(sourcePath string, src string) (string, int, bool) {
+	offset := GetSrcMapStart(src)
+	sourceCode, ok := c.SourceCode[sourcePath]
+	if !ok || offset < 0 || offset > len(sourceCode) {
+		return "", 0, false
+	}
+	return sourcePath, bytes.Count(sourceCode[:offset], []byte("\n")) + 1, true
+}
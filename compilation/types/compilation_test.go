@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompilationGetSourceLines tests that lines of cached source code are obtained within a range of line numbers,
+// clamped to the lines of the source file.
+func TestCompilationGetSourceLines(t *testing.T) {
+	compilation := NewCompilation()
+	compilation.SourceCode["test.sol"] = []byte("line 1\r\nline 2\nline 3\nline 4")
+
+	// Obtain lines within the source.
+	lines, firstLine, ok := compilation.GetSourceLines("test.sol", 2, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 2, firstLine)
+	assert.Equal(t, []string{"line 2", "line 3"}, lines)
+
+	// Obtain lines from a range exceeding the source, which should be clamped.
+	lines, firstLine, ok = compilation.GetSourceLines("test.sol", -1, 10)
+	assert.True(t, ok)
+	assert.Equal(t, 1, firstLine)
+	assert.Equal(t, []string{"line 1", "line 2", "line 3", "line 4"}, lines)
+
+	// Obtaining lines outside the source, or for a source which was not cached, should fail.
+	_, _, ok = compilation.GetSourceLines("test.sol", 5, 7)
+	assert.False(t, ok)
+	_, _, ok = compilation.GetSourceLines("missing.sol", 1, 2)
+	assert.False(t, ok)
+}
@@ -0,0 +1,329 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/exp/maps"
+)
+
+// maxStorageSlotResolutionDepth describes the maximum amount of nested mappings and dynamic arrays which are followed
+// when resolving the variable held within a storage slot.
+const maxStorageSlotResolutionDepth = 16
+
+// maxDynamicArraySlots describes the maximum distance of a storage slot from the hash its location was derived from,
+// for it to be considered part of the data of the mapping value or dynamic array that hash refers to.
+var maxDynamicArraySlots = big.NewInt(1 << 32)
+
+// staticArrayLengthRegex matches the length of a static array type label, such as "uint256[5]".
+var staticArrayLengthRegex = regexp.MustCompile(`\[(\d+)]$`)
+
+// StorageLayout describes the storage layout of a contract, as emitted by the compiler, which describes the storage
+// slot and type of each of its state variables.
+type StorageLayout struct {
+	// Storage describes the state variables of the contract, in the order they are laid out in storage.
+	Storage []StorageLayoutVariable `json:"storage"`
+
+	// Types maps the type identifiers referenced by the state variables to their definitions.
+	Types map[string]StorageLayoutType `json:"types"`
+}
+
+// StorageLayoutVariable describes a state variable, or a member of a struct, within a StorageLayout.
+type StorageLayoutVariable struct {
+	// Label describes the name of the variable.
+	Label string `json:"label"`
+
+	// Offset describes the offset in bytes of the variable within its storage slot.
+	Offset uint64 `json:"offset"`
+
+	// Slot describes the storage slot of the variable, as a base 10 string. For struct members, this is relative to
+	// the slot of the struct.
+	Slot string `json:"slot"`
+
+	// Type describes the identifier of the variable's type, which is a key in StorageLayout.Types.
+	Type string `json:"type"`
+}
+
+// StorageLayoutType describes a type referenced by a StorageLayout.
+type StorageLayoutType struct {
+	// Encoding describes how the type is encoded in storage: "inplace", "mapping", "dynamic_array", or "bytes".
+	Encoding string `json:"encoding"`
+
+	// Label describes the canonical name of the type, such as "uint256" or "mapping(address => uint256)".
+	Label string `json:"label"`
+
+	// NumberOfBytes describes the amount of bytes the type occupies in storage, as a base 10 string.
+	NumberOfBytes string `json:"numberOfBytes"`
+
+	// Key describes the identifier of the key type, if this is a mapping.
+	Key string `json:"key,omitempty"`
+
+	// Value describes the identifier of the value type, if this is a mapping.
+	Value string `json:"value,omitempty"`
+
+	// Base describes the identifier of the element type, if this is an array.
+	Base string `json:"base,omitempty"`
+
+	// Members describes the members of the type, if this is a struct.
+	Members []StorageLayoutVariable `json:"members,omitempty"`
+}
+
+// StorageSlotVariable describes a variable, or a part of one, which is held within a given storage slot.
+type StorageSlotVariable struct {
+	// Name describes the expression referring to the variable, such as "owner", "balances[0x1234]" or "config.fee".
+	Name string
+
+	// Type describes the type of the variable.
+	Type *StorageLayoutType
+
+	// Offset describes the offset in bytes of the variable within the storage slot.
+	Offset uint64
+}
+
+// ParseStorageLayoutFromInterface parses a generic object into a StorageLayout and returns it, or an error if one
+// occurs.
+func ParseStorageLayoutFromInterface(i any) (*StorageLayout, error) {
+	// If it's a string, just parse it. Otherwise, we assume it's an interface and serialize it into a string.
+	var (
+		b   []byte
+		err error
+	)
+	if s, ok := i.(string); ok {
+		b = []byte(s)
+	} else {
+		b, err = json.Marshal(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result StorageLayout
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// numberOfBytes returns the amount of bytes the type occupies in storage, or zero if it could not be parsed.
+func (t *StorageLayoutType) numberOfBytes() uint64 {
+	numberOfBytes, _ := strconv.ParseUint(t.NumberOfBytes, 10, 64)
+	return numberOfBytes
+}
+
+// isValueType indicates whether the type is a value type, which occupies at most a single storage slot.
+func (t *StorageLayoutType) isValueType() bool {
+	return t.Encoding == "inplace" && len(t.Members) == 0 && t.Base == ""
+}
+
+// ResolveSlot resolves the variables held within the provided storage slot. The storage slots of mapping values and
+// dynamic array elements are derived from hashes, so they can only be resolved if the preimage of the hash used to
+// derive them is provided in hashPreimages.
+// Returns the variables held within the storage slot, or nil if they could not be resolved.
+func (l *StorageLayout) ResolveSlot(slot common.Hash, hashPreimages map[common.Hash][]byte) []StorageSlotVariable {
+	return l.resolveSlot(slot.Big(), hashPreimages, 0)
+}
+
+// resolveSlot resolves the variables held within the provided storage slot, following at most
+// maxStorageSlotResolutionDepth hashes to mapping values or dynamic array elements.
+// Returns the variables held within the storage slot, or nil if they could not be resolved.
+func (l *StorageLayout) resolveSlot(slot *big.Int, hashPreimages map[common.Hash][]byte, depth int) []StorageSlotVariable {
+	// First try to resolve the slot to the state variables which are laid out statically.
+	variables := make([]StorageSlotVariable, 0)
+	for _, variable := range l.Storage {
+		variableSlot, ok := new(big.Int).SetString(variable.Slot, 10)
+		if !ok {
+			continue
+		}
+		variables = append(variables, l.resolveVariableSlot(variable.Label, variable.Type, variableSlot, variable.Offset, slot)...)
+	}
+	if len(variables) > 0 || depth >= maxStorageSlotResolutionDepth {
+		return variables
+	}
+
+	// Otherwise, the slot may hold a mapping value or dynamic array element, whose location is derived from a hash of
+	// the slot of the mapping or array. Try to find the hash and resolve the mapping or array it was derived from. We
+	// try the hashes in sorted order, so the variables resolved are returned in a deterministic order.
+	hashes := maps.Keys(hashPreimages)
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	for _, hash := range hashes {
+		preimage := hashPreimages[hash]
+		relativeSlot := new(big.Int).Sub(slot, hash.Big())
+		if len(preimage) < common.HashLength || relativeSlot.Sign() < 0 || relativeSlot.Cmp(maxDynamicArraySlots) >= 0 {
+			continue
+		}
+
+		// The slot of the mapping or array makes up the end of the preimage, with a mapping key preceding it.
+		parentSlot := new(big.Int).SetBytes(preimage[len(preimage)-common.HashLength:])
+		key := preimage[:len(preimage)-common.HashLength]
+		for _, parent := range l.resolveSlot(parentSlot, hashPreimages, depth+1) {
+			switch parent.Type.Encoding {
+			case "mapping":
+				keyType, ok := l.Types[parent.Type.Key]
+				if !ok {
+					continue
+				}
+				name := fmt.Sprintf("%v[%v]", parent.Name, formatStorageKey(&keyType, key))
+				variables = append(variables, l.resolveVariableSlot(name, parent.Type.Value, hash.Big(), 0, slot)...)
+			case "dynamic_array":
+				if len(key) == 0 {
+					variables = append(variables, l.resolveArrayElementSlot(parent.Name, parent.Type.Base, hash.Big(), nil, slot)...)
+				}
+			case "bytes":
+				if len(key) == 0 {
+					variables = append(variables, StorageSlotVariable{Name: parent.Name, Type: parent.Type, Offset: 0})
+				}
+			}
+		}
+	}
+	return variables
+}
+
+// resolveVariableSlot resolves the variables held within the provided storage slot, if it lies within the storage
+// occupied by a variable with the provided name and type identifier, located at the provided slot and offset.
+// Returns the variables held within the storage slot, or nil if it does not lie within the variable's storage.
+func (l *StorageLayout) resolveVariableSlot(name string, typeId string, variableSlot *big.Int, offset uint64, slot *big.Int) []StorageSlotVariable {
+	// Determine the range of slots the variable occupies and check if our slot lies within it.
+	variableType, ok := l.Types[typeId]
+	if !ok {
+		return nil
+	}
+	slotCount := new(big.Int).SetUint64((variableType.numberOfBytes() + common.HashLength - 1) / common.HashLength)
+	if slotCount.Sign() == 0 {
+		slotCount.SetUint64(1)
+	}
+	relativeSlot := new(big.Int).Sub(slot, variableSlot)
+	if relativeSlot.Sign() < 0 || relativeSlot.Cmp(slotCount) >= 0 {
+		return nil
+	}
+
+	// Structs and static arrays are resolved to the members or elements held within the slot.
+	if len(variableType.Members) > 0 && variableType.Encoding == "inplace" {
+		variables := make([]StorageSlotVariable, 0)
+		for _, member := range variableType.Members {
+			memberSlot, ok := new(big.Int).SetString(member.Slot, 10)
+			if !ok {
+				continue
+			}
+			memberSlot.Add(memberSlot, variableSlot)
+			variables = append(variables, l.resolveVariableSlot(name+"."+member.Label, member.Type, memberSlot, member.Offset, slot)...)
+		}
+		return variables
+	}
+	if variableType.Base != "" && variableType.Encoding == "inplace" {
+		var length *big.Int
+		if matches := staticArrayLengthRegex.FindStringSubmatch(variableType.Label); matches != nil {
+			length, _ = new(big.Int).SetString(matches[1], 10)
+		}
+		return l.resolveArrayElementSlot(name, variableType.Base, variableSlot, length, slot)
+	}
+
+	// Other variables are held entirely within their slot (mappings and dynamic arrays hold their data elsewhere).
+	return []StorageSlotVariable{{Name: name, Type: &variableType, Offset: offset}}
+}
+
+// resolveArrayElementSlot resolves the variables held within the provided storage slot, if it lies within the
+// storage occupied by the elements of an array with the provided name and element type identifier, whose data begins
+// at the provided slot. If the length of the array is provided, elements beyond it are not considered.
+// Returns the variables held within the storage slot, or nil if it does not lie within the array's elements.
+func (l *StorageLayout) resolveArrayElementSlot(name string, elementTypeId string, dataSlot *big.Int, length *big.Int, slot *big.Int) []StorageSlotVariable {
+	elementType, ok := l.Types[elementTypeId]
+	if !ok {
+		return nil
+	}
+	elementSize := elementType.numberOfBytes()
+	relativeSlot := new(big.Int).Sub(slot, dataSlot)
+	if elementSize == 0 || relativeSlot.Sign() < 0 {
+		return nil
+	}
+
+	// Elements which occupy at least one slot begin at a new slot, so we can determine the single element held.
+	if elementSize >= common.HashLength || !elementType.isValueType() {
+		slotsPerElement := new(big.Int).SetUint64((elementSize + common.HashLength - 1) / common.HashLength)
+		index := new(big.Int).Div(relativeSlot, slotsPerElement)
+		if length != nil && index.Cmp(length) >= 0 {
+			return nil
+		}
+		elementSlot := new(big.Int).Add(dataSlot, new(big.Int).Mul(index, slotsPerElement))
+		return l.resolveVariableSlot(fmt.Sprintf("%v[%v]", name, index), elementTypeId, elementSlot, 0, slot)
+	}
+
+	// Smaller elements are packed together within each slot, so we resolve every element held.
+	variables := make([]StorageSlotVariable, 0)
+	elementsPerSlot := common.HashLength / elementSize
+	firstIndex := new(big.Int).Mul(relativeSlot, new(big.Int).SetUint64(elementsPerSlot))
+	for i := uint64(0); i < elementsPerSlot; i++ {
+		index := new(big.Int).Add(firstIndex, new(big.Int).SetUint64(i))
+		if length != nil && index.Cmp(length) >= 0 {
+			break
+		}
+		variables = append(variables, StorageSlotVariable{
+			Name:   fmt.Sprintf("%v[%v]", name, index),
+			Type:   &elementType,
+			Offset: i * elementSize,
+		})
+	}
+	return variables
+}
+
+// FormatValue obtains a display string for the value of the variable, given the value of the storage slot it is held
+// within.
+func (v *StorageSlotVariable) FormatValue(slotValue common.Hash) string {
+	// Values which do not fit within the slot at their offset, or which are not value types, are displayed raw.
+	size := v.Type.numberOfBytes()
+	if !v.Type.isValueType() || size == 0 || v.Offset+size > common.HashLength {
+		return slotValue.Hex()
+	}
+	return formatStorageValue(v.Type, slotValue[common.HashLength-v.Offset-size:common.HashLength-v.Offset])
+}
+
+// formatStorageKey obtains a display string for a mapping key of the provided type, as it appears in the preimage of
+// the hash used to derive the storage slot of its value.
+func formatStorageKey(keyType *StorageLayoutType, key []byte) string {
+	// Value type keys are padded to a full word, while strings and bytes keys are used as is.
+	size := keyType.numberOfBytes()
+	switch {
+	case keyType.Encoding == "bytes" && keyType.Label == "string":
+		return strconv.Quote(string(key))
+	case keyType.Encoding == "bytes":
+		return hexutil.Encode(key)
+	case len(key) == common.HashLength && size > 0 && size <= common.HashLength:
+		if strings.HasPrefix(keyType.Label, "bytes") {
+			return formatStorageValue(keyType, key[:size])
+		}
+		return formatStorageValue(keyType, key[common.HashLength-size:])
+	default:
+		return hexutil.Encode(key)
+	}
+}
+
+// formatStorageValue obtains a display string for a value of the provided value type, given the bytes which hold it.
+func formatStorageValue(valueType *StorageLayoutType, data []byte) string {
+	label := valueType.Label
+	switch {
+	case label == "bool":
+		return strconv.FormatBool(new(big.Int).SetBytes(data).Sign() != 0)
+	case strings.HasPrefix(label, "address") || strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(data).Hex()
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(data).String()
+	case strings.HasPrefix(label, "int"):
+		value := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+		return value.String()
+	default:
+		return hexutil.Encode(data)
+	}
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStorageLayout describes the storage layout of the following contract, as emitted by solc:
+//
+//	contract TestContract {
+//	    address owner;
+//	    bool paused;
+//	    int8 delta;
+//	    mapping(address => uint256) balances;
+//	    uint256[] values;
+//	}
+const testStorageLayout = `{
+	"storage": [
+		{"label": "owner", "offset": 0, "slot": "0", "type": "t_address"},
+		{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "delta", "offset": 21, "slot": "0", "type": "t_int8"},
+		{"label": "balances", "offset": 0, "slot": "1", "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "values", "offset": 0, "slot": "2", "type": "t_array(t_uint256)dyn_storage"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_int8": {"encoding": "inplace", "label": "int8", "numberOfBytes": "1"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_mapping(t_address,t_uint256)": {"encoding": "mapping", "key": "t_address", "label": "mapping(address => uint256)", "numberOfBytes": "32", "value": "t_uint256"},
+		"t_array(t_uint256)dyn_storage": {"base": "t_uint256", "encoding": "dynamic_array", "label": "uint256[]", "numberOfBytes": "32"}
+	}
+}`
+
+// TestStorageLayoutResolveSlot tests that storage slots are resolved to the variables they hold, including packed
+// variables, mapping values and dynamic array elements, and that their values are formatted by type.
+func TestStorageLayoutResolveSlot(t *testing.T) {
+	layout, err := ParseStorageLayoutFromInterface(testStorageLayout)
+	assert.NoError(t, err)
+
+	// Resolve the packed variables in the first slot and format their values.
+	owner := common.HexToAddress("0x1234")
+	var slotValue common.Hash
+	copy(slotValue[12:], owner.Bytes())
+	slotValue[11] = 1
+	slotValue[10] = 0xff
+	variables := layout.ResolveSlot(common.Hash{}, nil)
+	assert.Len(t, variables, 3)
+	assert.Equal(t, "owner", variables[0].Name)
+	assert.Equal(t, owner.Hex(), variables[0].FormatValue(slotValue))
+	assert.Equal(t, "paused", variables[1].Name)
+	assert.Equal(t, "true", variables[1].FormatValue(slotValue))
+	assert.Equal(t, "delta", variables[2].Name)
+	assert.Equal(t, "-1", variables[2].FormatValue(slotValue))
+
+	// Resolve a mapping value, which is only possible given the preimage of its slot.
+	mappingPreimage := append(common.LeftPadBytes(owner.Bytes(), 32), common.BigToHash(big.NewInt(1)).Bytes()...)
+	mappingSlot := crypto.Keccak256Hash(mappingPreimage)
+	assert.Empty(t, layout.ResolveSlot(mappingSlot, nil))
+	variables = layout.ResolveSlot(mappingSlot, map[common.Hash][]byte{mappingSlot: mappingPreimage})
+	assert.Len(t, variables, 1)
+	assert.Equal(t, "balances["+owner.Hex()+"]", variables[0].Name)
+	assert.Equal(t, "5", variables[0].FormatValue(common.BigToHash(big.NewInt(5))))
+
+	// Resolve a dynamic array element, offset from the hash of the array's slot.
+	arrayPreimage := common.BigToHash(big.NewInt(2)).Bytes()
+	arrayDataSlot := crypto.Keccak256Hash(arrayPreimage)
+	elementSlot := common.BigToHash(new(big.Int).Add(arrayDataSlot.Big(), big.NewInt(3)))
+	variables = layout.ResolveSlot(elementSlot, map[common.Hash][]byte{arrayDataSlot: arrayPreimage})
+	assert.Len(t, variables, 1)
+	assert.Equal(t, "values[3]", variables[0].Name)
+
+	// Slots which do not hold any known variable are not resolved.
+	assert.Empty(t, layout.ResolveSlot(common.BigToHash(big.NewInt(7)), nil))
+}
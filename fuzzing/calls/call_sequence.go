@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/exp/slices"
 )
 
 // CallSequence describes a sequence of calls sent to a chain.
@@ -67,6 +68,23 @@ func (cs CallSequence) String() string {
 	return cs.Log().String()
 }
 
+// CheatCodesUsed obtains the names of the cheat codes which were invoked by any executed call in the sequence, in the
+// order they were first used. Elements which have not been executed are skipped.
+func (cs CallSequence) CheatCodesUsed() []string {
+	cheatCodesUsed := make([]string, 0)
+	for _, cse := range cs {
+		if cse == nil || cse.ChainReference == nil {
+			continue
+		}
+		for _, cheatCode := range cse.ChainReference.MessageResults().CheatCodesUsed {
+			if !slices.Contains(cheatCodesUsed, cheatCode) {
+				cheatCodesUsed = append(cheatCodesUsed, cheatCode)
+			}
+		}
+	}
+	return cheatCodesUsed
+}
+
 // Clone creates a copy of the underlying CallSequence.
 func (cs CallSequence) Clone() (CallSequence, error) {
 	var err error
@@ -153,8 +171,8 @@ type CallSequenceElement struct {
 
 	// BlockTimestampDelay defines how much the block timestamp should advance when executing this transaction,
 	// compared to the last executed transaction.
-	// This number is *suggestive*: if BlockNumberDelay is non-zero (indicating to add to the existing block), this
-	// value will not be used.
+	// This number is *suggestive*: if BlockNumberDelay is zero (indicating to add to the existing block), this
+	// value will not be used, as every transaction in a block shares its timestamp.
 	BlockTimestampDelay uint64 `json:"blockTimestampDelay"`
 
 	// ChainReference describes the inclusion of the Call as a transaction in a block. This block may not yet be
@@ -199,6 +217,13 @@ func (cse *CallSequenceElement) Clone() (*CallSequenceElement, error) {
 	return clone, nil
 }
 
+// Discarded indicates whether the CallSequenceElement was executed, but discarded because an assume cheat code was
+// invoked with a false condition during its execution.
+// Returns a boolean indicating whether the element was discarded.
+func (cse *CallSequenceElement) Discarded() bool {
+	return cse.ChainReference != nil && cse.ChainReference.MessageResults().AssumptionFailed
+}
+
 // Method obtains the abi.Method targeted by the CallSequenceElement.Call, or an error if one occurred while obtaining
 // it.
 func (cse *CallSequenceElement) Method() (*abi.Method, error) {
@@ -250,29 +275,30 @@ func (cse *CallSequenceElement) String() string {
 		contractName = cse.Contract.Name()
 	}
 
-	// Obtain our method name
-	method, err := cse.Method()
+	// Obtain our method name, then decode our arguments (we jump four bytes to skip the function selector)
 	methodName := "<unresolved method>"
+	argsText := "<unresolved args>"
+	method, err := cse.Method()
 	if err == nil && method != nil {
 		methodName = method.Sig
-	}
-
-	// Next decode our arguments (we jump four bytes to skip the function selector)
-	args, err := method.Inputs.Unpack(cse.Call.Data[4:])
-	argsText := "<unable to unpack args>"
-	if err == nil {
-		argsText, err = valuegeneration.EncodeABIArgumentsToString(method.Inputs, args)
-		if err != nil {
-			argsText = "<unresolved args>"
+		argsText = "<unable to unpack args>"
+		if args, err := method.Inputs.Unpack(cse.Call.Data[4:]); err == nil {
+			if encodedArgs, err := valuegeneration.EncodeABIArgumentsToString(method.Inputs, args); err == nil {
+				argsText = encodedArgs
+			}
 		}
 	}
 
 	// If we have runtime info, populate it
 	blockNumberStr := "n/a"
 	blockTimeStr := "n/a"
+	senderStr := utils.TrimLeadingZeroesFromAddress(cse.Call.From.String())
 	if cse.ChainReference != nil {
 		blockNumberStr = cse.ChainReference.Block.Header.Number.String()
 		blockTimeStr = strconv.FormatUint(cse.ChainReference.Block.Header.Time, 10)
+		if cse.ChainReference.SenderLabel != "" {
+			senderStr = fmt.Sprintf("%s [%s]", senderStr, cse.ChainReference.SenderLabel)
+		}
 	}
 
 	// Return a formatted string representing this element.
@@ -286,7 +312,7 @@ func (cse *CallSequenceElement) String() string {
 		cse.Call.GasLimit,
 		cse.Call.GasPrice.String(),
 		cse.Call.Value.String(),
-		utils.TrimLeadingZeroesFromAddress(cse.Call.From.String()),
+		senderStr,
 	)
 }
 
@@ -318,6 +344,10 @@ type CallSequenceElementChainReference struct {
 
 	// TransactionIndex describes the index at which the transaction was included into the Block.
 	TransactionIndex int
+
+	// SenderLabel describes the label the chain had for the sender of the transaction when it was included, or an empty
+	// string if it had none.
+	SenderLabel string
 }
 
 // MessageResults obtains the results of executing the CallSequenceElement.
@@ -24,7 +24,9 @@ type ExecuteCallSequenceExecutionCheckFunc func(currentExecutedSequence CallSequ
 // included in blocks which adhere to the CallSequence properties (such as delays) as much as possible.
 // A "fetch next call" function is provided to fetch the next element to execute.
 // A "post element executed check" function is provided to check whether execution should stop after each element is
-// executed.
+// executed. Elements which are discarded due to a failed assumption (see CallSequenceElement.Discarded) are not
+// provided to the check function. They remain in the executed call sequence, as any state changes they made (e.g. if
+// the failed assumption was caught) persist on the chain, and must be reproduced when the sequence is replayed.
 // Returns the call sequence which was executed and an error if one occurs.
 func ExecuteCallSequenceIteratively(chain *chain.TestChain, fetchElementFunc ExecuteCallSequenceFetchElementFunc, executionCheckFunc ExecuteCallSequenceExecutionCheckFunc, additionalTracers ...*chain.TestChainTracer) (CallSequence, error) {
 	// If there is no fetch element function provided, throw an error
@@ -114,11 +116,17 @@ func ExecuteCallSequenceIteratively(chain *chain.TestChain, fetchElementFunc Exe
 			callSequenceElement.ChainReference = &CallSequenceElementChainReference{
 				Block:            chain.PendingBlock(),
 				TransactionIndex: len(chain.PendingBlock().Messages) - 1,
+				SenderLabel:      chain.Labels[callSequenceElement.Call.From],
 			}
 
 			// Add to our executed call sequence
 			callSequenceExecuted = append(callSequenceExecuted, callSequenceElement)
 
+			// If an assumption made by this call failed, discard it rather than checking it as an executed call.
+			if callSequenceElement.Discarded() {
+				break
+			}
+
 			// We added our call to the block as a transaction. Call our step function with the update and check
 			// if it returned an error.
 			if executionCheckFunc != nil {
@@ -172,7 +180,7 @@ func ExecuteCallSequence(chain *chain.TestChain, callSequence CallSequence) (Cal
 // ExecuteCallSequenceWithExecutionTracer attaches an executiontracer.ExecutionTracer to ExecuteCallSequenceIteratively and attaches execution traces to the call sequence elements.
 func ExecuteCallSequenceWithExecutionTracer(testChain *chain.TestChain, contractDefinitions contracts.Contracts, callSequence CallSequence, verboseTracing bool) (CallSequence, error) {
 	// Create a new execution tracer
-	executionTracer := executiontracer.NewExecutionTracer(contractDefinitions, testChain.CheatCodeContracts())
+	executionTracer := executiontracer.NewExecutionTracer(contractDefinitions, testChain.CheatCodeContracts(), testChain.Labels)
 	defer executionTracer.Close()
 
 	// Execute our sequence with a simple fetch operation provided to obtain each element.
@@ -183,8 +191,13 @@ func ExecuteCallSequenceWithExecutionTracer(testChain *chain.TestChain, contract
 		return nil, nil
 	}
 
-	// Execute the call sequence and attach the execution tracer
-	executedCallSeq, err := ExecuteCallSequenceIteratively(testChain, fetchElementFunc, nil, executionTracer.NativeTracer())
+	// Execute the call sequence and attach the execution tracer. If verbose tracing is enabled, we also record the
+	// storage slots changed by each call.
+	additionalTracers := []*chain.TestChainTracer{executionTracer.NativeTracer()}
+	if verboseTracing {
+		additionalTracers = append(additionalTracers, chain.NewStateDiffTracer().NativeTracer())
+	}
+	executedCallSeq, err := ExecuteCallSequenceIteratively(testChain, fetchElementFunc, nil, additionalTracers...)
 
 	// By default, we only trace the last element in the call sequence.
 	traceFrom := len(callSequence) - 1
@@ -198,6 +211,9 @@ func ExecuteCallSequenceWithExecutionTracer(testChain *chain.TestChain, contract
 		callSequenceElement := callSequence[traceFrom]
 		hash := utils.MessageToTransaction(callSequenceElement.Call.ToCoreMessage()).Hash()
 		callSequenceElement.ExecutionTrace = executionTracer.GetTrace(hash)
+		if verboseTracing && callSequenceElement.ExecutionTrace != nil && callSequenceElement.ChainReference != nil {
+			callSequenceElement.ExecutionTrace.StateDiff = chain.GetStateDiffTracerResults(callSequenceElement.ChainReference.MessageResults())
+		}
 	}
 
 	return executedCallSeq, err
@@ -0,0 +1,84 @@
+package calls
+
+import "github.com/crytic/medusa/fuzzing/valuegeneration"
+
+// CallSequenceElementReport describes a CallSequenceElement in a stable, machine-readable format, so that call
+// sequences can be processed by external tooling.
+type CallSequenceElementReport struct {
+	// Contract describes the name of the contract which was called, or an empty string if it could not be resolved.
+	Contract string `json:"contract"`
+
+	// Method describes the signature of the method which was called, or an empty string if it could not be resolved.
+	Method string `json:"method"`
+
+	// Arguments describes the JSON-encoded arguments the method was called with, or nil if they could not be decoded.
+	Arguments []any `json:"arguments"`
+
+	// Sender describes the address which sent the call.
+	Sender string `json:"sender"`
+
+	// SenderLabel describes the label of the address which sent the call, or an empty string if it has none.
+	SenderLabel string `json:"senderLabel,omitempty"`
+
+	// To describes the address which was called, or an empty string if the call was a contract creation.
+	To string `json:"to"`
+
+	// BlockNumber describes the number of the block the call was included in, or zero if it was not executed.
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// BlockTimestamp describes the timestamp of the block the call was included in, or zero if it was not executed.
+	BlockTimestamp uint64 `json:"blockTimestamp"`
+
+	// GasLimit describes the gas limit the call was sent with.
+	GasLimit uint64 `json:"gasLimit"`
+
+	// GasPrice describes the gas price the call was sent with, as a base 10 string.
+	GasPrice string `json:"gasPrice"`
+
+	// Value describes the value the call was sent with, as a base 10 string.
+	Value string `json:"value"`
+}
+
+// Report obtains a CallSequenceElementReport describing each element of the CallSequence, in order.
+func (cs CallSequence) Report() []CallSequenceElementReport {
+	reports := make([]CallSequenceElementReport, len(cs))
+	for i, cse := range cs {
+		reports[i] = cse.Report()
+	}
+	return reports
+}
+
+// Report obtains a CallSequenceElementReport describing the CallSequenceElement.
+func (cse *CallSequenceElement) Report() CallSequenceElementReport {
+	report := CallSequenceElementReport{
+		Sender:   cse.Call.From.String(),
+		GasLimit: cse.Call.GasLimit,
+		GasPrice: cse.Call.GasPrice.String(),
+		Value:    cse.Call.Value.String(),
+	}
+	if cse.Call.To != nil {
+		report.To = cse.Call.To.String()
+	}
+	if cse.Contract != nil {
+		report.Contract = cse.Contract.Name()
+	}
+
+	// Resolve our method and decode our arguments (we jump four bytes to skip the function selector).
+	method, err := cse.Method()
+	if err == nil && method != nil {
+		report.Method = method.Sig
+		if args, err := method.Inputs.Unpack(cse.Call.Data[4:]); err == nil {
+			if encodedArgs, err := valuegeneration.EncodeJSONArgumentsToSlice(method.Inputs, args); err == nil {
+				report.Arguments = encodedArgs
+			}
+		}
+	}
+
+	// If we have runtime info, populate it.
+	if cse.ChainReference != nil {
+		report.BlockNumber = cse.ChainReference.Block.Header.Number.Uint64()
+		report.BlockTimestamp = cse.ChainReference.Block.Header.Time
+		report.SenderLabel = cse.ChainReference.SenderLabel
+	}
+	return report
+}
@@ -1,19 +1,25 @@
 package config
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/crytic/medusa/compilation/types"
 	"math/big"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/crytic/medusa/chain/config"
 	"github.com/crytic/medusa/compilation"
 	"github.com/crytic/medusa/logging"
 	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/rs/zerolog"
+	"golang.org/x/exp/slices"
 )
 
 // The following directives will be picked up by the `go generate` command to generate JSON marshaling code from
@@ -52,9 +58,22 @@ type FuzzingConfig struct {
 	// must be non-negative. A zero value indicates the test limit should not be enforced.
 	TestLimit uint64 `json:"testLimit"`
 
+	// StopOnCoveragePlateau describes a threshold for the number of call sequences which may be tested without any new
+	// coverage being achieved, after which it will exit. A zero value indicates the campaign should not stop when
+	// coverage plateaus. This requires coverage to be enabled.
+	StopOnCoveragePlateau uint64 `json:"stopOnCoveragePlateau"`
+
+	// RandomSeed describes the seed used to initialize the fuzzer's random provider. If nil, a seed is derived from the
+	// current time. With a single worker and a fixed corpus, providing the same seed generates the same call sequences.
+	RandomSeed *int64 `json:"randomSeed"`
+
 	// ShrinkLimit describes a threshold for the iterations (call sequence tests) which shrinking should perform.
 	ShrinkLimit uint64 `json:"shrinkLimit"`
 
+	// ParallelShrinking describes whether workers should cooperate on shrinking a failing call sequence, by testing
+	// candidate shrunken call sequences submitted by the worker shrinking it. This has no effect with a single worker.
+	ParallelShrinking bool `json:"parallelShrinking"`
+
 	// CallSequenceLength describes the maximum length a transaction sequence can be generated as.
 	CallSequenceLength int `json:"callSequenceLength"`
 
@@ -62,27 +81,62 @@ type FuzzingConfig struct {
 	// the in-memory corpus will be used, but not flush to disk.
 	CorpusDirectory string `json:"corpusDirectory"`
 
+	// FailOnCorpusLoadError describes whether the fuzzer should abort if any call sequence in the corpus cannot be
+	// replayed on startup, rather than disabling it and continuing with the remainder of the corpus.
+	FailOnCorpusLoadError bool `json:"failOnCorpusLoadError"`
+
+	// PruneInvalidCorpus describes whether call sequences in the corpus which cannot be replayed on startup should be
+	// removed from the corpus directory, rather than only being disabled for the current fuzzing campaign.
+	PruneInvalidCorpus bool `json:"pruneInvalidCorpus"`
+
+	// DumpDeployedBytecodeDir describes the directory the runtime bytecode of each contract deployed while setting up
+	// the test chain should be written to, one file per contract. If empty, the bytecode is not written.
+	DumpDeployedBytecodeDir string `json:"dumpDeployedBytecodeDir"`
+
 	// CoverageEnabled describes whether to use coverage-guided fuzzing
 	CoverageEnabled bool `json:"coverageEnabled"`
 
-	// CoverageFormats indicate which reports to generate: "lcov" and "html" are supported.
+	// CoverageFormats indicate which reports to generate: "lcov", "html", "cobertura" and "json" are supported.
 	CoverageFormats []string `json:"coverageFormats"`
 
+	// TestReportFormats indicate which test result reports to generate: "junit" and "sarif" are supported.
+	TestReportFormats []string `json:"testReportFormats"`
+
 	// TargetContracts are the target contracts for fuzz testing
 	TargetContracts []string `json:"targetContracts"`
 
+	// TargetAllContracts describes whether all compiled contracts which are not interfaces or libraries should be
+	// targeted when TargetContracts is empty and more than one such contract was compiled.
+	TargetAllContracts bool `json:"targetAllContracts"`
+
 	// PredeployedContracts are contracts that can be deterministically deployed at a specific address. It maps the
-	// contract name to the deployment address
-	PredeployedContracts map[string]string `json:"predeployedContracts"`
+	// contract name to the deployment address, and optionally the raw runtime bytecode and storage to set at it.
+	PredeployedContracts map[string]PredeployedContractConfig `json:"predeployedContracts"`
 
 	// TargetContractsBalances holds the amount of wei that should be sent during deployment for one or more contracts in
 	// TargetContracts
 	TargetContractsBalances []*big.Int `json:"targetContractsBalances"`
 
+	// OptionalContracts describes the names of contracts in TargetContracts which are not essential to the fuzzing
+	// campaign. If their deployment fails, a warning is logged and they are skipped, rather than aborting the campaign.
+	OptionalContracts []string `json:"optionalContracts"`
+
+	// ContractInstances maps the names of contracts in TargetContracts to the number of instances of them to deploy,
+	// each at a distinct address. Contracts which are not specified are deployed once.
+	ContractInstances map[string]int `json:"contractInstances"`
+
 	// ConstructorArgs holds the constructor arguments for TargetContracts deployments. It is available via the project
 	// configuration
 	ConstructorArgs map[string]map[string]any `json:"constructorArgs"`
 
+	// LenientConstructorArgs describes whether constructor arguments or struct fields missing from ConstructorArgs
+	// should be filled with their zero value (with a warning), rather than failing deployment.
+	LenientConstructorArgs bool `json:"lenientConstructorArgs"`
+
+	// SetupCalls describes calls to deployed contracts which are executed in order after all contracts have been
+	// deployed, before fuzzing begins. Each call must succeed or revert as expected, or the campaign is aborted.
+	SetupCalls []SetupCallConfig `json:"setupCalls"`
+
 	// DeployerAddress describe the account address to be used to deploy contracts.
 	DeployerAddress string `json:"deployerAddress"`
 
@@ -90,6 +144,18 @@ type FuzzingConfig struct {
 	// campaigns.
 	SenderAddresses []string `json:"senderAddresses"`
 
+	// SenderWeights describes the relative weight with which each of the SenderAddresses is chosen to send a call,
+	// keyed by address. Senders without a weight default to a weight of one, so an empty map selects senders uniformly.
+	SenderWeights map[string]uint64 `json:"senderWeights"`
+
+	// SenderBalance describes the balance (in wei) each of the SenderAddresses is funded with in the genesis block. If
+	// nil, a default balance of half the maximum int256 value is used.
+	SenderBalance *big.Int `json:"senderBalance"`
+
+	// DeployerBalance describes the balance (in wei) the DeployerAddress is funded with in the genesis block. If nil, a
+	// default balance of half the maximum int256 value is used.
+	DeployerBalance *big.Int `json:"deployerBalance"`
+
 	// MaxBlockNumberDelay describes the maximum distance in block numbers the fuzzer will use when generating blocks
 	// compared to the previous.
 	MaxBlockNumberDelay uint64 `json:"blockNumberDelayMax"`
@@ -98,13 +164,93 @@ type FuzzingConfig struct {
 	// compared to the previous.
 	MaxBlockTimestampDelay uint64 `json:"blockTimestampDelayMax"`
 
+	// BlockTimeBase describes the amount of seconds the fuzzer will advance the timestamp by for each block it advances,
+	// rather than generating timestamp delays up to MaxBlockTimestampDelay. This is disabled if zero.
+	BlockTimeBase uint64 `json:"blockTimeBase"`
+
+	// BlockTimeJitter describes the maximum amount of seconds by which each timestamp delay derived from BlockTimeBase
+	// will randomly deviate, in either direction. This must be less than BlockTimeBase.
+	BlockTimeJitter uint64 `json:"blockTimeJitter"`
+
+	// MethodBlockDelays maps the signatures of methods to the block number and timestamp delays the fuzzer will use when
+	// generating calls to them, instead of MaxBlockNumberDelay and MaxBlockTimestampDelay. Signatures specify the
+	// contract name and signature in the ABI format like `Contract.func(uint256,bytes32)`.
+	MethodBlockDelays map[string]MethodBlockDelayConfig `json:"methodBlockDelays"`
+
 	// BlockGasLimit describes the maximum amount of gas that can be used in a block by transactions. This defines
 	// limits for how many transactions can be included per block.
 	BlockGasLimit uint64 `json:"blockGasLimit"`
 
+	// TxsPerBlock describes the maximum number of consecutive generated calls which are included in the same block
+	// before it is committed. Only the first call in each block advances the block number and timestamp. Values of
+	// zero or one include each generated call in a new block.
+	TxsPerBlock uint64 `json:"txsPerBlock"`
+
 	// TransactionGasLimit describes the maximum amount of gas that will be used by the fuzzer generated transactions.
 	TransactionGasLimit uint64 `json:"transactionGasLimit"`
 
+	// IntegerArgumentRanges describes ranges which integer arguments of specific methods should be constrained to when
+	// they are generated or mutated by the fuzzer.
+	IntegerArgumentRanges []IntegerArgumentRangeConfig `json:"integerArgumentRanges"`
+
+	// StructFieldMutationProbabilities describes the probabilities with which fields of struct arguments of specific
+	// methods should be mutated by the fuzzer. Fields which are not specified are always mutated.
+	StructFieldMutationProbabilities []StructFieldMutationConfig `json:"structFieldMutationProbabilities"`
+
+	// ExpectedReverts describes reverts which are considered benign and are not counted as reverted calls. Each entry
+	// is either a hex-encoded 4-byte error selector (e.g. "0x4e487b71") or a revert reason string.
+	ExpectedReverts []string `json:"expectedReverts"`
+
+	// CallSequenceGeneratorStrategies describes named call sequence generator strategies and the fraction of workers
+	// which should use each. Workers not assigned to a strategy use the default call sequence generator config.
+	CallSequenceGeneratorStrategies []CallSequenceGeneratorStrategyConfig `json:"callSequenceGeneratorStrategies"`
+
+	// RecordSequenceSeeds describes whether workers should reseed their random provider with a recorded seed at the
+	// start of each call sequence, so the generation of a given call sequence can later be replayed deterministically.
+	RecordSequenceSeeds bool `json:"recordSequenceSeeds"`
+
+	// VerifyStateResetBetweenSequences describes whether workers should verify that their state root matches that of
+	// the base block after reverting to it between call sequences, reporting an error if it does not.
+	VerifyStateResetBetweenSequences bool `json:"verifyStateResetBetweenSequences"`
+
+	// TraceAllSequences describes whether workers should write every call sequence they execute while fuzzing to a
+	// file for their worker index, to help diagnose failures which do not reproduce.
+	TraceAllSequences bool `json:"traceAllSequences"`
+
+	// ZeroAddressProbability describes the probability that an address argument generated by the fuzzer is the zero
+	// address. If set, the zero address is otherwise never generated. If nil, the zero address is treated like any
+	// other address.
+	ZeroAddressProbability *float64 `json:"zeroAddressProbability"`
+
+	// FactoryCallProbability describes the probability that a newly generated call at the start of a call sequence
+	// targets a factory method, so more contract instances exist for subsequent calls. The probability decreases
+	// linearly over the course of the sequence. Zero disables this bias.
+	FactoryCallProbability float64 `json:"factoryCallProbability"`
+
+	// FactoryFunctions describes the signatures of methods which should be treated as factory methods, in addition to
+	// state-changing methods which return an address. Signatures specify the contract name and signature in the ABI
+	// format like `Contract.func(uint256,bytes32)`.
+	FactoryFunctions []string `json:"factoryFunctions"`
+
+	// SeedFromEvents describes whether values emitted in events during a call sequence should be added to the value
+	// set used to generate the remaining calls in that sequence.
+	SeedFromEvents bool `json:"seedFromEvents"`
+
+	// SeedFromEventsMaxValues describes the maximum number of event values which may be added to the value set over the
+	// course of a single call sequence when SeedFromEvents is enabled.
+	SeedFromEventsMaxValues int `json:"seedFromEventsMaxValues"`
+
+	// SeedFromReturnData describes whether values returned by calls during a call sequence should be added to the value
+	// set used to generate the remaining calls in that sequence. Arrays and structs are flattened into their leaf values.
+	SeedFromReturnData bool `json:"seedFromReturnData"`
+
+	// TargetReachabilityGracePeriod describes the number of call sequences after which a warning is logged if no call to
+	// a target contract has succeeded yet. Zero disables this check.
+	TargetReachabilityGracePeriod uint64 `json:"targetReachabilityGracePeriod"`
+
+	// CallValueGeneration describes how the fuzzer generates the amount of ether sent with calls to payable methods.
+	CallValueGeneration CallValueGenerationConfig `json:"callValueGeneration"`
+
 	// Testing describes the configuration used for different testing strategies.
 	Testing TestingConfig `json:"testing"`
 
@@ -117,6 +263,190 @@ type FuzzingConfig struct {
 // For example, this enables serialization of big.Int but specifying a different field type to control serialization.
 type fuzzingConfigMarshaling struct {
 	TargetContractsBalances []*hexutil.Big
+	SenderBalance           *hexutil.Big
+	DeployerBalance         *hexutil.Big
+}
+
+// IntegerArgumentRangeConfig describes an inclusive range which a given integer argument of a method should be
+// constrained to when the fuzzer generates or mutates values for it.
+type IntegerArgumentRangeConfig struct {
+	// FunctionSignature describes the method whose argument should be constrained. The signature should specify the
+	// contract name and signature in the ABI format like `Contract.func(uint256,bytes32)`.
+	FunctionSignature string `json:"functionSignature"`
+
+	// ArgumentIndex describes the index of the integer argument in the method's inputs which should be constrained.
+	ArgumentIndex int `json:"argumentIndex"`
+
+	// Min describes the minimum value (inclusive) the argument should take.
+	Min *big.Int `json:"min"`
+
+	// Max describes the maximum value (inclusive) the argument should take.
+	Max *big.Int `json:"max"`
+}
+
+// StructFieldMutationConfig describes the probability with which a given field of a struct argument of a method should be
+// mutated when the fuzzer mutates values for it.
+type StructFieldMutationConfig struct {
+	// FunctionSignature describes the method whose argument's field should be configured. The signature should specify
+	// the contract name and signature in the ABI format like `Contract.func((uint256,address))`.
+	FunctionSignature string `json:"functionSignature"`
+
+	// ArgumentIndex describes the index of the struct argument in the method's inputs.
+	ArgumentIndex int `json:"argumentIndex"`
+
+	// FieldPath describes the dot-separated names of the fields leading to the configured field, relative to the struct
+	// argument (e.g. `inner.amount`). Elements of arrays share the field path of their array.
+	FieldPath string `json:"fieldPath"`
+
+	// Probability describes the probability that the field is mutated when its argument is mutated. Zero pins the field
+	// to its existing value.
+	Probability float64 `json:"probability"`
+}
+
+// CallValueGenerationConfig describes how the fuzzer generates the amount of ether (in wei) sent with calls to payable
+// methods.
+type CallValueGenerationConfig struct {
+	// Enabled describes whether values should be generated using this configuration. If false, any 64-bit value may
+	// be sent with a call to a payable method.
+	Enabled bool `json:"enabled"`
+
+	// NonZeroProbability describes the probability that a call to a payable method sends a non-zero value.
+	NonZeroProbability float64 `json:"nonZeroProbability"`
+
+	// MaxValue describes the maximum value which may be sent with a call. If nil, values are only bounded by the
+	// balance of the sender.
+	MaxValue *big.Int `json:"maxValue"`
+
+	// EdgeValueProbability describes the probability that a non-zero value is an edge value rather than a random one.
+	// Edge values are 1 wei and the largest value which may be sent: MaxValue, or the sender's balance if it is lower.
+	EdgeValueProbability float64 `json:"edgeValueProbability"`
+}
+
+// SetupCallConfig describes a call to a deployed contract which is executed while setting up the test chain.
+type SetupCallConfig struct {
+	// Contract describes the name of the deployed contract to call.
+	Contract string `json:"contract"`
+
+	// Method describes the method to call, either by name or, if it is overloaded, by its signature in the ABI format
+	// like `func(uint256,bytes32)`.
+	Method string `json:"method"`
+
+	// Args maps the names of the method's inputs to their values, in the same format as ConstructorArgs.
+	Args map[string]any `json:"args"`
+
+	// ExpectRevert describes whether the call is expected to revert, rather than succeed.
+	ExpectRevert bool `json:"expectRevert"`
+}
+
+// PredeployedContractConfig describes a contract which is deterministically deployed at a specific address. In JSON, it
+// may be provided as an address string, in which case the compiled contract of the same name is deployed to it, or as
+// an object which additionally provides the raw runtime bytecode and storage to set at the address in the genesis block.
+type PredeployedContractConfig struct {
+	// Address describes the address the contract is deployed to.
+	Address string `json:"address"`
+
+	// RuntimeBytecode describes the hex-encoded runtime bytecode to set at the address in the genesis block. If empty,
+	// the compiled contract with the same name as this predeployed contract is deployed to the address instead.
+	RuntimeBytecode string `json:"runtimeBytecode,omitempty"`
+
+	// Storage maps hex-encoded storage slots to the hex-encoded values to set at the address in the genesis block. It
+	// may only be provided alongside RuntimeBytecode.
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// HasRuntimeBytecode indicates whether the predeployed contract provides its own runtime bytecode, rather than
+// referring to a compiled contract by name.
+func (p PredeployedContractConfig) HasRuntimeBytecode() bool {
+	return p.RuntimeBytecode != ""
+}
+
+// GenesisAccount decodes the runtime bytecode and storage of the predeployed contract into the account to set at its
+// address in the genesis block.
+// Returns the genesis account, or an error if the runtime bytecode or storage is not well-formed.
+func (p PredeployedContractConfig) GenesisAccount() (coreTypes.Account, error) {
+	code, err := decodeHexString(p.RuntimeBytecode)
+	if err != nil {
+		return coreTypes.Account{}, fmt.Errorf("invalid runtime bytecode: %v", err)
+	}
+	storage := make(map[common.Hash]common.Hash, len(p.Storage))
+	for slotStr, valueStr := range p.Storage {
+		slot, err := decodeHexString(slotStr)
+		if err != nil || len(slot) > common.HashLength {
+			return coreTypes.Account{}, fmt.Errorf("invalid storage slot: %v", slotStr)
+		}
+		value, err := decodeHexString(valueStr)
+		if err != nil || len(value) > common.HashLength {
+			return coreTypes.Account{}, fmt.Errorf("invalid value for storage slot %v: %v", slotStr, valueStr)
+		}
+		storage[common.BytesToHash(slot)] = common.BytesToHash(value)
+	}
+	return coreTypes.Account{
+		Code:    code,
+		Storage: storage,
+		Balance: big.NewInt(0),
+	}, nil
+}
+
+// MarshalJSON provides custom JSON marshalling for the struct. Predeployed contracts which only specify an address are
+// marshalled as an address string.
+func (p PredeployedContractConfig) MarshalJSON() ([]byte, error) {
+	if !p.HasRuntimeBytecode() && len(p.Storage) == 0 {
+		return json.Marshal(p.Address)
+	}
+	type predeployedContractConfig PredeployedContractConfig
+	return json.Marshal(predeployedContractConfig(p))
+}
+
+// UnmarshalJSON provides custom JSON unmarshalling for the struct, accepting either an address string or an object.
+func (p *PredeployedContractConfig) UnmarshalJSON(b []byte) error {
+	var address string
+	if err := json.Unmarshal(b, &address); err == nil {
+		*p = PredeployedContractConfig{Address: address}
+		return nil
+	}
+	type predeployedContractConfig PredeployedContractConfig
+	var decoded predeployedContractConfig
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+	*p = PredeployedContractConfig(decoded)
+	return nil
+}
+
+// decodeHexString decodes the provided hex string, which may optionally be prefixed with "0x" and have an odd length.
+// Returns the decoded bytes, or an error if the string is not valid hex.
+func decodeHexString(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	return hex.DecodeString(s)
+}
+
+// MethodBlockDelayConfig describes the inclusive ranges of block number and timestamp delays the fuzzer will use when
+// generating calls to a given method.
+type MethodBlockDelayConfig struct {
+	// MinBlockNumberDelay describes the minimum distance in block numbers a call to the method will advance the chain.
+	MinBlockNumberDelay uint64 `json:"blockNumberDelayMin"`
+
+	// MaxBlockNumberDelay describes the maximum distance in block numbers a call to the method will advance the chain.
+	MaxBlockNumberDelay uint64 `json:"blockNumberDelayMax"`
+
+	// MinBlockTimestampDelay describes the minimum distance in timestamps a call to the method will advance the chain.
+	MinBlockTimestampDelay uint64 `json:"blockTimestampDelayMin"`
+
+	// MaxBlockTimestampDelay describes the maximum distance in timestamps a call to the method will advance the chain.
+	MaxBlockTimestampDelay uint64 `json:"blockTimestampDelayMax"`
+}
+
+// CallSequenceGeneratorStrategyConfig describes a named call sequence generator strategy and the fraction of fuzzer
+// workers which should use it.
+type CallSequenceGeneratorStrategyConfig struct {
+	// Name describes the name the strategy was registered under in the fuzzer's hooks.
+	Name string `json:"name"`
+
+	// WorkerFraction describes the fraction of workers, in the range (0, 1], which should use this strategy.
+	WorkerFraction float64 `json:"workerFraction"`
 }
 
 // TestingConfig describes the configuration options used for testing
@@ -136,11 +466,21 @@ type TestingConfig struct {
 	// than just the contracts specified in the project configuration's deployment order.
 	TestAllContracts bool `json:"testAllContracts"`
 
+	// DynamicDeployers restricts the dynamically deployed contracts which are tested when TestAllContracts is enabled
+	// to those deployed by the given addresses, or by the contracts deployed during setup with the given names. If it
+	// is empty, all dynamically deployed contracts are tested.
+	DynamicDeployers []string `json:"dynamicDeployers"`
+
 	// TraceAll describes whether a trace should be attached to each element of a finalized shrunken call sequence,
 	// e.g. when a call sequence triggers a test failure. Test providers may attach execution traces by default,
 	// even if this option is not enabled.
 	TraceAll bool `json:"traceAll"`
 
+	// AssertionOnlyMode describes whether only assertion testing should be performed. If enabled, the assertion test
+	// case provider is attached and property and optimization test case providers are not, regardless of their
+	// individual configuration.
+	AssertionOnlyMode bool `json:"assertionOnlyMode"`
+
 	// AssertionTesting describes the configuration used for assertion testing.
 	AssertionTesting AssertionTestingConfig `json:"assertionTesting"`
 
@@ -150,6 +490,12 @@ type TestingConfig struct {
 	// OptimizationTesting describes the configuration used for optimization testing.
 	OptimizationTesting OptimizationTestingConfig `json:"optimizationTesting"`
 
+	// ERC20SupplyTesting describes the configuration used for automatic ERC20 supply invariant testing.
+	ERC20SupplyTesting ERC20SupplyTestingConfig `json:"erc20SupplyTesting"`
+
+	// GasTesting describes the configuration used for gas testing.
+	GasTesting GasTestingConfig `json:"gasTesting"`
+
 	// TargetFunctionSignatures is a list function signatures call the fuzzer should exclusively target by omitting calls to other signatures.
 	// The signatures should specify the contract name and signature in the ABI format like `Contract.func(uint256,bytes32)`.
 	TargetFunctionSignatures []string `json:"targetFunctionSignatures"`
@@ -159,6 +505,27 @@ type TestingConfig struct {
 	ExcludeFunctionSignatures []string `json:"excludeFunctionSignatures"`
 }
 
+// ERC20SupplyTestingConfig describes the configuration options used for automatically checking that the sum of
+// tracked holder balances equals the total supply for any detected ERC20 token.
+type ERC20SupplyTestingConfig struct {
+	// Enabled describes whether testing is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// GasTestingConfig describes the configuration options used for gas testing, which tracks the gas used by calls to
+// target functions and reports the call sequence which maximizes (or minimizes) it.
+type GasTestingConfig struct {
+	// Enabled describes whether testing is enabled.
+	Enabled bool `json:"enabled"`
+
+	// TargetFunctionSignatures is a list of function signatures whose gas usage should be tracked. The signatures
+	// should specify the contract name and signature in the ABI format like `Contract.func(uint256,bytes32)`.
+	TargetFunctionSignatures []string `json:"targetFunctionSignatures"`
+
+	// Minimize describes whether the gas used by calls to target functions should be minimized rather than maximized.
+	Minimize bool `json:"minimize"`
+}
+
 // Validate validates that the TestingConfig meets certain requirements.
 func (testCfg *TestingConfig) Validate() error {
 	// Verify that target and exclude function signatures are used mutually exclusive.
@@ -166,6 +533,18 @@ func (testCfg *TestingConfig) Validate() error {
 		return errors.New("project configuration must specify only one of blacklist or whitelist at a time")
 	}
 
+	// Verify dynamic deployers are only specified when dynamically deployed contracts are tested.
+	if len(testCfg.DynamicDeployers) != 0 && !testCfg.TestAllContracts {
+		return errors.New("project configuration must enable testing all contracts to restrict which dynamic deployers are tested")
+	}
+	for _, dynamicDeployer := range testCfg.DynamicDeployers {
+		if strings.HasPrefix(dynamicDeployer, "0x") {
+			if _, err := utils.HexStringToAddress(dynamicDeployer); err != nil {
+				return fmt.Errorf("project configuration specifies an invalid dynamic deployer address %q", dynamicDeployer)
+			}
+		}
+	}
+
 	// Verify property testing fields.
 	if testCfg.PropertyTesting.Enabled {
 		// Test prefixes must be supplied if property testing is enabled.
@@ -181,6 +560,28 @@ func (testCfg *TestingConfig) Validate() error {
 		}
 	}
 
+	// Verify that custom error selectors which are treated as failing cases are well-formed
+	for _, customErrorSelector := range testCfg.AssertionTesting.FailOnCustomErrors {
+		selector, err := hexutil.Decode(customErrorSelector)
+		if err != nil || len(selector) != 4 {
+			return fmt.Errorf("project configuration must specify custom error selectors to fail on as 4-byte hex strings: %s", customErrorSelector)
+		}
+	}
+
+	// Verify that the revert message regular expression compiles
+	if testCfg.AssertionTesting.FailOnRevertMessageRegex != "" {
+		if _, err := regexp.Compile(testCfg.AssertionTesting.FailOnRevertMessageRegex); err != nil {
+			return fmt.Errorf("project configuration must specify a valid revert message regular expression: %v", err)
+		}
+	}
+
+	if testCfg.GasTesting.Enabled {
+		// Target function signatures must be supplied if gas testing is enabled.
+		if len(testCfg.GasTesting.TargetFunctionSignatures) == 0 {
+			return errors.New("project configuration must specify target function signatures if gas testing is enabled")
+		}
+	}
+
 	// Validate that prefixes do not overlap
 	for _, prefix := range testCfg.PropertyTesting.TestPrefixes {
 		for _, prefix2 := range testCfg.OptimizationTesting.TestPrefixes {
@@ -203,6 +604,14 @@ type AssertionTestingConfig struct {
 
 	// PanicCodeConfig describes the various panic codes that can be enabled and be treated as a "failing case"
 	PanicCodeConfig PanicCodeConfig `json:"panicCodeConfig"`
+
+	// FailOnCustomErrors describes the 4-byte hex-encoded custom error selectors which should be treated as a failing
+	// case when a tested method reverts with them.
+	FailOnCustomErrors []string `json:"failOnCustomErrors"`
+
+	// FailOnRevertMessageRegex describes a regular expression which, when matched by the revert reason string of a
+	// tested method, is treated as a failing case. An empty string disables this check.
+	FailOnRevertMessageRegex string `json:"failOnRevertMessageRegex"`
 }
 
 // PanicCodeConfig describes the various panic codes that can be enabled and be treated as a failing assertion test
@@ -341,6 +750,11 @@ func (p *ProjectConfig) Validate() error {
 		return err
 	}
 
+	// Verify invalid corpus items are not configured to be both fatal and pruned.
+	if p.Fuzzing.FailOnCorpusLoadError && p.Fuzzing.PruneInvalidCorpus {
+		return errors.New("project configuration cannot enable both failOnCorpusLoadError and pruneInvalidCorpus")
+	}
+
 	// Verify the worker count is a positive number.
 	if p.Fuzzing.Workers <= 0 {
 		return errors.New("project configuration must specify a positive number for the worker count")
@@ -356,11 +770,40 @@ func (p *ProjectConfig) Validate() error {
 		return errors.New("project configuration must specify a positive number for the worker reset limit")
 	}
 
+	// Verify optional contracts are target contracts
+	for _, contractName := range p.Fuzzing.OptionalContracts {
+		if !slices.Contains(p.Fuzzing.TargetContracts, contractName) {
+			return fmt.Errorf("project configuration specifies optional contract %q which is not a target contract", contractName)
+		}
+	}
+
+	// Verify contract instance counts are positive and only specified for target contracts
+	for contractName, instances := range p.Fuzzing.ContractInstances {
+		if !slices.Contains(p.Fuzzing.TargetContracts, contractName) {
+			return fmt.Errorf("project configuration specifies contract instances for %q which is not a target contract", contractName)
+		}
+		if instances < 1 {
+			return fmt.Errorf("project configuration must specify at least one instance of contract %q", contractName)
+		}
+	}
+
+	// Verify setup calls specify a contract and method to call
+	for i, setupCall := range p.Fuzzing.SetupCalls {
+		if setupCall.Contract == "" || setupCall.Method == "" {
+			return fmt.Errorf("project configuration must specify a contract and method for setup call %d", i)
+		}
+	}
+
 	// Verify timeout
 	if p.Fuzzing.Timeout < 0 {
 		return errors.New("project configuration must specify a positive number for the timeout")
 	}
 
+	// Verify coverage is enabled if we stop when it plateaus
+	if p.Fuzzing.StopOnCoveragePlateau > 0 && !p.Fuzzing.CoverageEnabled {
+		return errors.New("project configuration must enable coverage to stop on a coverage plateau")
+	}
+
 	// Verify gas limits are appropriate
 	if p.Fuzzing.BlockGasLimit < p.Fuzzing.TransactionGasLimit {
 		return errors.New("project configuration must specify a block gas limit which is not less than the transaction gas limit")
@@ -387,27 +830,162 @@ func (p *ProjectConfig) Validate() error {
 		return errors.New("project configuration must specify only well-formed sender address(es)")
 	}
 
+	// Verify that sender weights are positive and only specified for senders
+	senders, _ := utils.HexStringsToAddresses(p.Fuzzing.SenderAddresses)
+	for senderString, weight := range p.Fuzzing.SenderWeights {
+		sender, err := utils.HexStringToAddress(senderString)
+		if err != nil {
+			return fmt.Errorf("project configuration specifies a sender weight for malformed address %q", senderString)
+		}
+		if !slices.Contains(senders, sender) {
+			return fmt.Errorf("project configuration specifies a sender weight for %q which is not a sender address", senderString)
+		}
+		if weight == 0 {
+			return fmt.Errorf("project configuration must specify a positive sender weight for %q", senderString)
+		}
+	}
+
+	// Verify that cheat code caller allowlist addresses are well-formed
+	if _, err := utils.HexStringsToAddresses(p.Fuzzing.TestChainConfig.CheatCodeConfig.CallerAllowlist); err != nil {
+		return errors.New("project configuration must specify only well-formed cheat code caller allowlist address(es)")
+	}
+
 	// Verify that deployer is a well-formed address
 	if _, err := utils.HexStringToAddress(p.Fuzzing.DeployerAddress); err != nil {
 		return errors.New("project configuration must specify only a well-formed deployer address")
 	}
 
-	// Verify that addresses of predeployed contracts are well-formed
-	for _, addr := range p.Fuzzing.PredeployedContracts {
-		if _, err := utils.HexStringToAddress(addr); err != nil {
+	// Verify that addresses, runtime bytecode, and storage of predeployed contracts are well-formed
+	for contractName, predeployedContract := range p.Fuzzing.PredeployedContracts {
+		if _, err := utils.HexStringToAddress(predeployedContract.Address); err != nil {
 			return errors.New("project configuration must specify only well-formed predeployed contract address(es)")
 		}
+		if !predeployedContract.HasRuntimeBytecode() {
+			if len(predeployedContract.Storage) > 0 {
+				return fmt.Errorf("project configuration must specify runtime bytecode for predeployed contract %v to set its storage", contractName)
+			}
+			continue
+		}
+		if _, err := predeployedContract.GenesisAccount(); err != nil {
+			return fmt.Errorf("project configuration specified an invalid predeployed contract %v: %v", contractName, err)
+		}
+	}
+
+	// Verify that integer argument ranges are well-formed
+	for _, argumentRange := range p.Fuzzing.IntegerArgumentRanges {
+		if argumentRange.FunctionSignature == "" || argumentRange.ArgumentIndex < 0 {
+			return errors.New("project configuration must specify a function signature and non-negative argument index for each integer argument range")
+		}
+		if argumentRange.Min == nil || argumentRange.Max == nil || argumentRange.Min.Cmp(argumentRange.Max) > 0 {
+			return fmt.Errorf("project configuration must specify an integer argument range with a minimum that does not exceed its maximum: %s", argumentRange.FunctionSignature)
+		}
+	}
+
+	// Verify that struct field mutation probabilities are well-formed
+	for _, fieldMutation := range p.Fuzzing.StructFieldMutationProbabilities {
+		if fieldMutation.FunctionSignature == "" || fieldMutation.ArgumentIndex < 0 || fieldMutation.FieldPath == "" {
+			return errors.New("project configuration must specify a function signature, non-negative argument index, and field path for each struct field mutation probability")
+		}
+		if fieldMutation.Probability < 0 || fieldMutation.Probability > 1 {
+			return fmt.Errorf("project configuration must specify struct field mutation probabilities between 0 and 1: %s", fieldMutation.FunctionSignature)
+		}
+	}
+
+	// Verify that block time jitter cannot result in blocks which do not advance the timestamp
+	if p.Fuzzing.BlockTimeBase > 0 && p.Fuzzing.BlockTimeJitter >= p.Fuzzing.BlockTimeBase {
+		return errors.New("project configuration must specify a block time jitter less than the block time base")
+	}
+
+	// Verify that method block delays are well-formed
+	for signature, delays := range p.Fuzzing.MethodBlockDelays {
+		if delays.MinBlockNumberDelay > delays.MaxBlockNumberDelay || delays.MinBlockTimestampDelay > delays.MaxBlockTimestampDelay {
+			return fmt.Errorf("project configuration must specify method block delays with minimums that do not exceed their maximums: %s", signature)
+		}
+	}
+
+	// Verify that expected revert selectors are well-formed
+	for _, expectedRevert := range p.Fuzzing.ExpectedReverts {
+		if strings.HasPrefix(expectedRevert, "0x") {
+			selector, err := hexutil.Decode(expectedRevert)
+			if err != nil || len(selector) != 4 {
+				return fmt.Errorf("project configuration must specify expected revert selectors as 4-byte hex strings: %s", expectedRevert)
+			}
+		}
 	}
 
-	// The coverage report format must be either "lcov" or "html"
+	// Verify that call sequence generator strategies are named uniquely and do not exceed the worker count
+	strategyNames := make(map[string]struct{})
+	strategyFractionTotal := 0.0
+	for _, strategy := range p.Fuzzing.CallSequenceGeneratorStrategies {
+		if strategy.Name == "" {
+			return errors.New("project configuration must specify a name for each call sequence generator strategy")
+		}
+		if _, exists := strategyNames[strategy.Name]; exists {
+			return fmt.Errorf("project configuration must not specify a call sequence generator strategy more than once: %s", strategy.Name)
+		}
+		strategyNames[strategy.Name] = struct{}{}
+		if strategy.WorkerFraction <= 0 || strategy.WorkerFraction > 1 {
+			return fmt.Errorf("project configuration must specify a worker fraction in the range (0, 1] for call sequence generator strategy: %s", strategy.Name)
+		}
+		strategyFractionTotal += strategy.WorkerFraction
+	}
+	if strategyFractionTotal > 1 {
+		return errors.New("project configuration must not specify call sequence generator strategy worker fractions which sum to more than 1")
+	}
+
+	// Verify that the zero address probability is a valid probability, if it is set
+	if p.Fuzzing.ZeroAddressProbability != nil && (*p.Fuzzing.ZeroAddressProbability < 0 || *p.Fuzzing.ZeroAddressProbability > 1) {
+		return errors.New("project configuration must specify a zero address probability in the range [0, 1]")
+	}
+
+	// Verify that the factory call probability is a valid probability
+	if p.Fuzzing.FactoryCallProbability < 0 || p.Fuzzing.FactoryCallProbability > 1 {
+		return errors.New("project configuration must specify a factory call probability in the range [0, 1]")
+	}
+
+	// Verify the genesis account balances are not negative, if they are set
+	if p.Fuzzing.SenderBalance != nil && p.Fuzzing.SenderBalance.Sign() < 0 {
+		return errors.New("project configuration must specify a non-negative sender balance, if one is set")
+	}
+	if p.Fuzzing.DeployerBalance != nil && p.Fuzzing.DeployerBalance.Sign() < 0 {
+		return errors.New("project configuration must specify a non-negative deployer balance, if one is set")
+	}
+
+	// Verify the event value seeding limit is positive, if seeding from events is enabled
+	if p.Fuzzing.SeedFromEvents && p.Fuzzing.SeedFromEventsMaxValues <= 0 {
+		return errors.New("project configuration must specify a positive maximum number of event values to seed per call sequence")
+	}
+
+	// Verify the call value generation config, if it is enabled
+	if p.Fuzzing.CallValueGeneration.Enabled {
+		callValueGeneration := p.Fuzzing.CallValueGeneration
+		if callValueGeneration.NonZeroProbability < 0 || callValueGeneration.NonZeroProbability > 1 {
+			return errors.New("project configuration must specify a call value non-zero probability in the range [0, 1]")
+		}
+		if callValueGeneration.EdgeValueProbability < 0 || callValueGeneration.EdgeValueProbability > 1 {
+			return errors.New("project configuration must specify a call value edge value probability in the range [0, 1]")
+		}
+		if callValueGeneration.MaxValue != nil && callValueGeneration.MaxValue.Sign() <= 0 {
+			return errors.New("project configuration must specify a positive maximum call value, if one is set")
+		}
+	}
+
+	// The coverage report format must be one of "lcov", "html", "cobertura" or "json"
 	if p.Fuzzing.CoverageFormats != nil {
 		for _, report := range p.Fuzzing.CoverageFormats {
-			if report != "lcov" && report != "html" {
-				return fmt.Errorf("project configuration must specify only valid coverage reports (lcov, html): %s", report)
+			if report != "lcov" && report != "html" && report != "cobertura" && report != "json" {
+				return fmt.Errorf("project configuration must specify only valid coverage reports (lcov, html, cobertura, json): %s", report)
 			}
 		}
 	}
 
+	// The test report format must be either "junit" or "sarif"
+	for _, report := range p.Fuzzing.TestReportFormats {
+		if report != "junit" && report != "sarif" {
+			return fmt.Errorf("project configuration must specify only valid test reports (junit, sarif): %s", report)
+		}
+	}
+
 	// Ensure that the log level is a valid one
 	level, err := zerolog.ParseLevel(p.Logging.Level.String())
 	if err != nil || level == zerolog.FatalLevel {
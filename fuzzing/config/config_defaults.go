@@ -45,31 +45,72 @@ func GetDefaultProjectConfig(platform string) (*ProjectConfig, error) {
 			WorkerResetLimit:        50,
 			Timeout:                 0,
 			TestLimit:               0,
+			StopOnCoveragePlateau:   0,
+			RandomSeed:              nil,
 			ShrinkLimit:             5_000,
+			ParallelShrinking:       false,
 			CallSequenceLength:      100,
 			TargetContracts:         []string{},
+			TargetAllContracts:      false,
 			TargetContractsBalances: []*big.Int{},
-			PredeployedContracts:    map[string]string{},
+			OptionalContracts:       []string{},
+			ContractInstances:       map[string]int{},
+			PredeployedContracts:    map[string]PredeployedContractConfig{},
 			ConstructorArgs:         map[string]map[string]any{},
+			LenientConstructorArgs:  false,
+			SetupCalls:              []SetupCallConfig{},
 			CorpusDirectory:         "",
+			FailOnCorpusLoadError:   false,
+			PruneInvalidCorpus:      false,
+			DumpDeployedBytecodeDir: "",
 			CoverageEnabled:         true,
 			CoverageFormats:         []string{"html", "lcov"},
+			TestReportFormats:       []string{},
 			SenderAddresses: []string{
 				"0x10000",
 				"0x20000",
 				"0x30000",
 			},
-			DeployerAddress:        "0x30000",
-			MaxBlockNumberDelay:    60480,
-			MaxBlockTimestampDelay: 604800,
-			BlockGasLimit:          125_000_000,
-			TransactionGasLimit:    12_500_000,
+			SenderWeights:                    map[string]uint64{},
+			SenderBalance:                    nil,
+			DeployerBalance:                  nil,
+			DeployerAddress:                  "0x30000",
+			MaxBlockNumberDelay:              60480,
+			MaxBlockTimestampDelay:           604800,
+			BlockTimeBase:                    0,
+			BlockTimeJitter:                  0,
+			MethodBlockDelays:                map[string]MethodBlockDelayConfig{},
+			BlockGasLimit:                    125_000_000,
+			TxsPerBlock:                      1,
+			TransactionGasLimit:              12_500_000,
+			IntegerArgumentRanges:            []IntegerArgumentRangeConfig{},
+			StructFieldMutationProbabilities: []StructFieldMutationConfig{},
+			ExpectedReverts:                  []string{},
+			CallSequenceGeneratorStrategies:  []CallSequenceGeneratorStrategyConfig{},
+			RecordSequenceSeeds:              false,
+			VerifyStateResetBetweenSequences: false,
+			TraceAllSequences:                false,
+			ZeroAddressProbability:           nil,
+			FactoryCallProbability:           0,
+			FactoryFunctions:                 []string{},
+			SeedFromEvents:                   false,
+			SeedFromEventsMaxValues:          100,
+			SeedFromReturnData:               true,
+			TargetReachabilityGracePeriod:    0,
+			CallValueGeneration: CallValueGenerationConfig{
+				Enabled:              false,
+				NonZeroProbability:   0.5,
+				MaxValue:             nil,
+				EdgeValueProbability: 0.1,
+			},
 			Testing: TestingConfig{
 				StopOnFailedTest:             true,
 				StopOnFailedContractMatching: false,
 				StopOnNoTests:                true,
 				TestAllContracts:             false,
+				DynamicDeployers:             []string{},
 				TraceAll:                     false,
+				AssertionOnlyMode:            false,
 				TargetFunctionSignatures:     []string{},
 				ExcludeFunctionSignatures:    []string{},
 				AssertionTesting: AssertionTestingConfig{
@@ -78,6 +119,8 @@ func GetDefaultProjectConfig(platform string) (*ProjectConfig, error) {
 					PanicCodeConfig: PanicCodeConfig{
 						FailOnAssertion: true,
 					},
+					FailOnCustomErrors:       []string{},
+					FailOnRevertMessageRegex: "",
 				},
 				PropertyTesting: PropertyTestingConfig{
 					Enabled: true,
@@ -91,6 +134,14 @@ func GetDefaultProjectConfig(platform string) (*ProjectConfig, error) {
 						"optimize_",
 					},
 				},
+				ERC20SupplyTesting: ERC20SupplyTestingConfig{
+					Enabled: false,
+				},
+				GasTesting: GasTestingConfig{
+					Enabled:                  false,
+					TargetFunctionSignatures: []string{},
+					Minimize:                 false,
+				},
 			},
 			TestChainConfig: *chainConfig,
 		},
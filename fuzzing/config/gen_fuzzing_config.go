@@ -15,39 +15,81 @@ var _ = (*fuzzingConfigMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (f FuzzingConfig) MarshalJSON() ([]byte, error) {
 	type FuzzingConfig struct {
-		Workers                 int                       `json:"workers"`
-		WorkerResetLimit        int                       `json:"workerResetLimit"`
-		Timeout                 int                       `json:"timeout"`
-		TestLimit               uint64                    `json:"testLimit"`
-		ShrinkLimit             uint64                    `json:"shrinkLimit"`
-		CallSequenceLength      int                       `json:"callSequenceLength"`
-		CorpusDirectory         string                    `json:"corpusDirectory"`
-		CoverageEnabled         bool                      `json:"coverageEnabled"`
-		CoverageFormats         []string                  `json:"coverageFormats"`
-		TargetContracts         []string                  `json:"targetContracts"`
-		PredeployedContracts    map[string]string         `json:"predeployedContracts"`
-		TargetContractsBalances []*hexutil.Big            `json:"targetContractsBalances"`
-		ConstructorArgs         map[string]map[string]any `json:"constructorArgs"`
-		DeployerAddress         string                    `json:"deployerAddress"`
-		SenderAddresses         []string                  `json:"senderAddresses"`
-		MaxBlockNumberDelay     uint64                    `json:"blockNumberDelayMax"`
-		MaxBlockTimestampDelay  uint64                    `json:"blockTimestampDelayMax"`
-		BlockGasLimit           uint64                    `json:"blockGasLimit"`
-		TransactionGasLimit     uint64                    `json:"transactionGasLimit"`
-		Testing                 TestingConfig             `json:"testing"`
-		TestChainConfig         config.TestChainConfig    `json:"chainConfig"`
+		Workers                          int                                   `json:"workers"`
+		WorkerResetLimit                 int                                   `json:"workerResetLimit"`
+		Timeout                          int                                   `json:"timeout"`
+		TestLimit                        uint64                                `json:"testLimit"`
+		StopOnCoveragePlateau            uint64                                `json:"stopOnCoveragePlateau"`
+		RandomSeed                       *int64                                `json:"randomSeed"`
+		ShrinkLimit                      uint64                                `json:"shrinkLimit"`
+		ParallelShrinking                bool                                  `json:"parallelShrinking"`
+		CallSequenceLength               int                                   `json:"callSequenceLength"`
+		CorpusDirectory                  string                                `json:"corpusDirectory"`
+		FailOnCorpusLoadError            bool                                  `json:"failOnCorpusLoadError"`
+		PruneInvalidCorpus               bool                                  `json:"pruneInvalidCorpus"`
+		DumpDeployedBytecodeDir          string                                `json:"dumpDeployedBytecodeDir"`
+		CoverageEnabled                  bool                                  `json:"coverageEnabled"`
+		CoverageFormats                  []string                              `json:"coverageFormats"`
+		TestReportFormats                []string                              `json:"testReportFormats"`
+		TargetContracts                  []string                              `json:"targetContracts"`
+		TargetAllContracts               bool                                  `json:"targetAllContracts"`
+		PredeployedContracts             map[string]PredeployedContractConfig  `json:"predeployedContracts"`
+		TargetContractsBalances          []*hexutil.Big                        `json:"targetContractsBalances"`
+		OptionalContracts                []string                              `json:"optionalContracts"`
+		ContractInstances                map[string]int                        `json:"contractInstances"`
+		ConstructorArgs                  map[string]map[string]any             `json:"constructorArgs"`
+		LenientConstructorArgs           bool                                  `json:"lenientConstructorArgs"`
+		SetupCalls                       []SetupCallConfig                     `json:"setupCalls"`
+		DeployerAddress                  string                                `json:"deployerAddress"`
+		SenderAddresses                  []string                              `json:"senderAddresses"`
+		SenderWeights                    map[string]uint64                     `json:"senderWeights"`
+		SenderBalance                    *hexutil.Big                          `json:"senderBalance"`
+		DeployerBalance                  *hexutil.Big                          `json:"deployerBalance"`
+		MaxBlockNumberDelay              uint64                                `json:"blockNumberDelayMax"`
+		MaxBlockTimestampDelay           uint64                                `json:"blockTimestampDelayMax"`
+		BlockTimeBase                    uint64                                `json:"blockTimeBase"`
+		BlockTimeJitter                  uint64                                `json:"blockTimeJitter"`
+		MethodBlockDelays                map[string]MethodBlockDelayConfig     `json:"methodBlockDelays"`
+		BlockGasLimit                    uint64                                `json:"blockGasLimit"`
+		TxsPerBlock                      uint64                                `json:"txsPerBlock"`
+		TransactionGasLimit              uint64                                `json:"transactionGasLimit"`
+		IntegerArgumentRanges            []IntegerArgumentRangeConfig          `json:"integerArgumentRanges"`
+		StructFieldMutationProbabilities []StructFieldMutationConfig           `json:"structFieldMutationProbabilities"`
+		ExpectedReverts                  []string                              `json:"expectedReverts"`
+		CallSequenceGeneratorStrategies  []CallSequenceGeneratorStrategyConfig `json:"callSequenceGeneratorStrategies"`
+		RecordSequenceSeeds              bool                                  `json:"recordSequenceSeeds"`
+		VerifyStateResetBetweenSequences bool                                  `json:"verifyStateResetBetweenSequences"`
+		TraceAllSequences                bool                                  `json:"traceAllSequences"`
+		ZeroAddressProbability           *float64                              `json:"zeroAddressProbability"`
+		FactoryCallProbability           float64                               `json:"factoryCallProbability"`
+		FactoryFunctions                 []string                              `json:"factoryFunctions"`
+		SeedFromEvents                   bool                                  `json:"seedFromEvents"`
+		SeedFromEventsMaxValues          int                                   `json:"seedFromEventsMaxValues"`
+		SeedFromReturnData               bool                                  `json:"seedFromReturnData"`
+		TargetReachabilityGracePeriod    uint64                                `json:"targetReachabilityGracePeriod"`
+		CallValueGeneration              CallValueGenerationConfig             `json:"callValueGeneration"`
+		Testing                          TestingConfig                         `json:"testing"`
+		TestChainConfig                  config.TestChainConfig                `json:"chainConfig"`
 	}
 	var enc FuzzingConfig
 	enc.Workers = f.Workers
 	enc.WorkerResetLimit = f.WorkerResetLimit
 	enc.Timeout = f.Timeout
 	enc.TestLimit = f.TestLimit
+	enc.StopOnCoveragePlateau = f.StopOnCoveragePlateau
+	enc.RandomSeed = f.RandomSeed
 	enc.ShrinkLimit = f.ShrinkLimit
+	enc.ParallelShrinking = f.ParallelShrinking
 	enc.CallSequenceLength = f.CallSequenceLength
 	enc.CorpusDirectory = f.CorpusDirectory
+	enc.FailOnCorpusLoadError = f.FailOnCorpusLoadError
+	enc.PruneInvalidCorpus = f.PruneInvalidCorpus
+	enc.DumpDeployedBytecodeDir = f.DumpDeployedBytecodeDir
 	enc.CoverageEnabled = f.CoverageEnabled
 	enc.CoverageFormats = f.CoverageFormats
+	enc.TestReportFormats = f.TestReportFormats
 	enc.TargetContracts = f.TargetContracts
+	enc.TargetAllContracts = f.TargetAllContracts
 	enc.PredeployedContracts = f.PredeployedContracts
 	if f.TargetContractsBalances != nil {
 		enc.TargetContractsBalances = make([]*hexutil.Big, len(f.TargetContractsBalances))
@@ -55,13 +97,39 @@ func (f FuzzingConfig) MarshalJSON() ([]byte, error) {
 			enc.TargetContractsBalances[k] = (*hexutil.Big)(v)
 		}
 	}
+	enc.OptionalContracts = f.OptionalContracts
+	enc.ContractInstances = f.ContractInstances
 	enc.ConstructorArgs = f.ConstructorArgs
+	enc.LenientConstructorArgs = f.LenientConstructorArgs
+	enc.SetupCalls = f.SetupCalls
 	enc.DeployerAddress = f.DeployerAddress
 	enc.SenderAddresses = f.SenderAddresses
+	enc.SenderWeights = f.SenderWeights
+	enc.SenderBalance = (*hexutil.Big)(f.SenderBalance)
+	enc.DeployerBalance = (*hexutil.Big)(f.DeployerBalance)
 	enc.MaxBlockNumberDelay = f.MaxBlockNumberDelay
 	enc.MaxBlockTimestampDelay = f.MaxBlockTimestampDelay
+	enc.BlockTimeBase = f.BlockTimeBase
+	enc.BlockTimeJitter = f.BlockTimeJitter
+	enc.MethodBlockDelays = f.MethodBlockDelays
 	enc.BlockGasLimit = f.BlockGasLimit
+	enc.TxsPerBlock = f.TxsPerBlock
 	enc.TransactionGasLimit = f.TransactionGasLimit
+	enc.IntegerArgumentRanges = f.IntegerArgumentRanges
+	enc.StructFieldMutationProbabilities = f.StructFieldMutationProbabilities
+	enc.ExpectedReverts = f.ExpectedReverts
+	enc.CallSequenceGeneratorStrategies = f.CallSequenceGeneratorStrategies
+	enc.RecordSequenceSeeds = f.RecordSequenceSeeds
+	enc.VerifyStateResetBetweenSequences = f.VerifyStateResetBetweenSequences
+	enc.TraceAllSequences = f.TraceAllSequences
+	enc.ZeroAddressProbability = f.ZeroAddressProbability
+	enc.FactoryCallProbability = f.FactoryCallProbability
+	enc.FactoryFunctions = f.FactoryFunctions
+	enc.SeedFromEvents = f.SeedFromEvents
+	enc.SeedFromEventsMaxValues = f.SeedFromEventsMaxValues
+	enc.SeedFromReturnData = f.SeedFromReturnData
+	enc.TargetReachabilityGracePeriod = f.TargetReachabilityGracePeriod
+	enc.CallValueGeneration = f.CallValueGeneration
 	enc.Testing = f.Testing
 	enc.TestChainConfig = f.TestChainConfig
 	return json.Marshal(&enc)
@@ -70,27 +138,61 @@ func (f FuzzingConfig) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (f *FuzzingConfig) UnmarshalJSON(input []byte) error {
 	type FuzzingConfig struct {
-		Workers                 *int                      `json:"workers"`
-		WorkerResetLimit        *int                      `json:"workerResetLimit"`
-		Timeout                 *int                      `json:"timeout"`
-		TestLimit               *uint64                   `json:"testLimit"`
-		ShrinkLimit             *uint64                   `json:"shrinkLimit"`
-		CallSequenceLength      *int                      `json:"callSequenceLength"`
-		CorpusDirectory         *string                   `json:"corpusDirectory"`
-		CoverageEnabled         *bool                     `json:"coverageEnabled"`
-		CoverageFormats         []string                  `json:"coverageFormats"`
-		TargetContracts         []string                  `json:"targetContracts"`
-		PredeployedContracts    map[string]string         `json:"predeployedContracts"`
-		TargetContractsBalances []*hexutil.Big            `json:"targetContractsBalances"`
-		ConstructorArgs         map[string]map[string]any `json:"constructorArgs"`
-		DeployerAddress         *string                   `json:"deployerAddress"`
-		SenderAddresses         []string                  `json:"senderAddresses"`
-		MaxBlockNumberDelay     *uint64                   `json:"blockNumberDelayMax"`
-		MaxBlockTimestampDelay  *uint64                   `json:"blockTimestampDelayMax"`
-		BlockGasLimit           *uint64                   `json:"blockGasLimit"`
-		TransactionGasLimit     *uint64                   `json:"transactionGasLimit"`
-		Testing                 *TestingConfig            `json:"testing"`
-		TestChainConfig         *config.TestChainConfig   `json:"chainConfig"`
+		Workers                          *int                                  `json:"workers"`
+		WorkerResetLimit                 *int                                  `json:"workerResetLimit"`
+		Timeout                          *int                                  `json:"timeout"`
+		TestLimit                        *uint64                               `json:"testLimit"`
+		StopOnCoveragePlateau            *uint64                               `json:"stopOnCoveragePlateau"`
+		RandomSeed                       *int64                                `json:"randomSeed"`
+		ShrinkLimit                      *uint64                               `json:"shrinkLimit"`
+		ParallelShrinking                *bool                                 `json:"parallelShrinking"`
+		CallSequenceLength               *int                                  `json:"callSequenceLength"`
+		CorpusDirectory                  *string                               `json:"corpusDirectory"`
+		FailOnCorpusLoadError            *bool                                 `json:"failOnCorpusLoadError"`
+		PruneInvalidCorpus               *bool                                 `json:"pruneInvalidCorpus"`
+		DumpDeployedBytecodeDir          *string                               `json:"dumpDeployedBytecodeDir"`
+		CoverageEnabled                  *bool                                 `json:"coverageEnabled"`
+		CoverageFormats                  []string                              `json:"coverageFormats"`
+		TestReportFormats                []string                              `json:"testReportFormats"`
+		TargetContracts                  []string                              `json:"targetContracts"`
+		TargetAllContracts               *bool                                 `json:"targetAllContracts"`
+		PredeployedContracts             map[string]PredeployedContractConfig  `json:"predeployedContracts"`
+		TargetContractsBalances          []*hexutil.Big                        `json:"targetContractsBalances"`
+		OptionalContracts                []string                              `json:"optionalContracts"`
+		ContractInstances                map[string]int                        `json:"contractInstances"`
+		ConstructorArgs                  map[string]map[string]any             `json:"constructorArgs"`
+		LenientConstructorArgs           *bool                                 `json:"lenientConstructorArgs"`
+		SetupCalls                       []SetupCallConfig                     `json:"setupCalls"`
+		DeployerAddress                  *string                               `json:"deployerAddress"`
+		SenderAddresses                  []string                              `json:"senderAddresses"`
+		SenderWeights                    map[string]uint64                     `json:"senderWeights"`
+		SenderBalance                    *hexutil.Big                          `json:"senderBalance"`
+		DeployerBalance                  *hexutil.Big                          `json:"deployerBalance"`
+		MaxBlockNumberDelay              *uint64                               `json:"blockNumberDelayMax"`
+		MaxBlockTimestampDelay           *uint64                               `json:"blockTimestampDelayMax"`
+		BlockTimeBase                    *uint64                               `json:"blockTimeBase"`
+		BlockTimeJitter                  *uint64                               `json:"blockTimeJitter"`
+		MethodBlockDelays                map[string]MethodBlockDelayConfig     `json:"methodBlockDelays"`
+		BlockGasLimit                    *uint64                               `json:"blockGasLimit"`
+		TxsPerBlock                      *uint64                               `json:"txsPerBlock"`
+		TransactionGasLimit              *uint64                               `json:"transactionGasLimit"`
+		IntegerArgumentRanges            []IntegerArgumentRangeConfig          `json:"integerArgumentRanges"`
+		StructFieldMutationProbabilities []StructFieldMutationConfig           `json:"structFieldMutationProbabilities"`
+		ExpectedReverts                  []string                              `json:"expectedReverts"`
+		CallSequenceGeneratorStrategies  []CallSequenceGeneratorStrategyConfig `json:"callSequenceGeneratorStrategies"`
+		RecordSequenceSeeds              *bool                                 `json:"recordSequenceSeeds"`
+		VerifyStateResetBetweenSequences *bool                                 `json:"verifyStateResetBetweenSequences"`
+		TraceAllSequences                *bool                                 `json:"traceAllSequences"`
+		ZeroAddressProbability           *float64                              `json:"zeroAddressProbability"`
+		FactoryCallProbability           *float64                              `json:"factoryCallProbability"`
+		FactoryFunctions                 []string                              `json:"factoryFunctions"`
+		SeedFromEvents                   *bool                                 `json:"seedFromEvents"`
+		SeedFromEventsMaxValues          *int                                  `json:"seedFromEventsMaxValues"`
+		SeedFromReturnData               *bool                                 `json:"seedFromReturnData"`
+		TargetReachabilityGracePeriod    *uint64                               `json:"targetReachabilityGracePeriod"`
+		CallValueGeneration              *CallValueGenerationConfig            `json:"callValueGeneration"`
+		Testing                          *TestingConfig                        `json:"testing"`
+		TestChainConfig                  *config.TestChainConfig               `json:"chainConfig"`
 	}
 	var dec FuzzingConfig
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -108,24 +210,48 @@ func (f *FuzzingConfig) UnmarshalJSON(input []byte) error {
 	if dec.TestLimit != nil {
 		f.TestLimit = *dec.TestLimit
 	}
+	if dec.StopOnCoveragePlateau != nil {
+		f.StopOnCoveragePlateau = *dec.StopOnCoveragePlateau
+	}
+	if dec.RandomSeed != nil {
+		f.RandomSeed = dec.RandomSeed
+	}
 	if dec.ShrinkLimit != nil {
 		f.ShrinkLimit = *dec.ShrinkLimit
 	}
+	if dec.ParallelShrinking != nil {
+		f.ParallelShrinking = *dec.ParallelShrinking
+	}
 	if dec.CallSequenceLength != nil {
 		f.CallSequenceLength = *dec.CallSequenceLength
 	}
 	if dec.CorpusDirectory != nil {
 		f.CorpusDirectory = *dec.CorpusDirectory
 	}
+	if dec.FailOnCorpusLoadError != nil {
+		f.FailOnCorpusLoadError = *dec.FailOnCorpusLoadError
+	}
+	if dec.PruneInvalidCorpus != nil {
+		f.PruneInvalidCorpus = *dec.PruneInvalidCorpus
+	}
+	if dec.DumpDeployedBytecodeDir != nil {
+		f.DumpDeployedBytecodeDir = *dec.DumpDeployedBytecodeDir
+	}
 	if dec.CoverageEnabled != nil {
 		f.CoverageEnabled = *dec.CoverageEnabled
 	}
 	if dec.CoverageFormats != nil {
 		f.CoverageFormats = dec.CoverageFormats
 	}
+	if dec.TestReportFormats != nil {
+		f.TestReportFormats = dec.TestReportFormats
+	}
 	if dec.TargetContracts != nil {
 		f.TargetContracts = dec.TargetContracts
 	}
+	if dec.TargetAllContracts != nil {
+		f.TargetAllContracts = *dec.TargetAllContracts
+	}
 	if dec.PredeployedContracts != nil {
 		f.PredeployedContracts = dec.PredeployedContracts
 	}
@@ -135,27 +261,105 @@ func (f *FuzzingConfig) UnmarshalJSON(input []byte) error {
 			f.TargetContractsBalances[k] = (*big.Int)(v)
 		}
 	}
+	if dec.OptionalContracts != nil {
+		f.OptionalContracts = dec.OptionalContracts
+	}
+	if dec.ContractInstances != nil {
+		f.ContractInstances = dec.ContractInstances
+	}
 	if dec.ConstructorArgs != nil {
 		f.ConstructorArgs = dec.ConstructorArgs
 	}
+	if dec.LenientConstructorArgs != nil {
+		f.LenientConstructorArgs = *dec.LenientConstructorArgs
+	}
+	if dec.SetupCalls != nil {
+		f.SetupCalls = dec.SetupCalls
+	}
 	if dec.DeployerAddress != nil {
 		f.DeployerAddress = *dec.DeployerAddress
 	}
 	if dec.SenderAddresses != nil {
 		f.SenderAddresses = dec.SenderAddresses
 	}
+	if dec.SenderWeights != nil {
+		f.SenderWeights = dec.SenderWeights
+	}
+	if dec.SenderBalance != nil {
+		f.SenderBalance = (*big.Int)(dec.SenderBalance)
+	}
+	if dec.DeployerBalance != nil {
+		f.DeployerBalance = (*big.Int)(dec.DeployerBalance)
+	}
 	if dec.MaxBlockNumberDelay != nil {
 		f.MaxBlockNumberDelay = *dec.MaxBlockNumberDelay
 	}
 	if dec.MaxBlockTimestampDelay != nil {
 		f.MaxBlockTimestampDelay = *dec.MaxBlockTimestampDelay
 	}
+	if dec.BlockTimeBase != nil {
+		f.BlockTimeBase = *dec.BlockTimeBase
+	}
+	if dec.BlockTimeJitter != nil {
+		f.BlockTimeJitter = *dec.BlockTimeJitter
+	}
+	if dec.MethodBlockDelays != nil {
+		f.MethodBlockDelays = dec.MethodBlockDelays
+	}
 	if dec.BlockGasLimit != nil {
 		f.BlockGasLimit = *dec.BlockGasLimit
 	}
+	if dec.TxsPerBlock != nil {
+		f.TxsPerBlock = *dec.TxsPerBlock
+	}
 	if dec.TransactionGasLimit != nil {
 		f.TransactionGasLimit = *dec.TransactionGasLimit
 	}
+	if dec.IntegerArgumentRanges != nil {
+		f.IntegerArgumentRanges = dec.IntegerArgumentRanges
+	}
+	if dec.StructFieldMutationProbabilities != nil {
+		f.StructFieldMutationProbabilities = dec.StructFieldMutationProbabilities
+	}
+	if dec.ExpectedReverts != nil {
+		f.ExpectedReverts = dec.ExpectedReverts
+	}
+	if dec.CallSequenceGeneratorStrategies != nil {
+		f.CallSequenceGeneratorStrategies = dec.CallSequenceGeneratorStrategies
+	}
+	if dec.RecordSequenceSeeds != nil {
+		f.RecordSequenceSeeds = *dec.RecordSequenceSeeds
+	}
+	if dec.VerifyStateResetBetweenSequences != nil {
+		f.VerifyStateResetBetweenSequences = *dec.VerifyStateResetBetweenSequences
+	}
+	if dec.TraceAllSequences != nil {
+		f.TraceAllSequences = *dec.TraceAllSequences
+	}
+	if dec.ZeroAddressProbability != nil {
+		f.ZeroAddressProbability = dec.ZeroAddressProbability
+	}
+	if dec.FactoryCallProbability != nil {
+		f.FactoryCallProbability = *dec.FactoryCallProbability
+	}
+	if dec.FactoryFunctions != nil {
+		f.FactoryFunctions = dec.FactoryFunctions
+	}
+	if dec.SeedFromEvents != nil {
+		f.SeedFromEvents = *dec.SeedFromEvents
+	}
+	if dec.SeedFromEventsMaxValues != nil {
+		f.SeedFromEventsMaxValues = *dec.SeedFromEventsMaxValues
+	}
+	if dec.SeedFromReturnData != nil {
+		f.SeedFromReturnData = *dec.SeedFromReturnData
+	}
+	if dec.TargetReachabilityGracePeriod != nil {
+		f.TargetReachabilityGracePeriod = *dec.TargetReachabilityGracePeriod
+	}
+	if dec.CallValueGeneration != nil {
+		f.CallValueGeneration = *dec.CallValueGeneration
+	}
 	if dec.Testing != nil {
 		f.Testing = *dec.Testing
 	}
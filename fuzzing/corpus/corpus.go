@@ -213,11 +213,16 @@ func (c *Corpus) RandomMutationTargetSequence() (calls.CallSequence, error) {
 // chain, using the map of deployed contracts (e.g. to check for non-existent method called, due to code changes).
 // Valid call sequences are added to the list of un-executed sequences the fuzzer should execute first.
 // If this sequence list being initialized is for use with mutations, it is added to the mutationTargetSequenceChooser.
-// Returns an error if one occurs.
-func (c *Corpus) initializeSequences(sequenceFiles *corpusDirectory[calls.CallSequence], testChain *chain.TestChain, deployedContracts map[common.Address]*contracts.Contract, useInMutations bool) error {
+// If failOnInvalidSequence is true, a sequence which can no longer be replayed results in an error, rather than being
+// disabled. If pruneInvalidSequences is true, such a sequence is instead removed from the corpus and deleted from disk.
+// Returns the number of sequences pruned, or an error if one occurs.
+func (c *Corpus) initializeSequences(sequenceFiles *corpusDirectory[calls.CallSequence], testChain *chain.TestChain, deployedContracts map[common.Address]*contracts.Contract, useInMutations bool, failOnInvalidSequence bool, pruneInvalidSequences bool) (int, error) {
 	// Cache the base block index so that you can reset back to it after every sequence
 	baseBlockIndex := uint64(len(testChain.CommittedBlocks()))
 
+	// Track the names of files which should be pruned, as we cannot remove them while iterating over them.
+	prunedFileNames := make([]string, 0)
+
 	// Loop for each sequence
 	var err error
 	for _, sequenceFileData := range sequenceFiles.files {
@@ -277,7 +282,7 @@ func (c *Corpus) initializeSequences(sequenceFiles *corpusDirectory[calls.CallSe
 
 		// If we failed to replay a sequence and measure coverage due to an unexpected error, report it.
 		if err != nil {
-			return fmt.Errorf("failed to initialize coverage maps from corpus, encountered an error while executing call sequence: %v", err)
+			return 0, fmt.Errorf("failed to initialize coverage maps from corpus, encountered an error while executing call sequence: %v", err)
 		}
 
 		// If the sequence was replayed successfully, we add it. If it was not, we exclude it with a warning.
@@ -301,23 +306,38 @@ func (c *Corpus) initializeSequences(sequenceFiles *corpusDirectory[calls.CallSe
 				c.mutationTargetSequenceChooser.AddChoices(randomutils.NewWeightedRandomChoice[calls.CallSequence](sequence, weight))
 			}
 			c.unexecutedCallSequences = append(c.unexecutedCallSequences, sequence)
+		} else if failOnInvalidSequence {
+			return 0, fmt.Errorf("failed to load corpus item %v, encountered an error when replaying it: %v", filepath.Join(sequenceFiles.path, sequenceFileData.fileName), sequenceInvalidError)
+		} else if pruneInvalidSequences {
+			c.logger.Info("Pruning corpus item ", colors.Bold, filepath.Join(sequenceFiles.path, sequenceFileData.fileName), colors.Reset, " as it could not be replayed: ", sequenceInvalidError)
+			prunedFileNames = append(prunedFileNames, sequenceFileData.fileName)
 		} else {
 			c.logger.Debug("Corpus item ", colors.Bold, sequenceFileData.fileName, colors.Reset, " disabled due to error when replaying it", sequenceInvalidError)
 		}
 
 		// Revert chain state to our starting point to test the next sequence.
 		if err := testChain.RevertToBlockIndex(baseBlockIndex); err != nil {
-			return fmt.Errorf("failed to reset the chain while seeding coverage: %v", err)
+			return 0, fmt.Errorf("failed to reset the chain while seeding coverage: %v", err)
 		}
 	}
-	return nil
+
+	// Remove any pruned sequences from the corpus and disk.
+	for _, fileName := range prunedFileNames {
+		if err := sequenceFiles.deleteFile(fileName); err != nil {
+			return 0, fmt.Errorf("failed to prune corpus item %v: %v", filepath.Join(sequenceFiles.path, fileName), err)
+		}
+	}
+	return len(prunedFileNames), nil
 }
 
 // Initialize initializes any runtime data needed for a Corpus on startup. Call sequences are replayed on the post-setup
 // (deployment) test chain to calculate coverage, while resolving references to compiled contracts.
+// If failOnInvalidSequence is true, an error is returned if any call sequence can no longer be replayed, rather than it
+// being disabled. Otherwise, if pruneInvalidSequences is true, such call sequences are removed from the corpus and
+// deleted from the corpus directory.
 // Returns the active number of corpus items, total number of corpus items, or an error if one occurred. If an error
 // is returned, then the corpus counts returned will always be zero.
-func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions contracts.Contracts) (int, int, error) {
+func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions contracts.Contracts, failOnInvalidSequence bool, pruneInvalidSequences bool) (int, int, error) {
 	// Acquire our call sequences lock during the duration of this method.
 	c.callSequencesLock.Lock()
 	defer c.callSequencesLock.Unlock()
@@ -375,16 +395,21 @@ func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions
 	// The order of initializations here is important, as it determines the order of "unexecuted sequences" to replay
 	// when the fuzzer's worker starts up. We want to replay test results first, so that other corpus items
 	// do not trigger the same test failures instead.
-	err = c.initializeSequences(c.testResultSequenceFiles, testChain, deployedContracts, false)
+	prunedTestResultSequences, err := c.initializeSequences(c.testResultSequenceFiles, testChain, deployedContracts, false, failOnInvalidSequence, pruneInvalidSequences)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	err = c.initializeSequences(c.callSequenceFiles, testChain, deployedContracts, true)
+	prunedCallSequences, err := c.initializeSequences(c.callSequenceFiles, testChain, deployedContracts, true, failOnInvalidSequence, pruneInvalidSequences)
 	if err != nil {
 		return 0, 0, err
 	}
 
+	// Summarize any pruning that took place.
+	if prunedSequences := prunedTestResultSequences + prunedCallSequences; prunedSequences > 0 {
+		c.logger.Info("Pruned ", colors.Bold, prunedSequences, colors.Reset, " invalid corpus item(s) (", prunedCallSequences, " call sequence(s), ", prunedTestResultSequences, " test result(s))")
+	}
+
 	// Calculate corpus health metrics
 	corpusSequencesTotal := len(c.callSequenceFiles.files) + len(c.testResultSequenceFiles.files)
 	corpusSequencesActive := len(c.unexecutedCallSequences)
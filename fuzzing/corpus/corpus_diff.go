@@ -0,0 +1,103 @@
+package corpus
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CorpusDiffEntry describes a call sequence in a CorpusDiff.
+type CorpusDiffEntry struct {
+	// Fingerprint describes the hash of the call sequence, as calculated by calls.CallSequence.Hash. Call sequences
+	// with the same fingerprint are considered equal.
+	Fingerprint common.Hash
+
+	// FileName describes the path of the call sequence file, relative to the corpus directory it was read from.
+	FileName string
+}
+
+// CorpusDiff describes the call sequences which are unique to, or shared between, two corpora.
+type CorpusDiff struct {
+	// OnlyA describes the call sequences which are only present in the first corpus.
+	OnlyA []CorpusDiffEntry
+
+	// OnlyB describes the call sequences which are only present in the second corpus.
+	OnlyB []CorpusDiffEntry
+
+	// Both describes the call sequences which are present in both corpora, with file names from the first corpus.
+	Both []CorpusDiffEntry
+}
+
+// DiffCorpora compares the call sequences of two corpora by their fingerprint. Both mutable call sequences and test
+// results are compared. A call sequence which occurs multiple times in a corpus is only reported once.
+// Returns the CorpusDiff, or an error if one occurs.
+func DiffCorpora(a *Corpus, b *Corpus) (*CorpusDiff, error) {
+	// Obtain the entries for each corpus.
+	entriesA, err := a.diffEntries()
+	if err != nil {
+		return nil, err
+	}
+	entriesB, err := b.diffEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort each entry into its set, by checking for its fingerprint in the other corpus.
+	diff := &CorpusDiff{
+		OnlyA: make([]CorpusDiffEntry, 0),
+		OnlyB: make([]CorpusDiffEntry, 0),
+		Both:  make([]CorpusDiffEntry, 0),
+	}
+	for fingerprint, entry := range entriesA {
+		if _, exists := entriesB[fingerprint]; exists {
+			diff.Both = append(diff.Both, entry)
+		} else {
+			diff.OnlyA = append(diff.OnlyA, entry)
+		}
+	}
+	for fingerprint, entry := range entriesB {
+		if _, exists := entriesA[fingerprint]; !exists {
+			diff.OnlyB = append(diff.OnlyB, entry)
+		}
+	}
+
+	// Sort our results so they are deterministic.
+	for _, entries := range [][]CorpusDiffEntry{diff.OnlyA, diff.OnlyB, diff.Both} {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].FileName < entries[j].FileName
+		})
+	}
+	return diff, nil
+}
+
+// diffEntries is a helper method for DiffCorpora. It obtains a CorpusDiffEntry for every call sequence in the corpus,
+// keyed by fingerprint.
+// Returns the entries, or an error if one occurs.
+func (c *Corpus) diffEntries() (map[common.Hash]CorpusDiffEntry, error) {
+	c.callSequencesLock.Lock()
+	defer c.callSequencesLock.Unlock()
+
+	entries := make(map[common.Hash]CorpusDiffEntry)
+	sequenceDirectories := map[string]*corpusDirectory[calls.CallSequence]{
+		"call_sequences": c.callSequenceFiles,
+		"test_results":   c.testResultSequenceFiles,
+	}
+	for directoryName, sequenceFiles := range sequenceDirectories {
+		for _, sequenceFile := range sequenceFiles.files {
+			fingerprint, err := sequenceFile.data.Hash()
+			if err != nil {
+				return nil, err
+			}
+
+			// If a call sequence occurs multiple times, we keep the entry with the lowest file name, so results are
+			// deterministic.
+			fileName := filepath.Join(directoryName, sequenceFile.fileName)
+			if existing, exists := entries[fingerprint]; !exists || fileName < existing.FileName {
+				entries[fingerprint] = CorpusDiffEntry{Fingerprint: fingerprint, FileName: fileName}
+			}
+		}
+	}
+	return entries, nil
+}
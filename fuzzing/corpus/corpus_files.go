@@ -92,6 +92,27 @@ func (cd *corpusDirectory[T]) removeFile(fileName string) bool {
 	return false
 }
 
+// deleteFile removes a given file from the file list and deletes it from disk, if it was previously written there.
+// Returns an error, if one occurred.
+func (cd *corpusDirectory[T]) deleteFile(fileName string) error {
+	// Remove the file from our list. If it was not found, there is nothing to delete.
+	if !cd.removeFile(fileName) {
+		return nil
+	}
+
+	// If our directory path is empty, nothing was written to disk.
+	if cd.path == "" {
+		return nil
+	}
+
+	// Delete the file from disk, ignoring the case where it was never flushed.
+	err := os.Remove(filepath.Join(cd.path, fileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 // readFiles takes a provided glob pattern representing files to parse within the corpusDirectory.path.
 // It parses any matching file into a corpusFile and adds it to the corpusDirectory.
 // Returns an error, if one occurred.
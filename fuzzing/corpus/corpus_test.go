@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"math/big"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -114,6 +115,51 @@ func TestCorpusReadWrite(t *testing.T) {
 	})
 }
 
+// TestCorpusCorruptFile ensures that a corpus with a call sequence file which cannot be parsed fails to load, rather
+// than being silently discarded.
+func TestCorpusCorruptFile(t *testing.T) {
+	testutils.ExecuteInDirectory(t, t.TempDir(), func() {
+		// Write a corrupt call sequence file to our corpus.
+		sequenceDirectory := filepath.Join("corpus", "call_sequences")
+		assert.NoError(t, os.MkdirAll(sequenceDirectory, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(sequenceDirectory, "corrupt.json"), []byte("{not json"), 0644))
+
+		// Loading the corpus should fail.
+		_, err := NewCorpus("corpus")
+		assert.Error(t, err)
+	})
+}
+
+// TestCorpusDeleteFile ensures that deleting a corpus file removes it from both the corpus and disk.
+func TestCorpusDeleteFile(t *testing.T) {
+	// Create a mock corpus
+	corpus, err := getMockSimpleCorpus(10, 20, 1, 7)
+	assert.NoError(t, err)
+	testutils.ExecuteInDirectory(t, t.TempDir(), func() {
+		// Write to disk
+		err := corpus.Flush()
+		assert.NoError(t, err)
+
+		// Delete the first call sequence file.
+		fileCount := len(corpus.callSequenceFiles.files)
+		fileName := corpus.callSequenceFiles.files[0].fileName
+		err = corpus.callSequenceFiles.deleteFile(fileName)
+		assert.NoError(t, err)
+
+		// Ensure it was removed from our file list and from disk.
+		assert.Len(t, corpus.callSequenceFiles.files, fileCount-1)
+		assert.NoFileExists(t, filepath.Join(corpus.callSequenceFiles.path, fileName))
+		matches, err := filepath.Glob(filepath.Join(corpus.callSequenceFiles.path, "*.json"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, fileCount-1, len(matches))
+
+		// Deleting a file which does not exist should have no effect.
+		err = corpus.callSequenceFiles.deleteFile(fileName)
+		assert.NoError(t, err)
+		assert.Len(t, corpus.callSequenceFiles.files, fileCount-1)
+	})
+}
+
 // TestCorpusCallSequenceMarshaling ensures that a corpus entry that is round trip serialized retains its original
 // values.
 func TestCorpusCallSequenceMarshaling(t *testing.T) {
@@ -145,3 +191,50 @@ func TestCorpusCallSequenceMarshaling(t *testing.T) {
 		assert.Empty(t, corpus.callSequenceFiles.files)
 	})
 }
+
+// TestCorpusDiff ensures that call sequences are correctly reported as unique to, or shared between, two corpora
+// with overlapping and disjoint entries.
+func TestCorpusDiff(t *testing.T) {
+	// Create our call sequences, where some are shared between both corpora.
+	sharedSequences := []calls.CallSequence{getMockCallSequence(3), getMockCallSequence(5)}
+	onlyASequences := []calls.CallSequence{getMockCallSequence(2)}
+	onlyBSequences := []calls.CallSequence{getMockCallSequence(1), getMockCallSequence(4), getMockCallSequence(6)}
+
+	// Create two in-memory corpora with the shared sequences, adding a test result to each.
+	corpusA, err := NewCorpus("")
+	assert.NoError(t, err)
+	corpusB, err := NewCorpus("")
+	assert.NoError(t, err)
+	for _, sequence := range sharedSequences {
+		assert.NoError(t, corpusA.addCallSequence(corpusA.callSequenceFiles, sequence, true, nil, false))
+		assert.NoError(t, corpusB.addCallSequence(corpusB.callSequenceFiles, sequence, true, nil, false))
+	}
+	for _, sequence := range onlyASequences {
+		assert.NoError(t, corpusA.AddTestResultCallSequence(sequence, nil, false))
+	}
+	for _, sequence := range onlyBSequences {
+		assert.NoError(t, corpusB.addCallSequence(corpusB.callSequenceFiles, sequence, true, nil, false))
+	}
+
+	// Diff the corpora and verify the counts.
+	diff, err := DiffCorpora(corpusA, corpusB)
+	assert.NoError(t, err)
+	assert.Len(t, diff.OnlyA, len(onlyASequences))
+	assert.Len(t, diff.OnlyB, len(onlyBSequences))
+	assert.Len(t, diff.Both, len(sharedSequences))
+
+	// Verify the fingerprints of the entries unique to the first corpus.
+	fingerprint, err := onlyASequences[0].Hash()
+	assert.NoError(t, err)
+	assert.EqualValues(t, fingerprint, diff.OnlyA[0].Fingerprint)
+
+	// Diffing a corpus against one with disjoint entries should report no shared sequences.
+	corpusC, err := NewCorpus("")
+	assert.NoError(t, err)
+	assert.NoError(t, corpusC.addCallSequence(corpusC.callSequenceFiles, getMockCallSequence(2), true, nil, false))
+	diff, err = DiffCorpora(corpusA, corpusC)
+	assert.NoError(t, err)
+	assert.Len(t, diff.OnlyA, len(sharedSequences)+len(onlyASequences))
+	assert.Len(t, diff.OnlyB, 1)
+	assert.Empty(t, diff.Both)
+}
@@ -42,6 +42,15 @@ type CoverageTracer struct {
 	// coverageMaps describes the execution coverage recorded. Call frames which errored are not recorded.
 	coverageMaps *CoverageMaps
 
+	// cumulativeCoverageMaps describes the execution coverage recorded across every transaction traced since
+	// UniquePCs was first called.
+	cumulativeCoverageMaps *CoverageMaps
+
+	// cumulativeCoverageEnabled indicates whether cumulativeCoverageMaps should be updated as transactions complete.
+	// This is only enabled once UniquePCs is first called, so tracers which are never queried do not pay to merge every
+	// transaction's coverage.
+	cumulativeCoverageEnabled bool
+
 	// callFrameStates describes the state tracked by the tracer per call frame.
 	callFrameStates []*coverageTracerCallFrameState
 
@@ -76,9 +85,10 @@ type coverageTracerCallFrameState struct {
 // NewCoverageTracer returns a new CoverageTracer.
 func NewCoverageTracer() *CoverageTracer {
 	tracer := &CoverageTracer{
-		coverageMaps:    NewCoverageMaps(),
-		callFrameStates: make([]*coverageTracerCallFrameState, 0),
-		codeHashCache:   [2]map[common.Hash]common.Hash{make(map[common.Hash]common.Hash), make(map[common.Hash]common.Hash)},
+		coverageMaps:           NewCoverageMaps(),
+		cumulativeCoverageMaps: NewCoverageMaps(),
+		callFrameStates:        make([]*coverageTracerCallFrameState, 0),
+		codeHashCache:          [2]map[common.Hash]common.Hash{make(map[common.Hash]common.Hash), make(map[common.Hash]common.Hash)},
 	}
 	nativeTracer := &tracers.Tracer{
 		Hooks: &tracing.Hooks{
@@ -98,6 +108,14 @@ func (t *CoverageTracer) NativeTracer() *chain.TestChainTracer {
 	return t.nativeTracer
 }
 
+// UniquePCs returns the amount of unique program counters covered across every transaction traced since this method
+// was first called, as cumulative coverage is only tracked from then on. Coverage of the transaction currently being
+// traced is not included until it completes.
+func (t *CoverageTracer) UniquePCs() uint64 {
+	t.cumulativeCoverageEnabled = true
+	return t.cumulativeCoverageMaps.UniquePCs()
+}
+
 // OnTxStart is called upon the start of transaction execution, as defined by tracers.Tracer.
 func (t *CoverageTracer) OnTxStart(vm *tracing.VMContext, tx *coretypes.Transaction, from common.Address) {
 	// Reset our call frame states
@@ -142,6 +160,9 @@ func (t *CoverageTracer) OnExit(depth int, output []byte, gasUsed uint64, err er
 	if isTopLevelFrame {
 		// Update the final coverage map if this is the top level call frame
 		_, _, coverageUpdateErr = t.coverageMaps.Update(t.callFrameStates[t.callDepth].pendingCoverageMap)
+		if coverageUpdateErr == nil && t.cumulativeCoverageEnabled {
+			_, _, coverageUpdateErr = t.cumulativeCoverageMaps.Update(t.callFrameStates[t.callDepth].pendingCoverageMap)
+		}
 	} else {
 		// Move coverage up one call frame
 		_, _, coverageUpdateErr = t.callFrameStates[t.callDepth-1].pendingCoverageMap.Update(t.callFrameStates[t.callDepth].pendingCoverageMap)
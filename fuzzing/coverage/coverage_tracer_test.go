@@ -0,0 +1,43 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCoverageTracerUniquePCs ensures the cumulative coverage reported by a CoverageTracer only counts transactions
+// which completed after it was first queried, and counts each program counter once across transactions, including
+// those which reverted.
+func TestCoverageTracerUniquePCs(t *testing.T) {
+	tracer := NewCoverageTracer()
+	address := common.HexToAddress("0x1234")
+	contract := vm.NewContract(vm.AccountRef(common.Address{}), vm.AccountRef(address), nil, 0)
+	contract.Code = make([]byte, 16)
+
+	// traceTransaction simulates a transaction which executes the provided program counters in a single call frame.
+	traceTransaction := func(err error, pcs ...uint64) {
+		tracer.OnTxStart(nil, nil, common.Address{})
+		tracer.OnEnter(0, byte(vm.CALL), common.Address{}, address, nil, 0, nil)
+		for _, pc := range pcs {
+			tracer.OnOpcode(pc, byte(vm.STOP), 0, 0, &vm.ScopeContext{Contract: contract}, nil, 0, nil)
+		}
+		tracer.OnExit(0, nil, 0, err, err != nil)
+	}
+
+	// Coverage is not tracked until the tracer is first queried.
+	traceTransaction(nil, 0, 1, 2)
+	assert.EqualValues(t, 0, tracer.UniquePCs())
+
+	// Once queried, coverage of every transaction which completes is counted.
+	traceTransaction(nil, 0, 1, 2)
+	assert.EqualValues(t, 3, tracer.UniquePCs())
+
+	// Program counters which were already covered are not counted again, while reverted coverage is counted.
+	traceTransaction(nil, 2, 3)
+	assert.EqualValues(t, 4, tracer.UniquePCs())
+	traceTransaction(vm.ErrExecutionReverted, 3, 4, 5)
+	assert.EqualValues(t, 6, tracer.UniquePCs())
+}
@@ -2,6 +2,8 @@ package coverage
 
 import (
 	_ "embed"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"html/template"
 	"math"
@@ -107,3 +109,261 @@ func WriteLCOVReport(sourceAnalysis *SourceAnalysis, reportDir string) (string,
 
 	return lcovReportPath, nil
 }
+
+// coberturaReport describes the root element of a Cobertura XML coverage report.
+type coberturaReport struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        float64            `xml:"line-rate,attr"`
+	BranchRate      float64            `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      float64            `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+// coberturaPackage describes a directory of source files in a Cobertura XML coverage report.
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   float64          `xml:"line-rate,attr"`
+	BranchRate float64          `xml:"branch-rate,attr"`
+	Complexity float64          `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+// coberturaClass describes a single source file in a Cobertura XML coverage report.
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	FileName   string          `xml:"filename,attr"`
+	LineRate   float64         `xml:"line-rate,attr"`
+	BranchRate float64         `xml:"branch-rate,attr"`
+	Complexity float64         `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+// coberturaLine describes the hit count of an active source line in a Cobertura XML coverage report.
+type coberturaLine struct {
+	Number int  `xml:"number,attr"`
+	Hits   uint `xml:"hits,attr"`
+}
+
+// coberturaLineRate returns the ratio of covered lines to active lines, treating sources without active lines as
+// fully covered.
+func coberturaLineRate(coveredLines int, activeLines int) float64 {
+	if activeLines == 0 {
+		return 1
+	}
+	return float64(coveredLines) / float64(activeLines)
+}
+
+// WriteCoberturaReport takes a previously performed source analysis and generates a Cobertura XML report from it.
+// Each source file is reported as a class, grouped into packages by the directory it resides in, and only its active
+// lines are reported. As with LCOV reports, only lines executed without reverting are considered covered. Branch
+// coverage is not tracked, so it is always reported as zero.
+func WriteCoberturaReport(sourceAnalysis *SourceAnalysis, reportDir string) (string, error) {
+	// Source file paths are reported relative to our working directory, which is listed as the sole source root.
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("could not export Cobertura report: %v", err)
+	}
+	report := coberturaReport{
+		Timestamp: time.Now().UnixMilli(),
+		Sources:   []string{cwd},
+		Packages:  make([]coberturaPackage, 0),
+	}
+
+	// Create a class for each source file, in the package for its directory. As our files are sorted by path, files
+	// in the same directory are always adjacent.
+	packageCoveredLines, packageActiveLines := 0, 0
+	for _, file := range sourceAnalysis.SortedFiles() {
+		fileName := file.Path
+		if relativePath, err := filepath.Rel(cwd, file.Path); err == nil {
+			fileName = relativePath
+		}
+		fileName = filepath.ToSlash(fileName)
+
+		class := coberturaClass{
+			Name:     fileName,
+			FileName: fileName,
+			Lines:    make([]coberturaLine, 0),
+		}
+		coveredLines := 0
+		for idx, line := range file.Lines {
+			if !line.IsActive {
+				continue
+			}
+			coverageLine := coberturaLine{Number: idx + 1}
+			if line.IsCovered {
+				coverageLine.Hits = line.SuccessHitCount
+				coveredLines++
+			}
+			class.Lines = append(class.Lines, coverageLine)
+		}
+		class.LineRate = coberturaLineRate(coveredLines, len(class.Lines))
+
+		// Start a new package if this file is in a different directory to the last.
+		packageName := filepath.ToSlash(filepath.Dir(fileName))
+		if len(report.Packages) == 0 || report.Packages[len(report.Packages)-1].Name != packageName {
+			report.Packages = append(report.Packages, coberturaPackage{Name: packageName})
+			packageCoveredLines, packageActiveLines = 0, 0
+		}
+		currentPackage := &report.Packages[len(report.Packages)-1]
+		currentPackage.Classes = append(currentPackage.Classes, class)
+		packageCoveredLines += coveredLines
+		packageActiveLines += len(class.Lines)
+		currentPackage.LineRate = coberturaLineRate(packageCoveredLines, packageActiveLines)
+
+		report.LinesCovered += coveredLines
+		report.LinesValid += len(class.Lines)
+	}
+	report.LineRate = coberturaLineRate(report.LinesCovered, report.LinesValid)
+
+	// Encode our report.
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not export Cobertura report: %v", err)
+	}
+
+	// If the directory doesn't exist, create it.
+	err = utils.MakeDirectory(reportDir)
+	if err != nil {
+		return "", err
+	}
+
+	// Write the Cobertura report to a file.
+	coberturaReportPath := filepath.Join(reportDir, "cobertura.xml")
+	err = os.WriteFile(coberturaReportPath, append([]byte(xml.Header), b...), 0644)
+	if err != nil {
+		return "", fmt.Errorf("could not export Cobertura report: %v", err)
+	}
+
+	return coberturaReportPath, nil
+}
+
+// JSONReport describes a JSON coverage report, which provides the coverage of each line of each source file in a
+// structured format for custom tooling.
+type JSONReport struct {
+	// Summary describes the coverage totals across all source files.
+	Summary JSONReportSummary `json:"summary"`
+
+	// Files describes the coverage of each source file, sorted by path.
+	Files []JSONReportFile `json:"files"`
+}
+
+// JSONReportSummary describes coverage totals in a JSONReport, either across all source files or for a single one.
+type JSONReportSummary struct {
+	// LineCount describes the amount of lines.
+	LineCount int `json:"lineCount"`
+
+	// ActiveLineCount describes the amount of lines which were executable.
+	ActiveLineCount int `json:"activeLineCount"`
+
+	// CoveredLineCount describes the amount of active lines which were executed without reverting.
+	CoveredLineCount int `json:"coveredLineCount"`
+
+	// CoveredRevertedLineCount describes the amount of active lines which were executed before reverting.
+	CoveredRevertedLineCount int `json:"coveredRevertedLineCount"`
+}
+
+// JSONReportFile describes the coverage of a single source file in a JSONReport.
+type JSONReportFile struct {
+	// Path describes the path of the source file.
+	Path string `json:"path"`
+
+	// Summary describes the coverage totals for the source file.
+	Summary JSONReportSummary `json:"summary"`
+
+	// Lines describes the coverage of each line in the source file, in order.
+	Lines []JSONReportLine `json:"lines"`
+}
+
+// JSONReportLine describes the coverage of a single source line in a JSONReport.
+type JSONReportLine struct {
+	// Number describes the line number (1-based).
+	Number int `json:"number"`
+
+	// Active indicates the line was executable.
+	Active bool `json:"active"`
+
+	// Covered indicates the line was executed without reverting.
+	Covered bool `json:"covered"`
+
+	// CoveredReverted indicates the line was executed before reverting.
+	CoveredReverted bool `json:"coveredReverted"`
+
+	// SuccessHitCount describes how many times the line was executed without reverting.
+	SuccessHitCount uint `json:"successHitCount"`
+
+	// RevertHitCount describes how many times the line was executed before reverting.
+	RevertHitCount uint `json:"revertHitCount"`
+}
+
+// add adds the totals of the provided summary to this one.
+func (s *JSONReportSummary) add(other JSONReportSummary) {
+	s.LineCount += other.LineCount
+	s.ActiveLineCount += other.ActiveLineCount
+	s.CoveredLineCount += other.CoveredLineCount
+	s.CoveredRevertedLineCount += other.CoveredRevertedLineCount
+}
+
+// WriteJSONReport takes a previously performed source analysis and generates a JSON report from it.
+func WriteJSONReport(sourceAnalysis *SourceAnalysis, reportDir string) (string, error) {
+	// Create an entry for each source file, adding its totals to our summary.
+	report := JSONReport{
+		Files: make([]JSONReportFile, 0, len(sourceAnalysis.Files)),
+	}
+	for _, file := range sourceAnalysis.SortedFiles() {
+		reportFile := JSONReportFile{
+			Path:  file.Path,
+			Lines: make([]JSONReportLine, 0, len(file.Lines)),
+		}
+		for idx, line := range file.Lines {
+			reportFile.Lines = append(reportFile.Lines, JSONReportLine{
+				Number:          idx + 1,
+				Active:          line.IsActive,
+				Covered:         line.IsCovered,
+				CoveredReverted: line.IsCoveredReverted,
+				SuccessHitCount: line.SuccessHitCount,
+				RevertHitCount:  line.RevertHitCount,
+			})
+			reportFile.Summary.LineCount++
+			if line.IsActive {
+				reportFile.Summary.ActiveLineCount++
+				if line.IsCovered {
+					reportFile.Summary.CoveredLineCount++
+				}
+				if line.IsCoveredReverted {
+					reportFile.Summary.CoveredRevertedLineCount++
+				}
+			}
+		}
+		report.Summary.add(reportFile.Summary)
+		report.Files = append(report.Files, reportFile)
+	}
+
+	// Encode our report.
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not export JSON report: %v", err)
+	}
+
+	// If the directory doesn't exist, create it.
+	err = utils.MakeDirectory(reportDir)
+	if err != nil {
+		return "", err
+	}
+
+	// Write the JSON report to a file.
+	jsonReportPath := filepath.Join(reportDir, "coverage.json")
+	err = os.WriteFile(jsonReportPath, b, 0644)
+	if err != nil {
+		return "", fmt.Errorf("could not export JSON report: %v", err)
+	}
+
+	return jsonReportPath, nil
+}
@@ -0,0 +1,163 @@
+package coverage
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crytic/medusa/compilation/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteCoberturaReport ensures a Cobertura report describes each source file as a class with its active lines and
+// line rate, grouped into packages by directory.
+func TestWriteCoberturaReport(t *testing.T) {
+	// Create a source analysis with two files in the same directory and one in another.
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	newFile := func(path string, lines ...*SourceLineAnalysis) *SourceFileAnalysis {
+		return &SourceFileAnalysis{Path: filepath.Join(cwd, path), Lines: lines}
+	}
+	sourceAnalysis := &SourceAnalysis{
+		Files: map[string]*SourceFileAnalysis{
+			"a": newFile(filepath.Join("contracts", "A.sol"),
+				&SourceLineAnalysis{IsActive: false},
+				&SourceLineAnalysis{IsActive: true, IsCovered: true, SuccessHitCount: 3},
+				&SourceLineAnalysis{IsActive: true, IsCoveredReverted: true, RevertHitCount: 2},
+			),
+			"b": newFile(filepath.Join("contracts", "B.sol"),
+				&SourceLineAnalysis{IsActive: true, IsCovered: true, SuccessHitCount: 1},
+			),
+			"c": newFile(filepath.Join("lib", "C.sol"),
+				&SourceLineAnalysis{IsActive: false},
+			),
+		},
+	}
+
+	// Write our report and read it back.
+	path, err := WriteCoberturaReport(sourceAnalysis, t.TempDir())
+	assert.NoError(t, err)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var report coberturaReport
+	err = xml.Unmarshal(b, &report)
+	assert.NoError(t, err)
+
+	// Verify our overall and per-package aggregates.
+	assert.EqualValues(t, 2, report.LinesCovered)
+	assert.EqualValues(t, 3, report.LinesValid)
+	assert.InDelta(t, 2.0/3.0, report.LineRate, 0.0001)
+	assert.Len(t, report.Packages, 2)
+	assert.EqualValues(t, "contracts", report.Packages[0].Name)
+	assert.InDelta(t, 2.0/3.0, report.Packages[0].LineRate, 0.0001)
+	assert.EqualValues(t, "lib", report.Packages[1].Name)
+	assert.EqualValues(t, 1, report.Packages[1].LineRate)
+
+	// Verify only active lines are reported, with hit counts and coverage only for lines executed without reverting.
+	classA := report.Packages[0].Classes[0]
+	assert.EqualValues(t, "contracts/A.sol", classA.FileName)
+	assert.InDelta(t, 0.5, classA.LineRate, 0.0001)
+	assert.Equal(t, []coberturaLine{{Number: 2, Hits: 3}, {Number: 3, Hits: 0}}, classA.Lines)
+	assert.Empty(t, report.Packages[1].Classes[0].Lines)
+}
+
+// TestWriteJSONReport ensures a JSON report describes every line of each source file, along with per-file and overall
+// totals.
+func TestWriteJSONReport(t *testing.T) {
+	// Create a source analysis with two files.
+	sourceAnalysis := &SourceAnalysis{
+		Files: map[string]*SourceFileAnalysis{
+			"b": {
+				Path: "B.sol",
+				Lines: []*SourceLineAnalysis{
+					{IsActive: true, IsCovered: true, SuccessHitCount: 4, IsCoveredReverted: true, RevertHitCount: 1},
+				},
+			},
+			"a": {
+				Path: "A.sol",
+				Lines: []*SourceLineAnalysis{
+					{IsActive: false},
+					{IsActive: true, IsCovered: true, SuccessHitCount: 3},
+					{IsActive: true},
+				},
+			},
+		},
+	}
+
+	// Write our report and read it back.
+	path, err := WriteJSONReport(sourceAnalysis, t.TempDir())
+	assert.NoError(t, err)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var report JSONReport
+	err = json.Unmarshal(b, &report)
+	assert.NoError(t, err)
+
+	// Verify our totals and that files are sorted by path.
+	assert.Equal(t, JSONReportSummary{LineCount: 4, ActiveLineCount: 3, CoveredLineCount: 2, CoveredRevertedLineCount: 1}, report.Summary)
+	assert.Len(t, report.Files, 2)
+	assert.EqualValues(t, "A.sol", report.Files[0].Path)
+	assert.Equal(t, JSONReportSummary{LineCount: 3, ActiveLineCount: 2, CoveredLineCount: 1}, report.Files[0].Summary)
+
+	// Verify every line is reported, including those which are not active.
+	assert.Equal(t, []JSONReportLine{
+		{Number: 1},
+		{Number: 2, Active: true, Covered: true, SuccessHitCount: 3},
+		{Number: 3, Active: true},
+	}, report.Files[0].Lines)
+}
+
+// TestWriteHTMLReportFunctionCoverage ensures externally callable functions which were never entered are reported as
+// uncalled, and that the HTML report lists function hit counts.
+func TestWriteHTMLReportFunctionCoverage(t *testing.T) {
+	// Create a source analysis with a mix of called, uncalled, and internal functions.
+	newFunction := func(name string, kind string, visibility string, successHits uint, revertHits uint) *SourceFunctionAnalysis {
+		return &SourceFunctionAnalysis{
+			Definition:      &types.FunctionDefinition{Name: name, Kind: kind, Visibility: visibility, Implemented: true},
+			SourcePath:      "A.sol",
+			ContractName:    "A",
+			Line:            1,
+			SuccessHitCount: successHits,
+			RevertHitCount:  revertHits,
+		}
+	}
+	sourceAnalysis := &SourceAnalysis{
+		Files: map[string]*SourceFileAnalysis{
+			"a": {
+				Path:  "A.sol",
+				Lines: []*SourceLineAnalysis{{IsActive: true, IsCovered: true, SuccessHitCount: 1}},
+				FunctionCoverage: []*SourceFunctionAnalysis{
+					newFunction("", "constructor", "public", 1, 0),
+					newFunction("called", "function", "external", 7, 0),
+					newFunction("alwaysReverts", "function", "public", 0, 2),
+					newFunction("neverCalled", "function", "public", 0, 0),
+					newFunction("internalHelper", "function", "internal", 0, 0),
+					newFunction("", "receive", "external", 0, 0),
+				},
+			},
+		},
+	}
+
+	// Verify our function counts and which functions are considered uncalled.
+	assert.EqualValues(t, 6, sourceAnalysis.FunctionCount())
+	assert.EqualValues(t, 2, sourceAnalysis.CoveredFunctionCount())
+	uncalledNames := make([]string, 0)
+	for _, function := range sourceAnalysis.UncalledExternalFunctions() {
+		uncalledNames = append(uncalledNames, function.Name())
+	}
+	assert.Equal(t, []string{"A.neverCalled", "A.receive"}, uncalledNames)
+
+	// Verify the HTML report renders our function list.
+	path, err := WriteHTMLReport(sourceAnalysis, t.TempDir())
+	assert.NoError(t, err)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	html := string(b)
+	assert.Contains(t, html, "Uncalled external functions")
+	assert.Contains(t, html, "A.neverCalled")
+	assert.Contains(t, html, "A.constructor")
+	assert.Contains(t, html, "2 / 6")
+	assert.Contains(t, html, "<td>A.called</td>")
+}
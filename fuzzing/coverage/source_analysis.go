@@ -56,6 +56,39 @@ func (s *SourceAnalysis) CoveredLineCount() int {
 	return count
 }
 
+// FunctionCount returns the count of implemented functions across all source files.
+func (s *SourceAnalysis) FunctionCount() int {
+	count := 0
+	for _, file := range s.Files {
+		count += len(file.FunctionCoverage)
+	}
+	return count
+}
+
+// CoveredFunctionCount returns the count of functions that were entered without reverting across all source files.
+func (s *SourceAnalysis) CoveredFunctionCount() int {
+	count := 0
+	for _, file := range s.Files {
+		count += file.CoveredFunctionCount()
+	}
+	return count
+}
+
+// UncalledExternalFunctions returns the externally callable functions across all source files which were never
+// entered, sorted by source file path and then definition order. These typically indicate functions a fuzzing harness
+// is unable to call.
+func (s *SourceAnalysis) UncalledExternalFunctions() []*SourceFunctionAnalysis {
+	functions := make([]*SourceFunctionAnalysis, 0)
+	for _, file := range s.SortedFiles() {
+		for _, function := range file.FunctionCoverage {
+			if function.IsExternallyCallable() && function.SuccessHitCount == 0 && function.RevertHitCount == 0 {
+				functions = append(functions, function)
+			}
+		}
+	}
+	return functions
+}
+
 // GenerateLCOVReport generates an LCOV report from the source analysis.
 // The spec of the format is here https://github.com/linux-test-project/lcov/blob/07a1127c2b4390abf4a516e9763fb28a956a9ce4/man/geninfo.1#L989
 func (s *SourceAnalysis) GenerateLCOVReport() string {
@@ -128,6 +161,10 @@ type SourceFileAnalysis struct {
 
 	// Functions is a list of functions defined in the source file
 	Functions []*types.FunctionDefinition
+
+	// FunctionCoverage describes coverage information for each implemented function defined in the source file, in the
+	// order they are defined.
+	FunctionCoverage []*SourceFunctionAnalysis
 }
 
 // ActiveLineCount returns the count of lines that are marked executable/active within the source file.
@@ -152,6 +189,74 @@ func (s *SourceFileAnalysis) CoveredLineCount() int {
 	return count
 }
 
+// CoveredFunctionCount returns the count of functions that were entered without reverting within the source file.
+func (s *SourceFileAnalysis) CoveredFunctionCount() int {
+	count := 0
+	for _, function := range s.FunctionCoverage {
+		if function.IsCovered() {
+			count++
+		}
+	}
+	return count
+}
+
+// SourceFunctionAnalysis describes coverage information for a function defined in a source file.
+type SourceFunctionAnalysis struct {
+	// Definition describes the AST definition of the function.
+	Definition *types.FunctionDefinition
+
+	// SourcePath describes the path of the source file the function is defined in.
+	SourcePath string
+
+	// ContractName describes the name of the contract the function is defined in, or is empty for free functions.
+	ContractName string
+
+	// Line describes the line number (1-based) the function definition starts at.
+	Line int
+
+	// SuccessHitCount describes how many times the function was entered without reverting.
+	SuccessHitCount uint
+
+	// RevertHitCount describes how many times the function was entered, but reverted.
+	RevertHitCount uint
+
+	// srcStart and srcLength describe the byte range of the function definition in its source file, which source map
+	// elements spanning the entire function are matched against.
+	srcStart  int
+	srcLength int
+}
+
+// Name returns a display name for the function, qualified by the contract it is defined in. Special functions without
+// a name (e.g. constructors) are named after their kind.
+func (s *SourceFunctionAnalysis) Name() string {
+	name := s.Definition.Name
+	if name == "" {
+		name = s.Definition.Kind
+	}
+	if s.ContractName == "" {
+		return name
+	}
+	return s.ContractName + "." + name
+}
+
+// IsCovered indicates whether the function was entered without reverting.
+func (s *SourceFunctionAnalysis) IsCovered() bool {
+	return s.SuccessHitCount > 0
+}
+
+// IsExternallyCallable indicates whether the function can be called by a transaction, i.e. it is a public or external
+// function, or a fallback or receive function.
+func (s *SourceFunctionAnalysis) IsExternallyCallable() bool {
+	switch s.Definition.Kind {
+	case "fallback", "receive":
+		return true
+	case "function":
+		return s.Definition.Visibility == "public" || s.Definition.Visibility == "external"
+	default:
+		return false
+	}
+}
+
 // SourceLineAnalysis describes coverage information for a specific source file line.
 type SourceLineAnalysis struct {
 	// IsActive indicates the given source line was executable.
@@ -198,6 +303,24 @@ func AnalyzeSourceCoverage(compilations []types.Compilation, coverageMaps *Cover
 
 			lines, cumulativeOffset := parseSourceLines(compilation.SourceCode[sourcePath])
 			funcs := make([]*types.FunctionDefinition, 0)
+			functionCoverage := make([]*SourceFunctionAnalysis, 0)
+			addFunctionCoverage := func(fn *types.FunctionDefinition, contractName string) {
+				// Functions without a body have no code to cover.
+				if !fn.Implemented {
+					return
+				}
+				srcStart := types.GetSrcMapStart(fn.Src)
+				functionCoverage = append(functionCoverage, &SourceFunctionAnalysis{
+					Definition:   fn,
+					SourcePath:   sourcePath,
+					ContractName: contractName,
+					Line: sort.Search(len(cumulativeOffset), func(i int) bool {
+						return cumulativeOffset[i] > srcStart
+					}),
+					srcStart:  srcStart,
+					srcLength: types.GetSrcMapLength(fn.Src),
+				})
+			}
 
 			var ast types.AST
 			b, err := json.Marshal(compilation.SourcePathToArtifact[sourcePath].Ast)
@@ -214,6 +337,7 @@ func AnalyzeSourceCoverage(compilations []types.Compilation, coverageMaps *Cover
 				if node.GetNodeType() == "FunctionDefinition" {
 					fn := node.(types.FunctionDefinition)
 					funcs = append(funcs, &fn)
+					addFunctionCoverage(&fn, "")
 				}
 				if node.GetNodeType() == "ContractDefinition" {
 					contract := node.(types.ContractDefinition)
@@ -224,6 +348,7 @@ func AnalyzeSourceCoverage(compilations []types.Compilation, coverageMaps *Cover
 						if subNode.GetNodeType() == "FunctionDefinition" {
 							fn := subNode.(types.FunctionDefinition)
 							funcs = append(funcs, &fn)
+							addFunctionCoverage(&fn, contract.CanonicalName)
 						}
 					}
 				}
@@ -237,6 +362,7 @@ func AnalyzeSourceCoverage(compilations []types.Compilation, coverageMaps *Cover
 					CumulativeOffsetByLine: cumulativeOffset,
 					Lines:                  lines,
 					Functions:              funcs,
+					FunctionCoverage:       functionCoverage,
 				}
 			}
 
@@ -282,6 +408,17 @@ func AnalyzeSourceCoverage(compilations []types.Compilation, coverageMaps *Cover
 					return nil, fmt.Errorf("could not perform source code analysis due to error parsing runtime byte code: %v", err)
 				}
 
+				// Analyze both init and runtime coverage for our functions. This must be done prior to filtering our
+				// source maps, as the elements which span entire functions are filtered out.
+				err = analyzeContractFunctionCoverage(compilation, sourceAnalysis, initSourceMap, initInstructionOffsetLookup, initCoverageMapData)
+				if err != nil {
+					return nil, err
+				}
+				err = analyzeContractFunctionCoverage(compilation, sourceAnalysis, runtimeSourceMap, runtimeInstructionOffsetLookup, runtimeCoverageMapData)
+				if err != nil {
+					return nil, err
+				}
+
 				// Filter our source maps
 				initSourceMap = filterSourceMaps(compilation, initSourceMap)
 				runtimeSourceMap = filterSourceMaps(compilation, runtimeSourceMap)
@@ -363,6 +500,53 @@ func analyzeContractSourceCoverage(compilation types.Compilation, sourceAnalysis
 	return nil
 }
 
+// analyzeContractFunctionCoverage takes a compilation, a SourceAnalysis, the unfiltered source map they were derived
+// from, a lookup of instruction index->offset, and coverage map data. It updates the hit counts of each function with
+// those of the instructions whose source map elements span the entire function definition, such as its entry point.
+// As several such instructions execute each time a function is entered, the highest hit count among them is used.
+// Returns an error if one occurs.
+func analyzeContractFunctionCoverage(compilation types.Compilation, sourceAnalysis *SourceAnalysis, sourceMap types.SourceMap, instructionOffsetLookup []int, contractCoverageData *ContractCoverageMap) error {
+	// If we have no coverage data for this contract, none of its functions were entered.
+	if contractCoverageData == nil {
+		return nil
+	}
+
+	// Determine the highest hit counts of the instructions which map to each function.
+	successHitCounts := make(map[*SourceFunctionAnalysis]uint)
+	revertHitCounts := make(map[*SourceFunctionAnalysis]uint)
+	for _, sourceMapElement := range sourceMap {
+		// Obtain the source file this element maps to, skipping elements which do not map to one we know of.
+		sourcePath, idExists := compilation.SourceIdToPath[sourceMapElement.SourceUnitID]
+		if !idExists {
+			continue
+		}
+		sourceFile, ok := sourceAnalysis.Files[sourcePath]
+		if !ok {
+			return fmt.Errorf("could not perform source code analysis, missing source '%v'", sourcePath)
+		}
+
+		// Find the function this element spans, if any.
+		for _, function := range sourceFile.FunctionCoverage {
+			if function.srcStart != sourceMapElement.Offset || function.srcLength != sourceMapElement.Length {
+				continue
+			}
+			instructionOffset := instructionOffsetLookup[sourceMapElement.Index]
+			successHitCounts[function] = max(successHitCounts[function], contractCoverageData.successfulCoverage.HitCount(instructionOffset))
+			revertHitCounts[function] = max(revertHitCounts[function], contractCoverageData.revertedCoverage.HitCount(instructionOffset))
+			break
+		}
+	}
+
+	// Add the hit counts for this contract to those of the functions.
+	for function, hitCount := range successHitCounts {
+		function.SuccessHitCount += hitCount
+	}
+	for function, hitCount := range revertHitCounts {
+		function.RevertHitCount += hitCount
+	}
+	return nil
+}
+
 // filterSourceMaps takes a given source map and filters it so overlapping (superset) source map elements are removed.
 // In addition to any which do not map to any source code. This is necessary as some source map entries select an
 // entire method definition.
@@ -1,9 +1,11 @@
 package executiontracer
 
 import (
+	"math/big"
+
+	compilationTypes "github.com/crytic/medusa/compilation/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"math/big"
 )
 
 // CallFrames represents a list of call frames recorded by the ExecutionTracer.
@@ -43,8 +45,13 @@ type CallFrame struct {
 	// CodeRuntimeBytecode refers to the bytecode recorded for the CodeAddress.
 	CodeRuntimeBytecode []byte
 
+	// CodeContractStorageLayout refers to the storage layout of the contract which was resolved for the CodeAddress.
+	// This is nil if the contract could not be resolved, or its compilation did not provide a storage layout.
+	CodeContractStorageLayout *compilationTypes.StorageLayout
+
 	// Operations contains a chronological history of updates in the call frame.
-	// Potential types currently are *types.Log (events) or CallFrame (entering of a new child frame).
+	// Potential types currently are *types.Log (events), *StorageWrite (storage writes) or CallFrame (entering of a
+	// new child frame).
 	Operations []any
 
 	// SelfDestructed indicates whether the call frame executed a SELFDESTRUCT operation.
@@ -63,6 +70,12 @@ type CallFrame struct {
 	// CallValue describes the ETH value attached to a given CallFrame
 	CallValue *big.Int
 
+	// Gas describes the amount of gas provided to the call frame.
+	Gas uint64
+
+	// GasUsed describes the amount of gas used by the call frame, including that of its child call frames.
+	GasUsed uint64
+
 	// ExecutedCode is a boolean that indicates whether code was executed within a CallFrame. A simple transfer of ETH
 	// would be an example of a CallFrame where ExecutedCode would be false
 	ExecutedCode bool
@@ -70,11 +83,25 @@ type CallFrame struct {
 	// ReturnError refers to any error returned by the EVM in the current call frame.
 	ReturnError error
 
+	// RecentPCs refers to the program counters of the most recently executed instructions within this call frame, in
+	// chronological order. Only a bounded number of them are kept, which is used to resolve the source location of
+	// a failure.
+	RecentPCs []uint64
+
 	// ParentCallFrame refers to the call frame which entered this call frame directly. It may be nil if the current
 	// call frame is a top level call frame.
 	ParentCallFrame *CallFrame
 }
 
+// StorageWrite describes a write to a storage slot of the executing contract, as recorded by the ExecutionTracer.
+type StorageWrite struct {
+	// Slot refers to the storage slot which was written to.
+	Slot common.Hash
+
+	// Value refers to the value which was written to the storage slot.
+	Value common.Hash
+}
+
 // IsContractCreation indicates whether a contract creation operation was attempted immediately within this call frame.
 // This does not include child or parent frames.
 // Returns true if this call frame attempted contract creation.
@@ -1,24 +1,33 @@
 package executiontracer
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/crytic/medusa/chain"
+	chainTypes "github.com/crytic/medusa/chain/types"
 	"github.com/crytic/medusa/compilation/abiutils"
+	compilationTypes "github.com/crytic/medusa/compilation/types"
 	"github.com/crytic/medusa/fuzzing/contracts"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/logging"
 	"github.com/crytic/medusa/logging/colors"
 	"github.com/crytic/medusa/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	coreTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 )
 
+// sourceSnippetContextLines describes the amount of source lines displayed before and after the line which caused an
+// execution to fail.
+const sourceSnippetContextLines = 2
+
 // ExecutionTrace contains information recorded by an ExecutionTracer. It contains information about each call
 // scope entered and exited, and their associated contract definitions.
 type ExecutionTrace struct {
@@ -26,19 +35,42 @@ type ExecutionTrace struct {
 	// address calls upon a contract.
 	TopLevelCallFrame *CallFrame
 
+	// StateDiff describes the storage slots changed by the traced transaction, along with their values before and
+	// after it executed. This is nil if state diffs were not recorded for the trace.
+	StateDiff []chainTypes.StorageDiff
+
 	// contractDefinitions represents the known contract definitions at the time of tracing. This is used to help
 	// obtain any additional information regarding execution.
 	contractDefinitions contracts.Contracts
+
+	// labels represents the human-readable address labels at the time of tracing. This is used to display friendly
+	// names for addresses in the trace.
+	labels map[common.Address]string
+
+	// hashPreimages maps the hashes computed by KECCAK256 operations during execution to their inputs. This is used to
+	// resolve the variables held in storage slots derived from hashes, such as mapping values.
+	hashPreimages map[common.Hash][]byte
 }
 
 // newExecutionTrace creates and returns a new ExecutionTrace, to be used by the ExecutionTracer.
-func newExecutionTrace(contracts contracts.Contracts) *ExecutionTrace {
+func newExecutionTrace(contracts contracts.Contracts, labels map[common.Address]string) *ExecutionTrace {
 	return &ExecutionTrace{
 		TopLevelCallFrame:   nil,
 		contractDefinitions: contracts,
+		labels:              labels,
+		hashPreimages:       make(map[common.Hash][]byte),
 	}
 }
 
+// formatAddress obtains a display string for the provided address, including its label if one exists.
+func (t *ExecutionTrace) formatAddress(address common.Address) string {
+	addressString := utils.TrimLeadingZeroesFromAddress(address.String())
+	if label, ok := t.labels[address]; ok {
+		return fmt.Sprintf("%v [%v]", addressString, label)
+	}
+	return addressString
+}
+
 // generateCallFrameEnterElements generates a list of elements describing top level information about this call frame.
 // This list of elements will hold information about what kind of call it is, wei sent, what method is called, and more.
 // Additionally, the list may also hold formatting options for console output. This function also returns a non-empty
@@ -139,22 +171,23 @@ func (t *ExecutionTrace) generateCallFrameEnterElements(callFrame *CallFrame) ([
 
 	// Generate the message we wish to output finally, using all these display string components.
 	// If we executed code, attach additional context such as the contract name, method, etc.
+	// The gas provided to and used by the call frame is attached as well, to help identify gas hotspots.
 	var callInfo string
 	if callFrame.IsProxyCall() {
 		if callFrame.ExecutedCode {
-			callInfo = fmt.Sprintf("%v -> %v.%v(%v) (addr=%v, code=%v, value=%v, sender=%v)", proxyContractName, codeContractName, methodName, *inputArgumentsDisplayText, utils.TrimLeadingZeroesFromAddress(callFrame.ToAddress.String()), utils.TrimLeadingZeroesFromAddress(callFrame.CodeAddress.String()), callFrame.CallValue, utils.TrimLeadingZeroesFromAddress(callFrame.SenderAddress.String()))
+			callInfo = fmt.Sprintf("%v -> %v.%v(%v) (addr=%v, code=%v, value=%v, gas=%v, gasUsed=%v, sender=%v)", proxyContractName, codeContractName, methodName, *inputArgumentsDisplayText, t.formatAddress(callFrame.ToAddress), t.formatAddress(callFrame.CodeAddress), callFrame.CallValue, callFrame.Gas, callFrame.GasUsed, t.formatAddress(callFrame.SenderAddress))
 		} else {
-			callInfo = fmt.Sprintf("(addr=%v, value=%v, sender=%v)", utils.TrimLeadingZeroesFromAddress(callFrame.ToAddress.String()), callFrame.CallValue, utils.TrimLeadingZeroesFromAddress(callFrame.SenderAddress.String()))
+			callInfo = fmt.Sprintf("(addr=%v, value=%v, gas=%v, gasUsed=%v, sender=%v)", t.formatAddress(callFrame.ToAddress), callFrame.CallValue, callFrame.Gas, callFrame.GasUsed, t.formatAddress(callFrame.SenderAddress))
 		}
 	} else {
 		if callFrame.ExecutedCode {
 			if callFrame.ToAddress == chain.ConsoleLogContractAddress {
 				callInfo = fmt.Sprintf("%v.%v(%v)", codeContractName, methodName, *inputArgumentsDisplayText)
 			} else {
-				callInfo = fmt.Sprintf("%v.%v(%v) (addr=%v, value=%v, sender=%v)", codeContractName, methodName, *inputArgumentsDisplayText, utils.TrimLeadingZeroesFromAddress(callFrame.ToAddress.String()), callFrame.CallValue, utils.TrimLeadingZeroesFromAddress(callFrame.SenderAddress.String()))
+				callInfo = fmt.Sprintf("%v.%v(%v) (addr=%v, value=%v, gas=%v, gasUsed=%v, sender=%v)", codeContractName, methodName, *inputArgumentsDisplayText, t.formatAddress(callFrame.ToAddress), callFrame.CallValue, callFrame.Gas, callFrame.GasUsed, t.formatAddress(callFrame.SenderAddress))
 			}
 		} else {
-			callInfo = fmt.Sprintf("(addr=%v, value=%v, sender=%v)", utils.TrimLeadingZeroesFromAddress(callFrame.ToAddress.String()), callFrame.CallValue, utils.TrimLeadingZeroesFromAddress(callFrame.SenderAddress.String()))
+			callInfo = fmt.Sprintf("(addr=%v, value=%v, gas=%v, gasUsed=%v, sender=%v)", t.formatAddress(callFrame.ToAddress), callFrame.CallValue, callFrame.Gas, callFrame.GasUsed, t.formatAddress(callFrame.SenderAddress))
 		}
 	}
 
@@ -164,23 +197,31 @@ func (t *ExecutionTrace) generateCallFrameEnterElements(callFrame *CallFrame) ([
 	return elements, consoleLogString
 }
 
+// resolveCallFrameMethod resolves the method definition called by the provided call frame from its code contract ABI.
+// Returns the constructor for contract creations, the method matching the call data otherwise, or nil if the method
+// could not be resolved.
+func resolveCallFrameMethod(callFrame *CallFrame) *abi.Method {
+	if callFrame.CodeContractAbi == nil {
+		return nil
+	}
+	if callFrame.IsContractCreation() {
+		return &callFrame.CodeContractAbi.Constructor
+	}
+	method, err := callFrame.CodeContractAbi.MethodById(callFrame.InputData)
+	if err != nil {
+		return nil
+	}
+	return method
+}
+
 // generateCallFrameExitElements generates a list of elements describing the return data of the call frame (e.g.
 // traditional return data, assertion failure, revert data, etc.). Additionally, the list may also hold formatting options for console output.
 func (t *ExecutionTrace) generateCallFrameExitElements(callFrame *CallFrame) []any {
 	// Create list of elements
 	elements := make([]any, 0)
 
-	// Define some strings that represent our current call frame
-	var method *abi.Method
-
 	// Resolve our method definition
-	if callFrame.CodeContractAbi != nil {
-		if callFrame.IsContractCreation() {
-			method = &callFrame.CodeContractAbi.Constructor
-		} else {
-			method, _ = callFrame.CodeContractAbi.MethodById(callFrame.InputData)
-		}
-	}
+	method := resolveCallFrameMethod(callFrame)
 
 	// Next we attempt to obtain a display string for the input and output arguments.
 	var outputArgumentsDisplayText *string
@@ -253,9 +294,41 @@ func (t *ExecutionTrace) generateCallFrameExitElements(callFrame *CallFrame) []a
 // generateEventEmittedElements generates a list of elements used to express an event emission. It contains information about an
 // event log such as the topics and the event data. Additionally, the list may also hold formatting options for console output.
 func (t *ExecutionTrace) generateEventEmittedElements(callFrame *CallFrame, eventLog *coreTypes.Log) []any {
-	// Create list of elements
-	elements := make([]any, 0)
+	// Add our output line with the event data to our elements.
+	return []any{colors.MagentaBold, "[event] ", colors.Reset, t.resolveEventDisplayText(callFrame, eventLog), "\n"}
+}
+
+// generateStorageWriteElements generates a list of elements used to express a write to a storage slot. If the storage
+// layout of the executing contract is known, the slot is resolved to the name of the variables it holds, along with
+// their new values. Additionally, the list may also hold formatting options for console output.
+func (t *ExecutionTrace) generateStorageWriteElements(callFrame *CallFrame, storageWrite *StorageWrite) []any {
+	// Try to resolve the variables held in the slot, otherwise we display the raw slot and value.
+	storageWriteDisplayText := t.resolveStorageWriteVariablesText(callFrame, storageWrite)
+	if storageWriteDisplayText == "" {
+		storageWriteDisplayText = fmt.Sprintf("slot %v = %v", storageWrite.Slot.Hex(), storageWrite.Value.Hex())
+	}
+	return []any{colors.CyanBold, "[SSTORE] ", colors.Reset, storageWriteDisplayText, "\n"}
+}
+
+// resolveStorageWriteVariablesText obtains a display string for the variables held in the storage slot written within
+// the provided call frame, along with their new values, such as "balances[0x1] = 5".
+// Returns the display string, or an empty string if the storage layout of the executing contract is unknown or does
+// not resolve the slot.
+func (t *ExecutionTrace) resolveStorageWriteVariablesText(callFrame *CallFrame, storageWrite *StorageWrite) string {
+	if callFrame.CodeContractStorageLayout == nil {
+		return ""
+	}
+	variables := callFrame.CodeContractStorageLayout.ResolveSlot(storageWrite.Slot, t.hashPreimages)
+	assignments := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		assignments = append(assignments, fmt.Sprintf("%v = %v", variable.Name, variable.FormatValue(storageWrite.Value)))
+	}
+	return strings.Join(assignments, ", ")
+}
 
+// resolveEventDisplayText obtains a display string for an event log emitted within the provided call frame, with its
+// decoded values if the event definition could be resolved, or its raw topics and data otherwise.
+func (t *ExecutionTrace) resolveEventDisplayText(callFrame *CallFrame, eventLog *coreTypes.Log) string {
 	// If this is an event log, match it in our contract's ABI.
 	var eventDisplayText *string
 
@@ -294,10 +367,7 @@ func (t *ExecutionTrace) generateEventEmittedElements(callFrame *CallFrame, even
 		temp := fmt.Sprintf("<unresolved(topics=[%v], data=%v)>", strings.Join(topicsStrings, ", "), hex.EncodeToString(eventLog.Data))
 		eventDisplayText = &temp
 	}
-
-	// Finally, add our output line with this event data to it.
-	elements = append(elements, colors.MagentaBold, "[event] ", colors.Reset, *eventDisplayText, "\n")
-	return elements
+	return *eventDisplayText
 }
 
 // generateElementsAndLogsForCallFrame generates a list of elements and logs for a given call frame and its children.
@@ -345,6 +415,10 @@ func (t *ExecutionTrace) generateElementsAndLogsForCallFrame(currentDepth int, c
 				// If an event log was emitted, add a message for it.
 				elements = append(elements, prefix)
 				elements = append(elements, t.generateEventEmittedElements(callFrame, eventLog)...)
+			} else if storageWrite, ok := operation.(*StorageWrite); ok {
+				// If a storage slot was written to, add a message for it.
+				elements = append(elements, prefix)
+				elements = append(elements, t.generateStorageWriteElements(callFrame, storageWrite)...)
 			}
 		}
 
@@ -363,6 +437,118 @@ func (t *ExecutionTrace) generateElementsAndLogsForCallFrame(currentDepth int, c
 	return elements, consoleLogs
 }
 
+// FailureSourceLocation resolves the source location of the code which caused the execution to fail. It follows
+// failed call frames which bubbled up the same return data down to the call frame the failure originated in. As
+// failures are often raised in compiler-generated code (e.g. checked arithmetic), the most recently executed
+// instructions are searched for the last one which maps to a known source file.
+// Returns a "path:line" string describing the location, or nil if the execution did not fail or the location could
+// not be resolved.
+func (t *ExecutionTrace) FailureSourceLocation() *string {
+	_, sourcePath, lineNumber, ok := t.resolveFailureSource()
+	if !ok {
+		return nil
+	}
+	location := fmt.Sprintf("%v:%v", sourcePath, lineNumber)
+	return &location
+}
+
+// resolveFailureSource resolves the source location of the code which caused the execution to fail, as described by
+// FailureSourceLocation.
+// Returns the compilation the source belongs to, the source path, the line number (1-based), and a boolean indicating
+// whether the location was resolved.
+func (t *ExecutionTrace) resolveFailureSource() (*compilationTypes.Compilation, string, int, bool) {
+	// If the execution did not fail, there is no location to resolve.
+	callFrame := t.TopLevelCallFrame
+	if callFrame == nil || callFrame.ReturnError == nil {
+		return nil, "", 0, false
+	}
+
+	// Descend into the last child call frame while it failed with the same return data, as that is where the
+	// failure originated.
+	for {
+		childCallFrames := callFrame.ChildCallFrames()
+		if len(childCallFrames) == 0 {
+			break
+		}
+		lastChildCallFrame := childCallFrames[len(childCallFrames)-1]
+		if lastChildCallFrame.ReturnError == nil || !bytes.Equal(lastChildCallFrame.ReturnData, callFrame.ReturnData) {
+			break
+		}
+		callFrame = lastChildCallFrame
+	}
+
+	// Resolve the contract definition for the code which was executing.
+	var contract *contracts.Contract
+	if callFrame.IsContractCreation() {
+		contract = t.contractDefinitions.MatchBytecode(callFrame.ToInitBytecode, nil)
+	} else {
+		contract = t.contractDefinitions.MatchBytecode(nil, callFrame.CodeRuntimeBytecode)
+	}
+	if contract == nil || contract.Compilation() == nil {
+		return nil, "", 0, false
+	}
+
+	// Parse the appropriate source map and create a lookup of instruction offsets to source map elements.
+	compiledContract := contract.CompiledContract()
+	sourceMapStr, bytecode := compiledContract.SrcMapsRuntime, compiledContract.RuntimeBytecode
+	if callFrame.IsContractCreation() {
+		sourceMapStr, bytecode = compiledContract.SrcMapsInit, compiledContract.InitBytecode
+	}
+	sourceMap, err := compilationTypes.ParseSourceMap(sourceMapStr)
+	if err != nil {
+		return nil, "", 0, false
+	}
+	indexToOffsetLookup, err := sourceMap.GetInstructionIndexToOffsetLookup(bytecode)
+	if err != nil {
+		return nil, "", 0, false
+	}
+	offsetToElementLookup := make(map[uint64]*compilationTypes.SourceMapElement, len(indexToOffsetLookup))
+	for i, offset := range indexToOffsetLookup {
+		offsetToElementLookup[uint64(offset)] = &sourceMap[i]
+	}
+
+	// Walk back through the most recently executed instructions and return the first location we can resolve.
+	for i := len(callFrame.RecentPCs) - 1; i >= 0; i-- {
+		sourceMapElement, ok := offsetToElementLookup[callFrame.RecentPCs[i]]
+		if !ok {
+			continue
+		}
+		sourcePath, lineNumber, ok := contract.Compilation().GetSourceLocation(sourceMapElement)
+		if ok {
+			return contract.Compilation(), sourcePath, lineNumber, true
+		}
+	}
+	return nil, "", 0, false
+}
+
+// generateFailureSourceElements generates a list of elements displaying the source code surrounding the location
+// which caused the execution to fail, with the failing line highlighted. If the location could not be resolved (e.g.
+// source maps or source code are unavailable), no elements are generated. Additionally, the list may also hold
+// formatting options for console output.
+func (t *ExecutionTrace) generateFailureSourceElements() []any {
+	// Resolve the failing location and the lines surrounding it.
+	compilation, sourcePath, lineNumber, ok := t.resolveFailureSource()
+	if !ok {
+		return nil
+	}
+	lines, firstLine, ok := compilation.GetSourceLines(sourcePath, lineNumber-sourceSnippetContextLines, lineNumber+sourceSnippetContextLines)
+	if !ok {
+		return nil
+	}
+
+	// Add a header with the location, followed by each line prefixed by its line number.
+	elements := []any{colors.Bold, "[Source] ", colors.Reset, fmt.Sprintf("%v:%v", sourcePath, lineNumber), "\n"}
+	lineNumberWidth := len(strconv.Itoa(firstLine + len(lines) - 1))
+	for i, line := range lines {
+		if firstLine+i == lineNumber {
+			elements = append(elements, colors.RedBold, fmt.Sprintf(" > %*d | %v", lineNumberWidth, firstLine+i, line), colors.Reset, "\n")
+		} else {
+			elements = append(elements, fmt.Sprintf("   %*d | %v\n", lineNumberWidth, firstLine+i, line))
+		}
+	}
+	return elements
+}
+
 // Log returns a logging.LogBuffer that represents this execution trace. This buffer will be passed to the underlying
 // logger which will format it accordingly for console or file.
 func (t *ExecutionTrace) Log() *logging.LogBuffer {
@@ -379,6 +565,17 @@ func (t *ExecutionTrace) Log() *logging.LogBuffer {
 		buffer.Append(logs...)
 	}
 
+	// If the execution failed, add the source code surrounding the location of the failure, if it can be resolved
+	buffer.Append(t.generateFailureSourceElements()...)
+
+	// If we recorded any storage slots changed by the transaction, add them to the overarching execution trace
+	if len(t.StateDiff) > 0 {
+		buffer.Append(colors.Bold, "[State Diff]", colors.Reset, "\n")
+		for _, storageDiff := range t.StateDiff {
+			buffer.Append(fmt.Sprintf(" => %v slot %v: %v -> %v\n", t.formatAddress(storageDiff.Account), storageDiff.Slot.Hex(), storageDiff.PreviousValue.Hex(), storageDiff.NewValue.Hex()))
+		}
+	}
+
 	return buffer
 }
 
@@ -0,0 +1,226 @@
+package executiontracer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/crytic/medusa/fuzzing/valuegeneration"
+	"github.com/crytic/medusa/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// CallFrameJSON describes a call frame in the structured JSON representation of an ExecutionTrace.
+type CallFrameJSON struct {
+	// Type describes the kind of call made, either "call", "creation", or "proxyCall".
+	Type string `json:"type"`
+
+	// Sender describes the address which produced the call.
+	Sender common.Address `json:"sender"`
+
+	// To describes the address which was called.
+	To common.Address `json:"to"`
+
+	// ToContract describes the name of the contract resolved for the To address, if any.
+	ToContract string `json:"toContract,omitempty"`
+
+	// Code describes the address of the code which was executed, which differs from To for proxy calls.
+	Code common.Address `json:"code"`
+
+	// CodeContract describes the name of the contract resolved for the Code address, if any.
+	CodeContract string `json:"codeContract,omitempty"`
+
+	// Method describes the signature of the method called, if it could be resolved.
+	Method string `json:"method,omitempty"`
+
+	// Inputs describes the decoded input arguments of the method called, if they could be resolved.
+	Inputs string `json:"inputs,omitempty"`
+
+	// InputData describes the raw data the call was made with.
+	InputData hexutil.Bytes `json:"inputData"`
+
+	// Value describes the amount of wei sent with the call, as a base 10 string.
+	Value string `json:"value"`
+
+	// Gas describes the amount of gas provided to the call.
+	Gas uint64 `json:"gas"`
+
+	// GasUsed describes the amount of gas used by the call, including that of the calls it made.
+	GasUsed uint64 `json:"gasUsed"`
+
+	// ExecutedCode indicates whether any code was executed by the call, as opposed to a plain value transfer.
+	ExecutedCode bool `json:"executedCode"`
+
+	// Operations describes the calls made, events emitted, and storage written by this call, in chronological order.
+	Operations []CallFrameOperationJSON `json:"operations"`
+
+	// SelfDestructed indicates whether the call executed a SELFDESTRUCT operation.
+	SelfDestructed bool `json:"selfDestructed"`
+
+	// Outputs describes the decoded return values of the method called, if they could be resolved.
+	Outputs string `json:"outputs,omitempty"`
+
+	// ReturnData describes the raw data returned (or reverted with) by the call.
+	ReturnData hexutil.Bytes `json:"returnData"`
+
+	// Reverted indicates whether the call reverted or otherwise failed.
+	Reverted bool `json:"reverted"`
+
+	// Error describes the error the EVM returned for the call, if it failed.
+	Error string `json:"error,omitempty"`
+
+	// Result describes the outcome of the call as displayed in the textual execution trace, such as
+	// "return (1)", "revert ('reason')", or "panic: assertion failed".
+	Result string `json:"result"`
+}
+
+// CallFrameOperationJSON describes an operation performed within a call frame in the structured JSON representation
+// of an ExecutionTrace. Exactly one of its fields is set.
+type CallFrameOperationJSON struct {
+	// Call describes a call made by the call frame.
+	Call *CallFrameJSON `json:"call,omitempty"`
+
+	// Event describes an event emitted by the call frame.
+	Event *EventJSON `json:"event,omitempty"`
+
+	// StorageWrite describes a storage slot written by the call frame.
+	StorageWrite *StorageWriteJSON `json:"storageWrite,omitempty"`
+}
+
+// EventJSON describes an emitted event in the structured JSON representation of an ExecutionTrace.
+type EventJSON struct {
+	// Address describes the address of the contract which emitted the event.
+	Address common.Address `json:"address"`
+
+	// Event describes the event with its decoded values, such as "Transfer(0x1, 0x2, 5)", if its definition could
+	// be resolved.
+	Event string `json:"event"`
+
+	// Topics describes the raw topics of the event log.
+	Topics []common.Hash `json:"topics"`
+
+	// Data describes the raw data of the event log.
+	Data hexutil.Bytes `json:"data"`
+}
+
+// StorageWriteJSON describes a write to a storage slot in the structured JSON representation of an ExecutionTrace.
+type StorageWriteJSON struct {
+	// Slot describes the storage slot which was written to, within the storage of the call frame's To address.
+	Slot common.Hash `json:"slot"`
+
+	// Value describes the value which was written to the storage slot.
+	Value common.Hash `json:"value"`
+
+	// Variables describes the variables held within the storage slot along with their new values, such as
+	// "balances[0x1] = 5", if they could be resolved from the storage layout of the executing contract.
+	Variables string `json:"variables,omitempty"`
+}
+
+// JSON returns a structured JSON representation of this execution trace, describing the tree of call frames entered
+// starting from the top level call frame, along with the events emitted and values returned within them.
+// Returns the JSON data, or an error if one occurs.
+func (t *ExecutionTrace) JSON() ([]byte, error) {
+	var topLevelCallFrame *CallFrameJSON
+	if t.TopLevelCallFrame != nil {
+		topLevelCallFrame = t.callFrameJSON(t.TopLevelCallFrame)
+	}
+	return json.Marshal(topLevelCallFrame)
+}
+
+// callFrameJSON creates the structured JSON representation of the provided call frame and its child call frames.
+func (t *ExecutionTrace) callFrameJSON(callFrame *CallFrame) *CallFrameJSON {
+	// Determine the type of call made
+	callType := "call"
+	if callFrame.IsContractCreation() {
+		callType = "creation"
+	} else if callFrame.IsProxyCall() {
+		callType = "proxyCall"
+	}
+
+	// Create our call frame representation
+	callFrameJSON := &CallFrameJSON{
+		Type:           callType,
+		Sender:         callFrame.SenderAddress,
+		To:             callFrame.ToAddress,
+		ToContract:     callFrame.ToContractName,
+		Code:           callFrame.CodeAddress,
+		CodeContract:   callFrame.CodeContractName,
+		InputData:      callFrame.InputData,
+		Value:          "0",
+		Gas:            callFrame.Gas,
+		GasUsed:        callFrame.GasUsed,
+		ExecutedCode:   callFrame.ExecutedCode,
+		Operations:     make([]CallFrameOperationJSON, 0),
+		SelfDestructed: callFrame.SelfDestructed,
+		ReturnData:     callFrame.ReturnData,
+		Reverted:       callFrame.ReturnError != nil,
+	}
+	if callFrame.CallValue != nil {
+		callFrameJSON.Value = callFrame.CallValue.String()
+	}
+	if callFrame.ReturnError != nil {
+		callFrameJSON.Error = callFrame.ReturnError.Error()
+	}
+
+	// Resolve our method, and decode our input and output values with it.
+	if method := resolveCallFrameMethod(callFrame); method != nil {
+		callFrameJSON.Method = method.Sig
+		if callFrame.IsContractCreation() {
+			callFrameJSON.Method = "constructor"
+		}
+
+		// Constructor argument data follows code for contract creations, while normal calls are prefixed with a
+		// function selector.
+		abiDataInputBuffer := make([]byte, 0)
+		if callFrame.IsContractCreation() {
+			abiDataInputBuffer = callFrame.ConstructorArgsData
+		} else if len(callFrame.InputData) >= 4 {
+			abiDataInputBuffer = callFrame.InputData[4:]
+		}
+		if inputValues, err := method.Inputs.Unpack(abiDataInputBuffer); err == nil {
+			if inputs, err := valuegeneration.EncodeABIArgumentsToString(method.Inputs, inputValues); err == nil {
+				callFrameJSON.Inputs = inputs
+			}
+		}
+		if callFrame.ReturnError == nil {
+			if outputValues, err := method.Outputs.Unpack(callFrame.ReturnData); err == nil {
+				if outputs, err := valuegeneration.EncodeABIArgumentsToString(method.Outputs, outputValues); err == nil {
+					callFrameJSON.Outputs = outputs
+				}
+			}
+		}
+	}
+
+	// Describe the result of the call as our textual trace would, without its surrounding brackets.
+	resultBuffer := logging.NewLogBuffer()
+	resultBuffer.Append(t.generateCallFrameExitElements(callFrame)...)
+	callFrameJSON.Result = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(resultBuffer.String()), "["), "]")
+
+	// Add each operation performed in the call frame, in chronological order.
+	for _, operation := range callFrame.Operations {
+		if childCallFrame, ok := operation.(*CallFrame); ok {
+			callFrameJSON.Operations = append(callFrameJSON.Operations, CallFrameOperationJSON{
+				Call: t.callFrameJSON(childCallFrame),
+			})
+		} else if eventLog, ok := operation.(*coreTypes.Log); ok {
+			callFrameJSON.Operations = append(callFrameJSON.Operations, CallFrameOperationJSON{
+				Event: &EventJSON{
+					Address: eventLog.Address,
+					Event:   t.resolveEventDisplayText(callFrame, eventLog),
+					Topics:  eventLog.Topics,
+					Data:    eventLog.Data,
+				},
+			})
+		} else if storageWrite, ok := operation.(*StorageWrite); ok {
+			callFrameJSON.Operations = append(callFrameJSON.Operations, CallFrameOperationJSON{
+				StorageWrite: &StorageWriteJSON{
+					Slot:      storageWrite.Slot,
+					Value:     storageWrite.Value,
+					Variables: t.resolveStorageWriteVariablesText(callFrame, storageWrite),
+				},
+			})
+		}
+	}
+	return callFrameJSON
+}
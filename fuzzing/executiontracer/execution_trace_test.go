@@ -0,0 +1,80 @@
+package executiontracer
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	compilationTypes "github.com/crytic/medusa/compilation/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStorageLayout describes the storage layout of the following contract, as emitted by solc:
+//
+//	contract TestContract {
+//	    address owner;
+//	    mapping(address => uint256) balances;
+//	}
+const testStorageLayout = `{
+	"storage": [
+		{"label": "owner", "offset": 0, "slot": "0", "type": "t_address"},
+		{"label": "balances", "offset": 0, "slot": "1", "type": "t_mapping(t_address,t_uint256)"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_mapping(t_address,t_uint256)": {"encoding": "mapping", "key": "t_address", "label": "mapping(address => uint256)", "numberOfBytes": "32", "value": "t_uint256"}
+	}
+}`
+
+// TestExecutionTraceStorageWrites tests that storage writes are described in the textual and JSON representations of
+// an execution trace, using the names of the variables written when the storage layout of the contract is known, and
+// the raw slot and value otherwise.
+func TestExecutionTraceStorageWrites(t *testing.T) {
+	storageLayout, err := compilationTypes.ParseStorageLayoutFromInterface(testStorageLayout)
+	assert.NoError(t, err)
+
+	// Create a trace of a call which writes a mapping value, whose slot is derived from a hash recorded during
+	// execution, followed by a slot which holds no known variable.
+	account := common.HexToAddress("0x1234")
+	mappingPreimage := append(common.LeftPadBytes(account.Bytes(), 32), common.BigToHash(big.NewInt(1)).Bytes()...)
+	mappingSlot := crypto.Keccak256Hash(mappingPreimage)
+	unknownSlot := common.BigToHash(big.NewInt(7))
+	contractAddress := common.HexToAddress("0x5678")
+	trace := newExecutionTrace(nil, nil)
+	trace.hashPreimages[mappingSlot] = mappingPreimage
+	trace.TopLevelCallFrame = &CallFrame{
+		ToAddress:                 contractAddress,
+		CodeAddress:               contractAddress,
+		CodeContractStorageLayout: storageLayout,
+		ExecutedCode:              true,
+		Operations: []any{
+			&StorageWrite{Slot: mappingSlot, Value: common.BigToHash(big.NewInt(5))},
+			&StorageWrite{Slot: unknownSlot, Value: common.BigToHash(big.NewInt(9))},
+		},
+	}
+
+	// The textual trace names the mapping value written, and falls back to the raw slot otherwise.
+	traceText := trace.Log().String()
+	assert.Contains(t, traceText, "[SSTORE] balances["+account.Hex()+"] = 5")
+	assert.Contains(t, traceText, "[SSTORE] slot "+unknownSlot.Hex()+" = "+common.BigToHash(big.NewInt(9)).Hex())
+
+	// The JSON trace describes the same writes, in the order they occurred.
+	b, err := trace.JSON()
+	assert.NoError(t, err)
+	var topLevelCallFrame CallFrameJSON
+	err = json.Unmarshal(b, &topLevelCallFrame)
+	assert.NoError(t, err)
+	assert.Len(t, topLevelCallFrame.Operations, 2)
+	assert.Equal(t, &StorageWriteJSON{
+		Slot:      mappingSlot,
+		Value:     common.BigToHash(big.NewInt(5)),
+		Variables: "balances[" + account.Hex() + "] = 5",
+	}, topLevelCallFrame.Operations[0].StorageWrite)
+	assert.Equal(t, &StorageWriteJSON{
+		Slot:  unknownSlot,
+		Value: common.BigToHash(big.NewInt(9)),
+	}, topLevelCallFrame.Operations[1].StorageWrite)
+}
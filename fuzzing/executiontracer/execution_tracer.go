@@ -13,6 +13,7 @@ import (
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"golang.org/x/exp/slices"
 )
@@ -22,7 +23,7 @@ import (
 // Returns the ExecutionTrace for the call or an error if one occurs.
 func CallWithExecutionTrace(testChain *chain.TestChain, contractDefinitions contracts.Contracts, msg *core.Message, state *state.StateDB) (*core.ExecutionResult, *ExecutionTrace, error) {
 	// Create an execution tracer
-	executionTracer := NewExecutionTracer(contractDefinitions, testChain.CheatCodeContracts())
+	executionTracer := NewExecutionTracer(contractDefinitions, testChain.CheatCodeContracts(), testChain.Labels)
 	defer executionTracer.Close()
 
 	// Call the contract on our chain with the provided state.
@@ -39,6 +40,13 @@ func CallWithExecutionTrace(testChain *chain.TestChain, contractDefinitions cont
 	return executionResult, trace, nil
 }
 
+// maxRecentPCs describes the maximum amount of recently executed program counters a CallFrame will keep track of.
+const maxRecentPCs = 512
+
+// maxHashPreimageSize describes the maximum size of a KECCAK256 input which will be recorded as a hash preimage, used
+// to resolve the storage slots of mapping values and dynamic array elements.
+const maxHashPreimageSize = 1024
+
 // ExecutionTracer records execution information into an ExecutionTrace, containing information about each call
 // scope entered and exited.
 type ExecutionTracer struct {
@@ -59,6 +67,9 @@ type ExecutionTracer struct {
 	// cheatCodeContracts  represents the cheat code contract definitions to match for execution traces.
 	cheatCodeContracts map[common.Address]*chain.CheatCodeContract
 
+	// labels represents the human-readable address labels to use when displaying execution traces.
+	labels map[common.Address]string
+
 	// onNextCaptureState refers to methods which should be executed the next time OnOpcode executes.
 	// OnOpcode is called prior to execution of an instruction. This allows actions to be performed
 	// after some state is captured, on the next state capture (e.g. detecting a log instruction, but
@@ -69,10 +80,11 @@ type ExecutionTracer struct {
 }
 
 // NewExecutionTracer creates a ExecutionTracer and returns it.
-func NewExecutionTracer(contractDefinitions contracts.Contracts, cheatCodeContracts map[common.Address]*chain.CheatCodeContract) *ExecutionTracer {
+func NewExecutionTracer(contractDefinitions contracts.Contracts, cheatCodeContracts map[common.Address]*chain.CheatCodeContract, labels map[common.Address]string) *ExecutionTracer {
 	tracer := &ExecutionTracer{
 		contractDefinitions: contractDefinitions,
 		cheatCodeContracts:  cheatCodeContracts,
+		labels:              labels,
 		traceMap:            make(map[common.Hash]*ExecutionTrace),
 	}
 	innerTracer := &tracers.Tracer{
@@ -122,7 +134,7 @@ func (t *ExecutionTracer) OnTxEnd(receipt *coretypes.Receipt, err error) {
 // OnTxStart is called upon the start of transaction execution, as defined by tracers.Tracer.
 func (t *ExecutionTracer) OnTxStart(vm *tracing.VMContext, tx *coretypes.Transaction, from common.Address) {
 	// Reset our capture state
-	t.trace = newExecutionTrace(t.contractDefinitions)
+	t.trace = newExecutionTrace(t.contractDefinitions, t.labels)
 	t.currentCallFrame = nil
 	t.onNextCaptureState = nil
 	t.traceMap = make(map[common.Hash]*ExecutionTrace)
@@ -167,6 +179,7 @@ func (t *ExecutionTracer) resolveCallFrameContractDefinitions(callFrame *CallFra
 				if callFrame.IsContractCreation() {
 					callFrame.CodeContractName = toContract.Name()
 					callFrame.CodeContractAbi = &toContract.CompiledContract().Abi
+					callFrame.CodeContractStorageLayout = toContract.CompiledContract().StorageLayout
 				}
 			}
 		}
@@ -185,6 +198,7 @@ func (t *ExecutionTracer) resolveCallFrameContractDefinitions(callFrame *CallFra
 			if codeContract != nil {
 				callFrame.CodeContractName = codeContract.Name()
 				callFrame.CodeContractAbi = &codeContract.CompiledContract().Abi
+				callFrame.CodeContractStorageLayout = codeContract.CompiledContract().StorageLayout
 				callFrame.ExecutedCode = true
 			}
 		}
@@ -192,7 +206,7 @@ func (t *ExecutionTracer) resolveCallFrameContractDefinitions(callFrame *CallFra
 }
 
 // captureEnteredCallFrame is a helper method used when a new call frame is entered to record information about it.
-func (t *ExecutionTracer) captureEnteredCallFrame(fromAddress common.Address, toAddress common.Address, inputData []byte, isContractCreation bool, value *big.Int) {
+func (t *ExecutionTracer) captureEnteredCallFrame(fromAddress common.Address, toAddress common.Address, inputData []byte, isContractCreation bool, value *big.Int, gas uint64) {
 	// Create our call frame struct to track data for this call frame we entered.
 	callFrameData := &CallFrame{
 		SenderAddress:       fromAddress,
@@ -212,7 +226,9 @@ func (t *ExecutionTracer) captureEnteredCallFrame(fromAddress common.Address, to
 		ReturnData:          nil,
 		ExecutedCode:        false,
 		CallValue:           value,
+		Gas:                 gas,
 		ReturnError:         nil,
+		RecentPCs:           make([]uint64, 0),
 		ParentCallFrame:     t.currentCallFrame,
 	}
 
@@ -231,7 +247,7 @@ func (t *ExecutionTracer) captureEnteredCallFrame(fromAddress common.Address, to
 }
 
 // captureExitedCallFrame is a helper method used when a call frame is exited, to record information about it.
-func (t *ExecutionTracer) captureExitedCallFrame(output []byte, err error) {
+func (t *ExecutionTracer) captureExitedCallFrame(output []byte, gasUsed uint64, err error) {
 	// If this was an initial deployment, now that we're exiting, we'll want to record the finally deployed bytecodes.
 	if t.currentCallFrame.ToRuntimeBytecode == nil {
 		// As long as this isn't a failed contract creation, we should be able to fetch "to" byte code on exit.
@@ -254,6 +270,7 @@ func (t *ExecutionTracer) captureExitedCallFrame(output []byte, err error) {
 
 	// Set our information for this call frame
 	t.currentCallFrame.ReturnData = slices.Clone(output)
+	t.currentCallFrame.GasUsed = gasUsed
 	t.currentCallFrame.ReturnError = err
 
 	// We're exiting the current frame, so set our current call frame to the parent
@@ -263,13 +280,13 @@ func (t *ExecutionTracer) captureExitedCallFrame(output []byte, err error) {
 // OnEnter initializes the tracing operation for the top of a call frame, as defined by tracers.Tracer.
 func (t *ExecutionTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
 	// Capture that a new call frame was entered.
-	t.captureEnteredCallFrame(from, to, input, (typ == byte(vm.CREATE) || typ == byte(vm.CREATE2)), value)
+	t.captureEnteredCallFrame(from, to, input, (typ == byte(vm.CREATE) || typ == byte(vm.CREATE2)), value, gas)
 }
 
 // OnExit is called after a call to finalize tracing completes for the top of a call frame, as defined by tracers.Tracer.
 func (t *ExecutionTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
 	// Capture that the call frame was exited.
-	t.captureExitedCallFrame(output, err)
+	t.captureExitedCallFrame(output, gasUsed, err)
 }
 
 // OnOpcode records data from an EVM state update, as defined by tracers.Tracer.
@@ -293,11 +310,39 @@ func (t *ExecutionTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope t
 		t.currentCallFrame.ExecutedCode = true
 	}
 
+	// Record the program counter, so we know which instructions the call frame last executed when it exits. Once we
+	// reach our limit, we drop the older half of the recorded program counters.
+	if len(t.currentCallFrame.RecentPCs) >= maxRecentPCs {
+		t.currentCallFrame.RecentPCs = append(t.currentCallFrame.RecentPCs[:0], t.currentCallFrame.RecentPCs[maxRecentPCs/2:]...)
+	}
+	t.currentCallFrame.RecentPCs = append(t.currentCallFrame.RecentPCs, pc)
+
 	// If we encounter a SELFDESTRUCT operation, record the operation.
 	if op == byte(vm.SELFDESTRUCT) {
 		t.currentCallFrame.SelfDestructed = true
 	}
 
+	// If we encounter an SSTORE operation, record the slot and value written.
+	stackData := scope.StackData()
+	if op == byte(vm.SSTORE) && err == nil && len(stackData) >= 2 {
+		t.currentCallFrame.Operations = append(t.currentCallFrame.Operations, &StorageWrite{
+			Slot:  common.Hash(stackData[len(stackData)-1].Bytes32()),
+			Value: common.Hash(stackData[len(stackData)-2].Bytes32()),
+		})
+	}
+
+	// If we encounter a KECCAK256 operation, record its input, so storage slots derived from the hash (e.g. mapping
+	// values) can be resolved to the variable they hold later.
+	if op == byte(vm.KECCAK256) && err == nil && len(stackData) >= 2 {
+		offset, size := stackData[len(stackData)-1], stackData[len(stackData)-2]
+		memoryData := scope.MemoryData()
+		if size.IsUint64() && size.Uint64() >= common.HashLength && size.Uint64() <= maxHashPreimageSize &&
+			offset.IsUint64() && offset.Uint64()+size.Uint64() <= uint64(len(memoryData)) {
+			preimage := slices.Clone(memoryData[offset.Uint64() : offset.Uint64()+size.Uint64()])
+			t.trace.hashPreimages[crypto.Keccak256Hash(preimage)] = preimage
+		}
+	}
+
 	// If a log operation occurred, add a deferred operation to capture it.
 	// TODO: Move this to OnLog
 	if op == byte(vm.LOG0) || op == byte(vm.LOG1) || op == byte(vm.LOG2) || op == byte(vm.LOG3) || op == byte(vm.LOG4) {
@@ -1,9 +1,13 @@
 package fuzzing
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"os"
@@ -13,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -23,12 +28,15 @@ import (
 	"github.com/crytic/medusa/logging"
 	"github.com/crytic/medusa/logging/colors"
 	"github.com/rs/zerolog"
+	"golang.org/x/exp/maps"
 
 	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/utils/randomutils"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/crytic/medusa/chain"
+	chainTypes "github.com/crytic/medusa/chain/types"
+	"github.com/crytic/medusa/compilation/abiutils"
 	compilationTypes "github.com/crytic/medusa/compilation/types"
 	"github.com/crytic/medusa/fuzzing/config"
 	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
@@ -38,6 +46,9 @@ import (
 	"github.com/crytic/medusa/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"golang.org/x/exp/slices"
 )
 
@@ -52,6 +63,9 @@ type Fuzzer struct {
 	config config.ProjectConfig
 	// senders describes a set of account addresses used to send state changing calls in fuzzing campaigns.
 	senders []common.Address
+	// senderWeights describes the weight with which each of the senders is chosen, by index. If nil, senders are chosen
+	// uniformly.
+	senderWeights []*big.Int
 	// deployer describes an account address used to deploy contracts in fuzzing campaigns.
 	deployer common.Address
 
@@ -71,8 +85,31 @@ type Fuzzer struct {
 	workers []*FuzzerWorker
 	// metrics represents the metrics for the fuzzing campaign.
 	metrics *FuzzerMetrics
+	// targetContractReached indicates whether any call to a target contract has succeeded in the current fuzzing
+	// campaign.
+	targetContractReached atomic.Bool
+	// targetContractsReachedChecked indicates whether checkTargetContractsReached has no further checking to do in the
+	// current fuzzing campaign, either because it warned, or because a target contract was reached.
+	targetContractsReachedChecked atomic.Bool
+	// targetReachabilitySequencesTested counts the call sequences tested while checkTargetContractsReached was still
+	// checking for successful calls to target contracts, to determine when the grace period has elapsed.
+	targetReachabilitySequencesTested atomic.Uint64
 	// corpus stores a list of transaction sequences that can be used for coverage-guided fuzzing
 	corpus *corpus.Corpus
+	// selfCheck indicates whether the Fuzzer runs campaigns in self-check mode, in which case workers enforce the test
+	// limit themselves, so that campaigns stop after the same call each time they are run with the same random seed.
+	selfCheck bool
+	// selfCheckCallsTested counts the calls tested by workers in the current fuzzing campaign, in self-check mode.
+	selfCheckCallsTested atomic.Uint64
+	// parallelShrinkTasks describes the queue of candidate shrunken call sequences submitted by workers which are
+	// shrinking, to be tested by any available worker. This is nil if parallel shrinking is not enabled.
+	parallelShrinkTasks chan *parallelShrinkTask
+	// parallelShrinkTasksAssisted counts the candidate shrunken call sequences tested by workers on behalf of another
+	// worker which was shrinking, in the current fuzzing campaign.
+	parallelShrinkTasksAssisted atomic.Uint64
+	// sequenceTraceFiles describes the file each worker writes every call sequence it executes to, by worker index.
+	// This is nil if sequence tracing is not enabled.
+	sequenceTraceFiles []*os.File
 
 	// randomProvider describes the provider used to generate random values in the Fuzzer. All other random providers
 	// used by the Fuzzer's subcomponents are derived from this one.
@@ -84,6 +121,9 @@ type Fuzzer struct {
 	testCasesLock sync.Mutex
 	// testCasesFinished describes test cases already reported as having been finalized.
 	testCasesFinished map[string]TestCase
+	// lastFailedTestCase describes the test case most recently reported as having been finalized with a failed status,
+	// or nil if none was.
+	lastFailedTestCase TestCase
 
 	// Events describes the event system for the Fuzzer.
 	Events FuzzerEvents
@@ -141,6 +181,27 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 		return nil, err
 	}
 
+	// Resolve the weight of each sender, if any were provided.
+	var senderWeights []*big.Int
+	if len(config.Fuzzing.SenderWeights) > 0 {
+		senderWeights = make([]*big.Int, len(senders))
+		for i := range senders {
+			senderWeights[i] = big.NewInt(1)
+		}
+		for senderString, weight := range config.Fuzzing.SenderWeights {
+			sender, err := utils.HexStringToAddress(senderString)
+			if err != nil {
+				logger.Error("Invalid sender weight address", err)
+				return nil, err
+			}
+			for i := range senders {
+				if senders[i] == sender {
+					senderWeights[i] = new(big.Int).SetUint64(weight)
+				}
+			}
+		}
+	}
+
 	// Parse the deployer address from our account config
 	deployer, err := utils.HexStringToAddress(config.Fuzzing.DeployerAddress)
 	if err != nil {
@@ -152,6 +213,7 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 	fuzzer := &Fuzzer{
 		config:              config,
 		senders:             senders,
+		senderWeights:       senderWeights,
 		deployer:            deployer,
 		baseValueSet:        valuegeneration.NewValueSet(),
 		contractDefinitions: make(fuzzerTypes.Contracts, 0),
@@ -159,9 +221,12 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 		testCasesFinished:   make(map[string]TestCase),
 		Hooks: FuzzerHooks{
 			NewCallSequenceGeneratorConfigFunc: defaultCallSequenceGeneratorConfigFunc,
-			NewShrinkingValueMutatorFunc:       defaultShrinkingValueMutatorFunc,
-			ChainSetupFunc:                     chainSetupFromCompilations,
-			CallSequenceTestFuncs:              make([]CallSequenceTestFunc, 0),
+			NamedCallSequenceGeneratorConfigFuncs: map[string]NewCallSequenceGeneratorConfigFunc{
+				"default": defaultCallSequenceGeneratorConfigFunc,
+			},
+			NewShrinkingValueMutatorFunc: defaultShrinkingValueMutatorFunc,
+			ChainSetupFunc:               chainSetupFromCompilations,
+			CallSequenceTestFuncs:        make([]CallSequenceTestFunc, 0),
 		},
 		logger: logger,
 	}
@@ -173,6 +238,11 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 		fuzzer.baseValueSet.AddAddress(sender)
 	}
 
+	// Add any mock ERC20 token addresses to the base value set, so they may be used as address arguments as well.
+	for i := 0; i < fuzzer.config.Fuzzing.TestChainConfig.MockERC20TokenCount; i++ {
+		fuzzer.baseValueSet.AddAddress(chain.MockERC20Address(i))
+	}
+
 	// If we have a compilation config
 	if fuzzer.config.Compilation != nil {
 		// Compile the targets specified in the compilation config
@@ -189,7 +259,11 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 		fuzzer.AddCompilationTargets(compilations)
 	}
 
-	// Register any default providers if specified.
+	// Register any default providers if specified. In assertion-only mode, we only register the assertion provider.
+	if fuzzer.config.Fuzzing.Testing.AssertionOnlyMode {
+		attachAssertionTestCaseProvider(fuzzer)
+		return fuzzer, nil
+	}
 	if fuzzer.config.Fuzzing.Testing.PropertyTesting.Enabled {
 		attachPropertyTestCaseProvider(fuzzer)
 	}
@@ -199,6 +273,12 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 	if fuzzer.config.Fuzzing.Testing.OptimizationTesting.Enabled {
 		attachOptimizationTestCaseProvider(fuzzer)
 	}
+	if fuzzer.config.Fuzzing.Testing.ERC20SupplyTesting.Enabled {
+		attachERC20SupplyTestCaseProvider(fuzzer)
+	}
+	if fuzzer.config.Fuzzing.Testing.GasTesting.Enabled {
+		attachGasTestCaseProvider(fuzzer)
+	}
 	return fuzzer, nil
 }
 
@@ -229,6 +309,11 @@ func (f *Fuzzer) DeployerAddress() common.Address {
 	return f.deployer
 }
 
+// Metrics exposes the metrics for the fuzzing campaign. This is nil until the Fuzzer is started.
+func (f *Fuzzer) Metrics() *FuzzerMetrics {
+	return f.metrics
+}
+
 // TestCases exposes the underlying tests run during the fuzzing campaign.
 func (f *Fuzzer) TestCases() []TestCase {
 	return f.testCases
@@ -246,6 +331,15 @@ func (f *Fuzzer) TestCasesWithStatus(status TestCaseStatus) []TestCase {
 	})
 }
 
+// LastFailedTestCase exposes the test case which was most recently reported as having failed, or nil if none was.
+// When several workers report failures concurrently, this is the last one the Fuzzer processed.
+func (f *Fuzzer) LastFailedTestCase() TestCase {
+	// Acquire a thread lock to avoid race conditions
+	f.testCasesLock.Lock()
+	defer f.testCasesLock.Unlock()
+	return f.lastFailedTestCase
+}
+
 // RegisterTestCase registers a new TestCase with the Fuzzer.
 func (f *Fuzzer) RegisterTestCase(testCase TestCase) {
 	// Acquire a thread lock to avoid race conditions
@@ -272,6 +366,9 @@ func (f *Fuzzer) ReportTestCaseFinished(testCase TestCase) {
 
 	// Otherwise now mark the test case as finished.
 	f.testCasesFinished[testCase.ID()] = testCase
+	if testCase.Status() == TestCaseStatusFailed {
+		f.lastFailedTestCase = testCase
+	}
 
 	// We only log here if we're not configured to stop on the first test failure. This is because the fuzzer prints
 	// results on exit, so we avoid duplicate messages.
@@ -375,22 +472,45 @@ func (f *Fuzzer) createTestChain() (*chain.TestChain, error) {
 	// NOTE: Sharing GenesisAlloc between chains will result in some accounts not being funded for some reason.
 	genesisAlloc := make(types.GenesisAlloc)
 
+	// Determine the balances to fund our accounts with, using a large default balance for any which is not configured.
+	defaultBalance := new(big.Int).Div(abi.MaxInt256, big.NewInt(2))
+	senderBalance, deployerBalance := defaultBalance, defaultBalance
+	if f.config.Fuzzing.SenderBalance != nil {
+		senderBalance = new(big.Int).Set(f.config.Fuzzing.SenderBalance)
+	}
+	if f.config.Fuzzing.DeployerBalance != nil {
+		deployerBalance = new(big.Int).Set(f.config.Fuzzing.DeployerBalance)
+	}
+
 	// Fund all of our sender addresses in the genesis block
-	initBalance := new(big.Int).Div(abi.MaxInt256, big.NewInt(2)) // TODO: make this configurable
 	for _, sender := range f.senders {
 		genesisAlloc[sender] = types.Account{
-			Balance: initBalance,
+			Balance: senderBalance,
 		}
 	}
 
 	// Fund our deployer address in the genesis block
 	genesisAlloc[f.deployer] = types.Account{
-		Balance: initBalance,
+		Balance: deployerBalance,
 	}
 
 	// Identify which contracts need to be predeployed to a deterministic address by iterating across the mapping
 	contractAddressOverrides := make(map[common.Hash]common.Address, len(f.config.Fuzzing.PredeployedContracts))
-	for contractName, addrStr := range f.config.Fuzzing.PredeployedContracts {
+	for contractName, predeployedContract := range f.config.Fuzzing.PredeployedContracts {
+		// If the predeployed contract provides its own runtime bytecode, set it directly in the genesis block
+		if predeployedContract.HasRuntimeBytecode() {
+			contractAddr, err := utils.HexStringToAddress(predeployedContract.Address)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address provided for a predeployed contract: %v", contractName)
+			}
+			account, err := predeployedContract.GenesisAccount()
+			if err != nil {
+				return nil, fmt.Errorf("invalid predeployed contract %v: %v", contractName, err)
+			}
+			genesisAlloc[contractAddr] = account
+			continue
+		}
+
 		found := false
 		// Try to find the associated compilation artifact
 		for _, contract := range f.contractDefinitions {
@@ -398,7 +518,7 @@ func (f *Fuzzer) createTestChain() (*chain.TestChain, error) {
 				// Hash the init bytecode (so that it can be easily identified in the EVM) and map it to the
 				// requested address
 				initBytecodeHash := crypto.Keccak256Hash(contract.CompiledContract().InitBytecode)
-				contractAddr, err := utils.HexStringToAddress(addrStr)
+				contractAddr, err := utils.HexStringToAddress(predeployedContract.Address)
 				if err != nil {
 					return nil, fmt.Errorf("invalid address provided for a predeployed contract: %v", contract.Name())
 				}
@@ -419,49 +539,86 @@ func (f *Fuzzer) createTestChain() (*chain.TestChain, error) {
 
 	// Create our test chain with our basic allocations and passed medusa's chain configuration
 	testChain, err := chain.NewTestChain(genesisAlloc, &f.config.Fuzzing.TestChainConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	// Set our block gas limit
 	testChain.BlockGasLimit = f.config.Fuzzing.BlockGasLimit
-	return testChain, err
+
+	// Label our deployer and sender addresses so they can be identified in execution traces. Labels which were
+	// explicitly set on the chain take precedence.
+	f.labelDefaultAddresses(testChain)
+	return testChain, nil
+}
+
+// labelDefaultAddresses labels the deployer and sender addresses on the provided test chain (e.g. "deployer",
+// "sender0", "sender1"). Any address which already has a label is not relabeled.
+func (f *Fuzzer) labelDefaultAddresses(testChain *chain.TestChain) {
+	if _, ok := testChain.Labels[f.deployer]; !ok {
+		testChain.Labels[f.deployer] = "deployer"
+	}
+	for i, sender := range f.senders {
+		if _, ok := testChain.Labels[sender]; !ok {
+			testChain.Labels[sender] = fmt.Sprintf("sender%d", i)
+		}
+	}
 }
 
 // chainSetupFromCompilations is a TestChainSetupFunc which sets up the base test chain state by deploying
 // all compiled contract definitions. This includes any successful compilations as a result of the Fuzzer.config
 // definitions, as well as those added by Fuzzer.AddCompilationTargets. The contract deployment order is defined by
-// the Fuzzer.config.
+// the Fuzzer.config. Once all contracts are deployed, any setup calls defined by the Fuzzer.config are executed.
 func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) (*executiontracer.ExecutionTrace, error) {
-	// Verify that target contracts is not empty. If it's empty, but we only have one contract definition,
-	// we can infer the target contracts. Otherwise, we report an error.
+	// Verify that target contracts is not empty. If it's empty, but we only have one contract definition, or were
+	// configured to target all contracts, we can infer the target contracts. Otherwise, we report an error.
 	if len(fuzzer.config.Fuzzing.TargetContracts) == 0 {
-		var found bool
+		// Obtain the candidate target contracts by filtering interfaces/libraries.
+		candidateContracts := make([]string, 0)
 		for _, contract := range fuzzer.contractDefinitions {
-			// If only one contract is defined, we can infer the target contract by filtering interfaces/libraries.
 			if contract.CompiledContract().Kind == compilationTypes.ContractKindContract {
-				if !found {
-					fuzzer.config.Fuzzing.TargetContracts = []string{contract.Name()}
-					found = true
-				} else {
-					// TODO list options for the user to choose from
-					return nil, fmt.Errorf("specify target contract(s)")
-				}
+				candidateContracts = append(candidateContracts, contract.Name())
+			}
+		}
+		if len(candidateContracts) > 1 && !fuzzer.config.Fuzzing.TargetAllContracts {
+			return nil, fmt.Errorf("specify target contract(s) using the targetContracts config option, or enable "+
+				"targetAllContracts to target all of them. Available contracts: %v", strings.Join(candidateContracts, ", "))
+		}
+		if len(candidateContracts) > 0 {
+			fuzzer.config.Fuzzing.TargetContracts = candidateContracts
+		}
+	}
+
+	// Predeployed contracts which provide their own runtime bytecode were set in the genesis block, so we only record
+	// their addresses, so that config-specified constructor args can reference them by name.
+	deployedContractAddr := make(map[string]common.Address)
+	deployedContracts := make(map[string]*fuzzerTypes.Contract)
+	for contractName, predeployedContract := range fuzzer.config.Fuzzing.PredeployedContracts {
+		if predeployedContract.HasRuntimeBytecode() {
+			contractAddr, err := utils.HexStringToAddress(predeployedContract.Address)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address provided for a predeployed contract: %v", contractName)
 			}
+			deployedContractAddr[contractName] = contractAddr
+			testChain.DeployedContractAddresses[contractName] = contractAddr
 		}
 	}
 
-	// Concatenate the predeployed contracts and target contracts
+	// Concatenate the remaining predeployed contracts and target contracts
 	// Ordering is important here (predeploys _then_ targets) so that you can have the same contract in both lists
 	// while still being able to use the contract address overrides
 	contractsToDeploy := make([]string, 0)
 	balances := make([]*big.Int, 0)
-	for contractName := range fuzzer.config.Fuzzing.PredeployedContracts {
-		contractsToDeploy = append(contractsToDeploy, contractName)
-		// Preserve index of target contract balances
-		balances = append(balances, big.NewInt(0))
+	for contractName, predeployedContract := range fuzzer.config.Fuzzing.PredeployedContracts {
+		if !predeployedContract.HasRuntimeBytecode() {
+			contractsToDeploy = append(contractsToDeploy, contractName)
+			// Preserve index of target contract balances
+			balances = append(balances, big.NewInt(0))
+		}
 	}
+	predeployedContractCount := len(contractsToDeploy)
 	contractsToDeploy = append(contractsToDeploy, fuzzer.config.Fuzzing.TargetContracts...)
 	balances = append(balances, fuzzer.config.Fuzzing.TargetContractsBalances...)
-
-	deployedContractAddr := make(map[string]common.Address)
 	// Loop for all contracts to deploy
 	for i, contractName := range contractsToDeploy {
 		// Look for a contract in our compiled contract definitions that matches this one
@@ -474,7 +631,7 @@ func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) (*ex
 				if len(contract.CompiledContract().Abi.Constructor.Inputs) > 0 {
 					// If the contract is a predeployed contract, throw an error because they do not accept constructor
 					// args.
-					if _, ok := fuzzer.config.Fuzzing.PredeployedContracts[contractName]; ok {
+					if i < predeployedContractCount {
 						return nil, fmt.Errorf("predeployed contracts cannot accept constructor arguments")
 					}
 					jsonArgs, ok := fuzzer.config.Fuzzing.ConstructorArgs[contractName]
@@ -482,7 +639,7 @@ func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) (*ex
 						return nil, fmt.Errorf("constructor arguments for contract %s not provided", contractName)
 					}
 					decoded, err := valuegeneration.DecodeJSONArgumentsFromMap(contract.CompiledContract().Abi.Constructor.Inputs,
-						jsonArgs, deployedContractAddr)
+						jsonArgs, deployedContractAddr, fuzzer.config.Fuzzing.LenientConstructorArgs)
 					if err != nil {
 						return nil, err
 					}
@@ -501,59 +658,84 @@ func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) (*ex
 					contractBalance = new(big.Int).Set(balances[i])
 				}
 
-				// Create a message to represent our contract deployment (we let deployments consume the whole block
-				// gas limit rather than use tx gas limit)
-				msg := calls.NewCallMessage(fuzzer.deployer, nil, 0, contractBalance, fuzzer.config.Fuzzing.BlockGasLimit, nil, nil, nil, msgData)
-				msg.FillFromTestChainProperties(testChain)
-
-				// Create a new pending block we'll commit to chain
-				block, err := testChain.PendingBlockCreate()
-				if err != nil {
-					return nil, err
+				// Determine how many instances of this contract to deploy. Predeployed contracts are only deployed once.
+				instances := 1
+				if i >= predeployedContractCount {
+					if configuredInstances, ok := fuzzer.config.Fuzzing.ContractInstances[contractName]; ok {
+						instances = configuredInstances
+					}
 				}
 
-				// Add our transaction to the block
-				err = testChain.PendingBlockAddTx(msg.ToCoreMessage())
-				if err != nil {
-					return nil, err
-				}
+				// Deploy each instance, naming additional instances with their index as a suffix.
+				for instance := 0; instance < instances; instance++ {
+					instanceName := contractName
+					if instance > 0 {
+						instanceName = fmt.Sprintf("%s_%d", contractName, instance)
+					}
 
-				// Commit the pending block to the chain, so it becomes the new head.
-				err = testChain.PendingBlockCommit()
-				if err != nil {
-					return nil, err
-				}
+					// Create a message to represent our contract deployment (we let deployments consume the whole block
+					// gas limit rather than use tx gas limit)
+					msg := calls.NewCallMessage(fuzzer.deployer, nil, 0, contractBalance, fuzzer.config.Fuzzing.BlockGasLimit, nil, nil, nil, msgData)
+					msg.FillFromTestChainProperties(testChain)
+
+					// Create a new pending block we'll commit to chain
+					block, err := testChain.PendingBlockCreate()
+					if err != nil {
+						return nil, err
+					}
 
-				// Ensure our transaction succeeded and, if it did not, attach an execution trace to it and re-run it.
-				// The execution trace will be returned so that it can be provided to the user for debugging
-				if block.MessageResults[0].Receipt.Status != types.ReceiptStatusSuccessful {
-					// Create a call sequence element to represent the failed contract deployment tx
-					cse := calls.NewCallSequenceElement(nil, msg, 0, 0)
-					cse.ChainReference = &calls.CallSequenceElementChainReference{
-						Block:            block,
-						TransactionIndex: len(block.Messages) - 1,
+					// Add our transaction to the block
+					err = testChain.PendingBlockAddTx(msg.ToCoreMessage())
+					if err != nil {
+						return nil, err
 					}
-					// Revert to one block before and re-run the failed contract deployment tx.
-					// This should be one index before the current head block index.
-					// We should be able to attach an execution trace; however, if it fails, we provide the ExecutionResult at a minimum.
-					err = testChain.RevertToBlockIndex(uint64(len(testChain.CommittedBlocks()) - 1))
+
+					// Commit the pending block to the chain, so it becomes the new head.
+					err = testChain.PendingBlockCommit()
 					if err != nil {
-						return nil, fmt.Errorf("failed to reset to genesis block: %v", err)
-					} else {
-						_, err = calls.ExecuteCallSequenceWithExecutionTracer(testChain, fuzzer.contractDefinitions, []*calls.CallSequenceElement{cse}, true)
+						return nil, err
+					}
+
+					// Ensure our transaction succeeded and, if it did not, attach an execution trace to it and re-run it.
+					// The execution trace will be returned so that it can be provided to the user for debugging
+					if block.MessageResults[0].Receipt.Status != types.ReceiptStatusSuccessful {
+						// If this contract is optional, warn about the failure and skip it (along with any remaining
+						// instances) rather than aborting.
+						if slices.Contains(fuzzer.config.Fuzzing.OptionalContracts, contractName) {
+							fuzzer.logger.Warn(fmt.Sprintf("Skipping optional contract %s, as deploying it returned a failed status: %v", instanceName, block.MessageResults[0].ExecutionResult.Err))
+							break
+						}
+
+						// Create a call sequence element to represent the failed contract deployment tx
+						cse := calls.NewCallSequenceElement(nil, msg, 0, 0)
+						cse.ChainReference = &calls.CallSequenceElementChainReference{
+							Block:            block,
+							TransactionIndex: len(block.Messages) - 1,
+						}
+						// Revert to one block before and re-run the failed contract deployment tx.
+						// This should be one index before the current head block index.
+						// We should be able to attach an execution trace; however, if it fails, we provide the ExecutionResult at a minimum.
+						err = testChain.RevertToBlockIndex(uint64(len(testChain.CommittedBlocks()) - 1))
 						if err != nil {
-							return nil, fmt.Errorf("deploying %s returned a failed status: %v", contractName, block.MessageResults[0].ExecutionResult.Err)
+							return nil, fmt.Errorf("failed to reset to genesis block: %v", err)
+						} else {
+							_, err = calls.ExecuteCallSequenceWithExecutionTracer(testChain, fuzzer.contractDefinitions, []*calls.CallSequenceElement{cse}, true)
+							if err != nil {
+								return nil, fmt.Errorf("deploying %s returned a failed status: %v", instanceName, block.MessageResults[0].ExecutionResult.Err)
+							}
 						}
+
+						// Return the execution error and the execution trace, if possible.
+						return cse.ExecutionTrace, fmt.Errorf("deploying %s returned a failed status: %v", instanceName, block.MessageResults[0].ExecutionResult.Err)
 					}
 
-					// Return the execution error and the execution trace, if possible.
-					return cse.ExecutionTrace, fmt.Errorf("deploying %s returned a failed status: %v", contractName, block.MessageResults[0].ExecutionResult.Err)
+					// Record our deployed contract so the next config-specified constructor args can reference this
+					// contract by name.
+					deployedContractAddr[instanceName] = block.MessageResults[0].Receipt.ContractAddress
+					deployedContracts[instanceName] = contract
+					testChain.DeployedContractAddresses[instanceName] = block.MessageResults[0].Receipt.ContractAddress
 				}
 
-				// Record our deployed contract so the next config-specified constructor args can reference this
-				// contract by name.
-				deployedContractAddr[contractName] = block.MessageResults[0].Receipt.ContractAddress
-
 				// Flag that we found a matching compiled contract definition and deployed it, then exit out of this
 				// inner loop to process the next contract to deploy in the outer loop.
 				found = true
@@ -566,9 +748,118 @@ func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) (*ex
 			return nil, fmt.Errorf("%v was specified in the target contracts but was not found in the compilation artifacts", contractName)
 		}
 	}
+
+	// Now that all contracts are deployed, execute any configured setup calls.
+	return executeSetupCalls(fuzzer, testChain, deployedContractAddr, deployedContracts)
+}
+
+// executeSetupCalls executes the setup calls specified by the Fuzzer.config on the provided test chain, in order, each
+// in its own block. The provided maps of deployed contract names to their addresses and definitions are used to
+// resolve the contracts to call and any contract name references in their arguments.
+// Returns an error if a setup call could not be executed or did not succeed/revert as expected. If possible, an
+// execution trace of the offending call is returned alongside the error.
+func executeSetupCalls(fuzzer *Fuzzer, testChain *chain.TestChain, deployedContractAddr map[string]common.Address, deployedContracts map[string]*fuzzerTypes.Contract) (*executiontracer.ExecutionTrace, error) {
+	for _, setupCall := range fuzzer.config.Fuzzing.SetupCalls {
+		// Resolve the contract to call
+		contract, ok := deployedContracts[setupCall.Contract]
+		if !ok {
+			return nil, fmt.Errorf("setup call to %s.%s failed, the contract was not deployed", setupCall.Contract, setupCall.Method)
+		}
+		contractAddr := deployedContractAddr[setupCall.Contract]
+
+		// Resolve the method to call, matching it by signature or, if it is not overloaded, by name.
+		var method *abi.Method
+		for _, abiMethod := range contract.CompiledContract().Abi.Methods {
+			if abiMethod.Sig == setupCall.Method {
+				method = &abiMethod
+				break
+			}
+			if abiMethod.RawName == setupCall.Method {
+				if method != nil {
+					return nil, fmt.Errorf("setup call to %s.%s failed, the method is overloaded and must be specified by its signature", setupCall.Contract, setupCall.Method)
+				}
+				method = &abiMethod
+			}
+		}
+		if method == nil {
+			return nil, fmt.Errorf("setup call to %s.%s failed, the method was not found", setupCall.Contract, setupCall.Method)
+		}
+
+		// Decode the arguments for the call
+		args, err := valuegeneration.DecodeJSONArgumentsFromMap(method.Inputs, setupCall.Args, deployedContractAddr, false)
+		if err != nil {
+			return nil, fmt.Errorf("setup call to %s.%s failed, could not decode its arguments: %v", setupCall.Contract, setupCall.Method, err)
+		}
+
+		// Create a message to represent our setup call (as with deployments, we let it consume the whole block gas
+		// limit rather than use tx gas limit)
+		msg := calls.NewCallMessageWithAbiValueData(fuzzer.deployer, &contractAddr, 0, big.NewInt(0), fuzzer.config.Fuzzing.BlockGasLimit, nil, nil, nil, &calls.CallMessageDataAbiValues{
+			Method:      method,
+			InputValues: args,
+		})
+		msg.FillFromTestChainProperties(testChain)
+
+		// Create a new pending block, add our transaction to it, and commit it to the chain.
+		block, err := testChain.PendingBlockCreate()
+		if err != nil {
+			return nil, err
+		}
+		err = testChain.PendingBlockAddTx(msg.ToCoreMessage())
+		if err != nil {
+			return nil, err
+		}
+		err = testChain.PendingBlockCommit()
+		if err != nil {
+			return nil, err
+		}
+
+		// If the call succeeded or reverted as expected, move on to the next one.
+		reverted := block.MessageResults[0].Receipt.Status != types.ReceiptStatusSuccessful
+		if reverted == setupCall.ExpectRevert {
+			continue
+		}
+		var callErr error
+		if reverted {
+			callErr = fmt.Errorf("setup call to %s.%s was expected to succeed, but reverted: %v", setupCall.Contract, setupCall.Method, block.MessageResults[0].ExecutionResult.Err)
+		} else {
+			callErr = fmt.Errorf("setup call to %s.%s was expected to revert, but succeeded", setupCall.Contract, setupCall.Method)
+		}
+
+		// Revert to one block before and re-run the call with an execution tracer attached, so the execution trace
+		// can be provided to the user for debugging.
+		cse := calls.NewCallSequenceElement(contract, msg, 0, 0)
+		err = testChain.RevertToBlockIndex(uint64(len(testChain.CommittedBlocks()) - 1))
+		if err != nil {
+			return nil, callErr
+		}
+		_, err = calls.ExecuteCallSequenceWithExecutionTracer(testChain, fuzzer.contractDefinitions, []*calls.CallSequenceElement{cse}, true)
+		if err != nil {
+			return nil, callErr
+		}
+		return cse.ExecutionTrace, callErr
+	}
 	return nil, nil
 }
 
+// dumpDeployedBytecode writes the runtime bytecode of each contract deployed on the provided test chain while setting
+// it up to the provided directory, as a hex string in a file named after the contract.
+// Returns an error if one occurs.
+func dumpDeployedBytecode(testChain *chain.TestChain, directory string) error {
+	err := utils.MakeDirectory(directory)
+	if err != nil {
+		return err
+	}
+	for contractName, contractAddress := range testChain.DeployedContractAddresses {
+		code := testChain.State().GetCode(contractAddress)
+		filePath := filepath.Join(directory, contractName+".bin")
+		err = os.WriteFile(filePath, []byte(hexutil.Encode(code)), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write the deployed bytecode of %s: %v", contractName, err)
+		}
+	}
+	return nil
+}
+
 // defaultCallSequenceGeneratorConfigFunc is a NewCallSequenceGeneratorConfigFunc which creates a
 // CallSequenceGeneratorConfig with a default configuration. Returns the config or an error, if one occurs.
 func defaultCallSequenceGeneratorConfigFunc(fuzzer *Fuzzer, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (*CallSequenceGeneratorConfig, error) {
@@ -599,11 +890,16 @@ func defaultCallSequenceGeneratorConfigFunc(fuzzer *Fuzzer, valueSet *valuegener
 			GenerateRandomStringMaxSize: 100,
 		},
 	}
+	if fuzzer.config.Fuzzing.ZeroAddressProbability != nil {
+		zeroAddressProbability := float32(*fuzzer.config.Fuzzing.ZeroAddressProbability)
+		mutationalGeneratorConfig.ZeroAddressProbability = &zeroAddressProbability
+	}
 	mutationalGenerator := valuegeneration.NewMutationalValueGenerator(mutationalGeneratorConfig, valueSet, randomProvider)
 
 	// Create a sequence generator config which uses the created value generator.
 	sequenceGenConfig := &CallSequenceGeneratorConfig{
 		NewSequenceProbability:                   0.3,
+		FactoryCallProbability:                   float32(fuzzer.config.Fuzzing.FactoryCallProbability),
 		RandomUnmodifiedCorpusHeadWeight:         800,
 		RandomUnmodifiedCorpusTailWeight:         100,
 		RandomUnmodifiedSpliceAtRandomWeight:     200,
@@ -618,6 +914,25 @@ func defaultCallSequenceGeneratorConfigFunc(fuzzer *Fuzzer, valueSet *valuegener
 	return sequenceGenConfig, nil
 }
 
+// callSequenceGeneratorStrategyForWorker determines which call sequence generator strategy the worker at the provided
+// index should use. Strategies are assigned to contiguous ranges of worker indexes according to their configured
+// worker fraction. Returns the name of the assigned strategy (empty if the default hook should be used) and the
+// NewCallSequenceGeneratorConfigFunc to use, or an error if the strategy was not registered.
+func (f *Fuzzer) callSequenceGeneratorStrategyForWorker(workerIndex int) (string, NewCallSequenceGeneratorConfigFunc, error) {
+	cumulativeFraction := 0.0
+	for _, strategy := range f.config.Fuzzing.CallSequenceGeneratorStrategies {
+		cumulativeFraction += strategy.WorkerFraction
+		if workerIndex < int(math.Round(cumulativeFraction*float64(f.config.Fuzzing.Workers))) {
+			configFunc, ok := f.Hooks.NamedCallSequenceGeneratorConfigFuncs[strategy.Name]
+			if !ok || configFunc == nil {
+				return "", nil, fmt.Errorf("call sequence generator strategy '%s' has not been registered", strategy.Name)
+			}
+			return strategy.Name, configFunc, nil
+		}
+	}
+	return "", f.Hooks.NewCallSequenceGeneratorConfigFunc, nil
+}
+
 // defaultShrinkingValueMutatorFunc is a NewShrinkingValueMutatorFunc which creates value mutator to be used for
 // shrinking purposes. Returns the value mutator or an error, if one occurs.
 func defaultShrinkingValueMutatorFunc(fuzzer *Fuzzer, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (valuegeneration.ValueMutator, error) {
@@ -738,6 +1053,18 @@ func (f *Fuzzer) spawnWorkersLoop(baseTestChain *chain.TestChain) error {
 	return err
 }
 
+// initializeRandomProvider initializes the Fuzzer's random provider, seeding it with the configured random seed, or
+// with the current time if none was provided.
+// Returns the seed used.
+func (f *Fuzzer) initializeRandomProvider() int64 {
+	seed := time.Now().UnixNano()
+	if f.config.Fuzzing.RandomSeed != nil {
+		seed = *f.config.Fuzzing.RandomSeed
+	}
+	f.randomProvider = rand.New(rand.NewSource(seed))
+	return seed
+}
+
 // Start begins a fuzzing operation on the provided project configuration. This operation will not return until an error
 // is encountered or the fuzzing operation has completed. Its execution can be cancelled using the Stop method.
 // Returns an error if one is encountered.
@@ -745,8 +1072,10 @@ func (f *Fuzzer) Start() error {
 	// Define our variable to catch errors
 	var err error
 
-	// While we're fuzzing, we'll want to have an initialized random provider.
-	f.randomProvider = rand.New(rand.NewSource(time.Now().UnixNano()))
+	// While we're fuzzing, we'll want to have an initialized random provider. We report the seed used so the campaign
+	// can be reproduced.
+	seed := f.initializeRandomProvider()
+	f.logger.Info("Using random seed ", colors.Bold, seed, colors.Reset)
 
 	// Create our running context (allows us to cancel across threads)
 	f.ctx, f.ctxCancelFunc = context.WithCancel(context.Background())
@@ -767,11 +1096,15 @@ func (f *Fuzzer) Start() error {
 
 	// Initialize our metrics and valueGenerator.
 	f.metrics = newFuzzerMetrics(f.config.Fuzzing.Workers)
+	f.targetContractReached.Store(false)
+	f.targetContractsReachedChecked.Store(false)
+	f.targetReachabilitySequencesTested.Store(0)
 
 	// Initialize our test cases and providers
 	f.testCasesLock.Lock()
 	f.testCases = make([]TestCase, 0)
 	f.testCasesFinished = make(map[string]TestCase)
+	f.lastFailedTestCase = nil
 	f.testCasesLock.Unlock()
 
 	// Create our test chain
@@ -794,13 +1127,22 @@ func (f *Fuzzer) Start() error {
 	}
 	f.logger.Info("Finished setting up test chain")
 
+	// If configured, write the runtime bytecode of each contract deployed during setup to disk.
+	if f.config.Fuzzing.DumpDeployedBytecodeDir != "" {
+		err = dumpDeployedBytecode(baseTestChain, f.config.Fuzzing.DumpDeployedBytecodeDir)
+		if err != nil {
+			f.logger.Error("Failed to dump the deployed bytecode", err)
+			return err
+		}
+	}
+
 	// Initialize our coverage maps by measuring the coverage we get from the corpus.
 	var corpusActiveSequences, corpusTotalSequences int
 	if totalCallSequences, testResults := f.corpus.CallSequenceEntryCount(); totalCallSequences > 0 || testResults > 0 {
 		f.logger.Info("Running call sequences in the corpus")
 	}
 	startTime := time.Now()
-	corpusActiveSequences, corpusTotalSequences, err = f.corpus.Initialize(baseTestChain, f.contractDefinitions)
+	corpusActiveSequences, corpusTotalSequences, err = f.corpus.Initialize(baseTestChain, f.contractDefinitions, f.config.Fuzzing.FailOnCorpusLoadError, f.config.Fuzzing.PruneInvalidCorpus)
 	if corpusTotalSequences > 0 {
 		f.logger.Info("Finished running call sequences in the corpus in ", time.Since(startTime).Round(time.Second))
 	}
@@ -818,6 +1160,26 @@ func (f *Fuzzer) Start() error {
 		)
 	}
 
+	// Reset the calls tested in self-check mode, as they are counted per fuzzing campaign.
+	f.selfCheckCallsTested.Store(0)
+
+	// If parallel shrinking is enabled with multiple workers, create the queue workers submit shrinking candidates to.
+	f.parallelShrinkTasks = nil
+	f.parallelShrinkTasksAssisted.Store(0)
+	if f.config.Fuzzing.ParallelShrinking && f.config.Fuzzing.Workers > 1 {
+		f.parallelShrinkTasks = make(chan *parallelShrinkTask, f.config.Fuzzing.Workers*f.config.Fuzzing.Workers)
+	}
+
+	// If configured, create the files our workers write every call sequence they execute to.
+	if f.config.Fuzzing.TraceAllSequences {
+		err = f.createSequenceTraceFiles()
+		if err != nil {
+			f.logger.Error("Failed to create the sequence trace files", err)
+			return err
+		}
+		defer f.closeSequenceTraceFiles()
+	}
+
 	// Log the start of our fuzzing campaign.
 	f.logger.Info("Fuzzing with ", colors.Bold, f.config.Fuzzing.Workers, colors.Reset, " workers")
 
@@ -876,26 +1238,31 @@ func (f *Fuzzer) Start() error {
 		if f.config.Fuzzing.CorpusDirectory != "" {
 			coverageReportDir = filepath.Join(f.config.Fuzzing.CorpusDirectory, "coverage")
 		}
-		sourceAnalysis, err := coverage.AnalyzeSourceCoverage(f.compilations, f.corpus.CoverageMaps())
+		// Failing to generate coverage reports is logged, but does not fail the campaign.
+		_ = f.writeCoverageReports(f.corpus.CoverageMaps(), coverageReportDir)
+	}
 
-		if err != nil {
-			f.logger.Error("Failed to analyze source coverage", err)
-		} else {
-			var path string
-			for _, reportType := range f.config.Fuzzing.CoverageFormats {
-				switch reportType {
-				case "html":
-					path, err = coverage.WriteHTMLReport(sourceAnalysis, coverageReportDir)
-				case "lcov":
-					path, err = coverage.WriteLCOVReport(sourceAnalysis, coverageReportDir)
-				default:
-					err = fmt.Errorf("unsupported coverage report type: %s", reportType)
-				}
-				if err != nil {
-					f.logger.Error(fmt.Sprintf("Failed to generate %s coverage report", reportType), err)
-				} else {
-					f.logger.Info(fmt.Sprintf("%s report(s) saved to: %s", reportType, path), colors.Bold, colors.Reset)
-				}
+	// Generate our test reports if requested, in the same directory structure as our coverage reports.
+	if err == nil && len(f.config.Fuzzing.TestReportFormats) > 0 {
+		testReportDir := filepath.Join("crytic-export", "reports")
+		if f.config.Fuzzing.CorpusDirectory != "" {
+			testReportDir = filepath.Join(f.config.Fuzzing.CorpusDirectory, "reports")
+		}
+
+		var path string
+		for _, reportType := range f.config.Fuzzing.TestReportFormats {
+			switch reportType {
+			case "junit":
+				path, err = f.writeJUnitTestReport(testReportDir)
+			case "sarif":
+				path, err = f.writeSARIFTestReport(testReportDir)
+			default:
+				err = fmt.Errorf("unsupported test report type: %s", reportType)
+			}
+			if err != nil {
+				f.logger.Error(fmt.Sprintf("Failed to generate %s test report", reportType), err)
+			} else {
+				f.logger.Info(fmt.Sprintf("%s test report saved to: %s", reportType, path), colors.Bold, colors.Reset)
 			}
 		}
 	}
@@ -904,6 +1271,223 @@ func (f *Fuzzer) Start() error {
 	return err
 }
 
+// Replay sets up a test chain in the same way as Start, then executes the provided call sequence on it once rather
+// than fuzzing. Every test case is evaluated after each call, so the printed results indicate whether each test still
+// fails when the sequence is replayed. This allows a saved call sequence, such as a corpus entry, to be re-run against
+// the current code.
+// Returns the executed call sequence with an execution trace attached to each element, or an error if one occurred.
+func (f *Fuzzer) Replay(callSequence calls.CallSequence) (calls.CallSequence, error) {
+	tracedCallSequence, err := f.replayCallSequence(callSequence)
+	if err == nil {
+		if len(tracedCallSequence) < len(callSequence) {
+			f.logger.Info("Stopped replaying after call ", len(tracedCallSequence), " as a test failed")
+		}
+		f.logger.Info("Replayed call sequence:\n", tracedCallSequence.Log().ColorString())
+	}
+
+	// Print our results on exit.
+	f.printExitingResults()
+	return tracedCallSequence, err
+}
+
+// ExecuteSequence sets up a test chain in the same way as Start, then executes the provided call sequence on it once,
+// evaluating every test case after each call, without starting a fuzzing campaign. As when fuzzing, execution stops at
+// the first call which fails a test. This provides an entry point for tools which embed the Fuzzer to analyze a given
+// call sequence.
+// Returns the message results for each executed call, the test cases which failed, or an error if one occurred.
+func (f *Fuzzer) ExecuteSequence(callSequence calls.CallSequence) ([]*chainTypes.MessageResults, []TestCase, error) {
+	executedCallSequence, err := f.replayCallSequence(callSequence)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Collect the message results for each executed call.
+	messageResults := make([]*chainTypes.MessageResults, 0, len(executedCallSequence))
+	for _, element := range executedCallSequence {
+		messageResults = append(messageResults, element.ChainReference.MessageResults())
+	}
+	return messageResults, f.TestCasesWithStatus(TestCaseStatusFailed), nil
+}
+
+// replayCallSequence sets up a test chain in the same way as Start and executes the provided call sequence on a clone of
+// it with a single worker, evaluating every test case after each call and stopping at the first call which fails a
+// test. Events are published as they would be for a fuzzing campaign, so test cases are registered and finalized.
+// Returns the executed call sequence with an execution trace attached to each element, or an error if one occurred.
+func (f *Fuzzer) replayCallSequence(callSequence calls.CallSequence) (calls.CallSequence, error) {
+	// Define our variable to catch errors
+	var err error
+
+	// Initialize our random provider, running context, and metrics for the single worker replaying the sequence.
+	f.initializeRandomProvider()
+	f.ctx, f.ctxCancelFunc = context.WithCancel(context.Background())
+	defer f.ctxCancelFunc()
+	f.metrics = newFuzzerMetrics(1)
+
+	// Initialize our test cases and providers
+	f.testCasesLock.Lock()
+	f.testCases = make([]TestCase, 0)
+	f.testCasesFinished = make(map[string]TestCase)
+	f.lastFailedTestCase = nil
+	f.testCasesLock.Unlock()
+
+	// Create our test chain
+	baseTestChain, err := f.createTestChain()
+	if err != nil {
+		f.logger.Error("Failed to create the test chain", err)
+		return nil, err
+	}
+
+	// Set it up with our deployment/setup strategy defined by the fuzzer.
+	f.logger.Info("Setting up test chain")
+	trace, err := f.Hooks.ChainSetupFunc(f, baseTestChain)
+	if err != nil {
+		if trace != nil {
+			f.logger.Error("Failed to initialize the test chain", err, errors.New(trace.Log().ColorString()))
+		} else {
+			f.logger.Error("Failed to initialize the test chain", err)
+		}
+		return nil, err
+	}
+	f.logger.Info("Finished setting up test chain")
+
+	// Publish a fuzzer starting event, so our test cases are registered.
+	err = f.Events.FuzzerStarting.Publish(FuzzerStartingEvent{Fuzzer: f})
+	if err != nil {
+		f.logger.Error("FuzzerStarting event subscriber returned an error", err)
+		return nil, err
+	}
+
+	// Create a worker and replay the call sequence with it.
+	f.logger.Info("Replaying call sequence with ", colors.Bold, len(callSequence), colors.Reset, " call(s)")
+	worker, err := newFuzzerWorker(f, 0, f.randomProvider)
+	if err != nil {
+		f.logger.Error("Failed to create a worker to replay the call sequence", err)
+		return nil, err
+	}
+	f.workers = []*FuzzerWorker{worker}
+	err = f.Events.WorkerCreated.Publish(FuzzerWorkerCreatedEvent{Worker: worker})
+	if err != nil {
+		f.logger.Error("WorkerCreated event subscriber returned an error", err)
+		return nil, err
+	}
+	tracedCallSequence, err := worker.replay(baseTestChain, callSequence)
+	if err != nil {
+		f.logger.Error("Failed to replay the call sequence", err)
+	}
+
+	// NOTE: After this point, we capture errors but do not return immediately, as we want to exit gracefully.
+
+	// Publish events indicating our worker was destroyed and we are stopping.
+	workerDestroyedErr := f.Events.WorkerDestroyed.Publish(FuzzerWorkerDestroyedEvent{Worker: worker})
+	if err == nil && workerDestroyedErr != nil {
+		err = workerDestroyedErr
+		f.logger.Error("WorkerDestroyed event subscriber returned an error", err)
+	}
+	fuzzerStoppingErr := f.Events.FuzzerStopping.Publish(FuzzerStoppingEvent{Fuzzer: f, err: err})
+	if err == nil && fuzzerStoppingErr != nil {
+		err = fuzzerStoppingErr
+		f.logger.Error("FuzzerStopping event subscriber returned an error", err)
+	}
+	return tracedCallSequence, err
+}
+
+// MergeCoverage replays the call sequences of each of the provided corpus directories on a freshly set up test chain to
+// measure their coverage, then merges it and writes coverage reports describing the combined coverage to the provided
+// directory, in the formats specified by the Fuzzer.config. Program counters covered by several corpora are only
+// counted once, as each corpus only contributes coverage which was not already achieved by those merged before it.
+// Returns the merged coverage maps, or an error if one occurs.
+func (f *Fuzzer) MergeCoverage(corpusDirectories []string, reportDir string) (*coverage.CoverageMaps, error) {
+	// Verify we have reports to write before doing any work.
+	if len(f.config.Fuzzing.CoverageFormats) == 0 {
+		err := errors.New("no coverage report formats were specified to write the merged coverage in")
+		f.logger.Error("Failed to merge coverage", err)
+		return nil, err
+	}
+
+	// Create our test chain
+	baseTestChain, err := f.createTestChain()
+	if err != nil {
+		f.logger.Error("Failed to create the test chain", err)
+		return nil, err
+	}
+
+	// Set it up with our deployment/setup strategy defined by the fuzzer.
+	f.logger.Info("Setting up test chain")
+	trace, err := f.Hooks.ChainSetupFunc(f, baseTestChain)
+	if err != nil {
+		if trace != nil {
+			f.logger.Error("Failed to initialize the test chain", err, errors.New(trace.Log().ColorString()))
+		} else {
+			f.logger.Error("Failed to initialize the test chain", err)
+		}
+		return nil, err
+	}
+	f.logger.Info("Finished setting up test chain")
+
+	// Measure the coverage of each corpus and merge it into our total coverage.
+	mergedCoverageMaps := coverage.NewCoverageMaps()
+	for _, corpusDirectory := range corpusDirectories {
+		c, err := corpus.NewCorpus(corpusDirectory)
+		if err != nil {
+			f.logger.Error("Failed to load the corpus", err)
+			return nil, err
+		}
+		activeSequences, totalSequences, err := c.Initialize(baseTestChain, f.contractDefinitions, f.config.Fuzzing.FailOnCorpusLoadError, false)
+		if err != nil {
+			f.logger.Error("Failed to initialize the corpus", err)
+			return nil, err
+		}
+		_, _, err = mergedCoverageMaps.Update(c.CoverageMaps())
+		if err != nil {
+			f.logger.Error("Failed to merge the coverage of the corpus", err)
+			return nil, err
+		}
+		f.logger.Info("Merged coverage from ", colors.Bold, activeSequences, "/", totalSequences, colors.Reset, " call sequence(s) in ", corpusDirectory)
+	}
+
+	// Write our coverage reports for the merged coverage.
+	err = f.writeCoverageReports(mergedCoverageMaps, reportDir)
+	return mergedCoverageMaps, err
+}
+
+// writeCoverageReports analyzes the provided coverage maps against the fuzzer's compilations and writes coverage
+// reports to the provided directory, in the formats specified by the Fuzzer.config. Each report which could not be
+// generated is logged.
+// Returns the first error encountered, if any.
+func (f *Fuzzer) writeCoverageReports(coverageMaps *coverage.CoverageMaps, reportDir string) error {
+	sourceAnalysis, err := coverage.AnalyzeSourceCoverage(f.compilations, coverageMaps)
+	if err != nil {
+		f.logger.Error("Failed to analyze source coverage", err)
+		return err
+	}
+
+	var firstErr error
+	for _, reportType := range f.config.Fuzzing.CoverageFormats {
+		var path string
+		switch reportType {
+		case "html":
+			path, err = coverage.WriteHTMLReport(sourceAnalysis, reportDir)
+		case "lcov":
+			path, err = coverage.WriteLCOVReport(sourceAnalysis, reportDir)
+		case "cobertura":
+			path, err = coverage.WriteCoberturaReport(sourceAnalysis, reportDir)
+		case "json":
+			path, err = coverage.WriteJSONReport(sourceAnalysis, reportDir)
+		default:
+			err = fmt.Errorf("unsupported coverage report type: %s", reportType)
+		}
+		if err != nil {
+			f.logger.Error(fmt.Sprintf("Failed to generate %s coverage report", reportType), err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			f.logger.Info(fmt.Sprintf("%s report(s) saved to: %s", reportType, path), colors.Bold, colors.Reset)
+		}
+	}
+	return firstErr
+}
+
 // Stop stops a running operation invoked by the Start method. This method may return before complete operation teardown
 // occurs.
 func (f *Fuzzer) Stop() {
@@ -913,6 +1497,132 @@ func (f *Fuzzer) Stop() {
 	}
 }
 
+// SelfCheckProjectConfig returns a copy of the provided project configuration, adjusted so a fuzzing campaign run with it
+// produces the same results each time it is run, for use in self-check mode. Campaigns use a single worker, a fixed
+// random seed (chosen now if one is not set), no timeout or coverage plateau, and no corpus directory, so one run cannot
+// influence another.
+// Returns the adjusted project configuration, or an error if no test limit is set, as the campaign would otherwise not
+// stop after the same call each time.
+func SelfCheckProjectConfig(projectConfig config.ProjectConfig) (config.ProjectConfig, error) {
+	if projectConfig.Fuzzing.TestLimit == 0 {
+		return projectConfig, errors.New("self-check mode requires a test limit to be set")
+	}
+	seed := time.Now().UnixNano()
+	if projectConfig.Fuzzing.RandomSeed != nil {
+		seed = *projectConfig.Fuzzing.RandomSeed
+	}
+	projectConfig.Fuzzing.RandomSeed = &seed
+	projectConfig.Fuzzing.Workers = 1
+	projectConfig.Fuzzing.Timeout = 0
+	projectConfig.Fuzzing.StopOnCoveragePlateau = 0
+	projectConfig.Fuzzing.CorpusDirectory = ""
+	projectConfig.Fuzzing.ParallelShrinking = false
+	return projectConfig, nil
+}
+
+// EnableSelfCheck configures the Fuzzer to run campaigns in self-check mode, where workers stop the campaign once the
+// test limit is reached, after the call sequence which reached it was fully processed. Otherwise, the test limit is
+// enforced asynchronously, so campaigns may stop after a different call each time. This should be called before Start,
+// with a Fuzzer created from a project configuration returned by SelfCheckProjectConfig.
+func (f *Fuzzer) EnableSelfCheck() {
+	f.selfCheck = true
+}
+
+// selfCheckTestLimitReached indicates whether the Fuzzer runs campaigns in self-check mode, and its workers have tested
+// as many calls as the test limit allows.
+func (f *Fuzzer) selfCheckTestLimitReached() bool {
+	testLimit := f.config.Fuzzing.TestLimit
+	return f.selfCheck && testLimit > 0 && f.selfCheckCallsTested.Load() >= testLimit
+}
+
+// CompareCampaignResults compares the results of the fuzzing campaign last run by this Fuzzer to those of the campaign
+// last run by the provided Fuzzer, as is done in self-check mode to detect non-determinism. The results match if both
+// campaigns achieved the same coverage and the same test cases failed in each.
+// Returns an error describing how the results diverged, or nil if they match.
+func (f *Fuzzer) CompareCampaignResults(other *Fuzzer) error {
+	// Compare the IDs of the test cases which failed in each campaign.
+	failedTestCaseIds := func(fuzzer *Fuzzer) []string {
+		ids := make([]string, 0)
+		for _, testCase := range fuzzer.TestCasesWithStatus(TestCaseStatusFailed) {
+			ids = append(ids, testCase.ID())
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	failedIds, otherFailedIds := failedTestCaseIds(f), failedTestCaseIds(other)
+	if !slices.Equal(failedIds, otherFailedIds) {
+		return fmt.Errorf("failed test cases diverged: [%s] vs [%s]", strings.Join(failedIds, ", "), strings.Join(otherFailedIds, ", "))
+	}
+
+	// Compare the coverage achieved by each campaign. We compare in both directions, as each comparison only checks
+	// the contracts covered by the receiver.
+	coverageMaps, otherCoverageMaps := f.corpus.CoverageMaps(), other.corpus.CoverageMaps()
+	if !coverageMaps.Equal(otherCoverageMaps) || !otherCoverageMaps.Equal(coverageMaps) {
+		return fmt.Errorf("coverage diverged: %d vs %d unique instructions covered", coverageMaps.UniquePCs(), otherCoverageMaps.UniquePCs())
+	}
+	return nil
+}
+
+// isUnexpectedRevert checks whether the provided execution result reverted with a revert which was not configured as
+// expected in the project configuration. Expected reverts are matched by their leading error selector or by their
+// revert reason string.
+// Expected reverts are neither counted as reverted calls, nor treated as failures by any revert failure policies.
+// Returns a boolean indicating whether the execution result reverted unexpectedly.
+func (f *Fuzzer) isUnexpectedRevert(executionResult *core.ExecutionResult) bool {
+	// If execution did not revert, there is nothing to check.
+	if executionResult == nil || !errors.Is(executionResult.Err, vm.ErrExecutionReverted) {
+		return false
+	}
+
+	// Obtain the revert reason string, if one was returned.
+	revertReason := abiutils.GetSolidityRevertErrorString(executionResult.Err, executionResult.ReturnData)
+
+	// Check each expected revert against the selector or revert reason.
+	for _, expectedRevert := range f.config.Fuzzing.ExpectedReverts {
+		if strings.HasPrefix(expectedRevert, "0x") {
+			selector, err := hexutil.Decode(expectedRevert)
+			if err == nil && len(executionResult.ReturnData) >= 4 && bytes.Equal(executionResult.ReturnData[:4], selector) {
+				return false
+			}
+		} else if revertReason != nil && *revertReason == expectedRevert {
+			return false
+		}
+	}
+	return true
+}
+
+// checkTargetContractsReached checks whether any call to a target contract has succeeded once the configured number of
+// call sequences has been tested, logging a warning if none has, as this typically indicates an issue with the test
+// harness (e.g. all calls reverting in a guard). The check is performed at most once per fuzzing campaign, and not at
+// all if the grace period is zero. It is called by workers each time they finish testing a call sequence.
+func (f *Fuzzer) checkTargetContractsReached() {
+	// If the check was already performed, there is nothing to do. Once the check is disabled or a target contract was
+	// reached, we mark it as performed so workers stop checking.
+	if f.targetContractsReachedChecked.Load() {
+		return
+	}
+	gracePeriod := f.config.Fuzzing.TargetReachabilityGracePeriod
+	if gracePeriod == 0 || f.targetContractReached.Load() {
+		f.targetContractsReachedChecked.Store(true)
+		return
+	}
+
+	// If our grace period has not elapsed, there is nothing to do yet.
+	sequencesTested := f.targetReachabilitySequencesTested.Add(1)
+	if sequencesTested < gracePeriod {
+		return
+	}
+
+	// Only one worker should perform the check. If a target contract was successfully called, the harness is reaching
+	// its targets.
+	if !f.targetContractsReachedChecked.CompareAndSwap(false, true) || f.targetContractReached.Load() {
+		return
+	}
+	f.logger.Warn(colors.Bold, fmt.Sprintf("No call to a target contract (%s) has succeeded after %d call sequences. ",
+		strings.Join(f.config.Fuzzing.TargetContracts, ", "), sequencesTested), colors.Reset,
+		"This may indicate an issue with the test harness, such as calls always reverting in a guard or failed assumption.")
+}
+
 // printMetricsLoop prints metrics to the console in a loop until ctx signals a stopped operation.
 func (f *Fuzzer) printMetricsLoop() {
 	// Define our start time
@@ -924,10 +1634,15 @@ func (f *Fuzzer) printMetricsLoop() {
 	lastWorkerStartupCount := big.NewInt(0)
 	lastGasUsed := big.NewInt(0)
 
+	// Define variables to track when coverage last increased, to detect a coverage plateau.
+	lastCoverage := uint64(0)
+	sequencesTestedAtLastCoverage := big.NewInt(0)
+
 	lastPrintedTime := time.Time{}
 	for !utils.CheckContextDone(f.ctx) {
 		// Obtain our metrics
 		callsTested := f.metrics.CallsTested()
+		callsDiscarded := f.metrics.CallsDiscarded()
 		sequencesTested := f.metrics.SequencesTested()
 		gasUsed := f.metrics.GasUsed()
 		failedSequences := f.metrics.FailedSequences()
@@ -949,9 +1664,13 @@ func (f *Fuzzer) printMetricsLoop() {
 		logBuffer.Append("elapsed: ", colors.Bold, time.Since(startTime).Round(time.Second).String(), colors.Reset)
 		logBuffer.Append(", calls: ", colors.Bold, fmt.Sprintf("%d (%d/sec)", callsTested, uint64(float64(new(big.Int).Sub(callsTested, lastCallsTested).Uint64())/secondsSinceLastUpdate)), colors.Reset)
 		logBuffer.Append(", seq/s: ", colors.Bold, fmt.Sprintf("%d", uint64(float64(new(big.Int).Sub(sequencesTested, lastSequencesTested).Uint64())/secondsSinceLastUpdate)), colors.Reset)
-		logBuffer.Append(", coverage: ", colors.Bold, fmt.Sprintf("%d", f.corpus.CoverageMaps().UniquePCs()), colors.Reset)
+		coverageUniquePCs := f.corpus.CoverageMaps().UniquePCs()
+		logBuffer.Append(", coverage: ", colors.Bold, fmt.Sprintf("%d", coverageUniquePCs), colors.Reset)
 		logBuffer.Append(", corpus: ", colors.Bold, fmt.Sprintf("%d", f.corpus.ActiveMutableSequenceCount()), colors.Reset)
 		logBuffer.Append(", failures: ", colors.Bold, fmt.Sprintf("%d/%d", failedSequences, sequencesTested), colors.Reset)
+		if callsDiscarded.Sign() > 0 {
+			logBuffer.Append(", discarded: ", colors.Bold, fmt.Sprintf("%d", callsDiscarded), colors.Reset)
+		}
 		logBuffer.Append(", gas/s: ", colors.Bold, fmt.Sprintf("%d", uint64(float64(new(big.Int).Sub(gasUsed, lastGasUsed).Uint64())/secondsSinceLastUpdate)), colors.Reset)
 		if f.logger.Level() <= zerolog.DebugLevel {
 			logBuffer.Append(", shrinking: ", colors.Bold, fmt.Sprintf("%v", workersShrinking), colors.Reset)
@@ -967,14 +1686,34 @@ func (f *Fuzzer) printMetricsLoop() {
 		lastGasUsed = gasUsed
 		lastWorkerStartupCount = workerStartupCount
 
-		// If we reached our transaction threshold, halt
+		// If we reached our transaction threshold, halt. In self-check mode, workers enforce the test limit instead.
 		testLimit := f.config.Fuzzing.TestLimit
-		if testLimit > 0 && (!callsTested.IsUint64() || callsTested.Uint64() >= testLimit) {
+		if testLimit > 0 && !f.selfCheck && (!callsTested.IsUint64() || callsTested.Uint64() >= testLimit) {
 			f.logger.Info("Transaction test limit reached, halting now...")
 			f.Stop()
 			break
 		}
 
+		// If coverage has not increased within our configured window of call sequences, halt
+		if coverageUniquePCs > lastCoverage {
+			lastCoverage = coverageUniquePCs
+			sequencesTestedAtLastCoverage = sequencesTested
+		} else if plateauLimit := f.config.Fuzzing.StopOnCoveragePlateau; plateauLimit > 0 {
+			sequencesWithoutCoverage := new(big.Int).Sub(sequencesTested, sequencesTestedAtLastCoverage)
+			if !sequencesWithoutCoverage.IsUint64() || sequencesWithoutCoverage.Uint64() >= plateauLimit {
+				f.logger.Info("No new coverage was achieved in the last ", sequencesWithoutCoverage, " call sequences, halting now...")
+				f.Stop()
+				break
+			}
+		}
+
+		// If a user-defined stopping condition was met, halt
+		if f.Hooks.ShouldStopFunc != nil && f.Hooks.ShouldStopFunc(f) {
+			f.logger.Info("Stopping condition met, halting now...")
+			f.Stop()
+			break
+		}
+
 		// Sleep some time between print iterations
 		time.Sleep(time.Second * 3)
 	}
@@ -985,9 +1724,10 @@ func (f *Fuzzer) printExitingResults() {
 	// Define the order our test cases should be sorted by when considering status.
 	testCaseDisplayOrder := map[TestCaseStatus]int{
 		TestCaseStatusNotStarted: 0,
-		TestCaseStatusPassed:     1,
-		TestCaseStatusFailed:     2,
-		TestCaseStatusRunning:    3,
+		TestCaseStatusSkipped:    1,
+		TestCaseStatusPassed:     2,
+		TestCaseStatusFailed:     3,
+		TestCaseStatusRunning:    4,
 	}
 
 	// Sort the test cases by status and then ID.
@@ -1005,8 +1745,9 @@ func (f *Fuzzer) printExitingResults() {
 
 	// Define variables to track our final test count.
 	var (
-		testCountPassed int
-		testCountFailed int
+		testCountPassed  int
+		testCountFailed  int
+		testCountSkipped int
 	)
 
 	// Print the results of each individual test case.
@@ -1014,14 +1755,278 @@ func (f *Fuzzer) printExitingResults() {
 	for _, testCase := range f.testCases {
 		f.logger.Info(testCase.LogMessage().ColorString())
 
-		// Tally our pass/fail count.
+		// Tally our pass/fail/skip count.
 		if testCase.Status() == TestCaseStatusPassed {
 			testCountPassed++
 		} else if testCase.Status() == TestCaseStatusFailed {
 			testCountFailed++
+		} else if testCase.Status() == TestCaseStatusSkipped {
+			testCountSkipped++
 		}
 	}
 
 	// Print our final tally of test statuses.
-	f.logger.Info("Test summary: ", colors.GreenBold, testCountPassed, colors.Reset, " test(s) passed, ", colors.RedBold, testCountFailed, colors.Reset, " test(s) failed")
+	f.logger.Info("Test summary: ", colors.GreenBold, testCountPassed, colors.Reset, " test(s) passed, ", colors.RedBold, testCountFailed, colors.Reset, " test(s) failed, ", colors.YellowBold, testCountSkipped, colors.Reset, " test(s) skipped")
+
+	// Print any counters and gauges recorded through cheat codes, sorted by name.
+	if f.metrics == nil {
+		return
+	}
+	counters := f.metrics.CustomMetrics().Counters()
+	gauges := f.metrics.CustomMetrics().Gauges()
+	if len(counters) == 0 && len(gauges) == 0 {
+		return
+	}
+	f.logger.Info("Custom metrics recorded during fuzzing follow below ...")
+	counterNames := maps.Keys(counters)
+	sort.Strings(counterNames)
+	for _, name := range counterNames {
+		f.logger.Info("[counter] ", colors.Bold, name, colors.Reset, ": ", counters[name])
+	}
+	gaugeNames := maps.Keys(gauges)
+	sort.Strings(gaugeNames)
+	for _, name := range gaugeNames {
+		f.logger.Info("[gauge] ", colors.Bold, name, colors.Reset, ": ", gauges[name].String())
+	}
+}
+
+// createSequenceTraceFiles creates a file for each worker index to write every call sequence it executes to,
+// overwriting any files left by a previous fuzzing campaign. The files are written to the corpus directory if one is
+// set, otherwise to the crytic-export directory.
+// Returns an error if one occurred.
+func (f *Fuzzer) createSequenceTraceFiles() error {
+	sequenceTraceDir := filepath.Join("crytic-export", "sequence_traces")
+	if f.config.Fuzzing.CorpusDirectory != "" {
+		sequenceTraceDir = filepath.Join(f.config.Fuzzing.CorpusDirectory, "sequence_traces")
+	}
+
+	f.sequenceTraceFiles = make([]*os.File, f.config.Fuzzing.Workers)
+	for i := 0; i < len(f.sequenceTraceFiles); i++ {
+		file, err := utils.CreateFile(sequenceTraceDir, fmt.Sprintf("worker-%d.log", i))
+		if err != nil {
+			f.closeSequenceTraceFiles()
+			return err
+		}
+		f.sequenceTraceFiles[i] = file
+	}
+	return nil
+}
+
+// closeSequenceTraceFiles closes any files created by createSequenceTraceFiles.
+func (f *Fuzzer) closeSequenceTraceFiles() {
+	for _, file := range f.sequenceTraceFiles {
+		if file != nil {
+			_ = file.Close()
+		}
+	}
+	f.sequenceTraceFiles = nil
+}
+
+// junitTestSuite describes the root element of a JUnit XML test report, holding a result for each TestCase.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase describes the result of a single TestCase in a JUnit XML test report.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+// junitFailure describes the failure of a TestCase in a JUnit XML test report.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitTestReport writes a JUnit XML test report with a test case element for each registered TestCase to the
+// provided directory. Failed test cases contain a failure element with the test case's message, which includes the
+// call sequence that caused the failure. Test cases which never started or were skipped are marked as skipped.
+// Returns the path to the written report, or an error if one occurred.
+func (f *Fuzzer) writeJUnitTestReport(directory string) (string, error) {
+	f.testCasesLock.Lock()
+	testSuite := junitTestSuite{
+		Name:      "medusa",
+		Tests:     len(f.testCases),
+		TestCases: make([]junitTestCase, 0, len(f.testCases)),
+	}
+	for _, testCase := range f.testCases {
+		junitCase := junitTestCase{
+			Name:      testCase.Name(),
+			ClassName: testCase.ID(),
+		}
+		switch testCase.Status() {
+		case TestCaseStatusFailed:
+			junitCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%s failed", testCase.Name()),
+				Text:    testCase.Message(),
+			}
+			testSuite.Failures++
+		case TestCaseStatusNotStarted, TestCaseStatusSkipped:
+			junitCase.Skipped = &struct{}{}
+			testSuite.Skipped++
+		}
+		testSuite.TestCases = append(testSuite.TestCases, junitCase)
+	}
+	f.testCasesLock.Unlock()
+
+	// Encode our report and write it to disk.
+	b, err := xml.MarshalIndent(testSuite, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	err = utils.MakeDirectory(directory)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(directory, "junit.xml")
+	err = os.WriteFile(path, append([]byte(xml.Header), b...), 0644)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// sarifLog describes the root object of a SARIF test report, holding a single run of the fuzzer.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun describes a run of the fuzzer in a SARIF test report, holding a result for each failed TestCase.
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// sarifTool describes the tool which produced a SARIF test report, along with a rule for each failed TestCase.
+type sarifTool struct {
+	Driver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	} `json:"driver"`
+}
+
+// sarifRule describes a TestCase which results in a SARIF test report refer to.
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+// sarifResult describes a failed TestCase in a SARIF test report.
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+// sarifMessage describes a text message in a SARIF test report.
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation describes the source file and line a result in a SARIF test report refers to.
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// testCaseDefinitionLocation resolves the source file path and line number of the method a TestCase tests, or of the
+// contract it tests if it does not target a specific method.
+// Returns the source path, the line number (1-based), and a boolean indicating whether the location was resolved.
+func testCaseDefinitionLocation(testCase TestCase) (string, int, bool) {
+	var contract *fuzzerTypes.Contract
+	var methodName string
+	switch t := testCase.(type) {
+	case *AssertionTestCase:
+		contract, methodName = t.targetContract, t.targetMethod.RawName
+	case *PropertyTestCase:
+		contract, methodName = t.targetContract, t.targetMethod.RawName
+	case *OptimizationTestCase:
+		contract, methodName = t.targetContract, t.targetMethod.RawName
+	case *ERC20SupplyTestCase:
+		contract = t.targetContract
+	}
+	if contract == nil || contract.Compilation() == nil {
+		return "", 0, false
+	}
+	return contract.Compilation().GetDefinitionLocation(contract.Name(), methodName)
+}
+
+// writeSARIFTestReport writes a SARIF test report with a result for each failed TestCase to the provided directory, so
+// failures can be surfaced by code scanning tools. Each result refers to the source file and line at which the tested
+// method is defined, and contains the test case's message, which includes the call sequence that caused the failure.
+// Returns the path to the written report, or an error if one occurred.
+func (f *Fuzzer) writeSARIFTestReport(directory string) (string, error) {
+	run := sarifRun{
+		Results: make([]sarifResult, 0),
+	}
+	run.Tool.Driver.Name = "medusa"
+	run.Tool.Driver.InformationURI = "https://github.com/crytic/medusa"
+	run.Tool.Driver.Rules = make([]sarifRule, 0)
+
+	for _, testCase := range f.TestCasesWithStatus(TestCaseStatusFailed) {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               testCase.ID(),
+			ShortDescription: sarifMessage{Text: testCase.Name()},
+		})
+		result := sarifResult{
+			RuleID:  testCase.ID(),
+			Level:   "error",
+			Message: sarifMessage{Text: testCase.Message()},
+		}
+
+		// Code scanning tools expect paths relative to the repository, so we make absolute paths relative to our
+		// working directory where possible.
+		if sourcePath, lineNumber, ok := testCaseDefinitionLocation(testCase); ok {
+			if filepath.IsAbs(sourcePath) {
+				if workingDirectory, err := os.Getwd(); err == nil {
+					if relativePath, err := filepath.Rel(workingDirectory, sourcePath); err == nil {
+						sourcePath = relativePath
+					}
+				}
+			}
+			var location sarifLocation
+			location.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(sourcePath)
+			location.PhysicalLocation.Region.StartLine = lineNumber
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	// Encode our report and write it to disk.
+	b, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	err = utils.MakeDirectory(directory)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(directory, "results.sarif")
+	err = os.WriteFile(path, b, 0644)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
 }
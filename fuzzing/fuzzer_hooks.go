@@ -18,6 +18,11 @@ type FuzzerHooks struct {
 	// avoid concurrent access issues between workers.
 	NewCallSequenceGeneratorConfigFunc NewCallSequenceGeneratorConfigFunc
 
+	// NamedCallSequenceGeneratorConfigFuncs describes a registry of named NewCallSequenceGeneratorConfigFunc
+	// strategies. Workers assigned a strategy through the project configuration use the function registered under its
+	// name, while the remaining workers use NewCallSequenceGeneratorConfigFunc.
+	NamedCallSequenceGeneratorConfigFuncs map[string]NewCallSequenceGeneratorConfigFunc
+
 	// NewShrinkingValueMutatorFunc describes the function used to set up a value mutator used to shrink call
 	// values in the fuzzer's call sequence shrinking process.
 	// The value mutator provided must be either thread safe, or a new instance must be provided per invocation to
@@ -30,6 +35,10 @@ type FuzzerHooks struct {
 	// CallSequenceTestFuncs describes a list of functions to be called upon by a FuzzerWorker after every call
 	// in a call sequence. These must not commit to state
 	CallSequenceTestFuncs []CallSequenceTestFunc
+
+	// ShouldStopFunc describes an optional function evaluated periodically while fuzzing (alongside metrics updates),
+	// which stops the fuzzer if it returns true.
+	ShouldStopFunc ShouldStopFunc
 }
 
 // NewShrinkingValueMutatorFunc describes the function used to set up a value mutator used to shrink call
@@ -46,6 +55,10 @@ type NewCallSequenceGeneratorConfigFunc func(fuzzer *Fuzzer, valueSet *valuegene
 // An execution trace can also be returned in case of a deployment error for an improved debugging experience
 type TestChainSetupFunc func(fuzzer *Fuzzer, testChain *chain.TestChain) (*executiontracer.ExecutionTrace, error)
 
+// ShouldStopFunc describes a user-defined stopping condition for a fuzzing campaign.
+// Returns a boolean indicating whether the Fuzzer should stop.
+type ShouldStopFunc func(fuzzer *Fuzzer) bool
+
 // CallSequenceTestFunc defines a method called after a fuzzing.FuzzerWorker sends another call in a types.CallSequence
 // during a fuzzing campaign. It returns a ShrinkCallSequenceRequest set, which represents a set of requests for
 // shrunken call sequences alongside verifiers to guide the shrinking process. This signals to the FuzzerWorker
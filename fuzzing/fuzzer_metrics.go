@@ -1,12 +1,19 @@
 package fuzzing
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/crytic/medusa/chain"
+)
 
 // FuzzerMetrics represents a struct tracking metrics for a Fuzzer run.
 type FuzzerMetrics struct {
 	// workerMetrics describes the metrics for each individual worker. This expands as needed and some slots may be nil
 	// while workers are initializing, as it corresponds to the indexes in Fuzzer.workers.
 	workerMetrics []fuzzerWorkerMetrics
+
+	// customMetrics describes the counters and gauges recorded through cheat codes, aggregated across all workers.
+	customMetrics *chain.CustomMetrics
 }
 
 // fuzzerWorkerMetrics represents metrics for a single FuzzerWorker instance.
@@ -20,6 +27,14 @@ type fuzzerWorkerMetrics struct {
 	// callsTested is the amount of transactions/calls the fuzzer executed and ran tests against.
 	callsTested *big.Int
 
+	// callsReverted is the amount of transactions/calls the fuzzer executed which reverted, excluding any reverts
+	// which were configured as expected.
+	callsReverted *big.Int
+
+	// callsDiscarded is the amount of transactions/calls the fuzzer executed which were discarded, as an assumption
+	// they made failed.
+	callsDiscarded *big.Int
+
 	// gasUsed is the amount of gas the fuzzer executed and ran tests against.
 	gasUsed *big.Int
 
@@ -36,11 +51,14 @@ func newFuzzerMetrics(workerCount int) *FuzzerMetrics {
 	// Create a new metrics struct and return it with as many slots as required.
 	metrics := FuzzerMetrics{
 		workerMetrics: make([]fuzzerWorkerMetrics, workerCount),
+		customMetrics: chain.NewCustomMetrics(),
 	}
 	for i := 0; i < len(metrics.workerMetrics); i++ {
 		metrics.workerMetrics[i].sequencesTested = big.NewInt(0)
 		metrics.workerMetrics[i].failedSequences = big.NewInt(0)
 		metrics.workerMetrics[i].callsTested = big.NewInt(0)
+		metrics.workerMetrics[i].callsReverted = big.NewInt(0)
+		metrics.workerMetrics[i].callsDiscarded = big.NewInt(0)
 		metrics.workerMetrics[i].workerStartupCount = big.NewInt(0)
 		metrics.workerMetrics[i].gasUsed = big.NewInt(0)
 	}
@@ -74,6 +92,26 @@ func (m *FuzzerMetrics) CallsTested() *big.Int {
 	return transactionsTested
 }
 
+// CallsReverted returns the amount of transactions/calls the fuzzer executed which reverted, excluding any expected
+// reverts.
+func (m *FuzzerMetrics) CallsReverted() *big.Int {
+	callsReverted := big.NewInt(0)
+	for _, workerMetrics := range m.workerMetrics {
+		callsReverted.Add(callsReverted, workerMetrics.callsReverted)
+	}
+	return callsReverted
+}
+
+// CallsDiscarded returns the amount of transactions/calls the fuzzer executed which were discarded due to failed
+// assumptions. These are not counted as calls tested.
+func (m *FuzzerMetrics) CallsDiscarded() *big.Int {
+	callsDiscarded := big.NewInt(0)
+	for _, workerMetrics := range m.workerMetrics {
+		callsDiscarded.Add(callsDiscarded, workerMetrics.callsDiscarded)
+	}
+	return callsDiscarded
+}
+
 func (m *FuzzerMetrics) GasUsed() *big.Int {
 	gasUsed := big.NewInt(0)
 	for _, workerMetrics := range m.workerMetrics {
@@ -102,3 +140,9 @@ func (m *FuzzerMetrics) WorkersShrinkingCount() uint64 {
 	}
 	return shrinkingCount
 }
+
+// CustomMetrics returns the counters and gauges recorded by the incrementCounter and setGauge cheat codes, aggregated
+// across all workers.
+func (m *FuzzerMetrics) CustomMetrics() *chain.CustomMetrics {
+	return m.customMetrics
+}
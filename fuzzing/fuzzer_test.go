@@ -2,19 +2,33 @@ package fuzzing
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"github.com/crytic/medusa/utils"
 	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/crytic/medusa/fuzzing/executiontracer"
 
 	"github.com/crytic/medusa/chain"
+	chainConfig "github.com/crytic/medusa/chain/config"
+	"github.com/crytic/medusa/compilation"
+	"github.com/crytic/medusa/compilation/platforms"
 	"github.com/crytic/medusa/events"
 	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
+	"github.com/crytic/medusa/logging"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/crytic/medusa/fuzzing/config"
 	"github.com/stretchr/testify/assert"
@@ -63,6 +77,201 @@ func TestFuzzerHooks(t *testing.T) {
 	})
 }
 
+// TestFuzzerStopOnCoveragePlateau runs a test to ensure that the fuzzer stops once no new coverage was achieved for the
+// configured number of call sequences.
+func TestFuzzerStopOnCoveragePlateau(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 0 // the coverage plateau should be the only thing stopping the fuzzer.
+			config.Fuzzing.Timeout = 60  // to be safe, we set a timeout in case the plateau does not stop it.
+			config.Fuzzing.StopOnCoveragePlateau = 100
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer and measure how long it ran.
+			startTime := time.Now()
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// The contract's coverage is achieved quickly, so the fuzzer should stop well before the timeout.
+			assert.Less(t, time.Since(startTime), 30*time.Second)
+			assert.GreaterOrEqual(t, f.fuzzer.Metrics().SequencesTested().Uint64(), uint64(100))
+		},
+	})
+}
+
+// TestFuzzerShouldStopHook runs a test to ensure that a user-defined stopping condition provided through the fuzzer
+// hooks stops the fuzzer once it is met.
+func TestFuzzerShouldStopHook(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 0 // the hook should be the only thing stopping the fuzzer.
+			config.Fuzzing.Timeout = 60  // to be safe, we set a timeout in case the hook does not stop it.
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Attach a hook which stops the fuzzer after a fixed number of sequences.
+			sequenceLimit := big.NewInt(100)
+			stopped := false
+			f.fuzzer.Hooks.ShouldStopFunc = func(fuzzer *Fuzzer) bool {
+				stopped = fuzzer.Metrics().SequencesTested().Cmp(sequenceLimit) >= 0
+				return stopped
+			}
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Assert that our hook stopped the fuzzer once the sequence limit was reached.
+			assert.True(t, stopped, "fuzzer was not stopped by the hook")
+			assert.GreaterOrEqual(t, f.fuzzer.Metrics().SequencesTested().Cmp(sequenceLimit), 0)
+		},
+	})
+}
+
+// TestFuzzerWorkerResetLimit runs a test to ensure that workers are reset after testing exactly the configured
+// amount of call sequences.
+func TestFuzzerWorkerResetLimit(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 1
+			config.Fuzzing.WorkerResetLimit = 7
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Count the call sequences tested by each worker instance, in the order they were created.
+			var sequenceCountsLock sync.Mutex
+			sequenceCounts := make([]int, 0)
+			f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+				sequenceCountsLock.Lock()
+				defer sequenceCountsLock.Unlock()
+				workerInstance := len(sequenceCounts)
+				sequenceCounts = append(sequenceCounts, 0)
+				event.Worker.Events.CallSequenceTested.Subscribe(func(event FuzzerWorkerCallSequenceTestedEvent) error {
+					sequenceCountsLock.Lock()
+					defer sequenceCountsLock.Unlock()
+					sequenceCounts[workerInstance]++
+					return nil
+				})
+				return nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Every worker instance but the last should have been reset after testing exactly the configured amount
+			// of call sequences, while the last may have been stopped early.
+			sequenceCountsLock.Lock()
+			defer sequenceCountsLock.Unlock()
+			assert.Greater(t, len(sequenceCounts), 1)
+			workerStartupCount := int(f.fuzzer.metrics.WorkerStartupCount().Uint64())
+			assert.LessOrEqual(t, workerStartupCount, len(sequenceCounts))
+			assert.GreaterOrEqual(t, workerStartupCount, len(sequenceCounts)-1)
+			for i, sequenceCount := range sequenceCounts {
+				if i < len(sequenceCounts)-1 {
+					assert.EqualValues(t, f.fuzzer.config.Fuzzing.WorkerResetLimit, sequenceCount)
+				} else {
+					assert.LessOrEqual(t, sequenceCount, f.fuzzer.config.Fuzzing.WorkerResetLimit)
+				}
+			}
+		},
+	})
+}
+
+// TestFuzzerNamedCallSequenceGenerators runs tests to ensure that named call sequence generator strategies registered
+// in the fuzzer hooks are assigned to workers according to the configured worker fractions.
+func TestFuzzerNamedCallSequenceGenerators(t *testing.T) {
+	strategies := []config.CallSequenceGeneratorStrategyConfig{
+		{Name: "aggressive", WorkerFraction: 0.5},
+		{Name: "conservative", WorkerFraction: 0.25},
+	}
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 4
+			config.Fuzzing.CallSequenceGeneratorStrategies = strategies
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Register an aggressive splicing strategy and a conservative strategy, both derived from the default.
+			var strategyLock sync.Mutex
+			strategyInvocations := make(map[string]int)
+			f.fuzzer.Hooks.NamedCallSequenceGeneratorConfigFuncs["aggressive"] = func(fuzzer *Fuzzer, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (*CallSequenceGeneratorConfig, error) {
+				strategyLock.Lock()
+				strategyInvocations["aggressive"]++
+				strategyLock.Unlock()
+				genConfig, err := defaultCallSequenceGeneratorConfigFunc(fuzzer, valueSet, randomProvider)
+				if err != nil {
+					return nil, err
+				}
+				genConfig.NewSequenceProbability = 0.1
+				genConfig.RandomMutatedSpliceAtRandomWeight = 800
+				return genConfig, nil
+			}
+			f.fuzzer.Hooks.NamedCallSequenceGeneratorConfigFuncs["conservative"] = func(fuzzer *Fuzzer, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (*CallSequenceGeneratorConfig, error) {
+				strategyLock.Lock()
+				strategyInvocations["conservative"]++
+				strategyLock.Unlock()
+				genConfig, err := defaultCallSequenceGeneratorConfigFunc(fuzzer, valueSet, randomProvider)
+				if err != nil {
+					return nil, err
+				}
+				genConfig.NewSequenceProbability = 0.5
+				return genConfig, nil
+			}
+
+			// Record the strategy assigned to each worker index as workers are created.
+			workerStrategies := make(map[int]map[string]struct{})
+			f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+				strategyLock.Lock()
+				defer strategyLock.Unlock()
+				index := event.Worker.WorkerIndex()
+				if workerStrategies[index] == nil {
+					workerStrategies[index] = make(map[string]struct{})
+				}
+				workerStrategies[index][event.Worker.CallSequenceGeneratorStrategy()] = struct{}{}
+				return nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			assertFailedTestsExpected(f, true)
+
+			// Assert that each worker index consistently used its assigned strategy, with the remainder using the default.
+			expectedStrategies := []string{"aggressive", "aggressive", "conservative", ""}
+			for index, strategies := range workerStrategies {
+				assert.Len(t, strategies, 1, "worker %d used more than one strategy", index)
+				assert.Contains(t, strategies, expectedStrategies[index], "worker %d used an unexpected strategy", index)
+			}
+			assert.Greater(t, strategyInvocations["aggressive"], 0, "aggressive strategy was never used")
+			assert.Greater(t, strategyInvocations["conservative"], 0, "conservative strategy was never used")
+		},
+	})
+}
+
 // TestSlitherPrinter runs slither and ensures that the constants are correctly added to the value set
 func TestSlitherPrinter(t *testing.T) {
 	expectedInts := []int64{
@@ -177,6 +386,131 @@ func TestAssertionsNotRequire(t *testing.T) {
 	})
 }
 
+// TestAssertionsCustomErrors runs a test to ensure that reverting with a custom error whose selector is configured in
+// FailOnCustomErrors is reported as an assertion failure, while other custom errors are not, and that no failure is
+// reported if the selector is also configured as an expected revert.
+func TestAssertionsCustomErrors(t *testing.T) {
+	selector := hexutil.Encode(crypto.Keccak256([]byte("InvariantBroken(uint256)"))[:4])
+	for _, expected := range []bool{false, true} {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: "testdata/contracts/assertions/assert_custom_error.sol",
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 1_000
+				config.Fuzzing.Testing.StopOnFailedTest = false
+				config.Fuzzing.Testing.AssertionTesting.FailOnCustomErrors = []string{selector}
+				if expected {
+					config.Fuzzing.ExpectedReverts = []string{selector}
+				}
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check that only the method reverting with the configured custom error failed, unless it was expected.
+				failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+				if expected {
+					assert.Empty(t, failedTestCases)
+					return
+				}
+				assert.Len(t, failedTestCases, 1)
+				if len(failedTestCases) == 1 {
+					assert.Contains(t, failedTestCases[0].Name(), "failCustomError")
+					assert.Contains(t, failedTestCases[0].Message(), "reverted with custom error InvariantBroken(uint256)")
+				}
+			},
+		})
+	}
+}
+
+// TestParallelShrinking runs a test to ensure that a failing call sequence shrunk by multiple workers in parallel is
+// fully shrunk and still reproduces the failure.
+func TestParallelShrinking(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_parallel_shrinking.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 4
+			config.Fuzzing.ParallelShrinking = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that the sequence was shrunk to the three calls to step(), followed by the failing call to check().
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.Len(t, failedTestCases, 1)
+			failingSequence := *failedTestCases[0].CallSequence()
+			assert.Len(t, failingSequence, 4)
+			for i, cse := range failingSequence {
+				method, err := cse.Method()
+				assert.NoError(t, err)
+				if i < len(failingSequence)-1 {
+					assert.EqualValues(t, "step", method.Name)
+				} else {
+					assert.EqualValues(t, "check", method.Name)
+				}
+			}
+
+			// Check that the shrunk sequence still reproduces the failure.
+			lastCall := failingSequence[len(failingSequence)-1]
+			assert.NotNil(t, lastCall.ExecutionTrace)
+			assert.Contains(t, lastCall.ExecutionTrace.Log().String(), "panic: assertion failed")
+
+			// Check that other workers tested candidates on behalf of the shrinking worker.
+			assert.Greater(t, f.fuzzer.parallelShrinkTasksAssisted.Load(), uint64(0))
+		},
+	})
+}
+
+// TestAssertionsRevertMessageRegex runs a test to ensure that reverting with a reason string which matches the
+// configured FailOnRevertMessageRegex is reported as an assertion failure, while other reason strings are not, and
+// that no failure is reported if the matching reason string is configured as an expected revert.
+func TestAssertionsRevertMessageRegex(t *testing.T) {
+	for _, expected := range []bool{false, true} {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: "testdata/contracts/assertions/assert_revert_message.sol",
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 1_000
+				config.Fuzzing.Testing.StopOnFailedTest = false
+				config.Fuzzing.Testing.AssertionTesting.FailOnRevertMessageRegex = "^INVARIANT_"
+				if expected {
+					config.Fuzzing.ExpectedReverts = []string{"INVARIANT_VALUE_EVEN"}
+				}
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check that only the method reverting with the matching reason string failed, unless it was expected.
+				failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+				if expected {
+					assert.Empty(t, failedTestCases)
+					return
+				}
+				assert.Len(t, failedTestCases, 1)
+				if len(failedTestCases) == 1 {
+					assert.Contains(t, failedTestCases[0].Name(), "failRevertMessage")
+					assert.Contains(t, failedTestCases[0].Message(), "INVARIANT_VALUE_EVEN")
+				}
+			},
+		})
+	}
+}
+
 // TestAssertionsAndProperties runs a test to property testing and assertion testing can both run in parallel.
 // This test does not stop on first failure and expects a failure from each after timeout.
 func TestAssertionsAndProperties(t *testing.T) {
@@ -200,6 +534,98 @@ func TestAssertionsAndProperties(t *testing.T) {
 	})
 }
 
+// TestAssertionOnlyMode runs a test to ensure only the assertion test case provider is active when assertion-only mode
+// is enabled, even if other testing modes are enabled.
+func TestAssertionOnlyMode(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_and_property_test.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.AssertionOnlyMode = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = true
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that every test case was registered by the assertion test case provider.
+			assert.NotEmpty(t, f.fuzzer.TestCases())
+			for _, testCase := range f.fuzzer.TestCases() {
+				assert.IsType(t, &AssertionTestCase{}, testCase)
+			}
+
+			// Only the assertion test should fail, as the property test is never run.
+			assert.EqualValues(t, 1, len(f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)))
+		},
+	})
+}
+
+// TestAssertionFailureReason runs a test to ensure that a failed assertion test reports the human-readable panic
+// reason and the source location of the panic in its message.
+func TestAssertionFailureReason(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_arithmetic_underflow.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.AssertionTesting.PanicCodeConfig.FailOnArithmeticUnderflow = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+
+			// Verify the message contains the panic reason and the source location of the panic.
+			message := failedTestCases[0].Message()
+			assert.Contains(t, message, "panic: arithmetic underflow/overflow")
+			assert.Contains(t, message, "assert_arithmetic_underflow.sol:")
+
+			// Verify the execution trace of the failing call displays the source code surrounding the panic.
+			failingSequence := *failedTestCases[0].CallSequence()
+			executionTraceMsg := failingSequence[len(failingSequence)-1].ExecutionTrace.Log().String()
+			assert.Contains(t, executionTraceMsg, "[Source]")
+			assert.Regexp(t, `> +\d+ \| +uint8 c = a \+ b;`, executionTraceMsg)
+		},
+	})
+}
+
+// TestAssertionCheatCodesUsed runs a test to ensure that a failed assertion test reports the cheat codes which were
+// used by its call sequence in its message.
+func TestAssertionCheatCodesUsed(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_cheat_codes_used.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+
+			// Verify the message lists the cheat codes used, in the order they were used.
+			assert.Contains(t, failedTestCases[0].Message(), "used: warp, prank, deal")
+		},
+	})
+}
+
 // TestOptimizationMode runs a test to ensure that optimization mode works as expected
 func TestOptimizationMode(t *testing.T) {
 	filePaths := []string{
@@ -232,6 +658,80 @@ func TestOptimizationMode(t *testing.T) {
 	}
 }
 
+// TestGasTesting runs tests to ensure gas testing reports the call sequence which maximizes or minimizes the gas used
+// by calls to a target function.
+func TestGasTesting(t *testing.T) {
+	gasUsed := make(map[bool]uint64)
+	for _, minimize := range []bool{false, true} {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: "testdata/contracts/optimizations/gas_usage.sol",
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 10_000
+				config.Fuzzing.Testing.GasTesting.Enabled = true
+				config.Fuzzing.Testing.GasTesting.TargetFunctionSignatures = []string{"TestContract.store(uint8)"}
+				config.Fuzzing.Testing.GasTesting.Minimize = minimize
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.AssertionTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check a gas test case was reported with a call sequence ending in a call to the target function.
+				testCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusPassed)
+				assert.Len(t, testCases, 1)
+				gasTestCase, ok := testCases[0].(*GasTestCase)
+				assert.True(t, ok)
+				assert.NotNil(t, gasTestCase.GasUsed())
+				assert.NotNil(t, gasTestCase.CallSequence())
+				callSequence := *gasTestCase.CallSequence()
+				assert.NotEmpty(t, callSequence)
+				lastCallMethod, err := callSequence[len(callSequence)-1].Method()
+				assert.NoError(t, err)
+				assert.EqualValues(t, "store(uint8)", lastCallMethod.Sig)
+				gasUsed[minimize] = *gasTestCase.GasUsed()
+			},
+		})
+	}
+
+	// The maximized gas used should exceed the minimized gas used.
+	assert.Greater(t, gasUsed[false], gasUsed[true])
+}
+
+// TestERC20SupplyTesting runs a test to ensure ERC20 tokens are detected and that a token which mints without updating
+// its total supply fails the automatic supply test.
+func TestERC20SupplyTesting(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/erc20_supply/mint_without_supply.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 10_000
+			config.Fuzzing.Testing.ERC20SupplyTesting.Enabled = true
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that the supply test failed.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.Len(t, failedTestCases, 1)
+			if len(failedTestCases) == 1 {
+				assert.IsType(t, &ERC20SupplyTestCase{}, failedTestCases[0])
+				assert.Contains(t, failedTestCases[0].Message(), "did not match the sum of tracked holder balances")
+			}
+		},
+	})
+}
+
 // TestChainBehaviour runs tests to ensure the chain behaves as expected.
 func TestChainBehaviour(t *testing.T) {
 	// Run a test to simulate out of gas errors to make sure its handled well by the Chain and does not panic.
@@ -264,20 +764,46 @@ func TestCheatCodes(t *testing.T) {
 		"testdata/contracts/cheat_codes/utils/addr.sol",
 		"testdata/contracts/cheat_codes/utils/to_string.sol",
 		"testdata/contracts/cheat_codes/utils/sign.sol",
+		"testdata/contracts/cheat_codes/utils/derive_key.sol",
 		"testdata/contracts/cheat_codes/utils/parse.sol",
+		"testdata/contracts/cheat_codes/utils/checksum_address.sol",
 		"testdata/contracts/cheat_codes/vm/snapshot_and_revert_to.sol",
+		"testdata/contracts/cheat_codes/vm/delete_state_snapshot.sol",
+		"testdata/contracts/cheat_codes/vm/save_and_restore_state.sol",
+		"testdata/contracts/cheat_codes/vm/assert_reversible.sol",
 		"testdata/contracts/cheat_codes/vm/coinbase.sol",
 		"testdata/contracts/cheat_codes/vm/coinbase_permanent.sol",
 		"testdata/contracts/cheat_codes/vm/chain_id.sol",
+		"testdata/contracts/cheat_codes/vm/cool.sol",
 		"testdata/contracts/cheat_codes/vm/deal.sol",
 		"testdata/contracts/cheat_codes/vm/difficulty.sol",
 		"testdata/contracts/cheat_codes/vm/etch.sol",
+		"testdata/contracts/cheat_codes/vm/set_code_marker.sol",
+		"testdata/contracts/cheat_codes/vm/expect_emit.sol",
+		"testdata/contracts/cheat_codes/vm/expect_emit_sequence.sol",
+		"testdata/contracts/cheat_codes/vm/expect_nonce_increase.sol",
+		"testdata/contracts/cheat_codes/vm/expect_revert.sol",
+		"testdata/contracts/cheat_codes/vm/expect_return.sol",
 		"testdata/contracts/cheat_codes/vm/fee.sol",
 		"testdata/contracts/cheat_codes/vm/fee_permanent.sol",
+		"testdata/contracts/cheat_codes/vm/get_deployed_address.sol",
+		"testdata/contracts/cheat_codes/vm/mock_call.sol",
+		"testdata/contracts/cheat_codes/vm/get_block_count.sol",
+		"testdata/contracts/cheat_codes/vm/get_block_number.sol",
+		"testdata/contracts/cheat_codes/vm/get_block_timestamp.sol",
+		"testdata/contracts/cheat_codes/vm/get_chain_id.sol",
+		"testdata/contracts/cheat_codes/vm/pause_gas_metering.sol",
 		"testdata/contracts/cheat_codes/vm/prank.sol",
+		"testdata/contracts/cheat_codes/vm/prank_origin.sol",
+		"testdata/contracts/cheat_codes/vm/prank_delegatecall.sol",
+		"testdata/contracts/cheat_codes/vm/broadcast.sol",
+		"testdata/contracts/cheat_codes/vm/assertions.sol",
+		"testdata/contracts/cheat_codes/vm/set_next_call_gas.sol",
 		"testdata/contracts/cheat_codes/vm/roll.sol",
 		"testdata/contracts/cheat_codes/vm/roll_permanent.sol",
 		"testdata/contracts/cheat_codes/vm/store_load.sol",
+		"testdata/contracts/cheat_codes/vm/store_load_transient.sol",
+		"testdata/contracts/cheat_codes/vm/state_diff_recording.sol",
 		"testdata/contracts/cheat_codes/vm/warp.sol",
 		"testdata/contracts/cheat_codes/vm/warp_permanent.sol",
 	}
@@ -325,9 +851,484 @@ func TestCheatCodes(t *testing.T) {
 	}
 }
 
-// TestConsoleLog tests the console.log precompile contract by logging a variety of different primitive types and
-// then failing. The execution trace for the failing call sequence should hold the various logs.
-func TestConsoleLog(t *testing.T) {
+// TestCheatCodeEnv runs a test to ensure that the env cheat codes read and parse environment variables when environment
+// access is enabled.
+func TestCheatCodeEnv(t *testing.T) {
+	// Set the environment variables read by the test contract.
+	t.Setenv("MEDUSA_TEST_ENV_UINT", "1337")
+	t.Setenv("MEDUSA_TEST_ENV_ADDRESS", "0x7109709ECfa91a80626fF3989D68f67F5b1DD12D")
+	t.Setenv("MEDUSA_TEST_ENV_STRING", "medusa")
+	t.Setenv("MEDUSA_TEST_ENV_BYTES", "0xdeadbeef")
+
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/utils/env.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.EnableEnvAccess = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			assertFailedTestsExpected(f, false)
+		},
+	})
+}
+
+// TestCheatCodeFileAccess runs a test to ensure that the file cheat codes can read and write files within the file
+// access root when file access is enabled.
+func TestCheatCodeFileAccess(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/utils/file_access.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+
+			// Use a single worker, as workers would otherwise write the same fixture concurrently.
+			config.Fuzzing.Workers = 1
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.EnableFileAccess = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			assertFailedTestsExpected(f, false)
+		},
+	})
+}
+
+// TestCheatCodeDifficultyPreMerge runs a test to ensure that the difficulty cheat code sets block.difficulty when the
+// chain is configured with a fork preceding the Merge.
+func TestCheatCodeDifficultyPreMerge(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/difficulty_pre_merge.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			// Compile for the same fork the chain uses, so no instructions introduced after it are emitted.
+			platformConfig, err := config.Compilation.GetPlatformConfig()
+			assert.NoError(t, err)
+			cryticConfig := platformConfig.(*platforms.CryticCompilationConfig)
+			cryticConfig.Args = append(cryticConfig.Args, "--solc-args", "--evm-version london")
+			config.Compilation, err = compilation.NewCompilationConfigFromPlatformConfig(cryticConfig)
+			assert.NoError(t, err)
+
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Fuzzing.TestChainConfig.Fork = chainConfig.ForkLondon
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			assertFailedTestsExpected(f, false)
+		},
+	})
+}
+
+// TestCheatCodeStateResetVerification runs tests to ensure that when verifying state resets between call sequences is
+// enabled, changes made with cheat codes are undone between sequences, while a change which persists after reverting
+// to the base block is reported as an error.
+func TestCheatCodeStateResetVerification(t *testing.T) {
+	// Changes made with cheat codes during a call sequence should be reverted with it.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/state_reset.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.VerifyStateResetBetweenSequences = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for any failed tests and verify coverage was captured
+			assertFailedTestsExpected(f, false)
+			assertCorpusCallSequencesCollected(f, true)
+		},
+	})
+
+	// Simulate a cheat code effect which persists after reverting, by writing to storage once blocks are removed.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/state_reset.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.VerifyStateResetBetweenSequences = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+				event.Worker.Events.FuzzerWorkerChainSetup.Subscribe(func(event FuzzerWorkerChainSetupEvent) error {
+					contractAddress := event.Chain.DeployedContractAddresses["TestContract"]
+					event.Chain.Events.BlocksRemoved.Subscribe(func(event chain.BlocksRemovedEvent) error {
+						event.Chain.State().SetState(contractAddress, common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(7)))
+						return nil
+					})
+					return nil
+				})
+				return nil
+			})
+
+			// Start the fuzzer, and verify the reset violation was reported.
+			err := f.fuzzer.Start()
+			assert.ErrorContains(t, err, "state was not reset")
+		},
+	})
+}
+
+// TestCheatCodeGetCoverageCount runs a test to ensure that the coverage count reported by the getCoverageCount cheat
+// code increases over a sequence which reaches new code.
+func TestCheatCodeGetCoverageCount(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/get_coverage_count.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 10_000 // this test should expose a failure quickly.
+			config.Fuzzing.CoverageEnabled = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests, indicating the coverage count increased.
+			assertFailedTestsExpected(f, true)
+		},
+	})
+}
+
+// TestCheatCodeCustomMetrics runs a test to ensure that the counters and gauges recorded by the incrementCounter and
+// setGauge cheat codes are aggregated across the calls of every worker.
+func TestCheatCodeCustomMetrics(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/custom_metrics.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Every call takes one of the two paths, so the counters should aggregate to more than a single call, but
+			// never exceed the amount of calls tested.
+			counters := f.fuzzer.metrics.CustomMetrics().Counters()
+			pathCount := counters["pathA"] + counters["pathB"]
+			assert.Greater(t, pathCount, uint64(1))
+			assert.LessOrEqual(t, pathCount, f.fuzzer.metrics.CallsTested().Uint64())
+
+			// Verify our gauge was recorded.
+			assert.Contains(t, f.fuzzer.metrics.CustomMetrics().Gauges(), "lastValue")
+		},
+	})
+}
+
+// TestCheatCodeAssume runs a test to ensure that calls whose assumptions fail are discarded, counting towards the
+// discarded calls metric rather than being tested.
+func TestCheatCodeAssume(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/assume.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Ensure no test failed, and that calls failing the assumption were discarded rather than tested.
+			assertFailedTestsExpected(f, false)
+			assert.Greater(t, f.fuzzer.metrics.CallsDiscarded().Uint64(), uint64(0))
+			assert.EqualValues(t, 0, f.fuzzer.metrics.CallsReverted().Uint64())
+		},
+	})
+}
+
+// TestCheatCodeAssumeCaught runs a test to ensure that discarded calls whose failed assumption was caught remain in the
+// failing call sequence, so the state changes they made are reproduced.
+func TestCheatCodeAssumeCaught(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/assume_caught.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 10_000 // this test should expose a failure quickly.
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that the failing sequence was shrunk to the discarded call which flagged the contract, followed by
+			// the failing check.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.Len(t, failedTestCases, 1)
+			failingSequence := *failedTestCases[0].CallSequence()
+			assert.Len(t, failingSequence, 2)
+			expectedMethodNames := []string{"flagOdd", "checkNotFlagged"}
+			for i, cse := range failingSequence {
+				method, err := cse.Method()
+				assert.NoError(t, err)
+				assert.EqualValues(t, expectedMethodNames[i], method.Name)
+			}
+		},
+	})
+}
+
+// TestCheatCodesDisabled runs a test to ensure that cheat codes listed in DisabledCheatcodes are unavailable and revert
+// when called, while other cheat codes remain available.
+func TestCheatCodesDisabled(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/disabled_cheat_codes.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.DisabledCheatcodes = []string{"etch"}
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			assertFailedTestsExpected(f, false)
+		},
+	})
+}
+
+// TestCheatCodesCallerAllowlist runs a test to ensure that callers in the CallerAllowlist can invoke cheat codes,
+// while cheat code invocations from callers which are not in it revert.
+func TestCheatCodesCallerAllowlist(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/caller_allowlist.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+
+			// Allow only the harness contract, which is the first contract the deployer deploys.
+			harnessAddress := crypto.CreateAddress(common.HexToAddress(config.Fuzzing.DeployerAddress), 0)
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CallerAllowlist = []string{harnessAddress.Hex()}
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for failed assertion tests.
+			assertFailedTestsExpected(f, false)
+		},
+	})
+}
+
+// TestCheatCodeSkip runs a test to ensure that property and assertion tests which call the skip cheat code with a true
+// condition are marked as skipped, rather than passed or failed.
+func TestCheatCodeSkip(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/skip.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = true
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Ensure no test failed, and that only the tests which called skip with a true condition were skipped.
+			assertFailedTestsExpected(f, false)
+			skippedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusSkipped)
+			skippedTestCaseNames := make([]string, 0, len(skippedTestCases))
+			for _, testCase := range skippedTestCases {
+				skippedTestCaseNames = append(skippedTestCaseNames, testCase.Name())
+				assert.True(t, strings.HasPrefix(testCase.Message(), "[SKIPPED]"))
+			}
+			assert.ElementsMatch(t, []string{
+				"Assertion Test: TestContract.testSkipped(uint256)",
+				"Property Test: TestContract.property_skipped()",
+			}, skippedTestCaseNames)
+			assert.Len(t, f.fuzzer.TestCasesWithStatus(TestCaseStatusPassed), 2)
+		},
+	})
+}
+
+// TestCheatCodeAssertionFailureMessages runs a test to ensure that assertion cheat codes whose comparisons do not hold
+// cause an assertion failure, and that the message describing the compared values is shown in the execution trace.
+func TestCheatCodeAssertionFailureMessages(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/cheat_codes/vm/assertions_unmet.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Ensure each test failed, and that the trace of its failing call describes the compared values.
+			expectedMessages := map[string]string{
+				"assertEqButNotEqual()":        "assertEq failed: 3 != 4",
+				"assertEqAddressButNotEqual()": "assertEq failed: 0x0000000000000000000000000000000000001234 != 0x0000000000000000000000000000000000005678",
+				"assertEqBytes32ButNotEqual()": "assertEq failed: 0x0000000000000000000000000000000000000000000000000000000000000001 != 0x0000000000000000000000000000000000000000000000000000000000000002",
+				"assertGtButNotGreater()":      "assertGt failed: -2 <= 5",
+				"assertTrueButFalse()":         "assertTrue failed",
+			}
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.Len(t, failedTestCases, len(expectedMessages))
+			for _, failedTestCase := range failedTestCases {
+				assertionTestCase, ok := failedTestCase.(*AssertionTestCase)
+				assert.True(t, ok)
+				expectedMessage, ok := expectedMessages[assertionTestCase.targetMethod.Sig]
+				assert.True(t, ok, "unexpected failed test %v", assertionTestCase.Name())
+
+				failingSequence := *assertionTestCase.CallSequence()
+				failingCall := failingSequence[len(failingSequence)-1]
+				assert.NotNil(t, failingCall.ExecutionTrace)
+				assert.Contains(t, failingCall.ExecutionTrace.Log().String(), fmt.Sprintf("[revert ('%v')]", expectedMessage))
+				assert.Contains(t, assertionTestCase.Message(), expectedMessage)
+			}
+		},
+	})
+}
+
+// TestCheatCodeUnmetExpectations runs tests to ensure that expectation cheat codes (e.g. expectRevert, expectEmit,
+// assertReversible) whose expectations are not met by the next call cause an assertion failure.
+func TestCheatCodeUnmetExpectations(t *testing.T) {
+	filePaths := []string{
+		"testdata/contracts/cheat_codes/vm/expect_revert_unmet.sol",
+		"testdata/contracts/cheat_codes/vm/expect_return_unmet.sol",
+		"testdata/contracts/cheat_codes/vm/expect_nonce_increase_unmet.sol",
+		"testdata/contracts/cheat_codes/vm/expect_emit_unmet.sol",
+		"testdata/contracts/cheat_codes/vm/expect_emit_sequence_unmet.sol",
+		"testdata/contracts/cheat_codes/vm/assert_reversible_unmet.sol",
+		"testdata/contracts/cheat_codes/vm/assert_reversible_invalidated.sol",
+	}
+	for _, filePath := range filePaths {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: filePath,
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 1_000 // both failures should be exposed quickly.
+				config.Fuzzing.Testing.StopOnFailedTest = false
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Fuzzing.Testing.AssertionTesting.Enabled = true
+				config.Fuzzing.TestChainConfig.CheatCodeConfig.CheatCodesEnabled = true
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Both methods with unmet expectations should have failed.
+				assert.Len(t, f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed), 2)
+			},
+		})
+	}
+}
+
+// TestMockERC20Tokens runs a test to ensure mock ERC20 tokens are pre-deployed when configured, that they can be
+// minted and transferred, that pranks apply to calls made to them, and that expectation cheat codes skip them.
+func TestMockERC20Tokens(t *testing.T) {
+	filePaths := []string{
+		"testdata/contracts/cheat_codes/mock_tokens/mock_erc20.sol",
+		"testdata/contracts/cheat_codes/mock_tokens/mock_erc20_prank.sol",
+		"testdata/contracts/cheat_codes/mock_tokens/mock_erc20_expect.sol",
+	}
+	for _, filePath := range filePaths {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: filePath,
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 1_000
+				config.Fuzzing.TestChainConfig.MockERC20TokenCount = 2
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check for any failed tests and verify coverage was captured
+				assertFailedTestsExpected(f, false)
+				assertCorpusCallSequencesCollected(f, true)
+			},
+		})
+	}
+}
+
+// TestConsoleLog tests the console.log precompile contract by logging a variety of different primitive types and
+// then failing. The execution trace for the failing call sequence should hold the various logs.
+func TestConsoleLog(t *testing.T) {
 	// These are the logs that should show up in the execution trace
 	expectedLogs := []string{
 		"2",
@@ -337,16 +1338,1442 @@ func TestConsoleLog(t *testing.T) {
 		"% bool is true, addr is 0x0000000000000000000000000000000000000000, u is 100",
 	}
 
-	filePaths := []string{
-		"testdata/contracts/cheat_codes/console_log/console_log.sol",
-	}
-	for _, filePath := range filePaths {
+	filePaths := []string{
+		"testdata/contracts/cheat_codes/console_log/console_log.sol",
+	}
+	for _, filePath := range filePaths {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: filePath,
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 10000
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check for failed assertion tests.
+				failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+				assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
+
+				// Obtain our first failed test case, get the message, and verify it contains our assertion failed.
+				failingSequence := *failedTestCase[0].CallSequence()
+				assert.NotEmpty(t, failingSequence, "expected to have calls in the call sequence failing an assertion test")
+
+				// Obtain the last call
+				lastCall := failingSequence[len(failingSequence)-1]
+				assert.NotNilf(t, lastCall.ExecutionTrace, "expected to have an execution trace attached to call sequence for this test")
+
+				// Get the execution trace message
+				executionTraceMsg := lastCall.ExecutionTrace.Log().String()
+
+				// Verify it contains all expected logs
+				for _, expectedLog := range expectedLogs {
+					assert.Contains(t, executionTraceMsg, expectedLog)
+				}
+			},
+		})
+	}
+}
+
+// TestDeploymentsInnerDeployments runs tests to ensure dynamically deployed contracts are detected by the Fuzzer and
+// their properties are tested appropriately.
+func TestDeploymentsInnerDeployments(t *testing.T) {
+	// These contracts provide functions to deploy inner contracts which have properties that will produce a failure.
+	filePaths := []string{
+		"testdata/contracts/deployments/inner_deployment.sol",
+		"testdata/contracts/deployments/inner_inner_deployment.sol",
+	}
+	for _, filePath := range filePaths {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: filePath,
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"InnerDeploymentFactory"}
+				config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
+				config.Fuzzing.Testing.StopOnFailedContractMatching = true
+				config.Fuzzing.Testing.TestAllContracts = true // test dynamically deployed contracts
+				config.Fuzzing.Testing.AssertionTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check for any failed tests and verify coverage was captured
+				assertFailedTestsExpected(f, true)
+				assertCorpusCallSequencesCollected(f, true)
+			},
+		})
+	}
+
+	// This contract deploys an inner contract upon construction, which contains properties that will produce a failure.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/inner_deployment_on_construction.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"InnerDeploymentFactory"}
+			config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
+			config.Fuzzing.Testing.StopOnFailedContractMatching = true
+			config.Fuzzing.Testing.TestAllContracts = true // test dynamically deployed contracts
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check to see if there are any failures
+			assertFailedTestsExpected(f, true)
+		},
+	})
+}
+
+// TestDeploymentsDynamicDeployers runs a test to ensure that when dynamic deployers are configured, only the
+// dynamically deployed contracts they deployed are tested.
+func TestDeploymentsDynamicDeployers(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/dynamic_deployers.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"AllowedFactory", "OtherFactory"}
+			config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
+			config.Fuzzing.Testing.StopOnFailedContractMatching = true
+			config.Fuzzing.Testing.TestAllContracts = true
+			config.Fuzzing.Testing.DynamicDeployers = []string{"AllowedFactory"}
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Record the addresses of the inner contracts each worker tests, along with the address the allowed
+			// factory deployed its inner contract to.
+			var lock sync.Mutex
+			testedInnerAddresses := make(map[common.Address]bool)
+			var allowedInnerAddress common.Address
+			f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+				event.Worker.Events.ContractAdded.Subscribe(func(event FuzzerWorkerContractAddedEvent) error {
+					lock.Lock()
+					defer lock.Unlock()
+					if event.ContractDefinition.Name() == "InnerDeployment" {
+						testedInnerAddresses[event.ContractAddress] = true
+						allowedInnerAddress = crypto.CreateAddress(event.Worker.chain.DeployedContractAddresses["AllowedFactory"], 1)
+					}
+					return nil
+				})
+				return nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Only the inner contract deployed by the allowed factory should have been tested, and failed.
+			assert.Len(t, testedInnerAddresses, 1)
+			assert.True(t, testedInnerAddresses[allowedInnerAddress])
+			assertFailedTestsExpected(f, true)
+		},
+	})
+}
+
+// TestDeploymentsInternalLibrary runs a test to ensure internal libraries behave correctly.
+func TestDeploymentsInternalLibrary(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/internal_library.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestInternalLibrary"}
+			config.Fuzzing.TestLimit = 100 // this test should expose a failure quickly.
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for any failed tests and verify coverage was captured
+			assertFailedTestsExpected(f, false)
+			assertCorpusCallSequencesCollected(f, true)
+		},
+	})
+}
+
+// TestDeploymentsWithPredeploy runs a test to ensure that predeployed contracts are instantiated correctly.
+func TestDeploymentsWithPredeploy(t *testing.T) {
+	predeployedContracts := map[string]config.PredeployedContractConfig{"PredeployContract": {Address: "0x1234"}}
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/predeploy_contract.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TargetContractsBalances = []*big.Int{big.NewInt(1)}
+			config.Fuzzing.TestLimit = 1000 // this test should expose a failure immediately
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.PredeployedContracts = predeployedContracts
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for any failed tests and verify coverage was captured
+			assertFailedTestsExpected(f, true)
+			assertCorpusCallSequencesCollected(f, true)
+		},
+	})
+}
+
+// TestDeploymentsWithPredeployRuntimeBytecode runs a test to ensure that predeployed contracts which provide their own
+// runtime bytecode and storage are set at their address.
+func TestDeploymentsWithPredeployRuntimeBytecode(t *testing.T) {
+	predeployedContracts := map[string]config.PredeployedContractConfig{
+		"StorageReader": {
+			Address:         "0x1234",
+			RuntimeBytecode: "0x60005460005260206000f3", // returns the value of storage slot 0
+			Storage:         map[string]string{"0x0": "0x2a"},
+		},
+	}
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/predeploy_runtime_bytecode.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1000 // this test should expose a failure immediately
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.PredeployedContracts = predeployedContracts
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Record the addresses of the contracts deployed while setting up the chain.
+			var deployedContractAddresses map[string]common.Address
+			existingChainSetupFunc := f.fuzzer.Hooks.ChainSetupFunc
+			f.fuzzer.Hooks.ChainSetupFunc = func(fuzzer *Fuzzer, testChain *chain.TestChain) (*executiontracer.ExecutionTrace, error) {
+				trace, err := existingChainSetupFunc(fuzzer, testChain)
+				deployedContractAddresses = testChain.DeployedContractAddresses
+				return trace, err
+			}
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for any failed tests and verify the predeployed contract was recorded under its name
+			assertFailedTestsExpected(f, true)
+			assert.EqualValues(t, common.HexToAddress("0x1234"), deployedContractAddresses["StorageReader"])
+		},
+	})
+}
+
+// TestDeploymentsWithOptionalContractFailure runs a test to ensure that an optional contract which fails to deploy is
+// skipped, while fuzzing proceeds with the other target contracts.
+func TestDeploymentsWithOptionalContractFailure(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/optional_contract_failure.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"FailingContract", "TestContract"}
+			config.Fuzzing.OptionalContracts = []string{"FailingContract"}
+			config.Fuzzing.TestLimit = 10_000
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// The remaining target contract should have been deployed and fuzzed, failing its property test.
+			assertFailedTestsExpected(f, true)
+		},
+	})
+}
+
+// TestDeploymentsContractInstances runs a test to ensure that when several instances of a target contract are
+// configured, each is deployed at a distinct address and receives calls from the fuzzer.
+func TestDeploymentsContractInstances(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/contract_instances.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.ContractInstances = map[string]int{"TestContract": 2}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Record the addresses each instance was deployed to.
+			var lock sync.Mutex
+			var instanceAddresses []common.Address
+			f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+				event.Worker.Events.FuzzerWorkerChainSetup.Subscribe(func(event FuzzerWorkerChainSetupEvent) error {
+					lock.Lock()
+					defer lock.Unlock()
+					if instanceAddresses == nil {
+						instanceAddresses = []common.Address{
+							event.Chain.DeployedContractAddresses["TestContract"],
+							event.Chain.DeployedContractAddresses["TestContract_1"],
+						}
+					}
+					return nil
+				})
+				return nil
+			})
+
+			// Record the addresses of every contract called by the fuzzer.
+			calledAddresses := make(map[common.Address]bool)
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				for _, element := range callSequence {
+					if element.Call.To != nil {
+						calledAddresses[*element.Call.To] = true
+					}
+				}
+				return make([]ShrinkCallSequenceRequest, 0), nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Verify both instances were deployed at distinct addresses, and both were called.
+			assert.Len(t, instanceAddresses, 2)
+			assert.NotEqual(t, common.Address{}, instanceAddresses[0])
+			assert.NotEqual(t, common.Address{}, instanceAddresses[1])
+			assert.NotEqual(t, instanceAddresses[0], instanceAddresses[1])
+			assert.True(t, calledAddresses[instanceAddresses[0]])
+			assert.True(t, calledAddresses[instanceAddresses[1]])
+		},
+	})
+}
+
+// TestDeploymentsSetupCalls runs tests to ensure that configured setup calls are executed after deployment, before
+// fuzzing begins, and that the campaign is aborted if a setup call does not succeed or revert as expected.
+func TestDeploymentsSetupCalls(t *testing.T) {
+	initializeCall := config.SetupCallConfig{Contract: "TestContract", Method: "initialize", Args: map[string]any{"value": "7"}}
+	reinitializeCall := config.SetupCallConfig{Contract: "TestContract", Method: "initialize(uint256)", Args: map[string]any{"value": "8"}, ExpectRevert: true}
+	unexpectedSuccessCall := initializeCall
+	unexpectedSuccessCall.ExpectRevert = true
+	expectedSetupCalls := []config.SetupCallConfig{initializeCall, reinitializeCall}
+	unexpectedSetupCalls := []config.SetupCallConfig{unexpectedSuccessCall}
+
+	// Run a test where the contract is initialized by a setup call which must succeed, and a second initialization
+	// attempt must revert.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/setup_calls.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.SetupCalls = expectedSetupCalls
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// The property relies on the setup call having initialized the contract, so it should not fail.
+			assertFailedTestsExpected(f, false)
+		},
+	})
+
+	// Run a test where a setup call which succeeds is expected to revert.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/setup_calls.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.SetupCalls = unexpectedSetupCalls
+			config.Fuzzing.TestLimit = 1_000
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer, which should fail to set up the chain.
+			err := f.fuzzer.Start()
+			assert.ErrorContains(t, err, "setup call to TestContract.initialize was expected to revert")
+		},
+	})
+}
+
+// TestDeploymentsTargetContractInference runs tests to ensure that when no target contracts are specified and several
+// contracts were compiled, the error lists the candidates, or all of them are targeted if configured to.
+func TestDeploymentsTargetContractInference(t *testing.T) {
+	candidateContracts := []string{"FirstContract", "InheritedFirstContract", "SecondContract", "InheritedSecondContract"}
+
+	// Without targetAllContracts, setting up the chain should fail with an error listing every candidate.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/deployment_order.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{}
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.Error(t, err)
+			for _, contractName := range candidateContracts {
+				assert.ErrorContains(t, err, contractName)
+			}
+		},
+	})
+
+	// With targetAllContracts, every candidate should be targeted.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/deployment_order.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{}
+			config.Fuzzing.TargetAllContracts = true
+			config.Fuzzing.TestLimit = 100
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, candidateContracts, f.fuzzer.config.Fuzzing.TargetContracts)
+		},
+	})
+}
+
+// TestDeploymentsDumpDeployedBytecode runs a test to ensure that the runtime bytecode of each deployed target contract
+// is written to the configured directory after setting up the test chain, matching its compiled runtime bytecode.
+func TestDeploymentsDumpDeployedBytecode(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/deployment_order.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"InheritedFirstContract", "InheritedSecondContract"}
+			config.Fuzzing.DumpDeployedBytecodeDir = "bytecode"
+			config.Fuzzing.TestLimit = 100
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Verify a file was written for each deployed target contract, holding its compiled runtime bytecode.
+			dumpedCount := 0
+			for _, contract := range f.fuzzer.contractDefinitions {
+				data, err := os.ReadFile(filepath.Join("bytecode", contract.Name()+".bin"))
+				if os.IsNotExist(err) {
+					continue
+				}
+				assert.NoError(t, err)
+				code, err := hexutil.Decode(string(data))
+				assert.NoError(t, err)
+				assert.EqualValues(t, contract.CompiledContract().RuntimeBytecode, code)
+				dumpedCount++
+			}
+			assert.EqualValues(t, len(f.fuzzer.config.Fuzzing.TargetContracts), dumpedCount)
+		},
+	})
+}
+
+// TestDeploymentsWithFactoryCallBias runs a test to ensure that enabling the factory call bias causes factory methods
+// to be called more often, creating more contract instances per call tested.
+func TestDeploymentsWithFactoryCallBias(t *testing.T) {
+	// Measure the ratio of instances created to calls tested with the provided factory call probability.
+	measureInstanceRatio := func(factoryCallProbability float64) float64 {
+		var instanceRatio float64
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: "testdata/contracts/deployments/factory_bias.sol",
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"Factory"}
+				config.Fuzzing.Workers = 1
+				config.Fuzzing.CallSequenceLength = 10
+				config.Fuzzing.TestLimit = 2_000
+				config.Fuzzing.FactoryCallProbability = factoryCallProbability
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Count the instances created by the factory as workers detect them.
+				var instanceCountLock sync.Mutex
+				instanceCount := 0
+				f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+					event.Worker.Events.ContractAdded.Subscribe(func(event FuzzerWorkerContractAddedEvent) error {
+						if event.ContractDefinition != nil && event.ContractDefinition.Name() == "Instance" {
+							instanceCountLock.Lock()
+							instanceCount++
+							instanceCountLock.Unlock()
+						}
+						return nil
+					})
+					return nil
+				})
+
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				instanceCountLock.Lock()
+				defer instanceCountLock.Unlock()
+				instanceRatio = float64(instanceCount) / float64(f.fuzzer.metrics.CallsTested().Uint64())
+			},
+		})
+		return instanceRatio
+	}
+
+	// The factory should be called far more often with the bias than with uniform method selection.
+	unbiasedRatio := measureInstanceRatio(0)
+	biasedRatio := measureInstanceRatio(0.9)
+	assert.Greater(t, biasedRatio, unbiasedRatio*2)
+}
+
+// TestDeploymentsWithPayableConstructor runs a test to ensure that we can send ether to payable constructors
+func TestDeploymentsWithPayableConstructors(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/deploy_payable_constructors.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"FirstContract", "SecondContract"}
+			config.Fuzzing.TargetContractsBalances = []*big.Int{big.NewInt(0), big.NewInt(1e18)}
+			config.Fuzzing.TestLimit = 1 // this should happen immediately
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check for any failed tests and verify coverage was captured
+			assertFailedTestsExpected(f, false)
+			assertCorpusCallSequencesCollected(f, true)
+		},
+	})
+}
+
+// TestDeploymentsSelfDestruct runs a test to ensure dynamically deployed contracts are detected by the Fuzzer and
+// their properties are tested appropriately.
+func TestDeploymentsSelfDestruct(t *testing.T) {
+	// These contracts provide functions to deploy inner contracts which have properties that will produce a failure.
+	filePaths := []string{
+		"testdata/contracts/deployments/selfdestruct_init.sol",
+		"testdata/contracts/deployments/selfdestruct_runtime.sol",
+	}
+	for _, filePath := range filePaths {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: filePath,
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"InnerDeploymentFactory"}
+				config.Fuzzing.TestLimit = 500 // this test should expose a failure quickly.
+				config.Fuzzing.Testing.StopOnNoTests = false
+				config.Fuzzing.Testing.AssertionTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Fuzzing.Testing.TestAllContracts = true
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Subscribe to any mined block events globally. When receiving them, check contract changes for a
+				// self-destruct.
+				selfDestructCount := 0
+				events.SubscribeAny(func(event chain.PendingBlockCommittedEvent) error {
+					for _, messageResults := range event.Block.MessageResults {
+						for _, contractDeploymentChange := range messageResults.ContractDeploymentChanges {
+							if contractDeploymentChange.SelfDestructed {
+								selfDestructCount++
+							}
+						}
+					}
+					return nil
+				})
+
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// When it's done, we should've had at least one self-destruction.
+				assert.Greater(t, selfDestructCount, 0, "no SELFDESTRUCT operations were detected, when they should have been.")
+			},
+		})
+	}
+}
+
+// TestExecutionTraceStateDiff runs a test to ensure that execution traces attached to every call of a failing call
+// sequence when TraceAll is enabled describe the storage slots changed by each call.
+func TestExecutionTraceStateDiff(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/execution_tracing/state_diff.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.TraceAll = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain our failing sequence, which should set x and then check it.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+			failingSequence := *failedTestCases[0].CallSequence()
+			assert.Len(t, failingSequence, 2)
+
+			// Verify the first call's trace describes the change made to x, while the failing call made no changes.
+			setCall := failingSequence[0]
+			assert.NotNil(t, setCall.ExecutionTrace)
+			assert.Len(t, setCall.ExecutionTrace.StateDiff, 1)
+			for _, storageDiff := range setCall.ExecutionTrace.StateDiff {
+				assert.EqualValues(t, *setCall.Call.To, storageDiff.Account)
+				assert.EqualValues(t, common.Hash{}, storageDiff.Slot)
+				assert.EqualValues(t, common.Hash{}, storageDiff.PreviousValue)
+				assert.NotEqualValues(t, common.Hash{}, storageDiff.NewValue)
+			}
+			assert.Contains(t, setCall.ExecutionTrace.Log().String(), "[State Diff]")
+			assert.Empty(t, failingSequence[1].ExecutionTrace.StateDiff)
+		},
+	})
+}
+
+// TestExecutionTraces runs tests to ensure that execution traces capture information
+// regarding assertion failures, revert reasons, etc.
+func TestExecutionTraces(t *testing.T) {
+	expectedMessagesPerTest := map[string][]string{
+		"testdata/contracts/execution_tracing/call_and_deployment_args.sol": {"Hello from deployment args!", "Hello from call args!"},
+		"testdata/contracts/execution_tracing/cheatcodes.sol":               {"StdCheats.toString(bool)(true)"},
+		"testdata/contracts/execution_tracing/event_emission.sol":           {"TestEvent", "TestIndexedEvent", "TestMixedEvent", "Hello from event args!", "Hello from library event args!"},
+		"testdata/contracts/execution_tracing/proxy_call.sol":               {"TestContract -> InnerDeploymentContract.setXY", "Hello from proxy call args!"},
+		"testdata/contracts/execution_tracing/revert_custom_error.sol":      {"CustomError", "Hello from a custom error!"},
+		"testdata/contracts/execution_tracing/revert_reasons.sol":           {"RevertingContract was called and reverted."},
+		"testdata/contracts/execution_tracing/self_destruct.sol":            {"[selfdestruct]", "[panic: assertion failed]"},
+	}
+	for filePath, expectedTraceMessages := range expectedMessagesPerTest {
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: filePath,
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+				config.Slither.UseSlither = false
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check for failed assertion tests.
+				failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+				assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
+
+				// Obtain our first failed test case, get the message, and verify it contains our assertion failed.
+				failingSequence := *failedTestCase[0].CallSequence()
+				assert.NotEmpty(t, failingSequence, "expected to have calls in the call sequence failing an assertion test")
+
+				// Obtain the last call
+				lastCall := failingSequence[len(failingSequence)-1]
+				assert.NotNilf(t, lastCall.ExecutionTrace, "expected to have an execution trace attached to call sequence for this test")
+
+				// Get the execution trace message
+				executionTraceMsg := lastCall.ExecutionTrace.Log().String()
+
+				// Verify it contains all expected strings
+				for _, expectedTraceMessage := range expectedTraceMessages {
+					assert.Contains(t, executionTraceMsg, expectedTraceMessage)
+				}
+			},
+		})
+	}
+}
+
+// TestExecutionTraceJSON runs a test to ensure that the structured JSON representation of an execution trace describes
+// the nested call structure of a proxy call.
+func TestExecutionTraceJSON(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/execution_tracing/proxy_call.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain the last call of our failing sequence and its execution trace.
+			failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
+			failingSequence := *failedTestCase[0].CallSequence()
+			lastCall := failingSequence[len(failingSequence)-1]
+			assert.NotNil(t, lastCall.ExecutionTrace)
+
+			// Serialize the execution trace and parse it back.
+			b, err := lastCall.ExecutionTrace.JSON()
+			assert.NoError(t, err)
+			var topLevelCallFrame executiontracer.CallFrameJSON
+			err = json.Unmarshal(b, &topLevelCallFrame)
+			assert.NoError(t, err)
+
+			// Verify the top level call failed the assertion.
+			assert.EqualValues(t, "call", topLevelCallFrame.Type)
+			assert.EqualValues(t, "TestContract", topLevelCallFrame.CodeContract)
+			assert.EqualValues(t, "testDelegateCall()", topLevelCallFrame.Method)
+			assert.True(t, topLevelCallFrame.Reverted)
+			assert.Contains(t, topLevelCallFrame.Result, "assertion failed")
+			assert.NotZero(t, topLevelCallFrame.GasUsed)
+
+			// Verify the proxy call is nested under it, with its decoded arguments.
+			assert.Len(t, topLevelCallFrame.Operations, 1)
+			proxyCallFrame := topLevelCallFrame.Operations[0].Call
+			assert.NotNil(t, proxyCallFrame)
+			assert.EqualValues(t, "proxyCall", proxyCallFrame.Type)
+			assert.EqualValues(t, "TestContract", proxyCallFrame.ToContract)
+			assert.EqualValues(t, "InnerDeploymentContract", proxyCallFrame.CodeContract)
+			assert.EqualValues(t, "setXY(uint256,uint256,string)", proxyCallFrame.Method)
+			assert.Contains(t, proxyCallFrame.Inputs, "Hello from proxy call args!")
+			assert.False(t, proxyCallFrame.Reverted)
+			assert.EqualValues(t, "return", proxyCallFrame.Result)
+
+			// Verify the storage written by the proxy call is described, in the order it was written.
+			assert.Len(t, proxyCallFrame.Operations, 2)
+			for i, expectedValue := range []int64{123, 321} {
+				storageWrite := proxyCallFrame.Operations[i].StorageWrite
+				assert.NotNil(t, storageWrite)
+				assert.EqualValues(t, common.BigToHash(big.NewInt(int64(i))), storageWrite.Slot)
+				assert.EqualValues(t, common.BigToHash(big.NewInt(expectedValue)), storageWrite.Value)
+			}
+		},
+	})
+}
+
+// TestExecutionTraceSenderLabels runs a test to ensure the deployer and sender addresses are labeled in execution
+// traces without any manual labeling.
+func TestExecutionTraceSenderLabels(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/execution_tracing/call_and_deployment_args.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain the last call of our failing sequence and its execution trace.
+			failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
+			failingSequence := *failedTestCase[0].CallSequence()
+			lastCall := failingSequence[len(failingSequence)-1]
+			assert.NotNil(t, lastCall.ExecutionTrace)
+
+			// Verify the sender of the call is displayed with its label.
+			executionTraceMsg := lastCall.ExecutionTrace.Log().String()
+			assert.Regexp(t, `sender=0x[0-9a-fA-F]+ \[(deployer|sender[0-9]+)\]`, executionTraceMsg)
+		},
+	})
+}
+
+// TestExecutionTraceGas runs a test to ensure that execution traces display the gas provided to and used by each call
+// frame, matching the values recorded by the tracer.
+func TestExecutionTraceGas(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/execution_tracing/call_and_deployment_args.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain the last call of our failing sequence and its execution trace.
+			failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
+			failingSequence := *failedTestCase[0].CallSequence()
+			lastCall := failingSequence[len(failingSequence)-1]
+			assert.NotNil(t, lastCall.ExecutionTrace)
+
+			// Verify the gas provided to and used by the top level call frame is recorded and displayed.
+			topLevelCallFrame := lastCall.ExecutionTrace.TopLevelCallFrame
+			assert.NotZero(t, topLevelCallFrame.Gas)
+			assert.NotZero(t, topLevelCallFrame.GasUsed)
+			assert.LessOrEqual(t, topLevelCallFrame.GasUsed, topLevelCallFrame.Gas)
+			executionTraceMsg := lastCall.ExecutionTrace.Log().String()
+			assert.Contains(t, executionTraceMsg, fmt.Sprintf("gas=%v, gasUsed=%v,", topLevelCallFrame.Gas, topLevelCallFrame.GasUsed))
+		},
+	})
+}
+
+// TestCallSequenceRendering runs a test to ensure that a failing call sequence is rendered as a numbered list of calls,
+// with decoded arguments, labeled senders, and the block and time each call was included at.
+func TestCallSequenceRendering(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain our failing sequence and its rendered output.
+			failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
+			failingSequence := *failedTestCase[0].CallSequence()
+			renderedSequence := failingSequence.String()
+
+			// Verify each call is numbered, with its decoded argument and the block and time it was included at.
+			for i, cse := range failingSequence {
+				method, err := cse.Method()
+				assert.NoError(t, err)
+				args, err := method.Inputs.Unpack(cse.Call.Data[4:])
+				assert.NoError(t, err)
+
+				header := cse.ChainReference.Block.Header
+				assert.Contains(t, renderedSequence, fmt.Sprintf(
+					"%d) TestContract.callingMeFails(uint256)(%v) (block=%v, time=%v,",
+					i+1, args[0], header.Number, header.Time,
+				))
+			}
+
+			// Verify senders are displayed with their labels.
+			assert.Regexp(t, `sender=0x[0-9a-fA-F]+ \[sender[0-9]+\]\)`, renderedSequence)
+		},
+	})
+}
+
+// TestCallSequenceReport runs a test to ensure failed test cases can be reported in a machine-readable format
+// describing each call of their call sequence.
+func TestCallSequenceReport(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain our failing test case and its report.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+			report := NewTestCaseReport(failedTestCases[0])
+			assert.EqualValues(t, failedTestCases[0].ID(), report.ID)
+			assert.EqualValues(t, TestCaseStatusFailed, report.Status)
+
+			// Verify each call in the report matches the call sequence.
+			failingSequence := *failedTestCases[0].CallSequence()
+			assert.Len(t, report.CallSequence, len(failingSequence))
+			for i, cse := range failingSequence {
+				callReport := report.CallSequence[i]
+				assert.EqualValues(t, "TestContract", callReport.Contract)
+				assert.EqualValues(t, "callingMeFails(uint256)", callReport.Method)
+				assert.Len(t, callReport.Arguments, 1)
+				assert.EqualValues(t, cse.Call.From.String(), callReport.Sender)
+				assert.EqualValues(t, cse.ChainReference.Block.Header.Number.Uint64(), callReport.BlockNumber)
+				assert.EqualValues(t, cse.ChainReference.Block.Header.Time, callReport.BlockTimestamp)
+				assert.EqualValues(t, cse.Call.Value.String(), callReport.Value)
+			}
+
+			// Verify the report can be serialized.
+			_, err = json.Marshal(report)
+			assert.NoError(t, err)
+		},
+	})
+}
+
+// TestJUnitTestReport runs a test to ensure a JUnit XML test report is written with a test case element for each test
+// case, where failed test cases contain their call sequence.
+func TestJUnitTestReport(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestReportFormats = []string{"junit"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Read our test report and verify it describes every test case.
+			b, err := os.ReadFile(filepath.Join("crytic-export", "reports", "junit.xml"))
+			assert.NoError(t, err)
+			var testSuite junitTestSuite
+			err = xml.Unmarshal(b, &testSuite)
+			assert.NoError(t, err)
+			assert.Len(t, testSuite.TestCases, len(f.fuzzer.TestCases()))
+
+			// Verify our failed test case is reported with its call sequence.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+			assert.EqualValues(t, len(failedTestCases), testSuite.Failures)
+			for _, junitCase := range testSuite.TestCases {
+				if junitCase.ClassName == failedTestCases[0].ID() {
+					assert.NotNil(t, junitCase.Failure)
+					assert.Contains(t, junitCase.Failure.Text, failedTestCases[0].CallSequence().String())
+				}
+			}
+		},
+	})
+}
+
+// TestSARIFTestReport runs a test to ensure a SARIF test report is written for failed test cases, with each result
+// pointing at the source line of the tested method's definition.
+func TestSARIFTestReport(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestReportFormats = []string{"sarif"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Read our test report and verify it has a result for our failed test case.
+			b, err := os.ReadFile(filepath.Join("crytic-export", "reports", "results.sarif"))
+			assert.NoError(t, err)
+			var report sarifLog
+			err = json.Unmarshal(b, &report)
+			assert.NoError(t, err)
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+			assert.Len(t, report.Runs, 1)
+			assert.Len(t, report.Runs[0].Results, len(failedTestCases))
+
+			// Verify the result points at the line defining the failing method.
+			result := report.Runs[0].Results[0]
+			assert.EqualValues(t, failedTestCases[0].ID(), result.RuleID)
+			assert.Len(t, result.Locations, 1)
+			location := result.Locations[0].PhysicalLocation
+			source, err := os.ReadFile(filepath.FromSlash(location.ArtifactLocation.URI))
+			assert.NoError(t, err)
+			lines := strings.Split(string(source), "\n")
+			assert.Greater(t, location.Region.StartLine, 0)
+			assert.LessOrEqual(t, location.Region.StartLine, len(lines))
+			assert.Contains(t, lines[location.Region.StartLine-1], "function callingMeFails")
+		},
+	})
+}
+
+// TestFailOnCorpusLoadError runs a test to ensure that when failOnCorpusLoadError is enabled, a corpus call sequence
+// which can no longer be replayed aborts the fuzzer on startup, rather than being disabled.
+func TestFailOnCorpusLoadError(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.CorpusDirectory = "corpus"
+			config.Fuzzing.FailOnCorpusLoadError = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Write a call sequence to the corpus which cannot be replayed.
+			writeInvalidCorpusCallSequence(f)
+
+			// Start the fuzzer, which should abort as the call sequence cannot be replayed.
+			err := f.fuzzer.Start()
+			assert.Error(t, err)
+			assert.ErrorContains(t, err, "invalid.json")
+		},
+	})
+}
+
+// TestFuzzerRandomSeed runs a test to ensure that two fuzzers configured with the same random seed and a single
+// worker generate call sequences from the same sequence of seeds.
+func TestFuzzerRandomSeed(t *testing.T) {
+	seed := int64(1234)
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/state_changing_methods_only.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 1
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.RandomSeed = &seed
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Define a helper which runs a fuzzer and records the sender and calldata of every call it generated. The
+			// contract has multiple methods, so this also ensures methods are selected in a deterministic order.
+			recordCalls := func(fuzzer *Fuzzer) []string {
+				generatedCalls := make([]string, 0)
+				fuzzer.Hooks.CallSequenceTestFuncs = append(fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+					lastCall := callSequence[len(callSequence)-1].Call
+					generatedCalls = append(generatedCalls, lastCall.From.Hex()+":"+hexutil.Encode(lastCall.Data))
+					return make([]ShrinkCallSequenceRequest, 0), nil
+				})
+				err := fuzzer.Start()
+				assert.NoError(t, err)
+				return generatedCalls
+			}
+
+			// Run the fuzzer, then run a new fuzzer with the same configuration and ensure the same calls were made.
+			// The test limit is enforced asynchronously, so we only compare the calls both fuzzers generated.
+			generatedCalls := recordCalls(f.fuzzer)
+			secondFuzzer, err := NewFuzzer(f.fuzzer.Config())
+			assert.NoError(t, err)
+			secondGeneratedCalls := recordCalls(secondFuzzer)
+			count := min(len(generatedCalls), len(secondGeneratedCalls))
+			assert.Greater(t, count, 0)
+			assert.EqualValues(t, generatedCalls[:count], secondGeneratedCalls[:count])
+		},
+	})
+}
+
+// TestFuzzerSelfCheck runs a test to ensure that campaigns run in self-check mode stop after the same call and produce
+// the same results each time, and that campaigns whose results diverge are reported as such.
+func TestFuzzerSelfCheck(t *testing.T) {
+	// Define a helper which creates and runs a fuzzer in self-check mode with the provided project configuration.
+	runFuzzer := func(projectConfig config.ProjectConfig) *Fuzzer {
+		fuzzer, err := NewFuzzer(projectConfig)
+		assert.NoError(t, err)
+		fuzzer.EnableSelfCheck()
+		err = fuzzer.Start()
+		assert.NoError(t, err)
+		return fuzzer
+	}
+
+	// Run campaigns against a harness with multiple methods, which must be selected in the same order each time.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/self_check/deterministic.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 4
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// A self-check configuration requires a test limit.
+			projectConfig := f.fuzzer.Config()
+			projectConfig.Fuzzing.TestLimit = 0
+			_, err := SelfCheckProjectConfig(projectConfig)
+			assert.Error(t, err)
+
+			// Create a self-check configuration and verify it was adjusted to produce reproducible campaigns.
+			projectConfig, err = SelfCheckProjectConfig(f.fuzzer.Config())
+			assert.NoError(t, err)
+			assert.NotNil(t, projectConfig.Fuzzing.RandomSeed)
+			assert.EqualValues(t, 1, projectConfig.Fuzzing.Workers)
+
+			// Run two campaigns with the same configuration and ensure both stopped after exactly the test limit, and
+			// their results match.
+			selfCheckFuzzer := runFuzzer(projectConfig)
+			fuzzer := runFuzzer(projectConfig)
+			assert.EqualValues(t, projectConfig.Fuzzing.TestLimit, selfCheckFuzzer.metrics.CallsTested().Uint64())
+			assert.EqualValues(t, projectConfig.Fuzzing.TestLimit, fuzzer.metrics.CallsTested().Uint64())
+			assert.NotEmpty(t, fuzzer.TestCasesWithStatus(TestCaseStatusFailed))
+			assert.NoError(t, fuzzer.CompareCampaignResults(selfCheckFuzzer))
+
+			// Run a campaign which cannot fail any tests, and ensure the divergence is reported.
+			projectConfig.Fuzzing.Testing.AssertionTesting.Enabled = false
+			divergentFuzzer := runFuzzer(projectConfig)
+			assert.Error(t, divergentFuzzer.CompareCampaignResults(selfCheckFuzzer))
+		},
+	})
+
+	// Run campaigns against a harness which depends on host state, and ensure the divergence is reported.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/self_check/nondeterministic.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.EnableFileAccess = true
+			config.Fuzzing.TestChainConfig.CheatCodeConfig.FileAccessRoot = t.TempDir()
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			projectConfig, err := SelfCheckProjectConfig(f.fuzzer.Config())
+			assert.NoError(t, err)
+			selfCheckFuzzer := runFuzzer(projectConfig)
+			fuzzer := runFuzzer(projectConfig)
+			assert.NotEmpty(t, selfCheckFuzzer.TestCasesWithStatus(TestCaseStatusFailed))
+			assert.Empty(t, fuzzer.TestCasesWithStatus(TestCaseStatusFailed))
+			assert.Error(t, fuzzer.CompareCampaignResults(selfCheckFuzzer))
+		},
+	})
+}
+
+// TestFuzzerTraceAllSequences runs a test to ensure that when sequence tracing is enabled, each worker writes the call
+// sequences it executes to its own file.
+func TestFuzzerTraceAllSequences(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_even_number.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 2
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.TraceAllSequences = true
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Read the trace file for each worker and verify it describes the executed calls.
+			for i := 0; i < f.fuzzer.Config().Fuzzing.Workers; i++ {
+				b, err := os.ReadFile(filepath.Join("crytic-export", "sequence_traces", fmt.Sprintf("worker-%d.log", i)))
+				assert.NoError(t, err)
+				assert.Contains(t, string(b), "[Sequence ")
+				assert.Contains(t, string(b), "TestContract.")
+				assert.Contains(t, string(b), "block=")
+			}
+		},
+	})
+}
+
+// TestFuzzerGenesisBalances runs a test to ensure that the sender and deployer accounts are funded with the balances
+// specified by the config when the test chain is created.
+func TestFuzzerGenesisBalances(t *testing.T) {
+	senderBalance, deployerBalance := big.NewInt(1_000), big.NewInt(2_000)
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 100
+			config.Fuzzing.SenderAddresses = []string{"0x10000", "0x20000", "0x30000"}
+			config.Fuzzing.DeployerAddress = "0x30000"
+			config.Fuzzing.SenderBalance = senderBalance
+			config.Fuzzing.DeployerBalance = deployerBalance
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Record the genesis balances of our accounts before the chain is set up.
+			balances := make(map[common.Address]*big.Int)
+			existingChainSetupFunc := f.fuzzer.Hooks.ChainSetupFunc
+			f.fuzzer.Hooks.ChainSetupFunc = func(fuzzer *Fuzzer, testChain *chain.TestChain) (*executiontracer.ExecutionTrace, error) {
+				for _, address := range []string{"0x10000", "0x20000", "0x30000"} {
+					account := common.HexToAddress(address)
+					balances[account] = testChain.State().GetBalance(account).ToBig()
+				}
+				return existingChainSetupFunc(fuzzer, testChain)
+			}
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Verify the senders were funded with the sender balance, and the deployer with the deployer balance, as it
+			// takes precedence for an account which is both.
+			assert.EqualValues(t, senderBalance, balances[common.HexToAddress("0x10000")])
+			assert.EqualValues(t, senderBalance, balances[common.HexToAddress("0x20000")])
+			assert.EqualValues(t, deployerBalance, balances[common.HexToAddress("0x30000")])
+		},
+	})
+}
+
+// TestStateChangingMethodsOnly runs a test to ensure that when testing view methods is disabled, the fuzzer never
+// calls pure or view methods, and only calls state changing methods.
+func TestStateChangingMethodsOnly(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/state_changing_methods_only.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 5_000
+			config.Fuzzing.Testing.StopOnNoTests = false
+			config.Fuzzing.Testing.AssertionTesting.TestViewMethods = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Record the methods of every call executed.
+			calledMethods := make(map[string]bool)
+			var lock sync.Mutex
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				lastCall := callSequence[len(callSequence)-1].Call
+				if lastCall.DataAbiValues != nil {
+					assert.False(t, lastCall.DataAbiValues.Method.IsConstant(), "pure/view method %v was called", lastCall.DataAbiValues.Method.Sig)
+					calledMethods[lastCall.DataAbiValues.Method.Sig] = true
+				}
+				return make([]ShrinkCallSequenceRequest, 0), nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Ensure the state changing methods were called, while the pure/view methods were not.
+			assert.True(t, calledMethods["setX(uint256)"])
+			assert.True(t, calledMethods["incrementX()"])
+			assert.False(t, calledMethods["getX()"])
+			assert.False(t, calledMethods["addOne(uint256)"])
+		},
+	})
+}
+
+// TestFuzzerSenderWeights runs a test to ensure that when sender weights are provided, generated calls are sent from
+// each sender according to its weight, with unweighted senders defaulting to a weight of one.
+func TestFuzzerSenderWeights(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 2_000
+			config.Fuzzing.SenderAddresses = []string{"0x10000", "0x20000", "0x30000"}
+			config.Fuzzing.SenderWeights = map[string]uint64{"0x10000": 100}
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Count the sender of every call executed.
+			senderCounts := make(map[common.Address]int)
+			var lock sync.Mutex
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				senderCounts[callSequence[len(callSequence)-1].Call.From]++
+				return make([]ShrinkCallSequenceRequest, 0), nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// The heavily weighted sender should send far more calls than either unweighted sender, which should still
+			// be used.
+			weightedSender := common.HexToAddress("0x10000")
+			for _, sender := range []common.Address{common.HexToAddress("0x20000"), common.HexToAddress("0x30000")} {
+				assert.Greater(t, senderCounts[sender], 0)
+				assert.Greater(t, senderCounts[weightedSender], 10*senderCounts[sender])
+			}
+		},
+	})
+}
+
+// TestFuzzerTxsPerBlock runs a test to ensure that when multiple transactions per block are configured, generated
+// calls are grouped into blocks of that size, sharing their block number and timestamp.
+func TestFuzzerTxsPerBlock(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/chain/txs_per_block.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.TxsPerBlock = 3
+			config.Fuzzing.Testing.StopOnNoTests = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Only generate new call sequences, so corpus mutations do not alter the block structure.
+			existingSeqGenConfigFunc := f.fuzzer.Hooks.NewCallSequenceGeneratorConfigFunc
+			f.fuzzer.Hooks.NewCallSequenceGeneratorConfigFunc = func(fuzzer *Fuzzer, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (*CallSequenceGeneratorConfig, error) {
+				seqGenConfig, err := existingSeqGenConfigFunc(fuzzer, valueSet, randomProvider)
+				if err == nil {
+					seqGenConfig.NewSequenceProbability = 1
+				}
+				return seqGenConfig, err
+			}
+
+			// Verify each call is included in the same block as the previous one, unless it begins a new group of three.
+			var groupsChecked int
+			var lock sync.Mutex
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				index := len(callSequence) - 1
+				if index == 0 {
+					return make([]ShrinkCallSequenceRequest, 0), nil
+				}
+				current := callSequence[index].ChainReference
+				previous := callSequence[index-1].ChainReference
+				if index%3 == 0 {
+					assert.Greater(t, current.Block.Header.Number.Uint64(), previous.Block.Header.Number.Uint64())
+					assert.EqualValues(t, 0, current.TransactionIndex)
+				} else {
+					assert.EqualValues(t, previous.Block.Header.Number.Uint64(), current.Block.Header.Number.Uint64())
+					assert.EqualValues(t, previous.Block.Header.Time, current.Block.Header.Time)
+					assert.EqualValues(t, previous.TransactionIndex+1, current.TransactionIndex)
+					if index%3 == 2 {
+						groupsChecked++
+					}
+				}
+				return make([]ShrinkCallSequenceRequest, 0), nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+			assert.Greater(t, groupsChecked, 0)
+		},
+	})
+}
+
+// TestPruneInvalidCorpus runs a test to ensure that when pruneInvalidCorpus is enabled, a corpus call sequence which
+// can no longer be replayed is deleted from the corpus directory on startup.
+func TestPruneInvalidCorpus(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.CorpusDirectory = "corpus"
+			config.Fuzzing.PruneInvalidCorpus = true
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Write a call sequence to the corpus which cannot be replayed.
+			invalidSequencePath := writeInvalidCorpusCallSequence(f)
+
+			// Start the fuzzer, which should prune the invalid call sequence rather than aborting.
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+			assert.NoFileExists(t, invalidSequencePath)
+		},
+	})
+}
+
+// TestFuzzerReplay runs a test to ensure a serialized failing call sequence can be replayed on a new Fuzzer, failing
+// the same test without fuzzing and attaching an execution trace to each call.
+func TestFuzzerReplay(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Serialize our failing sequence, as it would be saved in the corpus.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+			b, err := json.Marshal(*failedTestCases[0].CallSequence())
+			assert.NoError(t, err)
+			var callSequence calls.CallSequence
+			err = json.Unmarshal(b, &callSequence)
+			assert.NoError(t, err)
+
+			// Replay the sequence on a new fuzzer and verify the same test fails.
+			replayFuzzer, err := NewFuzzer(f.fuzzer.Config())
+			assert.NoError(t, err)
+			replayedSequence, err := replayFuzzer.Replay(callSequence)
+			assert.NoError(t, err)
+			replayedFailedTestCases := replayFuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.Len(t, replayedFailedTestCases, 1)
+			assert.EqualValues(t, failedTestCases[0].ID(), replayedFailedTestCases[0].ID())
+
+			// Verify every replayed call has an execution trace attached.
+			assert.Len(t, replayedSequence, len(callSequence))
+			for _, cse := range replayedSequence {
+				assert.NotNil(t, cse.ExecutionTrace)
+			}
+		},
+	})
+}
+
+// TestFuzzerExecuteSequence runs a test to ensure that a call sequence executed through the library entry point
+// returns the message results of each call and the test cases it failed.
+func TestFuzzerExecuteSequence(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_immediate.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Obtain our failing sequence.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.NotEmpty(t, failedTestCases, "expected to have failed test cases")
+			callSequence, err := failedTestCases[0].CallSequence().Clone()
+			assert.NoError(t, err)
+
+			// Execute the sequence on a new fuzzer and verify we obtain results for every call, and the same failure.
+			executingFuzzer, err := NewFuzzer(f.fuzzer.Config())
+			assert.NoError(t, err)
+			messageResults, executedFailedTestCases, err := executingFuzzer.ExecuteSequence(callSequence)
+			assert.NoError(t, err)
+			assert.Len(t, messageResults, len(callSequence))
+			for _, messageResult := range messageResults {
+				assert.NotNil(t, messageResult.Receipt)
+			}
+			assert.Len(t, executedFailedTestCases, 1)
+			assert.EqualValues(t, failedTestCases[0].ID(), executedFailedTestCases[0].ID())
+		},
+	})
+}
+
+// TestTestingScope runs tests to ensure dynamically deployed contracts are tested when the "test all contracts"
+// config option is specified. It also runs the fuzzer without the option enabled to ensure they are not tested.
+func TestTestingScope(t *testing.T) {
+	for _, testingAllContracts := range []bool{false, true} {
 		runFuzzerTest(t, &fuzzerSolcFileTest{
-			filePath: filePath,
+			filePath: "testdata/contracts/deployments/testing_scope.sol",
 			configUpdates: func(config *config.ProjectConfig) {
 				config.Fuzzing.TargetContracts = []string{"TestContract"}
-				config.Fuzzing.TestLimit = 10000
-				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
+				config.Fuzzing.Testing.TestAllContracts = testingAllContracts
+				config.Fuzzing.Testing.StopOnFailedTest = false
 				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 				config.Slither.UseSlither = false
 			},
@@ -355,47 +2782,75 @@ func TestConsoleLog(t *testing.T) {
 				err := f.fuzzer.Start()
 				assert.NoError(t, err)
 
-				// Check for failed assertion tests.
-				failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
-				assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
-
-				// Obtain our first failed test case, get the message, and verify it contains our assertion failed.
-				failingSequence := *failedTestCase[0].CallSequence()
-				assert.NotEmpty(t, failingSequence, "expected to have calls in the call sequence failing an assertion test")
-
-				// Obtain the last call
-				lastCall := failingSequence[len(failingSequence)-1]
-				assert.NotNilf(t, lastCall.ExecutionTrace, "expected to have an execution trace attached to call sequence for this test")
-
-				// Get the execution trace message
-				executionTraceMsg := lastCall.ExecutionTrace.Log().String()
-
-				// Verify it contains all expected logs
-				for _, expectedLog := range expectedLogs {
-					assert.Contains(t, executionTraceMsg, expectedLog)
+				// Define our expected failure count
+				var expectedFailureCount int
+				if testingAllContracts {
+					expectedFailureCount = 4
+				} else {
+					expectedFailureCount = 2
 				}
+
+				// Check for any failed tests and verify coverage was captured
+				assert.EqualValues(t, len(f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)), expectedFailureCount)
 			},
 		})
 	}
 }
 
-// TestDeploymentsInnerDeployments runs tests to ensure dynamically deployed contracts are detected by the Fuzzer and
-// their properties are tested appropriately.
-func TestDeploymentsInnerDeployments(t *testing.T) {
-	// These contracts provide functions to deploy inner contracts which have properties that will produce a failure.
-	filePaths := []string{
-		"testdata/contracts/deployments/inner_deployment.sol",
-		"testdata/contracts/deployments/inner_inner_deployment.sol",
+// TestDeploymentsWithArgs runs tests to ensure contracts deployed with config provided constructor arguments are
+// deployed as expected. It expects all properties should fail (indicating values provided were set accordingly).
+func TestDeploymentsWithArgs(t *testing.T) {
+	// This contract deploys a contract with specific constructor arguments. Property tests will fail if they are
+	// set correctly.
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/deployments/deployment_with_args.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"DeploymentWithArgs", "Dependent"}
+			config.Fuzzing.ConstructorArgs = map[string]map[string]any{
+				"DeploymentWithArgs": {
+					"_x": "123456789",
+					"_y": "0x5465",
+					"_z": map[string]any{
+						"a": "0x4d2",
+						"b": "0x54657374206465706c6f796d656e74207769746820617267756d656e7473",
+					},
+				},
+				"Dependent": {
+					"_deployed": "DeployedContract:DeploymentWithArgs",
+				},
+			}
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.TestLimit = 500 // this test should expose a failure quickly.
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check to see if there are any failures
+			assert.EqualValues(t, len(f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)), 4)
+		},
+	})
+}
+
+// TestExpectedReverts runs a test to ensure reverts which are configured as expected are not counted as reverted calls,
+// while the same reverts are counted when they are not configured.
+func TestExpectedReverts(t *testing.T) {
+	expectedRevertConfigs := [][]string{
+		{"expected revert", hexutil.Encode(crypto.Keccak256([]byte("ExpectedError()"))[:4])},
+		{},
 	}
-	for _, filePath := range filePaths {
+	for _, expectedReverts := range expectedRevertConfigs {
 		runFuzzerTest(t, &fuzzerSolcFileTest{
-			filePath: filePath,
+			filePath: "testdata/contracts/reverts/expected_reverts.sol",
 			configUpdates: func(config *config.ProjectConfig) {
-				config.Fuzzing.TargetContracts = []string{"InnerDeploymentFactory"}
-				config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
-				config.Fuzzing.Testing.StopOnFailedContractMatching = true
-				config.Fuzzing.Testing.TestAllContracts = true // test dynamically deployed contracts
-				config.Fuzzing.Testing.AssertionTesting.Enabled = false
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 1_000
+				config.Fuzzing.ExpectedReverts = expectedReverts
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
 				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 				config.Slither.UseSlither = false
 			},
@@ -404,43 +2859,25 @@ func TestDeploymentsInnerDeployments(t *testing.T) {
 				err := f.fuzzer.Start()
 				assert.NoError(t, err)
 
-				// Check for any failed tests and verify coverage was captured
-				assertFailedTestsExpected(f, true)
-				assertCorpusCallSequencesCollected(f, true)
+				// Every call reverts, so reverted calls should only be counted if they were not expected.
+				assert.Greater(t, f.fuzzer.metrics.CallsTested().Uint64(), uint64(0))
+				if len(expectedReverts) > 0 {
+					assert.EqualValues(t, 0, f.fuzzer.metrics.CallsReverted().Uint64())
+				} else {
+					assert.Greater(t, f.fuzzer.metrics.CallsReverted().Uint64(), uint64(0))
+				}
 			},
 		})
 	}
-
-	// This contract deploys an inner contract upon construction, which contains properties that will produce a failure.
-	runFuzzerTest(t, &fuzzerSolcFileTest{
-		filePath: "testdata/contracts/deployments/inner_deployment_on_construction.sol",
-		configUpdates: func(config *config.ProjectConfig) {
-			config.Fuzzing.TargetContracts = []string{"InnerDeploymentFactory"}
-			config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
-			config.Fuzzing.Testing.StopOnFailedContractMatching = true
-			config.Fuzzing.Testing.TestAllContracts = true // test dynamically deployed contracts
-			config.Fuzzing.Testing.AssertionTesting.Enabled = false
-			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
-			config.Slither.UseSlither = false
-		},
-		method: func(f *fuzzerTestContext) {
-			// Start the fuzzer
-			err := f.fuzzer.Start()
-			assert.NoError(t, err)
-
-			// Check to see if there are any failures
-			assertFailedTestsExpected(f, true)
-		},
-	})
 }
 
-// TestDeploymentsInternalLibrary runs a test to ensure internal libraries behave correctly.
-func TestDeploymentsInternalLibrary(t *testing.T) {
+// TestValueGenerationGenerateAllTypes runs a test to ensure various types of fuzzer inputs can be generated.
+func TestValueGenerationGenerateAllTypes(t *testing.T) {
 	runFuzzerTest(t, &fuzzerSolcFileTest{
-		filePath: "testdata/contracts/deployments/internal_library.sol",
+		filePath: "testdata/contracts/value_generation/generate_all_types.sol",
 		configUpdates: func(config *config.ProjectConfig) {
-			config.Fuzzing.TargetContracts = []string{"TestInternalLibrary"}
-			config.Fuzzing.TestLimit = 100 // this test should expose a failure quickly.
+			config.Fuzzing.TargetContracts = []string{"GenerateAllTypes"}
+			config.Fuzzing.TestLimit = 10_000
 			config.Fuzzing.Testing.AssertionTesting.Enabled = false
 			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 			config.Slither.UseSlither = false
@@ -457,17 +2894,21 @@ func TestDeploymentsInternalLibrary(t *testing.T) {
 	})
 }
 
-// TestDeploymentsWithPredeploy runs a test to ensure that predeployed contracts are instantiated correctly.
-func TestDeploymentsWithPredeploy(t *testing.T) {
+// TestValueGenerationIntegerArgumentRanges runs a test to ensure integer arguments are constrained to the ranges
+// provided in the project configuration.
+func TestValueGenerationIntegerArgumentRanges(t *testing.T) {
+	integerArgumentRanges := []config.IntegerArgumentRangeConfig{
+		{FunctionSignature: "TestContract.setPercentage(uint256)", ArgumentIndex: 0, Min: big.NewInt(0), Max: big.NewInt(100)},
+		{FunctionSignature: "TestContract.setOffset(int8)", ArgumentIndex: 0, Min: big.NewInt(-5), Max: big.NewInt(5)},
+	}
 	runFuzzerTest(t, &fuzzerSolcFileTest{
-		filePath: "testdata/contracts/deployments/predeploy_contract.sol",
+		filePath: "testdata/contracts/value_generation/integer_argument_range.sol",
 		configUpdates: func(config *config.ProjectConfig) {
 			config.Fuzzing.TargetContracts = []string{"TestContract"}
-			config.Fuzzing.TargetContractsBalances = []*big.Int{big.NewInt(1)}
-			config.Fuzzing.TestLimit = 1000 // this test should expose a failure immediately
+			config.Fuzzing.TestLimit = 10_000
+			config.Fuzzing.IntegerArgumentRanges = integerArgumentRanges
 			config.Fuzzing.Testing.PropertyTesting.Enabled = false
 			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
-			config.Fuzzing.PredeployedContracts = map[string]string{"PredeployContract": "0x1234"}
 			config.Slither.UseSlither = false
 		},
 		method: func(f *fuzzerTestContext) {
@@ -476,143 +2917,129 @@ func TestDeploymentsWithPredeploy(t *testing.T) {
 			assert.NoError(t, err)
 
 			// Check for any failed tests and verify coverage was captured
-			assertFailedTestsExpected(f, true)
+			assertFailedTestsExpected(f, false)
 			assertCorpusCallSequencesCollected(f, true)
 		},
 	})
 }
 
-// TestDeploymentsWithPayableConstructor runs a test to ensure that we can send ether to payable constructors
-func TestDeploymentsWithPayableConstructors(t *testing.T) {
+// TestValueGenerationCallValues runs a test to ensure the values sent with calls to payable methods are generated
+// within the configured maximum, including zero and edge values.
+func TestValueGenerationCallValues(t *testing.T) {
+	maxValue := big.NewInt(1000)
 	runFuzzerTest(t, &fuzzerSolcFileTest{
-		filePath: "testdata/contracts/deployments/deploy_payable_constructors.sol",
+		filePath: "testdata/contracts/value_generation/call_value_generation.sol",
 		configUpdates: func(config *config.ProjectConfig) {
-			config.Fuzzing.TargetContracts = []string{"FirstContract", "SecondContract"}
-			config.Fuzzing.TargetContractsBalances = []*big.Int{big.NewInt(0), big.NewInt(1e18)}
-			config.Fuzzing.TestLimit = 1 // this should happen immediately
-			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 5_000
+			config.Fuzzing.CallValueGeneration.Enabled = true
+			config.Fuzzing.CallValueGeneration.NonZeroProbability = 0.5
+			config.Fuzzing.CallValueGeneration.MaxValue = maxValue
+			config.Fuzzing.CallValueGeneration.EdgeValueProbability = 0.5
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
 			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 			config.Slither.UseSlither = false
 		},
 		method: func(f *fuzzerTestContext) {
+			// Record the values sent with each call to our payable method.
+			sentValues := make(map[string]bool)
+			var lock sync.Mutex
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				lastCall := callSequence[len(callSequence)-1].Call
+				if lastCall.DataAbiValues != nil && lastCall.DataAbiValues.Method.Name == "deposit" {
+					assert.LessOrEqual(t, lastCall.Value.Cmp(maxValue), 0, "call value %v exceeds the maximum", lastCall.Value)
+					sentValues[lastCall.Value.String()] = true
+				}
+				return make([]ShrinkCallSequenceRequest, 0), nil
+			})
+
 			// Start the fuzzer
 			err := f.fuzzer.Start()
 			assert.NoError(t, err)
 
-			// Check for any failed tests and verify coverage was captured
-			assertFailedTestsExpected(f, false)
-			assertCorpusCallSequencesCollected(f, true)
+			// Ensure zero, the edge values, and other values were all sent.
+			assert.True(t, sentValues["0"])
+			assert.True(t, sentValues["1"])
+			assert.True(t, sentValues[maxValue.String()])
+			assert.Greater(t, len(sentValues), 3)
 		},
 	})
 }
 
-// TestDeploymentsSelfDestruct runs a test to ensure dynamically deployed contracts are detected by the Fuzzer and
-// their properties are tested appropriately.
-func TestDeploymentsSelfDestruct(t *testing.T) {
-	// These contracts provide functions to deploy inner contracts which have properties that will produce a failure.
-	filePaths := []string{
-		"testdata/contracts/deployments/selfdestruct_init.sol",
-		"testdata/contracts/deployments/selfdestruct_runtime.sol",
-	}
-	for _, filePath := range filePaths {
+// TestValueGenerationSeedFromEvents runs a test to ensure values emitted in events are added to the value set when
+// seeding from events is enabled, allowing the fuzzer to solve a problem it otherwise cannot.
+func TestValueGenerationSeedFromEvents(t *testing.T) {
+	for _, seedFromEvents := range []bool{false, true} {
 		runFuzzerTest(t, &fuzzerSolcFileTest{
-			filePath: filePath,
+			filePath: "testdata/contracts/value_generation/seed_from_events.sol",
 			configUpdates: func(config *config.ProjectConfig) {
-				config.Fuzzing.TargetContracts = []string{"InnerDeploymentFactory"}
-				config.Fuzzing.TestLimit = 500 // this test should expose a failure quickly.
-				config.Fuzzing.Testing.StopOnNoTests = false
-				config.Fuzzing.Testing.AssertionTesting.Enabled = false
+				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 20_000
+				config.Fuzzing.SeedFromEvents = seedFromEvents
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
 				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
-				config.Fuzzing.Testing.TestAllContracts = true
 				config.Slither.UseSlither = false
 			},
 			method: func(f *fuzzerTestContext) {
-				// Subscribe to any mined block events globally. When receiving them, check contract changes for a
-				// self-destruct.
-				selfDestructCount := 0
-				events.SubscribeAny(func(event chain.PendingBlockCommittedEvent) error {
-					for _, messageResults := range event.Block.MessageResults {
-						for _, contractDeploymentChange := range messageResults.ContractDeploymentChanges {
-							if contractDeploymentChange.SelfDestructed {
-								selfDestructCount++
-							}
-						}
-					}
-					return nil
-				})
-
 				// Start the fuzzer
 				err := f.fuzzer.Start()
 				assert.NoError(t, err)
 
-				// When it's done, we should've had at least one self-destruction.
-				assert.Greater(t, selfDestructCount, 0, "no SELFDESTRUCT operations were detected, when they should have been.")
+				// The secret should only be guessed if event values were added to the value set.
+				assertFailedTestsExpected(f, seedFromEvents)
 			},
 		})
 	}
 }
 
-// TestExecutionTraces runs tests to ensure that execution traces capture information
-// regarding assertion failures, revert reasons, etc.
-func TestExecutionTraces(t *testing.T) {
-	expectedMessagesPerTest := map[string][]string{
-		"testdata/contracts/execution_tracing/call_and_deployment_args.sol": {"Hello from deployment args!", "Hello from call args!"},
-		"testdata/contracts/execution_tracing/cheatcodes.sol":               {"StdCheats.toString(bool)(true)"},
-		"testdata/contracts/execution_tracing/event_emission.sol":           {"TestEvent", "TestIndexedEvent", "TestMixedEvent", "Hello from event args!", "Hello from library event args!"},
-		"testdata/contracts/execution_tracing/proxy_call.sol":               {"TestContract -> InnerDeploymentContract.setXY", "Hello from proxy call args!"},
-		"testdata/contracts/execution_tracing/revert_custom_error.sol":      {"CustomError", "Hello from a custom error!"},
-		"testdata/contracts/execution_tracing/revert_reasons.sol":           {"RevertingContract was called and reverted."},
-		"testdata/contracts/execution_tracing/self_destruct.sol":            {"[selfdestruct]", "[panic: assertion failed]"},
-	}
-	for filePath, expectedTraceMessages := range expectedMessagesPerTest {
+// TestTargetReachabilityWarning runs a test to ensure a warning is logged once the grace period elapses if no call to a
+// target contract has succeeded, and that no warning is logged if a target contract is reached.
+func TestTargetReachabilityWarning(t *testing.T) {
+	for _, reachable := range []bool{false, true} {
 		runFuzzerTest(t, &fuzzerSolcFileTest{
-			filePath: filePath,
+			filePath: "testdata/contracts/deployments/unreachable_target.sol",
 			configUpdates: func(config *config.ProjectConfig) {
 				config.Fuzzing.TargetContracts = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 5_000
+				config.Fuzzing.TargetReachabilityGracePeriod = 10
+				config.Fuzzing.ConstructorArgs = map[string]map[string]any{
+					"TestContract": {"_open": reachable},
+				}
 				config.Fuzzing.Testing.PropertyTesting.Enabled = false
 				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 				config.Slither.UseSlither = false
 			},
 			method: func(f *fuzzerTestContext) {
+				// Capture the fuzzer's log output.
+				logOutput := &syncBuffer{}
+				f.fuzzer.logger.AddWriter(logOutput, logging.UNSTRUCTURED, false)
+
 				// Start the fuzzer
 				err := f.fuzzer.Start()
 				assert.NoError(t, err)
 
-				// Check for failed assertion tests.
-				failedTestCase := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
-				assert.NotEmpty(t, failedTestCase, "expected to have failed test cases")
-
-				// Obtain our first failed test case, get the message, and verify it contains our assertion failed.
-				failingSequence := *failedTestCase[0].CallSequence()
-				assert.NotEmpty(t, failingSequence, "expected to have calls in the call sequence failing an assertion test")
-
-				// Obtain the last call
-				lastCall := failingSequence[len(failingSequence)-1]
-				assert.NotNilf(t, lastCall.ExecutionTrace, "expected to have an execution trace attached to call sequence for this test")
-
-				// Get the execution trace message
-				executionTraceMsg := lastCall.ExecutionTrace.Log().String()
-
-				// Verify it contains all expected strings
-				for _, expectedTraceMessage := range expectedTraceMessages {
-					assert.Contains(t, executionTraceMsg, expectedTraceMessage)
-				}
+				// The warning should only be logged if every call to the target contract reverted.
+				assert.EqualValues(t, !reachable, strings.Contains(logOutput.String(), "No call to a target contract"))
+				assert.EqualValues(t, reachable, f.fuzzer.targetContractReached.Load())
 			},
 		})
 	}
 }
 
-// TestTestingScope runs tests to ensure dynamically deployed contracts are tested when the "test all contracts"
-// config option is specified. It also runs the fuzzer without the option enabled to ensure they are not tested.
-func TestTestingScope(t *testing.T) {
-	for _, testingAllContracts := range []bool{false, true} {
+// TestValueGenerationSeedFromReturnData runs a test to ensure that values nested in the return data of calls are only
+// added to the value set when seeding from return data is enabled.
+func TestValueGenerationSeedFromReturnData(t *testing.T) {
+	for _, seedFromReturnData := range []bool{false, true} {
 		runFuzzerTest(t, &fuzzerSolcFileTest{
-			filePath: "testdata/contracts/deployments/testing_scope.sol",
+			filePath: "testdata/contracts/value_generation/seed_from_return_data.sol",
 			configUpdates: func(config *config.ProjectConfig) {
 				config.Fuzzing.TargetContracts = []string{"TestContract"}
-				config.Fuzzing.TestLimit = 1_000 // this test should expose a failure quickly.
-				config.Fuzzing.Testing.TestAllContracts = testingAllContracts
-				config.Fuzzing.Testing.StopOnFailedTest = false
+				config.Fuzzing.TestLimit = 20_000
+				config.Fuzzing.SeedFromReturnData = seedFromReturnData
+				config.Fuzzing.Testing.AssertionTesting.TestViewMethods = true
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
 				config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 				config.Slither.UseSlither = false
 			},
@@ -621,46 +3048,32 @@ func TestTestingScope(t *testing.T) {
 				err := f.fuzzer.Start()
 				assert.NoError(t, err)
 
-				// Define our expected failure count
-				var expectedFailureCount int
-				if testingAllContracts {
-					expectedFailureCount = 4
-				} else {
-					expectedFailureCount = 2
-				}
-
-				// Check for any failed tests and verify coverage was captured
-				assert.EqualValues(t, len(f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)), expectedFailureCount)
+				// The secret should only be guessed if flattened return values were added to the value set.
+				assertFailedTestsExpected(f, seedFromReturnData)
 			},
 		})
 	}
 }
 
-// TestDeploymentsWithArgs runs tests to ensure contracts deployed with config provided constructor arguments are
-// deployed as expected. It expects all properties should fail (indicating values provided were set accordingly).
-func TestDeploymentsWithArgs(t *testing.T) {
-	// This contract deploys a contract with specific constructor arguments. Property tests will fail if they are
-	// set correctly.
+// TestValueGenerationMethodBlockDelays runs a test to ensure calls to methods with configured block delays advance the
+// block number and timestamp within those delays, rather than the global ones.
+func TestValueGenerationMethodBlockDelays(t *testing.T) {
+	methodBlockDelays := map[string]config.MethodBlockDelayConfig{
+		"TestContract.advance()": {
+			MinBlockNumberDelay:    1,
+			MaxBlockNumberDelay:    1,
+			MinBlockTimestampDelay: 1000,
+			MaxBlockTimestampDelay: 2000,
+		},
+		"TestContract.stay()": {},
+	}
 	runFuzzerTest(t, &fuzzerSolcFileTest{
-		filePath: "testdata/contracts/deployments/deployment_with_args.sol",
+		filePath: "testdata/contracts/value_generation/method_block_delays.sol",
 		configUpdates: func(config *config.ProjectConfig) {
-			config.Fuzzing.TargetContracts = []string{"DeploymentWithArgs", "Dependent"}
-			config.Fuzzing.ConstructorArgs = map[string]map[string]any{
-				"DeploymentWithArgs": {
-					"_x": "123456789",
-					"_y": "0x5465",
-					"_z": map[string]any{
-						"a": "0x4d2",
-						"b": "0x54657374206465706c6f796d656e74207769746820617267756d656e7473",
-					},
-				},
-				"Dependent": {
-					"_deployed": "DeployedContract:DeploymentWithArgs",
-				},
-			}
-			config.Fuzzing.Testing.StopOnFailedTest = false
-			config.Fuzzing.TestLimit = 500 // this test should expose a failure quickly.
-			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 10_000
+			config.Fuzzing.MethodBlockDelays = methodBlockDelays
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
 			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
 			config.Slither.UseSlither = false
 		},
@@ -669,21 +3082,27 @@ func TestDeploymentsWithArgs(t *testing.T) {
 			err := f.fuzzer.Start()
 			assert.NoError(t, err)
 
-			// Check to see if there are any failures
-			assert.EqualValues(t, len(f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)), 4)
+			// Check for any failed tests and verify coverage was captured
+			assertFailedTestsExpected(f, false)
+			assertCorpusCallSequencesCollected(f, true)
 		},
 	})
 }
 
-// TestValueGenerationGenerateAllTypes runs a test to ensure various types of fuzzer inputs can be generated.
-func TestValueGenerationGenerateAllTypes(t *testing.T) {
+// TestValueGenerationBlockTimeJitter runs a test to ensure that when a block time base is configured, each block
+// advanced advances the timestamp by the base, with the total jump deviating by no more than the configured jitter.
+func TestValueGenerationBlockTimeJitter(t *testing.T) {
 	runFuzzerTest(t, &fuzzerSolcFileTest{
-		filePath: "testdata/contracts/value_generation/generate_all_types.sol",
+		filePath: "testdata/contracts/value_generation/block_time_jitter.sol",
 		configUpdates: func(config *config.ProjectConfig) {
-			config.Fuzzing.TargetContracts = []string{"GenerateAllTypes"}
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
 			config.Fuzzing.TestLimit = 10_000
-			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.MaxBlockNumberDelay = 5
+			config.Fuzzing.BlockTimeBase = 12
+			config.Fuzzing.BlockTimeJitter = 3
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
 			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
 			config.Slither.UseSlither = false
 		},
 		method: func(f *fuzzerTestContext) {
@@ -698,6 +3117,69 @@ func TestValueGenerationGenerateAllTypes(t *testing.T) {
 	})
 }
 
+// TestSequenceSeedReplay runs a test to ensure that when sequence seed recording is enabled, replaying the seed a call
+// sequence was generated with regenerates the same call sequence.
+func TestSequenceSeedReplay(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/value_generation/sequence_seed_replay.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.Workers = 1
+			config.Fuzzing.TestLimit = 2_000
+			config.Fuzzing.CoverageEnabled = false
+			config.Fuzzing.RecordSequenceSeeds = true
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Track the latest call sequence executed by the worker.
+			var lastCallSequence calls.CallSequence
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				lastCallSequence = callSequence
+				return make([]ShrinkCallSequenceRequest, 0), nil
+			})
+
+			// Alternate between recording a call sequence and replaying its seed, verifying each replay regenerates the
+			// recorded call sequence.
+			var recordedSeed int64
+			var recordedHash common.Hash
+			replaying := false
+			replayCount := 0
+			f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+				event.Worker.Events.CallSequenceTested.Subscribe(func(event FuzzerWorkerCallSequenceTestedEvent) error {
+					// Call sequences interrupted by the fuzzer stopping are incomplete, so we ignore them.
+					if utils.CheckContextDone(f.fuzzer.ctx) {
+						return nil
+					}
+					hash, err := lastCallSequence.Hash()
+					if err != nil {
+						return err
+					}
+					if replaying {
+						assert.EqualValues(t, recordedSeed, event.Worker.SequenceSeed())
+						assert.EqualValues(t, recordedHash, hash, "replayed call sequence differs from the recorded one")
+						replayCount++
+					} else {
+						recordedSeed, recordedHash = event.Worker.SequenceSeed(), hash
+						event.Worker.ReplaySequenceSeed(recordedSeed)
+					}
+					replaying = !replaying
+					return nil
+				})
+				return nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Ensure we replayed at least one call sequence.
+			assert.Greater(t, replayCount, 0, "no call sequences were replayed")
+		},
+	})
+}
+
 // TestValueGenerationSolving runs a series of tests to test the value generator can solve expected problems.
 func TestValueGenerationSolving(t *testing.T) {
 	// TODO: match_ints_xy is slower than match_uints_xy in the value generator because AST doesn't retain negative
@@ -930,6 +3412,43 @@ func TestCorpusReplayability(t *testing.T) {
 	})
 }
 
+// TestMergeCoverage runs a test to ensure that merging the coverage of several corpus directories writes coverage
+// reports, and that coverage shared between corpora is not counted more than once.
+func TestMergeCoverage(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/value_generation/match_uints_xy.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.TargetContracts = []string{"TestContract"}
+			config.Fuzzing.CorpusDirectory = "corpus"
+			config.Fuzzing.CoverageFormats = []string{"html", "lcov"}
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+			config.Fuzzing.Testing.OptimizationTesting.Enabled = false
+			config.Slither.UseSlither = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer to populate our corpus
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+			assertCorpusCallSequencesCollected(f, true)
+
+			// Merge the coverage of the corpus alone, and of the corpus alongside a copy of itself.
+			singleCoverage, err := f.fuzzer.MergeCoverage([]string{"corpus"}, "merged_single")
+			assert.NoError(t, err)
+			mergedCoverage, err := f.fuzzer.MergeCoverage([]string{"corpus", "corpus"}, "merged")
+			assert.NoError(t, err)
+
+			// The duplicated corpus should not contribute any coverage or hit counts.
+			assert.NotZero(t, mergedCoverage.UniquePCs())
+			assert.True(t, singleCoverage.Equal(mergedCoverage))
+			assert.True(t, mergedCoverage.Equal(singleCoverage))
+
+			// Verify our reports were written.
+			assert.FileExists(t, filepath.Join("merged", "coverage_report.html"))
+			assert.FileExists(t, filepath.Join("merged", "lcov.info"))
+		},
+	})
+}
+
 // TestDeploymentOrderWithCoverage will ensure that changing the order of deployment for the target contracts does not
 // lead to the same coverage. This is also proof that changing the order changes the addresses of the contracts leading
 // to the coverage not being useful.
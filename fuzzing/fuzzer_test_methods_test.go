@@ -1,11 +1,20 @@
 package fuzzing
 
 import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/crytic/medusa/compilation"
 	"github.com/crytic/medusa/events"
+	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,6 +103,23 @@ func assertCorpusCallSequencesCollected(f *fuzzerTestContext, expectCallSequence
 	}
 }
 
+// writeInvalidCorpusCallSequence writes a call sequence to the "corpus" corpus directory, which targets an address no
+// contract is deployed at, so it cannot be replayed when the corpus is initialized.
+// Returns the path of the call sequence file written.
+func writeInvalidCorpusCallSequence(f *fuzzerTestContext) string {
+	to := common.HexToAddress("0xdeadbeef")
+	callSequence := calls.CallSequence{
+		calls.NewCallSequenceElement(nil, calls.NewCallMessage(f.fuzzer.senders[0], &to, 0, big.NewInt(0), f.fuzzer.config.Fuzzing.TransactionGasLimit, nil, nil, nil, []byte{0x01, 0x02, 0x03, 0x04}), 1, 1),
+	}
+	b, err := json.Marshal(callSequence)
+	assert.NoError(f.t, err)
+	sequenceDirectory := filepath.Join("corpus", "call_sequences")
+	assert.NoError(f.t, utils.MakeDirectory(sequenceDirectory))
+	invalidSequencePath := filepath.Join(sequenceDirectory, "invalid.json")
+	assert.NoError(f.t, os.WriteFile(invalidSequencePath, b, 0644))
+	return invalidSequencePath
+}
+
 // expectEventEmitted will subscribe to some event T, update the eventCounter for that event (when the event callback is
 // triggered) and then also add a post execution check to make sure that the event was captured properly.
 func expectEventEmitted[T any](f *fuzzerTestContext, eventEmitter *events.EventEmitter[T]) {
@@ -111,3 +137,26 @@ func expectEventEmitted[T any](f *fuzzerTestContext, eventEmitter *events.EventE
 		assert.Greater(f.t, f.eventCounter[eventType], 0, "Event was not emitted at all")
 	})
 }
+
+// syncBuffer is a bytes.Buffer which is safe for concurrent use, used to capture log output written by multiple workers.
+type syncBuffer struct {
+	// buffer describes the underlying buffer written to.
+	buffer bytes.Buffer
+
+	// lock provides thread synchronization to prevent concurrent access errors.
+	lock sync.Mutex
+}
+
+// Write writes the provided data to the buffer.
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+// String returns the data written to the buffer as a string.
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
@@ -1,18 +1,24 @@
 package fuzzing
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"math/rand"
+	"sort"
+	"strings"
 
 	"github.com/crytic/medusa/chain"
+	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/fuzzing/calls"
 	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
 	"github.com/crytic/medusa/fuzzing/coverage"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // FuzzerWorker describes a single thread worker utilizing its own go-ethereum test node to run property tests against
@@ -40,6 +46,10 @@ type FuzzerWorker struct {
 	// before executing tests.
 	stateChangingMethods []fuzzerTypes.DeployedContractMethod
 
+	// factoryMethods is the subset of stateChangingMethods which are suspected of deploying contracts, as they return
+	// an address or are configured as factory functions. These are favored at the start of generated call sequences.
+	factoryMethods []fuzzerTypes.DeployedContractMethod
+
 	// pureMethods is a list of contract functions which are side-effect free with respect to the EVM (view and/or pure in terms of Solidity mutability).
 	pureMethods []fuzzerTypes.DeployedContractMethod
 
@@ -57,6 +67,18 @@ type FuzzerWorker struct {
 	// FuzzerWorker. It is the value set shared with the underlying valueGenerator.
 	valueSet *valuegeneration.ValueSet
 
+	// callSequenceGeneratorStrategy describes the name of the call sequence generator strategy this worker was
+	// assigned, or an empty string if it uses the default NewCallSequenceGeneratorConfigFunc hook.
+	callSequenceGeneratorStrategy string
+
+	// sequenceSeed describes the seed the randomProvider was reseeded with at the start of the current call sequence.
+	// This is only set if sequence seed recording is enabled in the project configuration.
+	sequenceSeed int64
+
+	// replaySeed describes a previously recorded sequence seed which should be used for the next call sequence instead
+	// of drawing a new one, or nil if no replay was requested.
+	replaySeed *int64
+
 	// Events describes the event system for the FuzzerWorker.
 	Events FuzzerWorkerEvents
 }
@@ -68,8 +90,12 @@ func newFuzzerWorker(fuzzer *Fuzzer, workerIndex int, randomProvider *rand.Rand)
 	// Clone the fuzzer's base value set, so we can build on it with runtime values.
 	valueSet := fuzzer.baseValueSet.Clone()
 
-	// Create a config for our call sequence generator for this new worker.
-	callSequenceGenConfig, err := fuzzer.Hooks.NewCallSequenceGeneratorConfigFunc(fuzzer, valueSet, randomProvider)
+	// Create a config for our call sequence generator for this new worker, using the strategy assigned to its index.
+	strategyName, callSequenceGenConfigFunc, err := fuzzer.callSequenceGeneratorStrategyForWorker(workerIndex)
+	if err != nil {
+		return nil, err
+	}
+	callSequenceGenConfig, err := callSequenceGenConfigFunc(fuzzer, valueSet, randomProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -82,14 +108,16 @@ func newFuzzerWorker(fuzzer *Fuzzer, workerIndex int, randomProvider *rand.Rand)
 
 	// Create a new worker with the data provided.
 	worker := &FuzzerWorker{
-		workerIndex:          workerIndex,
-		fuzzer:               fuzzer,
-		deployedContracts:    make(map[common.Address]*fuzzerTypes.Contract),
-		stateChangingMethods: make([]fuzzerTypes.DeployedContractMethod, 0),
-		pureMethods:          make([]fuzzerTypes.DeployedContractMethod, 0),
-		coverageTracer:       nil,
-		randomProvider:       randomProvider,
-		valueSet:             valueSet,
+		workerIndex:                   workerIndex,
+		fuzzer:                        fuzzer,
+		deployedContracts:             make(map[common.Address]*fuzzerTypes.Contract),
+		stateChangingMethods:          make([]fuzzerTypes.DeployedContractMethod, 0),
+		factoryMethods:                make([]fuzzerTypes.DeployedContractMethod, 0),
+		pureMethods:                   make([]fuzzerTypes.DeployedContractMethod, 0),
+		coverageTracer:                nil,
+		randomProvider:                randomProvider,
+		valueSet:                      valueSet,
+		callSequenceGeneratorStrategy: strategyName,
 	}
 	worker.sequenceGenerator = NewCallSequenceGenerator(worker, callSequenceGenConfig)
 	worker.shrinkingValueMutator = shrinkingValueMutator
@@ -102,6 +130,38 @@ func (fw *FuzzerWorker) WorkerIndex() int {
 	return fw.workerIndex
 }
 
+// CallSequenceGeneratorStrategy returns the name of the call sequence generator strategy assigned to this
+// FuzzerWorker, or an empty string if it uses the default NewCallSequenceGeneratorConfigFunc hook.
+func (fw *FuzzerWorker) CallSequenceGeneratorStrategy() string {
+	return fw.callSequenceGeneratorStrategy
+}
+
+// SequenceSeed returns the seed the worker's random provider was reseeded with at the start of the current (or last)
+// call sequence. This is only meaningful if sequence seed recording is enabled in the project configuration.
+func (fw *FuzzerWorker) SequenceSeed() int64 {
+	return fw.sequenceSeed
+}
+
+// ReplaySequenceSeed requests that the next call sequence generated by this worker use the provided, previously
+// recorded sequence seed, so that its generation is replayed. This has no effect unless sequence seed recording is
+// enabled in the project configuration.
+func (fw *FuzzerWorker) ReplaySequenceSeed(seed int64) {
+	fw.replaySeed = &seed
+}
+
+// reseedRandomProvider reseeds the worker's random provider at the start of a new call sequence, recording the seed
+// used so the call sequence's generation can be replayed. If a replay was requested, its seed is used instead of a
+// newly drawn one.
+func (fw *FuzzerWorker) reseedRandomProvider() {
+	if fw.replaySeed != nil {
+		fw.sequenceSeed = *fw.replaySeed
+		fw.replaySeed = nil
+	} else {
+		fw.sequenceSeed = fw.randomProvider.Int63()
+	}
+	fw.randomProvider.Seed(fw.sequenceSeed)
+}
+
 // workerMetrics returns the fuzzerWorkerMetrics for this specific worker.
 func (fw *FuzzerWorker) workerMetrics() *fuzzerWorkerMetrics {
 	return &fw.fuzzer.metrics.workerMetrics[fw.workerIndex]
@@ -157,8 +217,9 @@ func (fw *FuzzerWorker) getNewCorpusCallSequenceWeight() *big.Int {
 // onChainContractDeploymentAddedEvent is the event callback used when the chain detects a new contract deployment.
 // It attempts bytecode matching and updates the list of deployed contracts the worker should use for fuzz testing.
 func (fw *FuzzerWorker) onChainContractDeploymentAddedEvent(event chain.ContractDeploymentsAddedEvent) error {
-	// Do not track the deployed contract if the contract deployment was a dynamic one and testAllContracts is false
-	if !fw.fuzzer.config.Fuzzing.Testing.TestAllContracts && event.DynamicDeployment {
+	// Do not track the deployed contract if the contract deployment was a dynamic one and testAllContracts is false,
+	// or it was not deployed by one of the dynamic deployers we were configured to test.
+	if event.DynamicDeployment && (!fw.fuzzer.config.Fuzzing.Testing.TestAllContracts || !fw.isTestedDynamicDeployer(event.Deployer)) {
 		// Add the contract address to our value set so our generator can use it in calls.
 		fw.valueSet.AddAddress(event.Contract.Address)
 		return nil
@@ -196,6 +257,27 @@ func (fw *FuzzerWorker) onChainContractDeploymentAddedEvent(event chain.Contract
 	return nil
 }
 
+// isTestedDynamicDeployer indicates whether contracts dynamically deployed by the provided address should be tested,
+// according to the dynamic deployers the fuzzer was configured with. Each configured deployer is either an address, or
+// the name of a contract deployed while setting up the chain.
+func (fw *FuzzerWorker) isTestedDynamicDeployer(deployer common.Address) bool {
+	// If no dynamic deployers were specified, all of them are tested.
+	dynamicDeployers := fw.fuzzer.config.Fuzzing.Testing.DynamicDeployers
+	if len(dynamicDeployers) == 0 {
+		return true
+	}
+	for _, dynamicDeployer := range dynamicDeployers {
+		if strings.HasPrefix(dynamicDeployer, "0x") {
+			if address, err := utils.HexStringToAddress(dynamicDeployer); err == nil && address == deployer {
+				return true
+			}
+		} else if address, ok := fw.chain.DeployedContractAddresses[dynamicDeployer]; ok && address == deployer {
+			return true
+		}
+	}
+	return false
+}
+
 // onChainContractDeploymentRemovedEvent is the event callback used when the chain detects removal of a previously
 // deployed contract. It updates the list of deployed contracts the worker should use for fuzz testing.
 func (fw *FuzzerWorker) onChainContractDeploymentRemovedEvent(event chain.ContractDeploymentsRemovedEvent) error {
@@ -232,10 +314,17 @@ func (fw *FuzzerWorker) onChainContractDeploymentRemovedEvent(event chain.Contra
 func (fw *FuzzerWorker) updateMethods() {
 	// Clear our list of methods
 	fw.stateChangingMethods = make([]fuzzerTypes.DeployedContractMethod, 0)
+	fw.factoryMethods = make([]fuzzerTypes.DeployedContractMethod, 0)
 	fw.pureMethods = make([]fuzzerTypes.DeployedContractMethod, 0)
 
-	// Loop through each deployed contract
-	for contractAddress, contractDefinition := range fw.deployedContracts {
+	// Loop through each deployed contract, ordered by address, so that the methods are ordered deterministically and
+	// campaigns can be reproduced from the same random seed.
+	contractAddresses := maps.Keys(fw.deployedContracts)
+	sort.Slice(contractAddresses, func(i, j int) bool {
+		return bytes.Compare(contractAddresses[i][:], contractAddresses[j][:]) < 0
+	})
+	for _, contractAddress := range contractAddresses {
+		contractDefinition := fw.deployedContracts[contractAddress]
 		// If we deployed the contract, also enumerate property tests and state changing methods.
 		for _, method := range contractDefinition.AssertionTestMethods {
 			// Any non-constant method should be tracked as a state changing method.
@@ -246,9 +335,67 @@ func (fw *FuzzerWorker) updateMethods() {
 				}
 			} else {
 				fw.stateChangingMethods = append(fw.stateChangingMethods, fuzzerTypes.DeployedContractMethod{Address: contractAddress, Contract: contractDefinition, Method: method})
+				if fw.isFactoryMethod(contractDefinition, method) {
+					fw.factoryMethods = append(fw.factoryMethods, fuzzerTypes.DeployedContractMethod{Address: contractAddress, Contract: contractDefinition, Method: method})
+				}
+			}
+		}
+	}
+}
+
+// isFactoryMethod checks whether the provided method of the provided contract is suspected of deploying contracts,
+// as it returns an address or is configured as a factory function in the project configuration.
+// Returns a boolean indicating whether the method is a factory method.
+func (fw *FuzzerWorker) isFactoryMethod(contract *fuzzerTypes.Contract, method abi.Method) bool {
+	for _, output := range method.Outputs {
+		if output.Type.T == abi.AddressTy {
+			return true
+		}
+	}
+	return slices.Contains(fw.fuzzer.config.Fuzzing.FactoryFunctions, contract.Name()+"."+method.Sig)
+}
+
+// addEventValuesToValueSet decodes the events emitted by the provided executed call sequence element and adds their
+// values to the worker's value set, adding no more than the provided maximum number of values. Events which cannot be
+// resolved against a known contract ABI are skipped.
+// Returns the number of values added.
+func (fw *FuzzerWorker) addEventValuesToValueSet(element *calls.CallSequenceElement, maxValues int) int {
+	// If we cannot add any values or the call did not emit events, there is nothing to do.
+	if maxValues <= 0 || element.ChainReference == nil {
+		return 0
+	}
+	messageResults := element.ChainReference.MessageResults()
+	if messageResults == nil || messageResults.Receipt == nil {
+		return 0
+	}
+
+	valuesAdded := 0
+	for _, eventLog := range messageResults.Receipt.Logs {
+		// Resolve the event using the ABI of the contract which emitted it. If it was emitted by a library, it may not
+		// exist there, so we try any of our contract definitions.
+		var eventValues []any
+		if contract, ok := fw.deployedContracts[eventLog.Address]; ok {
+			_, eventValues = abiutils.UnpackEventAndValues(&contract.CompiledContract().Abi, eventLog)
+		}
+		if eventValues == nil {
+			for _, contract := range fw.fuzzer.contractDefinitions {
+				if event, values := abiutils.UnpackEventAndValues(&contract.CompiledContract().Abi, eventLog); event != nil {
+					eventValues = values
+					break
+				}
 			}
 		}
+
+		// Add each value, until we reach our limit.
+		for _, value := range eventValues {
+			if valuesAdded >= maxValues {
+				return valuesAdded
+			}
+			fw.valueSet.Add([]any{value})
+			valuesAdded++
+		}
 	}
+	return valuesAdded
 }
 
 // testNextCallSequence tests a call message sequence against the underlying FuzzerWorker's Chain and calls every
@@ -270,6 +417,11 @@ func (fw *FuzzerWorker) testNextCallSequence() (calls.CallSequence, []ShrinkCall
 		}
 	}()
 
+	// If we are recording sequence seeds, reseed our random provider so this sequence's generation can be replayed.
+	if fw.fuzzer.config.Fuzzing.RecordSequenceSeeds {
+		fw.reseedRandomProvider()
+	}
+
 	// Initialize a new sequence within our sequence generator.
 	var isNewSequence bool
 	isNewSequence, err = fw.sequenceGenerator.InitializeNextSequence()
@@ -280,28 +432,46 @@ func (fw *FuzzerWorker) testNextCallSequence() (calls.CallSequence, []ShrinkCall
 	// Define our shrink requests we'll collect during execution.
 	shrinkCallSequenceRequests := make([]ShrinkCallSequenceRequest, 0)
 
-	// Our "fetch next call" method will generate new calls as needed, if we are generating a new sequence.
+	// Track the number of event values added to the value set in this sequence, so it does not grow unbounded.
+	eventValuesAdded := 0
+
+	// Our "fetch next call" method will generate new calls as needed, if we are generating a new sequence. We track
+	// the elements fetched so that any discarded during execution can be counted afterwards.
+	fetchedElements := make([]*calls.CallSequenceElement, 0)
 	fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
-		return fw.sequenceGenerator.PopSequenceElement()
+		element, err := fw.sequenceGenerator.PopSequenceElement()
+		if element != nil {
+			fetchedElements = append(fetchedElements, element)
+		}
+		return element, err
 	}
 
 	// Our "post execution check function" method will check coverage and call all testing functions. If one returns a
 	// request for a shrunk call sequence, we exit our call sequence execution immediately to go fulfill the shrink
 	// request. Additionally, the execution check function will also attempt to add any return data to the value set for
-	// this call sequence. Note that the value set is reset after each call sequence (see the defer section above)
+	// this call sequence, if enabled. Note that the value set is reset after each call sequence (see the defer section
+	// above)
 	executionCheckFunc := func(currentlyExecutedSequence calls.CallSequence) (bool, error) {
 		// Get the last call sequence element that was executed
 		latestCallSequenceElement := currentlyExecutedSequence[len(currentlyExecutedSequence)-1]
-		// Get the decoded return values and add it to the base value set
+
+		// If enabled, get the decoded return values and add their leaf values to the base value set.
 		// Don't throw an error since we care more about coverage than adding the return values to the base value set
-		decodedReturnValues, err := latestCallSequenceElement.DecodedReturnValues()
-		if decodedReturnValues != nil && err == nil {
-			fw.valueSet.Add(decodedReturnValues)
+		if fw.fuzzer.config.Fuzzing.SeedFromReturnData {
+			decodedReturnValues, err := latestCallSequenceElement.DecodedReturnValues()
+			if decodedReturnValues != nil && err == nil {
+				fw.valueSet.AddFlattened(decodedReturnValues)
+			}
+		}
+
+		// If enabled, add the values of any events emitted to the value set as well.
+		if fw.fuzzer.config.Fuzzing.SeedFromEvents {
+			eventValuesAdded += fw.addEventValuesToValueSet(latestCallSequenceElement, fw.fuzzer.config.Fuzzing.SeedFromEventsMaxValues-eventValuesAdded)
 		}
 
 		// Check for updates to coverage and corpus.
 		// If we detect coverage changes, add this sequence with weight as 1 + sequences tested (to avoid zero weights)
-		err = fw.fuzzer.corpus.CheckSequenceCoverageAndUpdate(currentlyExecutedSequence, fw.getNewCorpusCallSequenceWeight(), true)
+		err := fw.fuzzer.corpus.CheckSequenceCoverageAndUpdate(currentlyExecutedSequence, fw.getNewCorpusCallSequenceWeight(), true)
 		if err != nil {
 			return true, err
 		}
@@ -319,7 +489,26 @@ func (fw *FuzzerWorker) testNextCallSequence() (calls.CallSequence, []ShrinkCall
 		// Update our metrics
 		fw.workerMetrics().callsTested.Add(fw.workerMetrics().callsTested, big.NewInt(1))
 		lastCallSequenceElement := currentlyExecutedSequence[len(currentlyExecutedSequence)-1]
-		fw.workerMetrics().gasUsed.Add(fw.workerMetrics().gasUsed, new(big.Int).SetUint64(lastCallSequenceElement.ChainReference.Block.MessageResults[lastCallSequenceElement.ChainReference.TransactionIndex].Receipt.GasUsed))
+		lastMessageResults := lastCallSequenceElement.ChainReference.MessageResults()
+		fw.workerMetrics().gasUsed.Add(fw.workerMetrics().gasUsed, new(big.Int).SetUint64(lastMessageResults.Receipt.GasUsed))
+		if fw.fuzzer.isUnexpectedRevert(lastMessageResults.ExecutionResult) {
+			fw.workerMetrics().callsReverted.Add(fw.workerMetrics().callsReverted, big.NewInt(1))
+		}
+		if lastMessageResults.ExecutionResult.Err == nil && lastCallSequenceElement.Contract != nil &&
+			!fw.fuzzer.targetContractReached.Load() &&
+			slices.Contains(fw.fuzzer.config.Fuzzing.TargetContracts, lastCallSequenceElement.Contract.Name()) {
+			fw.fuzzer.targetContractReached.Store(true)
+		}
+
+		// In self-check mode, we end the sequence once our test limit is reached, so the campaign stops after the same
+		// call each time it is run with the same random seed. The fuzzer is only stopped once the sequence's results
+		// were processed, so that any failed test is still reported and shrunk.
+		if fw.fuzzer.selfCheck {
+			fw.fuzzer.selfCheckCallsTested.Add(1)
+			if fw.fuzzer.selfCheckTestLimitReached() {
+				return true, nil
+			}
+		}
 
 		// If our fuzzer context is done, exit out immediately without results.
 		if utils.CheckContextDone(fw.fuzzer.ctx) {
@@ -338,11 +527,31 @@ func (fw *FuzzerWorker) testNextCallSequence() (calls.CallSequence, []ShrinkCall
 		return nil, nil, err
 	}
 
+	// Update our metrics for any calls which were discarded due to failed assumptions.
+	for _, element := range fetchedElements {
+		if element.Discarded() {
+			fw.workerMetrics().callsDiscarded.Add(fw.workerMetrics().callsDiscarded, big.NewInt(1))
+		}
+	}
+
+	// If we are tracing all sequences, write the sequence we executed to this worker's trace file.
+	if fw.fuzzer.sequenceTraceFiles != nil {
+		err = fw.writeSequenceTrace(testedCallSequence)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// If our fuzzer context is done, exit out immediately without results.
 	if utils.CheckContextDone(fw.fuzzer.ctx) {
 		return nil, nil, nil
 	}
 
+	// If this sequence failed a test and we are recording sequence seeds, report the seed it was generated with.
+	if len(shrinkCallSequenceRequests) > 0 && fw.fuzzer.config.Fuzzing.RecordSequenceSeeds {
+		fw.fuzzer.logger.Info(fmt.Sprintf("[Worker %d] Call sequence failing a test was generated with sequence seed %d", fw.workerIndex, fw.sequenceSeed))
+	}
+
 	// If this was not a new call sequence, indicate not to save the shrunken result to the corpus again.
 	if !isNewSequence {
 		for i := 0; i < len(shrinkCallSequenceRequests); i++ {
@@ -354,6 +563,18 @@ func (fw *FuzzerWorker) testNextCallSequence() (calls.CallSequence, []ShrinkCall
 	return testedCallSequence, shrinkCallSequenceRequests, nil
 }
 
+// writeSequenceTrace writes the provided executed call sequence to the sequence trace file for this worker's index,
+// describing the block number, block timestamp, and sender of each call.
+// Returns an error if one occurred.
+func (fw *FuzzerWorker) writeSequenceTrace(callSequence calls.CallSequence) error {
+	header := fmt.Sprintf("[Sequence %v] %d call(s)", fw.workerMetrics().sequencesTested, len(callSequence))
+	if fw.fuzzer.config.Fuzzing.RecordSequenceSeeds {
+		header += fmt.Sprintf(", sequence seed %d", fw.sequenceSeed)
+	}
+	_, err := fmt.Fprintf(fw.fuzzer.sequenceTraceFiles[fw.workerIndex], "%s\n%s\n", header, callSequence.String())
+	return err
+}
+
 // testShrunkenCallSequence tests a provided shrunken call sequence to verify it continues to satisfy the provided
 // shrink verifier. Chain state is reverted to the testing base prior to returning.
 // Returns a boolean indicating if the shrunken call sequence is valid for a given shrink request, or an error if one occurred.
@@ -446,71 +667,80 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 		fw.workerMetrics().shrinking = true
 		fw.fuzzer.logger.Info(fmt.Sprintf("[Worker %d] Shrinking call sequence with %d call(s)", fw.workerIndex, len(callSequence)))
 
-		for removalStrategy := 0; removalStrategy < 2 && !shrinkingEnded(); removalStrategy++ {
-			for i := len(optimizedSequence) - 1; i >= 0 && !shrinkingEnded(); i-- {
-				// Recreate our current optimized sequence without the item at this index
-				possibleShrunkSequence, err := optimizedSequence.Clone()
-				removedCall := possibleShrunkSequence[i]
-				if err != nil {
-					return nil, err
-				}
-				possibleShrunkSequence = append(possibleShrunkSequence[:i], possibleShrunkSequence[i+1:]...)
-
-				// Exercise the next removal strategy for this call.
-				if removalStrategy == 0 {
-					// Case 1: Plain removal.
-				} else if removalStrategy == 1 {
-					// Case 2: Add block/time delay to previous call.
-					if i > 0 {
-						possibleShrunkSequence[i-1].BlockNumberDelay += removedCall.BlockNumberDelay
-						possibleShrunkSequence[i-1].BlockTimestampDelay += removedCall.BlockTimestampDelay
+		// If parallel shrinking is enabled, the same passes are performed with candidates partitioned across workers.
+		if fw.fuzzer.parallelShrinkTasks != nil {
+			var err error
+			optimizedSequence, err = fw.shrinkCallSequenceInParallel(callSequence, shrinkRequest)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			for removalStrategy := 0; removalStrategy < 2 && !shrinkingEnded(); removalStrategy++ {
+				for i := len(optimizedSequence) - 1; i >= 0 && !shrinkingEnded(); i-- {
+					// Recreate our current optimized sequence without the item at this index
+					possibleShrunkSequence, err := optimizedSequence.Clone()
+					removedCall := possibleShrunkSequence[i]
+					if err != nil {
+						return nil, err
+					}
+					possibleShrunkSequence = append(possibleShrunkSequence[:i], possibleShrunkSequence[i+1:]...)
+
+					// Exercise the next removal strategy for this call.
+					if removalStrategy == 0 {
+						// Case 1: Plain removal.
+					} else if removalStrategy == 1 {
+						// Case 2: Add block/time delay to previous call.
+						if i > 0 {
+							possibleShrunkSequence[i-1].BlockNumberDelay += removedCall.BlockNumberDelay
+							possibleShrunkSequence[i-1].BlockTimestampDelay += removedCall.BlockTimestampDelay
+						}
 					}
-				}
 
-				// Test the shrunken sequence.
-				validShrunkSequence, err := fw.testShrunkenCallSequence(possibleShrunkSequence, shrinkRequest)
-				shrinkIteration++
-				if err != nil {
-					return nil, err
-				}
+					// Test the shrunken sequence.
+					validShrunkSequence, err := fw.testShrunkenCallSequence(possibleShrunkSequence, shrinkRequest)
+					shrinkIteration++
+					if err != nil {
+						return nil, err
+					}
 
-				// If the current sequence satisfied our conditions, set it as our optimized sequence.
-				if validShrunkSequence {
-					optimizedSequence = possibleShrunkSequence
+					// If the current sequence satisfied our conditions, set it as our optimized sequence.
+					if validShrunkSequence {
+						optimizedSequence = possibleShrunkSequence
+					}
 				}
 			}
-		}
 
-		// The second pass of shrinking attempts to shrink values for each call in our call sequence.
-		// This is performed exhaustively in a round-robin fashion for each call, until the shrink limit is hit.
-		for !shrinkingEnded() {
-			for i := len(optimizedSequence) - 1; i >= 0 && !shrinkingEnded(); i-- {
-				// Clone the optimized sequence.
-				possibleShrunkSequence, _ := optimizedSequence.Clone()
-
-				// Loop for each argument in the currently indexed call to mutate it.
-				abiValuesMsgData := possibleShrunkSequence[i].Call.DataAbiValues
-				for j := 0; j < len(abiValuesMsgData.InputValues); j++ {
-					mutatedInput, err := valuegeneration.MutateAbiValue(fw.sequenceGenerator.config.ValueGenerator, fw.shrinkingValueMutator, &abiValuesMsgData.Method.Inputs[j].Type, abiValuesMsgData.InputValues[j])
-					if err != nil {
-						return nil, fmt.Errorf("error when shrinking call sequence input argument: %v", err)
+			// The second pass of shrinking attempts to shrink values for each call in our call sequence.
+			// This is performed exhaustively in a round-robin fashion for each call, until the shrink limit is hit.
+			for !shrinkingEnded() {
+				for i := len(optimizedSequence) - 1; i >= 0 && !shrinkingEnded(); i-- {
+					// Clone the optimized sequence.
+					possibleShrunkSequence, _ := optimizedSequence.Clone()
+
+					// Loop for each argument in the currently indexed call to mutate it.
+					abiValuesMsgData := possibleShrunkSequence[i].Call.DataAbiValues
+					for j := 0; j < len(abiValuesMsgData.InputValues); j++ {
+						mutatedInput, err := valuegeneration.MutateAbiValue(fw.sequenceGenerator.config.ValueGenerator, fw.shrinkingValueMutator, &abiValuesMsgData.Method.Inputs[j].Type, abiValuesMsgData.InputValues[j])
+						if err != nil {
+							return nil, fmt.Errorf("error when shrinking call sequence input argument: %v", err)
+						}
+						abiValuesMsgData.InputValues[j] = mutatedInput
 					}
-					abiValuesMsgData.InputValues[j] = mutatedInput
-				}
 
-				// Re-encode the message's calldata
-				possibleShrunkSequence[i].Call.WithDataAbiValues(abiValuesMsgData)
+					// Re-encode the message's calldata
+					possibleShrunkSequence[i].Call.WithDataAbiValues(abiValuesMsgData)
 
-				// Test the shrunken sequence.
-				validShrunkSequence, err := fw.testShrunkenCallSequence(possibleShrunkSequence, shrinkRequest)
-				shrinkIteration++
-				if err != nil {
-					return nil, err
-				}
+					// Test the shrunken sequence.
+					validShrunkSequence, err := fw.testShrunkenCallSequence(possibleShrunkSequence, shrinkRequest)
+					shrinkIteration++
+					if err != nil {
+						return nil, err
+					}
 
-				// If this current sequence satisfied our conditions, set it as our optimized sequence.
-				if validShrunkSequence {
-					optimizedSequence = possibleShrunkSequence
+					// If this current sequence satisfied our conditions, set it as our optimized sequence.
+					if validShrunkSequence {
+						optimizedSequence = possibleShrunkSequence
+					}
 				}
 			}
 		}
@@ -546,10 +776,22 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 	return optimizedSequence, err
 }
 
-// run takes a base Chain in a setup state ready for testing, clones it, and begins executing fuzzed transaction calls
-// and asserting properties are upheld. This runs until Fuzzer.ctx cancels the operation.
-// Returns a boolean indicating whether Fuzzer.ctx has indicated we cancel the operation, and an error if one occurred.
-func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
+// verifyStateReset verifies that the worker's chain state matches the state of the base block the worker reverts to
+// between call sequences. This catches changes which persisted after reverting to the base block.
+// Returns an error if the state roots do not match.
+func (fw *FuzzerWorker) verifyStateReset() error {
+	baseBlock := fw.chain.CommittedBlocks()[fw.testingBaseBlockIndex-1]
+	stateRoot := fw.chain.State().IntermediateRoot(true)
+	if stateRoot != baseBlock.Header.Root {
+		return fmt.Errorf("state was not reset to base block %d between call sequences: expected state root %v, got %v", baseBlock.Header.Number.Uint64(), baseBlock.Header.Root, stateRoot)
+	}
+	return nil
+}
+
+// setupChain takes a base Chain in a setup state ready for testing and clones it as the worker's Chain, recording the
+// block index the worker should revert to between call sequences. The caller is responsible for closing the Chain.
+// Returns an error if one occurred, in which case the Chain does not need to be closed.
+func (fw *FuzzerWorker) setupChain(baseTestChain *chain.TestChain) error {
 	// Clone our chain, attaching our necessary components for fuzzing post-genesis, prior to all blocks being copied.
 	// This means any tracers added or events subscribed to within this inner function are done so prior to chain
 	// setup (initial contract deployments), so data regarding that can be tracked as well.
@@ -572,17 +814,19 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 		if fw.fuzzer.config.Fuzzing.CoverageEnabled {
 			fw.coverageTracer = coverage.NewCoverageTracer()
 			initializedChain.AddTracer(fw.coverageTracer.NativeTracer(), true, false)
+			initializedChain.CoverageCountFunc = fw.coverageTracer.UniquePCs
 		}
 		return nil
 	})
 
 	// If we encountered an error during cloning, return it.
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	// Defer the closing of the test chain object
-	defer fw.chain.Close()
+	// Aggregate the custom metrics recorded by cheat codes across the campaign. We only do this once the chain was
+	// cloned, so values recorded while replaying the setup of the base chain are not counted again by every worker.
+	fw.chain.CustomMetrics = fw.fuzzer.metrics.CustomMetrics()
 
 	// Emit an event indicating the worker has setup its chain.
 	err = fw.Events.FuzzerWorkerChainSetup.Publish(FuzzerWorkerChainSetupEvent{
@@ -590,21 +834,37 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 		Chain:  fw.chain,
 	})
 	if err != nil {
-		return false, fmt.Errorf("error returned by an event handler when emitting a worker chain setup event: %v", err)
+		fw.chain.Close()
+		return fmt.Errorf("error returned by an event handler when emitting a worker chain setup event: %v", err)
 	}
 
-	// Increase our generation metric as we successfully generated a test node
-	fw.workerMetrics().workerStartupCount.Add(fw.workerMetrics().workerStartupCount, big.NewInt(1))
-
 	// Save the current block index as all contracts have been deployed at this point, and we'll want to revert
 	// to this state between testing.
 	fw.testingBaseBlockIndex = uint64(len(fw.chain.CommittedBlocks()))
+	return nil
+}
+
+// run takes a base Chain in a setup state ready for testing, clones it, and begins executing fuzzed transaction calls
+// and asserting properties are upheld. This runs until Fuzzer.ctx cancels the operation.
+// Returns a boolean indicating whether Fuzzer.ctx has indicated we cancel the operation, and an error if one occurred.
+func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
+	// Clone and set up our chain.
+	err := fw.setupChain(baseTestChain)
+	if err != nil {
+		return false, err
+	}
+
+	// Defer the closing of the test chain object
+	defer fw.chain.Close()
+
+	// Increase our generation metric as we successfully generated a test node
+	fw.workerMetrics().workerStartupCount.Add(fw.workerMetrics().workerStartupCount, big.NewInt(1))
 
 	// Enter the main fuzzing loop, restricting our memory database size based on our config variable.
-	// When the limit is reached, we exit this method gracefully, which will cause the fuzzing to recreate
-	// this worker with a fresh memory database.
+	// When the limit of call sequences tested is reached, we exit this method gracefully, which will cause the fuzzing
+	// to recreate this worker with a fresh memory database.
 	sequencesTested := 0
-	for sequencesTested <= fw.fuzzer.config.Fuzzing.WorkerResetLimit {
+	for sequencesTested < fw.fuzzer.config.Fuzzing.WorkerResetLimit {
 		// If our context signalled to close the operation, exit our testing loop accordingly, otherwise continue.
 		if utils.CheckContextDone(fw.fuzzer.ctx) {
 			return true, nil
@@ -618,6 +878,14 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 			return false, fmt.Errorf("error returned by an event handler when a worker emitted an event indicating testing of a new call sequence is starting: %v", err)
 		}
 
+		// If parallel shrinking is enabled, help other workers test their candidate shrunken call sequences first.
+		if fw.fuzzer.parallelShrinkTasks != nil {
+			err = fw.assistParallelShrinking()
+			if err != nil {
+				return false, err
+			}
+		}
+
 		// Test a new sequence
 		callSequence, shrinkVerifiers, err := fw.testNextCallSequence()
 		if err != nil {
@@ -632,6 +900,14 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 			}
 		}
 
+		// If configured, verify that reverting to the base block fully restored our state.
+		if fw.fuzzer.config.Fuzzing.VerifyStateResetBetweenSequences {
+			err = fw.verifyStateReset()
+			if err != nil {
+				return false, err
+			}
+		}
+
 		// Emit an event indicating the worker is about to test a new call sequence.
 		err = fw.Events.CallSequenceTested.Publish(FuzzerWorkerCallSequenceTestedEvent{
 			Worker: fw,
@@ -643,8 +919,97 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 		// Update our sequences tested metrics
 		fw.workerMetrics().sequencesTested.Add(fw.workerMetrics().sequencesTested, big.NewInt(1))
 		sequencesTested++
+
+		// In self-check mode, stop fuzzing once the sequence which reached our test limit was processed.
+		if fw.fuzzer.selfCheckTestLimitReached() {
+			fw.fuzzer.Stop()
+		}
+
+		// Warn if the grace period has elapsed without any call to a target contract succeeding.
+		fw.fuzzer.checkTargetContractsReached()
 	}
 
 	// We have not cancelled fuzzing operations, but this worker exited, signalling for it to be regenerated.
 	return false, nil
 }
+
+// replay takes a base Chain in a setup state ready for testing, clones it, and executes the provided call sequence
+// once, calling every CallSequenceTestFunc registered with the parent Fuzzer after each call. As when fuzzing,
+// execution stops at the first call which fails a test. Test failures are finalized through their shrink request's
+// FinishedCallback without any shrinking, so the sequence is reported as provided.
+// Returns the executed call sequence with an execution trace attached to each element, or an error if one occurred.
+func (fw *FuzzerWorker) replay(baseTestChain *chain.TestChain, callSequence calls.CallSequence) (calls.CallSequence, error) {
+	// Clone and set up our chain.
+	err := fw.setupChain(baseTestChain)
+	if err != nil {
+		return nil, err
+	}
+	defer fw.chain.Close()
+
+	// Serialized call sequences do not reference contract definitions, so we resolve them from the contracts deployed
+	// on our chain, along with the method each call's ABI values target.
+	for i, element := range callSequence {
+		if element.Call.To == nil {
+			continue
+		}
+		contract := fw.DeployedContract(*element.Call.To)
+		if contract == nil {
+			return nil, fmt.Errorf("call %d targets a contract at address '%v' which could not be resolved", i+1, element.Call.To.String())
+		}
+		element.Contract = contract
+		if element.Call.DataAbiValues != nil {
+			err = element.Call.DataAbiValues.Resolve(contract.CompiledContract().Abi)
+			if err != nil {
+				return nil, fmt.Errorf("call %d could not resolve its method in contract '%v': %v", i+1, contract.Name(), err)
+			}
+		}
+	}
+
+	// Execute our call sequence, testing after each call and stopping once a test has failed.
+	shrinkCallSequenceRequests := make([]ShrinkCallSequenceRequest, 0)
+	fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
+		if currentIndex < len(callSequence) {
+			return callSequence[currentIndex], nil
+		}
+		return nil, nil
+	}
+	executionCheckFunc := func(currentlyExecutedSequence calls.CallSequence) (bool, error) {
+		for _, callSequenceTestFunc := range fw.fuzzer.Hooks.CallSequenceTestFuncs {
+			newShrinkRequests, err := callSequenceTestFunc(fw, currentlyExecutedSequence)
+			if err != nil {
+				return true, err
+			}
+			shrinkCallSequenceRequests = append(shrinkCallSequenceRequests, newShrinkRequests...)
+		}
+		return len(shrinkCallSequenceRequests) > 0, nil
+	}
+	testedCallSequence, err := calls.ExecuteCallSequenceIteratively(fw.chain, fetchElementFunc, executionCheckFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err = fw.chain.RevertToBlockIndex(fw.testingBaseBlockIndex); err != nil {
+		return nil, err
+	}
+
+	// Report every failed test with the sequence which was executed up to the failure.
+	for _, shrinkRequest := range shrinkCallSequenceRequests {
+		failedCallSequence, err := testedCallSequence.Clone()
+		if err != nil {
+			return nil, err
+		}
+		err = shrinkRequest.FinishedCallback(fw, failedCallSequence, fw.fuzzer.config.Fuzzing.Testing.TraceAll)
+		if err != nil {
+			return nil, err
+		}
+		if err = fw.chain.RevertToBlockIndex(fw.testingBaseBlockIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	// Finally, execute the sequence once more to attach an execution trace to every call in it.
+	tracedCallSequence, err := calls.ExecuteCallSequenceWithExecutionTracer(fw.chain, fw.fuzzer.contractDefinitions, testedCallSequence, true)
+	if err != nil {
+		return nil, err
+	}
+	return tracedCallSequence, fw.chain.RevertToBlockIndex(fw.testingBaseBlockIndex)
+}
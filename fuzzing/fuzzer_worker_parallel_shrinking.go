@@ -0,0 +1,275 @@
+package fuzzing
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/valuegeneration"
+	"github.com/crytic/medusa/utils"
+)
+
+// parallelShrinkJob describes a ShrinkCallSequenceRequest being fulfilled cooperatively by multiple FuzzerWorker
+// instances, when parallel shrinking is enabled.
+type parallelShrinkJob struct {
+	// worker describes the FuzzerWorker which submitted the job and is fulfilling its shrink request.
+	worker *FuzzerWorker
+
+	// shrinkRequest describes the shrink request being fulfilled.
+	shrinkRequest ShrinkCallSequenceRequest
+
+	// verifierLock provides thread synchronization for calls to the shrink request's verifier function, as verifiers
+	// may track state across calls (e.g. the best value found so far) and are not safe to call concurrently.
+	verifierLock sync.Mutex
+
+	// verifications describes the amount of calls made to the shrink request's verifier function. This is used to
+	// determine the order in which candidates were verified.
+	verifications uint64
+
+	// tasksAssisted describes the amount of candidates tested by workers other than the one which submitted the job.
+	tasksAssisted atomic.Uint64
+}
+
+// parallelShrinkTask describes a candidate shrunken call sequence to be tested by any FuzzerWorker, on behalf of the
+// FuzzerWorker fulfilling a parallelShrinkJob.
+type parallelShrinkTask struct {
+	// job describes the parallelShrinkJob the candidate belongs to.
+	job *parallelShrinkJob
+
+	// index describes the index of the candidate within the batch of candidates it was submitted with.
+	index int
+
+	// callSequence describes the candidate shrunken call sequence to test.
+	callSequence calls.CallSequence
+
+	// results describes the channel the parallelShrinkResult should be sent to once the candidate was tested.
+	results chan parallelShrinkResult
+}
+
+// parallelShrinkResult describes the result of testing a parallelShrinkTask.
+type parallelShrinkResult struct {
+	// index describes the index of the candidate within the batch of candidates it was submitted with.
+	index int
+
+	// valid indicates whether the candidate satisfied the shrink request's verifier.
+	valid bool
+
+	// verification describes the order in which the candidate was verified, relative to other candidates of the same
+	// parallelShrinkJob. Candidates verified later were verified against any state the verifier tracks from those
+	// verified earlier.
+	verification uint64
+
+	// err describes an error which occurred while testing the candidate, if any.
+	err error
+}
+
+// testParallelShrinkTask tests the candidate shrunken call sequence described by the provided task on this worker's
+// chain, and sends the result to the task's result channel. Chain state is reverted to the testing base prior to
+// returning.
+// Returns an error if one occurred while testing the candidate.
+func (fw *FuzzerWorker) testParallelShrinkTask(task *parallelShrinkTask) error {
+	// Wrap the verifier so calls to it are synchronized across workers, recording the order they occurred in.
+	result := parallelShrinkResult{index: task.index}
+	shrinkRequest := task.job.shrinkRequest
+	shrinkRequest.VerifierFunction = func(worker *FuzzerWorker, callSequence calls.CallSequence) (bool, error) {
+		task.job.verifierLock.Lock()
+		defer task.job.verifierLock.Unlock()
+		task.job.verifications++
+		result.verification = task.job.verifications
+		return task.job.shrinkRequest.VerifierFunction(worker, callSequence)
+	}
+
+	// Test the candidate and report the result. The results channel is buffered for every candidate in the batch, so
+	// this never blocks.
+	if fw != task.job.worker {
+		task.job.tasksAssisted.Add(1)
+		fw.fuzzer.parallelShrinkTasksAssisted.Add(1)
+	}
+	result.valid, result.err = fw.testShrunkenCallSequence(task.callSequence, shrinkRequest)
+	task.results <- result
+	return result.err
+}
+
+// assistParallelShrinking tests any candidate shrunken call sequences submitted by other workers which are currently
+// shrinking, until none remain. This is called between call sequences, when the worker's chain is at its testing base.
+// Returns an error if one occurred.
+func (fw *FuzzerWorker) assistParallelShrinking() error {
+	for {
+		select {
+		case task := <-fw.fuzzer.parallelShrinkTasks:
+			fw.workerMetrics().shrinking = true
+			err := fw.testParallelShrinkTask(task)
+			fw.workerMetrics().shrinking = false
+			if err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// testShrunkenCallSequencesInParallel tests the provided candidate shrunken call sequences, submitting them to be
+// tested by any worker which is available, while testing any which were not picked up on this worker's chain.
+// Returns the index of the accepted candidate, or -1 if no candidate satisfied the shrink request's verifier, or an
+// error if one occurred. If multiple candidates were valid, the one verified last is accepted, so verifiers which
+// track the best value found so far remain consistent with the accepted candidate.
+func (fw *FuzzerWorker) testShrunkenCallSequencesInParallel(job *parallelShrinkJob, candidates []calls.CallSequence) (int, error) {
+	// Submit each candidate to be tested by other workers, keeping those which could not be queued to test ourselves.
+	results := make(chan parallelShrinkResult, len(candidates))
+	localTasks := make([]*parallelShrinkTask, 0)
+	for i, candidate := range candidates {
+		task := &parallelShrinkTask{job: job, index: i, callSequence: candidate, results: results}
+		select {
+		case fw.fuzzer.parallelShrinkTasks <- task:
+		default:
+			localTasks = append(localTasks, task)
+		}
+	}
+
+	// Collect the result of every candidate. While waiting, we test our own tasks and any queued tasks, so progress
+	// is made even if no other worker is available.
+	var err error
+	accepted, acceptedVerification := -1, uint64(0)
+	for remaining := len(candidates); remaining > 0; {
+		if len(localTasks) > 0 {
+			task := localTasks[0]
+			localTasks = localTasks[1:]
+			if taskErr := fw.testParallelShrinkTask(task); taskErr != nil && err == nil {
+				err = taskErr
+			}
+			continue
+		}
+
+		select {
+		case task := <-fw.fuzzer.parallelShrinkTasks:
+			if taskErr := fw.testParallelShrinkTask(task); taskErr != nil && err == nil {
+				err = taskErr
+			}
+		case result := <-results:
+			remaining--
+			if result.err != nil && err == nil {
+				err = result.err
+			}
+			if result.valid && (accepted == -1 || result.verification > acceptedVerification) {
+				accepted, acceptedVerification = result.index, result.verification
+			}
+		}
+	}
+	if err != nil {
+		return -1, err
+	}
+	return accepted, nil
+}
+
+// shrinkCallSequenceInParallel performs the same shrinking passes as shrinkCallSequence, testing a batch of candidate
+// shrunken call sequences at a time, which are partitioned across all workers available to test them. After each
+// batch, one valid candidate (if any) is accepted, and the next batch is derived from it.
+// Returns the most optimized call sequence found, or an error if one occurred.
+func (fw *FuzzerWorker) shrinkCallSequenceInParallel(callSequence calls.CallSequence, shrinkRequest ShrinkCallSequenceRequest) (calls.CallSequence, error) {
+	job := &parallelShrinkJob{worker: fw, shrinkRequest: shrinkRequest}
+	optimizedSequence := callSequence
+	batchSize := uint64(fw.fuzzer.config.Fuzzing.Workers)
+
+	// Obtain our shrink limits, counting each candidate tested as a shrink iteration.
+	shrinkIteration := uint64(0)
+	shrinkLimit := fw.fuzzer.config.Fuzzing.ShrinkLimit
+	shrinkingEnded := func() bool {
+		return shrinkIteration >= shrinkLimit || utils.CheckContextDone(fw.fuzzer.ctx)
+	}
+
+	// The first pass of shrinking is greedy towards trying to remove any unnecessary calls, using the same removal
+	// strategies as shrinkCallSequence. Each batch tries to remove the next calls, one per candidate.
+	for removalStrategy := 0; removalStrategy < 2 && !shrinkingEnded(); removalStrategy++ {
+		for i := len(optimizedSequence) - 1; i >= 0 && !shrinkingEnded(); {
+			// Create a candidate for each call we try to remove in this batch.
+			candidates := make([]calls.CallSequence, 0)
+			removedIndices := make([]int, 0)
+			for j := i; j >= 0 && uint64(len(candidates)) < min(batchSize, shrinkLimit-shrinkIteration); j-- {
+				possibleShrunkSequence, err := optimizedSequence.Clone()
+				if err != nil {
+					return nil, err
+				}
+				removedCall := possibleShrunkSequence[j]
+				possibleShrunkSequence = append(possibleShrunkSequence[:j], possibleShrunkSequence[j+1:]...)
+				if removalStrategy == 1 && j > 0 {
+					possibleShrunkSequence[j-1].BlockNumberDelay += removedCall.BlockNumberDelay
+					possibleShrunkSequence[j-1].BlockTimestampDelay += removedCall.BlockTimestampDelay
+				}
+				candidates = append(candidates, possibleShrunkSequence)
+				removedIndices = append(removedIndices, j)
+			}
+
+			// Test the candidates. If one was accepted, the calls which followed the removed call in this batch were
+			// only tested against the previous sequence, so we continue from them (their indices shifted down by one).
+			// Otherwise, we continue from the call preceding the last one tested.
+			accepted, err := fw.testShrunkenCallSequencesInParallel(job, candidates)
+			shrinkIteration += uint64(len(candidates))
+			if err != nil {
+				return nil, err
+			}
+			if accepted >= 0 {
+				optimizedSequence = candidates[accepted]
+				i--
+			} else {
+				i = removedIndices[len(removedIndices)-1] - 1
+			}
+		}
+	}
+
+	// The second pass of shrinking attempts to shrink values for each call in our call sequence, in a round-robin
+	// fashion. Each batch mutates the next calls, one per candidate.
+	i := len(optimizedSequence) - 1
+	for len(optimizedSequence) > 0 && !shrinkingEnded() {
+		candidates := make([]calls.CallSequence, 0)
+		for uint64(len(candidates)) < min(batchSize, shrinkLimit-shrinkIteration) {
+			if i < 0 || i >= len(optimizedSequence) {
+				i = len(optimizedSequence) - 1
+			}
+
+			// Clone the optimized sequence and mutate each argument of the currently indexed call.
+			possibleShrunkSequence, err := optimizedSequence.Clone()
+			if err != nil {
+				return nil, err
+			}
+			abiValuesMsgData := possibleShrunkSequence[i].Call.DataAbiValues
+			for j := 0; j < len(abiValuesMsgData.InputValues); j++ {
+				mutatedInput, err := valuegeneration.MutateAbiValue(fw.sequenceGenerator.config.ValueGenerator, fw.shrinkingValueMutator, &abiValuesMsgData.Method.Inputs[j].Type, abiValuesMsgData.InputValues[j])
+				if err != nil {
+					return nil, fmt.Errorf("error when shrinking call sequence input argument: %v", err)
+				}
+				abiValuesMsgData.InputValues[j] = mutatedInput
+			}
+			possibleShrunkSequence[i].Call.WithDataAbiValues(abiValuesMsgData)
+			candidates = append(candidates, possibleShrunkSequence)
+			i--
+		}
+
+		// Test the candidates, accepting one if it satisfied our conditions.
+		accepted, err := fw.testShrunkenCallSequencesInParallel(job, candidates)
+		shrinkIteration += uint64(len(candidates))
+		if err != nil {
+			return nil, err
+		}
+		if accepted >= 0 {
+			optimizedSequence = candidates[accepted]
+		}
+	}
+
+	fw.fuzzer.logger.Debug(fmt.Sprintf("[Worker %d] Shrinking tested %d candidate call sequence(s), of which %d were tested by other workers", fw.workerIndex, shrinkIteration, job.tasksAssisted.Load()))
+
+	// Candidates may have been tested on other workers' chains, so we verify the final sequence still satisfies our
+	// conditions on our own chain, to ensure it reproduces the result. If it does not, we fall back to the original.
+	if shrinkIteration > 0 {
+		validShrunkSequence, err := fw.testShrunkenCallSequence(optimizedSequence, shrinkRequest)
+		if err != nil {
+			return nil, err
+		}
+		if !validShrunkSequence && !utils.CheckContextDone(fw.fuzzer.ctx) {
+			fw.fuzzer.logger.Warn(fmt.Sprintf("[Worker %d] Call sequence shrunk in parallel could not be verified, the original call sequence will be used", fw.workerIndex))
+			optimizedSequence = callSequence
+		}
+	}
+	return optimizedSequence, nil
+}
@@ -2,13 +2,19 @@ package fuzzing
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"strings"
+	"sync"
 
 	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/config"
 	"github.com/crytic/medusa/fuzzing/contracts"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/utils"
 	"github.com/crytic/medusa/utils/randomutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // CallSequenceGenerator generates call sequences iteratively per element, for use in fuzzing campaigns. It is attached
@@ -37,6 +43,10 @@ type CallSequenceGenerator struct {
 	// mutationStrategyChooser is a weighted random selector of functions that prepare the CallSequenceGenerator with
 	// a baseSequence derived from corpus entries.
 	mutationStrategyChooser *randomutils.WeightedRandomChooser[CallSequenceGeneratorMutationStrategy]
+
+	// senderChooser is a weighted random selector of the senders of newly generated calls. If nil, senders are
+	// selected uniformly.
+	senderChooser *randomutils.WeightedRandomChooser[common.Address]
 }
 
 // CallSequenceGeneratorConfig defines the configuration for a CallSequenceGenerator to be created and used by a
@@ -46,6 +56,10 @@ type CallSequenceGeneratorConfig struct {
 	// sequence rather than mutating one from the corpus.
 	NewSequenceProbability float32
 
+	// FactoryCallProbability defines the probability that a newly generated call at the start of a sequence should
+	// target a factory method (see FuzzerWorker.factoryMethods), decreasing linearly to zero by the end of the sequence.
+	FactoryCallProbability float32
+
 	// RandomUnmodifiedCorpusHeadWeight defines the weight that the CallSequenceGenerator should use the call sequence
 	// generation strategy of taking the head of a corpus sequence (without mutations) and append newly generated calls
 	// to the end of it.
@@ -116,10 +130,12 @@ type CallSequenceGeneratorMutationStrategy struct {
 
 // NewCallSequenceGenerator creates a CallSequenceGenerator to generate call sequences for use in fuzzing campaigns.
 func NewCallSequenceGenerator(worker *FuzzerWorker, config *CallSequenceGeneratorConfig) *CallSequenceGenerator {
+	// The mutation strategy chooser draws from the worker's random provider, so that strategy decisions are reproduced
+	// when a recorded sequence seed is replayed.
 	generator := &CallSequenceGenerator{
 		worker:                  worker,
 		config:                  config,
-		mutationStrategyChooser: randomutils.NewWeightedRandomChooser[CallSequenceGeneratorMutationStrategy](),
+		mutationStrategyChooser: randomutils.NewWeightedRandomChooserWithRand[CallSequenceGeneratorMutationStrategy](worker.randomProvider, &sync.Mutex{}),
 	}
 
 	generator.mutationStrategyChooser.AddChoices(
@@ -181,6 +197,14 @@ func NewCallSequenceGenerator(worker *FuzzerWorker, config *CallSequenceGenerato
 		),
 	)
 
+	// If sender weights were provided, senders are selected using a weighted random selector too.
+	if worker.fuzzer.senderWeights != nil {
+		generator.senderChooser = randomutils.NewWeightedRandomChooserWithRand[common.Address](worker.randomProvider, &sync.Mutex{})
+		for i, sender := range worker.fuzzer.senders {
+			generator.senderChooser.AddChoices(randomutils.NewWeightedRandomChoice(sender, worker.fuzzer.senderWeights[i]))
+		}
+	}
+
 	return generator
 }
 
@@ -291,12 +315,23 @@ func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement
 	var selectedMethod *contracts.DeployedContractMethod
 	if (len(g.worker.pureMethods) > 0 && g.worker.randomProvider.Intn(1000) == 0) || callOnlyPureFunctions {
 		selectedMethod = &g.worker.pureMethods[g.worker.randomProvider.Intn(len(g.worker.pureMethods))]
+	} else if g.shouldCallFactoryMethod() {
+		selectedMethod = &g.worker.factoryMethods[g.worker.randomProvider.Intn(len(g.worker.factoryMethods))]
 	} else {
 		selectedMethod = &g.worker.stateChangingMethods[g.worker.randomProvider.Intn(len(g.worker.stateChangingMethods))]
 	}
 
-	// Select a random sender
-	selectedSender := g.worker.fuzzer.senders[g.worker.randomProvider.Intn(len(g.worker.fuzzer.senders))]
+	// Select a random sender, according to its weight if sender weights were provided.
+	var selectedSender common.Address
+	if g.senderChooser != nil {
+		chosenSender, err := g.senderChooser.Choose()
+		if err != nil {
+			return nil, err
+		}
+		selectedSender = *chosenSender
+	} else {
+		selectedSender = g.worker.fuzzer.senders[g.worker.randomProvider.Intn(len(g.worker.fuzzer.senders))]
+	}
 
 	// Generate fuzzed parameters for the function call
 	args := make([]any, len(selectedMethod.Method.Inputs))
@@ -306,11 +341,17 @@ func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement
 		args[i] = valuegeneration.GenerateAbiValue(g.config.ValueGenerator, &input.Type)
 	}
 
+	// Constrain any integer arguments which have a configured range.
+	err := g.constrainIntegerArguments(selectedMethod.Contract.Name(), &selectedMethod.Method, args)
+	if err != nil {
+		return nil, err
+	}
+
 	// If this is a payable function, generate value to send
 	var value *big.Int
 	value = big.NewInt(0)
 	if selectedMethod.Method.StateMutability == "payable" {
-		value = g.config.ValueGenerator.GenerateInteger(false, 64)
+		value = g.generateCallValue(selectedSender)
 	}
 
 	// Create our message using the provided parameters.
@@ -325,14 +366,22 @@ func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement
 		msg.SkipAccountChecks = true
 	}
 
-	// Determine our delay values for this element
-	blockNumberDelay := uint64(0)
-	blockTimestampDelay := uint64(0)
-	if g.worker.fuzzer.config.Fuzzing.MaxBlockNumberDelay > 0 {
-		blockNumberDelay = g.config.ValueGenerator.GenerateInteger(false, 64).Uint64() % (g.worker.fuzzer.config.Fuzzing.MaxBlockNumberDelay + 1)
+	// Determine our delay values for this element, using those configured for the method if there are any.
+	delays := config.MethodBlockDelayConfig{
+		MaxBlockNumberDelay:    g.worker.fuzzer.config.Fuzzing.MaxBlockNumberDelay,
+		MaxBlockTimestampDelay: g.worker.fuzzer.config.Fuzzing.MaxBlockTimestampDelay,
+	}
+	canonicalSig := strings.Join([]string{selectedMethod.Contract.Name(), selectedMethod.Method.Sig}, ".")
+	methodDelays, hasMethodDelays := g.worker.fuzzer.config.Fuzzing.MethodBlockDelays[canonicalSig]
+	if hasMethodDelays {
+		delays = methodDelays
 	}
-	if g.worker.fuzzer.config.Fuzzing.MaxBlockTimestampDelay > 0 {
-		blockTimestampDelay = g.config.ValueGenerator.GenerateInteger(false, 64).Uint64() % (g.worker.fuzzer.config.Fuzzing.MaxBlockTimestampDelay + 1)
+	blockNumberDelay := g.generateDelay(delays.MinBlockNumberDelay, delays.MaxBlockNumberDelay)
+	var blockTimestampDelay uint64
+	if !hasMethodDelays && g.worker.fuzzer.config.Fuzzing.BlockTimeBase > 0 {
+		blockTimestampDelay = g.generateBlockTimeDelay(blockNumberDelay)
+	} else {
+		blockTimestampDelay = g.generateDelay(delays.MinBlockTimestampDelay, delays.MaxBlockTimestampDelay)
 	}
 
 	// For each block we jump, we need a unique time stamp for chain semantics, so if our block number jump is too small,
@@ -345,10 +394,135 @@ func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement
 		}
 	}
 
+	// If multiple calls should be included in each block, only the first call of each block advances to a new block,
+	// while the remaining calls are added to the same pending block.
+	if txsPerBlock := g.worker.fuzzer.config.Fuzzing.TxsPerBlock; txsPerBlock > 1 {
+		if uint64(g.fetchIndex)%txsPerBlock != 0 {
+			blockNumberDelay, blockTimestampDelay = 0, 0
+		} else if blockNumberDelay == 0 {
+			blockNumberDelay, blockTimestampDelay = 1, max(blockTimestampDelay, 1)
+		}
+	}
+
 	// Return our call sequence element.
 	return calls.NewCallSequenceElement(selectedMethod.Contract, msg, blockNumberDelay, blockTimestampDelay), nil
 }
 
+// generateCallValue generates the value to send with a call to a payable method from the provided sender. If call value
+// generation is configured, the value is bounded by the configured maximum and the sender's balance, and may be biased
+// towards edge values.
+// Returns the generated value.
+func (g *CallSequenceGenerator) generateCallValue(sender common.Address) *big.Int {
+	// If call value generation is not configured, any 64-bit value may be sent.
+	callValueConfig := g.worker.fuzzer.config.Fuzzing.CallValueGeneration
+	if !callValueConfig.Enabled {
+		return g.config.ValueGenerator.GenerateInteger(false, 64)
+	}
+
+	// Determine whether we should send any value at all.
+	if g.worker.randomProvider.Float64() >= callValueConfig.NonZeroProbability {
+		return big.NewInt(0)
+	}
+
+	// Determine the maximum value we can send, which is bounded by the sender's balance.
+	balance := g.worker.chain.State().GetBalance(sender).ToBig()
+	maxValue := balance
+	if callValueConfig.MaxValue != nil && callValueConfig.MaxValue.Cmp(balance) < 0 {
+		maxValue = new(big.Int).Set(callValueConfig.MaxValue)
+	}
+	if maxValue.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	// Select an edge value (1 wei or the maximum value), or a random value in the range [1, maxValue].
+	if g.worker.randomProvider.Float64() < callValueConfig.EdgeValueProbability {
+		if g.worker.randomProvider.Intn(2) == 0 {
+			return big.NewInt(1)
+		}
+		return maxValue
+	}
+	value := new(big.Int).Rand(g.worker.randomProvider, maxValue)
+	return value.Add(value, big.NewInt(1))
+}
+
+// generateDelay generates a block number or timestamp delay within the provided inclusive range.
+// Returns the generated delay.
+func (g *CallSequenceGenerator) generateDelay(minDelay uint64, maxDelay uint64) uint64 {
+	if maxDelay <= minDelay {
+		return minDelay
+	}
+	delay := g.config.ValueGenerator.GenerateInteger(false, 64).Uint64()
+	if span := maxDelay - minDelay; span < math.MaxUint64 {
+		delay %= span + 1
+	}
+	return minDelay + delay
+}
+
+// generateBlockTimeDelay generates a timestamp delay for a jump of the provided amount of blocks, advancing the
+// configured block time base for each block, and deviating from the total by up to the configured block time jitter.
+// As the jitter is less than the base, each block advanced still advances the timestamp.
+// Returns the generated delay.
+func (g *CallSequenceGenerator) generateBlockTimeDelay(blockNumberDelay uint64) uint64 {
+	if blockNumberDelay == 0 {
+		return 0
+	}
+	delay := blockNumberDelay * g.worker.fuzzer.config.Fuzzing.BlockTimeBase
+	jitter := g.worker.fuzzer.config.Fuzzing.BlockTimeJitter
+	if jitter == 0 {
+		return delay
+	}
+	return delay - jitter + g.worker.randomProvider.Uint64()%(2*jitter+1)
+}
+
+// shouldCallFactoryMethod determines whether the next newly generated call should target a factory method. Factory
+// methods are favored at the start of a sequence, so the contract instances they create exist for subsequent calls.
+// Returns a boolean indicating whether a factory method should be called.
+func (g *CallSequenceGenerator) shouldCallFactoryMethod() bool {
+	if len(g.worker.factoryMethods) == 0 || g.config.FactoryCallProbability <= 0 || len(g.baseSequence) == 0 {
+		return false
+	}
+	sequenceProgress := float32(g.fetchIndex) / float32(len(g.baseSequence))
+	return g.worker.randomProvider.Float32() < g.config.FactoryCallProbability*(1-sequenceProgress)
+}
+
+// constrainIntegerArguments constrains the provided arguments for a given contract method to any integer argument
+// ranges defined in the project configuration. The arguments are updated in place.
+// Returns an error if one occurs.
+func (g *CallSequenceGenerator) constrainIntegerArguments(contractName string, method *abi.Method, args []any) error {
+	// Loop through every configured range and apply those targeting this method.
+	canonicalSig := strings.Join([]string{contractName, method.Sig}, ".")
+	for _, argumentRange := range g.worker.fuzzer.config.Fuzzing.IntegerArgumentRanges {
+		if argumentRange.FunctionSignature != canonicalSig || argumentRange.ArgumentIndex >= len(args) {
+			continue
+		}
+
+		constrainedValue, err := valuegeneration.ConstrainAbiIntegerValue(&method.Inputs[argumentRange.ArgumentIndex].Type, args[argumentRange.ArgumentIndex], argumentRange.Min, argumentRange.Max)
+		if err != nil {
+			return fmt.Errorf("could not constrain argument %d of %s: %v", argumentRange.ArgumentIndex, canonicalSig, err)
+		}
+		args[argumentRange.ArgumentIndex] = constrainedValue
+	}
+	return nil
+}
+
+// structFieldMutationProbabilities obtains the struct field mutation probabilities defined in the project configuration
+// for the argument at the provided index of a given contract method, mapping field paths to their probabilities.
+// Returns nil if none are defined for the argument.
+func (g *CallSequenceGenerator) structFieldMutationProbabilities(contractName string, method *abi.Method, argumentIndex int) map[string]float64 {
+	var fieldMutationProbabilities map[string]float64
+	canonicalSig := strings.Join([]string{contractName, method.Sig}, ".")
+	for _, fieldMutation := range g.worker.fuzzer.config.Fuzzing.StructFieldMutationProbabilities {
+		if fieldMutation.FunctionSignature != canonicalSig || fieldMutation.ArgumentIndex != argumentIndex {
+			continue
+		}
+		if fieldMutationProbabilities == nil {
+			fieldMutationProbabilities = make(map[string]float64)
+		}
+		fieldMutationProbabilities[fieldMutation.FieldPath] = fieldMutation.Probability
+	}
+	return fieldMutationProbabilities
+}
+
 // callSeqGenFuncCorpusHead is a CallSequenceGeneratorFunc which prepares a CallSequenceGenerator to generate a sequence
 // whose head is based off of an existing corpus call sequence.
 // Returns an error if one occurs.
@@ -467,15 +641,23 @@ func prefetchModifyCallFuncMutate(sequenceGenerator *CallSequenceGenerator, elem
 		return nil
 	}
 
-	// Loop for each input value and mutate it
+	// Loop for each input value and mutate it, applying any struct field mutation probabilities configured for it.
 	abiValuesMsgData := element.Call.DataAbiValues
 	for i := 0; i < len(abiValuesMsgData.InputValues); i++ {
-		mutatedInput, err := valuegeneration.MutateAbiValue(sequenceGenerator.config.ValueGenerator, sequenceGenerator.config.ValueMutator, &abiValuesMsgData.Method.Inputs[i].Type, abiValuesMsgData.InputValues[i])
+		fieldMutationProbabilities := sequenceGenerator.structFieldMutationProbabilities(element.Contract.Name(), abiValuesMsgData.Method, i)
+		mutatedInput, err := valuegeneration.MutateAbiValueWithFieldProbabilities(sequenceGenerator.config.ValueGenerator, sequenceGenerator.config.ValueMutator, &abiValuesMsgData.Method.Inputs[i].Type, abiValuesMsgData.InputValues[i], fieldMutationProbabilities, sequenceGenerator.worker.randomProvider)
 		if err != nil {
 			return fmt.Errorf("error when mutating call sequence input argument: %v", err)
 		}
 		abiValuesMsgData.InputValues[i] = mutatedInput
 	}
+
+	// Constrain any integer arguments which have a configured range.
+	err := sequenceGenerator.constrainIntegerArguments(element.Contract.Name(), abiValuesMsgData.Method, abiValuesMsgData.InputValues)
+	if err != nil {
+		return err
+	}
+
 	// Re-encode the message's calldata
 	element.Call.WithDataAbiValues(abiValuesMsgData)
 
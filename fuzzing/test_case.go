@@ -18,6 +18,9 @@ const (
 	TestCaseStatusPassed TestCaseStatus = "PASSED"
 	// TestCaseStatusFailed describes a test status where testing has concluded and the test failed.
 	TestCaseStatusFailed TestCaseStatus = "FAILED"
+	// TestCaseStatusSkipped describes a test status where the test requested to be skipped (e.g. through the skip
+	// cheat code), so it is neither considered passed nor failed.
+	TestCaseStatusSkipped TestCaseStatus = "SKIPPED"
 )
 
 // TestCase describes a test which is being conducted by a test provider attached to the Fuzzer.
@@ -43,3 +46,33 @@ type TestCase interface {
 	// TestResult instances (even if the CallSequence differs or has not been shrunk).
 	ID() string
 }
+
+// TestCaseReport describes the result of a TestCase in a stable, machine-readable format, so that test results can be
+// processed by external tooling.
+type TestCaseReport struct {
+	// ID describes the unique identifier of the test case.
+	ID string `json:"id"`
+
+	// Name describes the name of the test case.
+	Name string `json:"name"`
+
+	// Status describes the TestCaseStatus of the test case at the time the report was created.
+	Status TestCaseStatus `json:"status"`
+
+	// CallSequence describes each call in the call sequence which resulted in the test case result, or nil if the
+	// result is not related to a call sequence.
+	CallSequence []calls.CallSequenceElementReport `json:"callSequence"`
+}
+
+// NewTestCaseReport creates a TestCaseReport describing the provided TestCase.
+func NewTestCaseReport(testCase TestCase) TestCaseReport {
+	report := TestCaseReport{
+		ID:     testCase.ID(),
+		Name:   testCase.Name(),
+		Status: testCase.Status(),
+	}
+	if callSequence := testCase.CallSequence(); callSequence != nil {
+		report.CallSequence = callSequence.Report()
+	}
+	return report
+}
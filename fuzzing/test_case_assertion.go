@@ -21,6 +21,10 @@ type AssertionTestCase struct {
 	targetMethod abi.Method
 	// callSequence describes the call sequence that broke the assertion
 	callSequence *calls.CallSequence
+	// panicReason describes the human-readable reason for the panic which broke the assertion
+	panicReason string
+	// sourceLocation describes the source location of the panic which broke the assertion, if it could be resolved
+	sourceLocation *string
 }
 
 // Status describes the TestCaseStatus used to define the current state of the test.
@@ -47,11 +51,27 @@ func (t *AssertionTestCase) LogMessage() *logging.LogBuffer {
 	if t.Status() == TestCaseStatusFailed {
 		buffer.Append(colors.RedBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset, "\n")
 		buffer.Append(fmt.Sprintf("Test for method \"%s.%s\" resulted in an assertion failure after the following call sequence:\n", t.targetContract.Name(), t.targetMethod.Sig))
+		if t.panicReason != "" {
+			buffer.Append(colors.Bold, "[Failure Reason] ", colors.Reset, t.panicReason)
+			if t.sourceLocation != nil {
+				buffer.Append(fmt.Sprintf(" (at %s)", *t.sourceLocation))
+			}
+			buffer.Append("\n")
+		}
+		if cheatCodesUsed := t.CallSequence().CheatCodesUsed(); len(cheatCodesUsed) > 0 {
+			buffer.Append(colors.Bold, "[Cheat Codes]", colors.Reset, fmt.Sprintf(" used: %s\n", strings.Join(cheatCodesUsed, ", ")))
+		}
 		buffer.Append(colors.Bold, "[Call Sequence]", colors.Reset, "\n")
 		buffer.Append(t.CallSequence().Log().Elements()...)
 		return buffer
 	}
 
+	// If the test was skipped, return a message distinguishing it from passed tests.
+	if t.Status() == TestCaseStatusSkipped {
+		buffer.Append(colors.YellowBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset)
+		return buffer
+	}
+
 	buffer.Append(colors.GreenBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset)
 	return buffer
 }
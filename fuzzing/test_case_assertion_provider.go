@@ -1,13 +1,21 @@
 package fuzzing
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"math/big"
+	"regexp"
 	"sync"
 
+	"github.com/crytic/medusa/chain"
 	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/fuzzing/config"
 	"github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
 
 	"golang.org/x/exp/slices"
 )
@@ -24,6 +32,14 @@ type AssertionTestCaseProvider struct {
 
 	// testCasesLock is used for thread-synchronization when updating testCases
 	testCasesLock sync.Mutex
+
+	// failOnCustomErrorSelectors describes the 4-byte custom error selectors which are treated as a failing case when
+	// a tested method reverts with them.
+	failOnCustomErrorSelectors [][]byte
+
+	// failOnRevertMessageRegex describes the regular expression which, when matched by the revert reason string of a
+	// tested method, is treated as a failing case. If nil, revert reason strings are not checked.
+	failOnRevertMessageRegex *regexp.Regexp
 }
 
 // attachAssertionTestCaseProvider attaches a new AssertionTestCaseProvider to the Fuzzer and returns it.
@@ -44,18 +60,19 @@ func attachAssertionTestCaseProvider(fuzzer *Fuzzer) *AssertionTestCaseProvider
 }
 
 // checkAssertionFailures checks the results of the last call for assertion failures.
-// Returns the method ID, a boolean indicating if an assertion test failed, or an error if one occurs.
-func (t *AssertionTestCaseProvider) checkAssertionFailures(callSequence calls.CallSequence) (*contracts.ContractMethodID, bool, error) {
+// Returns the method ID, a boolean indicating if an assertion test failed, a boolean indicating if the call requested
+// its test be skipped through the skip cheat code, or an error if one occurs.
+func (t *AssertionTestCaseProvider) checkAssertionFailures(callSequence calls.CallSequence) (*contracts.ContractMethodID, bool, bool, error) {
 	// If we have an empty call sequence, we cannot have an assertion failure
 	if len(callSequence) == 0 {
-		return nil, false, nil
+		return nil, false, false, nil
 	}
 
 	// Obtain the contract and method from the last call made in our sequence
 	lastCall := callSequence[len(callSequence)-1]
 	lastCallMethod, err := lastCall.Method()
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
 	}
 	methodId := contracts.GetContractMethodID(lastCall.Contract, lastCallMethod)
 
@@ -71,7 +88,15 @@ func (t *AssertionTestCaseProvider) checkAssertionFailures(callSequence calls.Ca
 		failure = encounteredAssertionFailure(panicCode.Uint64(), t.fuzzer.config.Fuzzing.Testing.AssertionTesting.PanicCodeConfig)
 	}
 
-	return &methodId, failure, nil
+	// Check if we reverted in a way which was configured to be treated as a failing case.
+	if !failure {
+		failure = t.encounteredRevertFailure(lastExecutionResult, lastCall.Contract) != nil
+	}
+
+	// Check if the call reverted through the skip cheat code.
+	skipped := lastExecutionResult.Failed() && chain.IsSkipRevertData(lastExecutionResult.Revert())
+
+	return &methodId, failure, skipped, nil
 }
 
 // onFuzzerStarting is the event handler triggered when the Fuzzer is starting a fuzzing campaign. It creates test cases
@@ -79,6 +104,22 @@ func (t *AssertionTestCaseProvider) checkAssertionFailures(callSequence calls.Ca
 func (t *AssertionTestCaseProvider) onFuzzerStarting(event FuzzerStartingEvent) error {
 	// Reset our state
 	t.testCases = make(map[contracts.ContractMethodID]*AssertionTestCase)
+	t.failOnCustomErrorSelectors = make([][]byte, 0)
+	for _, customErrorSelector := range t.fuzzer.config.Fuzzing.Testing.AssertionTesting.FailOnCustomErrors {
+		selector, err := hexutil.Decode(customErrorSelector)
+		if err != nil {
+			return err
+		}
+		t.failOnCustomErrorSelectors = append(t.failOnCustomErrorSelectors, selector)
+	}
+	t.failOnRevertMessageRegex = nil
+	if revertMessageRegex := t.fuzzer.config.Fuzzing.Testing.AssertionTesting.FailOnRevertMessageRegex; revertMessageRegex != "" {
+		var err error
+		t.failOnRevertMessageRegex, err = regexp.Compile(revertMessageRegex)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Create a test case for every test method.
 	for _, contract := range t.fuzzer.ContractDefinitions() {
@@ -166,7 +207,7 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 	shrinkRequests := make([]ShrinkCallSequenceRequest, 0)
 
 	// Obtain the method ID for the last call and check if it encountered assertion failures.
-	methodId, testFailed, err := t.checkAssertionFailures(callSequence)
+	methodId, testFailed, testSkipped, err := t.checkAssertionFailures(callSequence)
 	if err != nil {
 		return nil, err
 	}
@@ -181,8 +222,17 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 		return shrinkRequests, nil
 	}
 
-	// If the test case already failed, skip it
-	if testCase.Status() == TestCaseStatusFailed {
+	// If the test case already failed or was skipped, skip it
+	if testCase.Status() == TestCaseStatusFailed || testCase.Status() == TestCaseStatusSkipped {
+		return shrinkRequests, nil
+	}
+
+	// If the test method requested to be skipped, we mark it as skipped and report it finalized.
+	if testSkipped {
+		t.testCasesLock.Lock()
+		testCase.status = TestCaseStatusSkipped
+		t.testCasesLock.Unlock()
+		worker.Fuzzer().ReportTestCaseFinished(testCase)
 		return shrinkRequests, nil
 	}
 
@@ -193,7 +243,7 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 		shrinkRequest := ShrinkCallSequenceRequest{
 			VerifierFunction: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence) (bool, error) {
 				// Obtain the method ID for the last call and check if it encountered assertion failures.
-				shrunkSeqMethodId, shrunkSeqTestFailed, err := t.checkAssertionFailures(shrunkenCallSequence)
+				shrunkSeqMethodId, shrunkSeqTestFailed, _, err := t.checkAssertionFailures(shrunkenCallSequence)
 				if err != nil {
 					return false, err
 				}
@@ -210,6 +260,21 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 					}
 				}
 
+				// Resolve the human-readable panic reason and its source location from the last call, to aid triage.
+				if len(shrunkenCallSequence) > 0 {
+					lastCall := shrunkenCallSequence[len(shrunkenCallSequence)-1]
+					lastExecutionResult := lastCall.ChainReference.MessageResults().ExecutionResult
+					panicCode := abiutils.GetSolidityPanicCode(lastExecutionResult.Err, lastExecutionResult.ReturnData, true)
+					if panicCode != nil {
+						testCase.panicReason = abiutils.GetPanicReason(panicCode.Uint64())
+					} else if revertReason := t.encounteredRevertFailure(lastExecutionResult, lastCall.Contract); revertReason != nil {
+						testCase.panicReason = *revertReason
+					}
+					if lastCall.ExecutionTrace != nil {
+						testCase.sourceLocation = lastCall.ExecutionTrace.FailureSourceLocation()
+					}
+				}
+
 				// Update our test state and report it finalized.
 				testCase.status = TestCaseStatusFailed
 				testCase.callSequence = &shrunkenCallSequence
@@ -227,6 +292,56 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 	return shrinkRequests, nil
 }
 
+// encounteredRevertFailure checks whether the provided execution result, produced by a call to the provided contract
+// (or nil if unresolved), reverted with a configured custom error selector or a revert reason matching the configured
+// regular expression.
+// Returns a human-readable reason for the failure, or nil if the execution result is not treated as a failing case.
+func (t *AssertionTestCaseProvider) encounteredRevertFailure(executionResult *core.ExecutionResult, contract *contracts.Contract) *string {
+	// If execution did not revert, there is nothing to check.
+	if executionResult == nil || !errors.Is(executionResult.Err, vm.ErrExecutionReverted) {
+		return nil
+	}
+
+	// Reverts caused by the skip cheat code are never treated as a failing case.
+	if chain.IsSkipRevertData(executionResult.ReturnData) {
+		return nil
+	}
+
+	// Reverts configured as expected are never treated as a failing case.
+	if !t.fuzzer.isUnexpectedRevert(executionResult) {
+		return nil
+	}
+
+	// Check whether we reverted with a reason string which matches the configured regular expression.
+	if t.failOnRevertMessageRegex != nil {
+		revertMessage := abiutils.GetSolidityRevertErrorString(executionResult.Err, executionResult.ReturnData)
+		if revertMessage != nil && t.failOnRevertMessageRegex.MatchString(*revertMessage) {
+			reason := fmt.Sprintf("reverted with message %q", *revertMessage)
+			return &reason
+		}
+	}
+
+	// Check whether we reverted with a custom error selector which is treated as a failing case.
+	if len(executionResult.ReturnData) >= 4 {
+		for _, selector := range t.failOnCustomErrorSelectors {
+			if !bytes.Equal(executionResult.ReturnData[:4], selector) {
+				continue
+			}
+
+			// Describe the custom error by its signature if we can resolve it, otherwise by its selector.
+			reason := fmt.Sprintf("reverted with custom error selector %v", hexutil.Encode(selector))
+			if contract != nil {
+				customError, _ := abiutils.GetSolidityCustomRevertError(&contract.CompiledContract().Abi, executionResult.Err, executionResult.ReturnData)
+				if customError != nil {
+					reason = fmt.Sprintf("reverted with custom error %v", customError.Sig)
+				}
+			}
+			return &reason
+		}
+	}
+	return nil
+}
+
 // encounteredAssertionFailure takes in a panic code and a config.AssertionModesConfig and will determine whether the
 // panic code that was hit should be treated as a failing case - which will be determined by whether that panic
 // code was enabled in the config. Note that the panic codes are defined in the abiutils package and that this function
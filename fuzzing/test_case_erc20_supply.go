@@ -0,0 +1,73 @@
+package fuzzing
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/logging"
+	"github.com/crytic/medusa/logging/colors"
+)
+
+// ERC20SupplyTestCase describes a test being run by an ERC20SupplyTestCaseProvider.
+type ERC20SupplyTestCase struct {
+	// status describes the status of the test case
+	status TestCaseStatus
+	// targetContract describes the ERC20 token contract which the test case checks
+	targetContract *fuzzerTypes.Contract
+	// callSequence describes the call sequence that broke the supply invariant
+	callSequence *calls.CallSequence
+	// totalSupply describes the total supply reported by the token when the invariant was broken
+	totalSupply *big.Int
+	// balanceSum describes the sum of tracked holder balances when the invariant was broken
+	balanceSum *big.Int
+}
+
+// Status describes the TestCaseStatus used to define the current state of the test.
+func (t *ERC20SupplyTestCase) Status() TestCaseStatus {
+	return t.status
+}
+
+// CallSequence describes the types.CallSequence of calls sent to the EVM which resulted in this TestCase result.
+// This should be nil if the result is not related to the CallSequence.
+func (t *ERC20SupplyTestCase) CallSequence() *calls.CallSequence {
+	return t.callSequence
+}
+
+// Name describes the name of the test case.
+func (t *ERC20SupplyTestCase) Name() string {
+	return fmt.Sprintf("ERC20 Supply Test: %s", t.targetContract.Name())
+}
+
+// LogMessage obtains a buffer that represents the result of the ERC20SupplyTestCase. This buffer can be passed to a
+// logger for console or file logging.
+func (t *ERC20SupplyTestCase) LogMessage() *logging.LogBuffer {
+	// If the test failed, return a failure message.
+	buffer := logging.NewLogBuffer()
+	if t.Status() == TestCaseStatusFailed {
+		buffer.Append(colors.RedBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset, "\n")
+		buffer.Append(fmt.Sprintf("Total supply of \"%s\" (%v) did not match the sum of tracked holder balances (%v) after the following call sequence:\n", t.targetContract.Name(), t.totalSupply, t.balanceSum))
+		if cheatCodesUsed := t.CallSequence().CheatCodesUsed(); len(cheatCodesUsed) > 0 {
+			buffer.Append(colors.Bold, "[Cheat Codes]", colors.Reset, fmt.Sprintf(" used: %s\n", strings.Join(cheatCodesUsed, ", ")))
+		}
+		buffer.Append(colors.Bold, "[Call Sequence]", colors.Reset, "\n")
+		buffer.Append(t.CallSequence().Log().Elements()...)
+		return buffer
+	}
+
+	buffer.Append(colors.GreenBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset)
+	return buffer
+}
+
+// Message obtains a text-based printable message which describes the result of the ERC20SupplyTestCase.
+func (t *ERC20SupplyTestCase) Message() string {
+	// Internally, we just call log message and convert it to a string. This can be useful for 3rd party apps
+	return t.LogMessage().String()
+}
+
+// ID obtains a unique identifier for a test result.
+func (t *ERC20SupplyTestCase) ID() string {
+	return strings.Replace(fmt.Sprintf("ERC20-SUPPLY-%s", t.targetContract.Name()), "_", "-", -1)
+}
@@ -0,0 +1,364 @@
+package fuzzing
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/exp/slices"
+)
+
+// erc20TransferEventID describes the topic identifying ERC20 Transfer(address,address,uint256) events.
+var erc20TransferEventID = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// ERC20SupplyTestCaseProvider is a provider for automatic ERC20 supply invariant tests.
+// Any target contract which exposes ERC20-like `totalSupply()` and `balanceOf(address)` methods is checked after every
+// call the Fuzzer makes, to ensure the sum of all tracked token holder balances equals the total supply. Tracked
+// holders include the deployer, senders, deployed contracts, and any account seen in a Transfer event emitted by the
+// token. If the invariant is broken, the test signals a failed status. If no failure is found before the fuzzing
+// campaign ends, the test signals a passed status.
+type ERC20SupplyTestCaseProvider struct {
+	// fuzzer describes the Fuzzer which this provider is attached to.
+	fuzzer *Fuzzer
+
+	// testCases is a map of ERC20 contract definitions to their supply test cases.
+	testCases map[*contracts.Contract]*ERC20SupplyTestCase
+
+	// testCasesLock is used for thread-synchronization when updating testCases
+	testCasesLock sync.Mutex
+
+	// workerStates is a slice where each element stores state for a given worker index.
+	workerStates []erc20SupplyTestCaseProviderWorkerState
+}
+
+// erc20SupplyTestCaseProviderWorkerState represents the state for an individual worker maintained by
+// ERC20SupplyTestCaseProvider.
+type erc20SupplyTestCaseProviderWorkerState struct {
+	// tokens is a mapping of deployed ERC20 token addresses to their contract definitions.
+	tokens map[common.Address]*contracts.Contract
+
+	// tokensLock is used for thread-synchronization when updating tokens
+	tokensLock sync.Mutex
+}
+
+// attachERC20SupplyTestCaseProvider attaches a new ERC20SupplyTestCaseProvider to the Fuzzer and returns it.
+func attachERC20SupplyTestCaseProvider(fuzzer *Fuzzer) *ERC20SupplyTestCaseProvider {
+	// Create a test case provider
+	t := &ERC20SupplyTestCaseProvider{
+		fuzzer: fuzzer,
+	}
+
+	// Subscribe the provider to relevant events the fuzzer emits.
+	fuzzer.Events.FuzzerStarting.Subscribe(t.onFuzzerStarting)
+	fuzzer.Events.FuzzerStopping.Subscribe(t.onFuzzerStopping)
+	fuzzer.Events.WorkerCreated.Subscribe(t.onWorkerCreated)
+
+	// Add the provider's call sequence test function to the fuzzer.
+	fuzzer.Hooks.CallSequenceTestFuncs = append(fuzzer.Hooks.CallSequenceTestFuncs, t.callSequencePostCallTest)
+	return t
+}
+
+// isERC20Contract determines whether the provided contract definition exposes ERC20-like `totalSupply()` and
+// `balanceOf(address)` methods, each returning a single uint256.
+func isERC20Contract(contract *contracts.Contract) bool {
+	contractAbi := contract.CompiledContract().Abi
+	totalSupplyMethod, hasTotalSupply := contractAbi.Methods["totalSupply"]
+	balanceOfMethod, hasBalanceOf := contractAbi.Methods["balanceOf"]
+	if !hasTotalSupply || !hasBalanceOf {
+		return false
+	}
+	if totalSupplyMethod.Sig != "totalSupply()" || balanceOfMethod.Sig != "balanceOf(address)" {
+		return false
+	}
+	return len(totalSupplyMethod.Outputs) == 1 && totalSupplyMethod.Outputs[0].Type.String() == "uint256" &&
+		len(balanceOfMethod.Outputs) == 1 && balanceOfMethod.Outputs[0].Type.String() == "uint256"
+}
+
+// callTokenUint256 calls a method of a deployed token which returns a single uint256.
+// Returns the value returned, nil if the call failed, or an error if one occurred.
+func (t *ERC20SupplyTestCaseProvider) callTokenUint256(worker *FuzzerWorker, tokenAddress common.Address, token *contracts.Contract, methodName string, args ...any) (*big.Int, error) {
+	// Generate our ABI input data for the call.
+	contractAbi := token.CompiledContract().Abi
+	data, err := contractAbi.Pack(methodName, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create and execute a call targeting our token method
+	msg := calls.NewCallMessage(worker.Fuzzer().senders[0], &tokenAddress, 0, big.NewInt(0), worker.fuzzer.config.Fuzzing.TransactionGasLimit, nil, nil, nil, data)
+	msg.FillFromTestChainProperties(worker.chain)
+	executionResult, err := worker.Chain().CallContract(msg.ToCoreMessage(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ERC20 method '%s': %v", methodName, err)
+	}
+
+	// If the call failed, we cannot evaluate the value.
+	if executionResult.Failed() {
+		return nil, nil
+	}
+
+	// Decode our ABI outputs
+	retVals, err := contractAbi.Methods[methodName].Outputs.Unpack(executionResult.Return())
+	if err != nil || len(retVals) != 1 {
+		return nil, fmt.Errorf("failed to decode ERC20 method '%s' return value: %v", methodName, err)
+	}
+	value, ok := retVals[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse ERC20 method '%s' return value", methodName)
+	}
+	return value, nil
+}
+
+// trackedHolders obtains all token holders of a given token which should be accounted for when checking its supply.
+// This includes the deployer, senders, deployed contracts, and any account seen in a Transfer event emitted by the
+// token on the worker's chain.
+func (t *ERC20SupplyTestCaseProvider) trackedHolders(worker *FuzzerWorker, tokenAddress common.Address) []common.Address {
+	// Add our known accounts.
+	holders := make([]common.Address, 0)
+	addHolder := func(holder common.Address) {
+		if !slices.Contains(holders, holder) {
+			holders = append(holders, holder)
+		}
+	}
+	addHolder(worker.fuzzer.deployer)
+	for _, sender := range worker.fuzzer.senders {
+		addHolder(sender)
+	}
+	for contractAddress := range worker.deployedContracts {
+		addHolder(contractAddress)
+	}
+
+	// Add any accounts which were part of a transfer, in committed blocks and the pending block.
+	blocks := worker.chain.CommittedBlocks()
+	if worker.chain.PendingBlock() != nil {
+		blocks = append(slices.Clone(blocks), worker.chain.PendingBlock())
+	}
+	for _, block := range blocks {
+		for _, messageResults := range block.MessageResults {
+			if messageResults.Receipt == nil {
+				continue
+			}
+			for _, log := range messageResults.Receipt.Logs {
+				if log.Address == tokenAddress && len(log.Topics) == 3 && log.Topics[0] == erc20TransferEventID {
+					addHolder(common.BytesToAddress(log.Topics[1].Bytes()))
+					addHolder(common.BytesToAddress(log.Topics[2].Bytes()))
+				}
+			}
+		}
+	}
+
+	// The zero address is used to represent minting and burning, so it is not considered a holder.
+	holders = slices.DeleteFunc(holders, func(holder common.Address) bool {
+		return holder == common.Address{}
+	})
+	return holders
+}
+
+// checkSupplyTestFailed checks whether the total supply of a given deployed token matches the sum of its tracked
+// holder balances.
+// Returns a boolean indicating if the supply test failed, the total supply and sum of balances, or an error if one
+// occurred.
+func (t *ERC20SupplyTestCaseProvider) checkSupplyTestFailed(worker *FuzzerWorker, tokenAddress common.Address, token *contracts.Contract) (bool, *big.Int, *big.Int, error) {
+	// Obtain the total supply. If it could not be obtained, we cannot evaluate the invariant.
+	totalSupply, err := t.callTokenUint256(worker, tokenAddress, token, "totalSupply")
+	if err != nil || totalSupply == nil {
+		return false, nil, nil, err
+	}
+
+	// Sum the balances of all tracked holders.
+	balanceSum := big.NewInt(0)
+	for _, holder := range t.trackedHolders(worker, tokenAddress) {
+		balance, err := t.callTokenUint256(worker, tokenAddress, token, "balanceOf", holder)
+		if err != nil || balance == nil {
+			return false, nil, nil, err
+		}
+		balanceSum.Add(balanceSum, balance)
+	}
+	return totalSupply.Cmp(balanceSum) != 0, totalSupply, balanceSum, nil
+}
+
+// onFuzzerStarting is the event handler triggered when the Fuzzer is starting a fuzzing campaign. It creates test cases
+// in a "not started" state for every ERC20-like contract discovered in the contract definitions known to the Fuzzer.
+func (t *ERC20SupplyTestCaseProvider) onFuzzerStarting(event FuzzerStartingEvent) error {
+	// Reset our state
+	t.testCases = make(map[*contracts.Contract]*ERC20SupplyTestCase)
+	t.workerStates = make([]erc20SupplyTestCaseProviderWorkerState, t.fuzzer.Config().Fuzzing.Workers)
+
+	// Create a test case for every ERC20 contract.
+	for _, contract := range t.fuzzer.ContractDefinitions() {
+		// If we're not testing all contracts, verify the current contract is one we specified in our target contracts.
+		if !t.fuzzer.config.Fuzzing.Testing.TestAllContracts && !slices.Contains(t.fuzzer.config.Fuzzing.TargetContracts, contract.Name()) {
+			continue
+		}
+		if !isERC20Contract(contract) {
+			continue
+		}
+
+		// Create our supply test case, add it to our test cases and register it with the fuzzer
+		supplyTestCase := &ERC20SupplyTestCase{
+			status:         TestCaseStatusNotStarted,
+			targetContract: contract,
+			callSequence:   nil,
+		}
+		t.testCases[contract] = supplyTestCase
+		t.fuzzer.RegisterTestCase(supplyTestCase)
+	}
+	return nil
+}
+
+// onFuzzerStopping is the event handler triggered when the Fuzzer is stopping the fuzzing campaign and all workers
+// have been destroyed. It clears state tracked for each FuzzerWorker and sets test cases in "running" states to
+// "passed".
+func (t *ERC20SupplyTestCaseProvider) onFuzzerStopping(event FuzzerStoppingEvent) error {
+	// Clear our tracked tokens
+	t.workerStates = nil
+
+	// Loop through each test case and set any tests with a running status to a passed status.
+	for _, testCase := range t.testCases {
+		if testCase.status == TestCaseStatusRunning {
+			testCase.status = TestCaseStatusPassed
+		}
+	}
+	return nil
+}
+
+// onWorkerCreated is the event handler triggered when a FuzzerWorker is created by the Fuzzer. It ensures state tracked
+// for that worker index is refreshed and subscribes to relevant worker events.
+func (t *ERC20SupplyTestCaseProvider) onWorkerCreated(event FuzzerWorkerCreatedEvent) error {
+	// Create a new state for this worker.
+	t.workerStates[event.Worker.WorkerIndex()] = erc20SupplyTestCaseProviderWorkerState{
+		tokens:     make(map[common.Address]*contracts.Contract),
+		tokensLock: sync.Mutex{},
+	}
+
+	// Subscribe to relevant worker events.
+	event.Worker.Events.ContractAdded.Subscribe(t.onWorkerDeployedContractAdded)
+	event.Worker.Events.ContractDeleted.Subscribe(t.onWorkerDeployedContractDeleted)
+	return nil
+}
+
+// onWorkerDeployedContractAdded is the event handler triggered when a FuzzerWorker detects a new contract deployment
+// on its underlying chain. If the deployed contract has a supply test case, the deployment is tracked by the provider
+// for testing and a test case in a "not started" state is put into a "running" state.
+func (t *ERC20SupplyTestCaseProvider) onWorkerDeployedContractAdded(event FuzzerWorkerContractAddedEvent) error {
+	// If we don't have a contract definition, we can't run supply tests against the contract.
+	if event.ContractDefinition == nil {
+		return nil
+	}
+
+	// If we have a test case targeting this contract that has not failed, track this deployment.
+	t.testCasesLock.Lock()
+	supplyTestCase, supplyTestCaseExists := t.testCases[event.ContractDefinition]
+	t.testCasesLock.Unlock()
+
+	if supplyTestCaseExists {
+		if supplyTestCase.Status() == TestCaseStatusNotStarted {
+			supplyTestCase.status = TestCaseStatusRunning
+		}
+		if supplyTestCase.Status() != TestCaseStatusFailed {
+			workerState := &t.workerStates[event.Worker.WorkerIndex()]
+			workerState.tokensLock.Lock()
+			workerState.tokens[event.ContractAddress] = event.ContractDefinition
+			workerState.tokensLock.Unlock()
+		}
+	}
+	return nil
+}
+
+// onWorkerDeployedContractDeleted is the event handler triggered when a FuzzerWorker detects that a previously deployed
+// contract no longer exists on its underlying chain. It ensures the deployment is no longer tracked by the provider for
+// testing.
+func (t *ERC20SupplyTestCaseProvider) onWorkerDeployedContractDeleted(event FuzzerWorkerContractDeletedEvent) error {
+	workerState := &t.workerStates[event.Worker.WorkerIndex()]
+	workerState.tokensLock.Lock()
+	delete(workerState.tokens, event.ContractAddress)
+	workerState.tokensLock.Unlock()
+	return nil
+}
+
+// callSequencePostCallTest provides is a CallSequenceTestFunc that performs post-call testing logic for the attached
+// Fuzzer and any underlying FuzzerWorker. It is called after every call made in a call sequence. It checks whether the
+// supply invariant of every tracked token is upheld after each call the Fuzzer makes when testing a call sequence.
+func (t *ERC20SupplyTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+	// Create a list of shrink call sequence verifiers, which we populate for each failed supply test we want a call
+	// sequence shrunk for.
+	shrinkRequests := make([]ShrinkCallSequenceRequest, 0)
+
+	// Obtain the test provider state for this worker
+	workerState := &t.workerStates[worker.WorkerIndex()]
+
+	// Loop through all tracked tokens and test them.
+	for tokenAddress, token := range workerState.tokens {
+		// Obtain the test case for this token
+		t.testCasesLock.Lock()
+		testCase := t.testCases[token]
+		t.testCasesLock.Unlock()
+
+		// If the test case already failed, skip it
+		if testCase.Status() == TestCaseStatusFailed {
+			continue
+		}
+
+		// Test our supply invariant (create local copies to avoid the loop overwriting them)
+		tokenAddress := tokenAddress
+		token := token
+		failedSupplyTest, _, _, err := t.checkSupplyTestFailed(worker, tokenAddress, token)
+		if err != nil {
+			return nil, err
+		}
+
+		// If we failed a test, we provide a shrink verifier which will update the call sequence for each shrunken
+		// sequence provided that fails the supply test.
+		if failedSupplyTest {
+			shrinkRequest := ShrinkCallSequenceRequest{
+				VerifierFunction: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence) (bool, error) {
+					// First verify the token is still deployed to call upon.
+					if _, tokenDeployed := worker.deployedContracts[tokenAddress]; !tokenDeployed {
+						return false, nil
+					}
+
+					// Then ensure the previously failed supply test fails for the shrunk sequence as well.
+					shrunkenSequenceFailedTest, _, _, err := t.checkSupplyTestFailed(worker, tokenAddress, token)
+					return shrunkenSequenceFailedTest, err
+				},
+				FinishedCallback: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence, verboseTracing bool) error {
+					// When we're finished shrinking, attach an execution trace to the last call. If verboseTracing is true, attach to all calls.
+					if len(shrunkenCallSequence) > 0 {
+						_, err = calls.ExecuteCallSequenceWithExecutionTracer(worker.chain, worker.fuzzer.contractDefinitions, shrunkenCallSequence, verboseTracing)
+						if err != nil {
+							return err
+						}
+					}
+
+					// Execute the supply test a final time to capture the values which broke the invariant.
+					shrunkenSequenceFailedTest, totalSupply, balanceSum, err := t.checkSupplyTestFailed(worker, tokenAddress, token)
+					if err != nil {
+						return err
+					}
+					if !shrunkenSequenceFailedTest {
+						return fmt.Errorf("ERC20 supply test provider did not fail supply test on final shrunken sequence")
+					}
+
+					// Update our test state and report it finalized.
+					testCase.status = TestCaseStatusFailed
+					testCase.callSequence = &shrunkenCallSequence
+					testCase.totalSupply = totalSupply
+					testCase.balanceSum = balanceSum
+					worker.workerMetrics().failedSequences.Add(worker.workerMetrics().failedSequences, big.NewInt(1))
+					worker.Fuzzer().ReportTestCaseFinished(testCase)
+					return nil
+				},
+				RecordResultInCorpus: true,
+			}
+
+			// Add our shrink request to our list.
+			shrinkRequests = append(shrinkRequests, shrinkRequest)
+		}
+	}
+
+	return shrinkRequests, nil
+}
@@ -0,0 +1,103 @@
+package fuzzing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/logging"
+	"github.com/crytic/medusa/logging/colors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// GasTestCase describes a test being run by a GasTestCaseProvider.
+type GasTestCase struct {
+	// status describes the status of the test case
+	status TestCaseStatus
+	// targetContract describes the target contract where the test case was found
+	targetContract *contracts.Contract
+	// targetMethod describes the target method for the test case
+	targetMethod abi.Method
+	// minimize describes whether the gas used by the target method is minimized rather than maximized
+	minimize bool
+	// callSequence describes the call sequence that resulted in the optimal gas used, ending in a call to the target
+	// method
+	callSequence *calls.CallSequence
+	// gasUsed is used to store the optimal gas used by a call to the target method, or nil if no call was made yet
+	gasUsed *uint64
+	// gasUsedLock is used for thread-synchronization when updating the gas used and its call sequence
+	gasUsedLock sync.Mutex
+}
+
+// Status describes the TestCaseStatus used to define the current state of the test.
+func (t *GasTestCase) Status() TestCaseStatus {
+	return t.status
+}
+
+// CallSequence describes the calls.CallSequence of calls sent to the EVM which resulted in this TestCase result.
+// This should be nil if the result is not related to the CallSequence.
+func (t *GasTestCase) CallSequence() *calls.CallSequence {
+	t.gasUsedLock.Lock()
+	defer t.gasUsedLock.Unlock()
+	return t.callSequence
+}
+
+// Name describes the name of the test case.
+func (t *GasTestCase) Name() string {
+	return fmt.Sprintf("Gas Test: %s.%s", t.targetContract.Name(), t.targetMethod.Sig)
+}
+
+// LogMessage obtains a buffer that represents the result of the GasTestCase. This buffer can be passed to a logger for
+// console or file logging.
+func (t *GasTestCase) LogMessage() *logging.LogBuffer {
+	buffer := logging.NewLogBuffer()
+
+	// Note that gas tests will always pass
+	buffer.Append(colors.GreenBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset, "\n")
+	t.gasUsedLock.Lock()
+	gasUsed, callSequence := t.gasUsed, t.callSequence
+	t.gasUsedLock.Unlock()
+	if gasUsed != nil && callSequence != nil {
+		objective := "maximum"
+		if t.minimize {
+			objective = "minimum"
+		}
+		buffer.Append(fmt.Sprintf("Calls to method \"%s.%s\" resulted in the %s gas used: ", t.targetContract.Name(), t.targetMethod.Sig, objective))
+		buffer.Append(colors.Bold, *gasUsed, colors.Reset, "\n")
+		buffer.Append(colors.Bold, "[Call Sequence]", colors.Reset, "\n")
+		buffer.Append(callSequence.Log().Elements()...)
+	}
+	return buffer
+}
+
+// Message obtains a text-based printable message which describes the result of the GasTestCase.
+func (t *GasTestCase) Message() string {
+	// Internally, we just call log message and convert it to a string. This can be useful for 3rd party apps
+	return t.LogMessage().String()
+}
+
+// ID obtains a unique identifier for a test result.
+func (t *GasTestCase) ID() string {
+	return strings.Replace(fmt.Sprintf("GAS-%s-%s", t.targetContract.Name(), t.targetMethod.Sig), "_", "-", -1)
+}
+
+// GasUsed obtains the optimal gas used by a call to the target method found till now, or nil if no call was made yet.
+func (t *GasTestCase) GasUsed() *uint64 {
+	t.gasUsedLock.Lock()
+	defer t.gasUsedLock.Unlock()
+	return t.gasUsed
+}
+
+// improvesGasUsed indicates whether the provided gas used by a call to the target method is strictly better than the
+// provided current optimum, which may be nil if no call was made yet.
+func (t *GasTestCase) improvesGasUsed(gasUsed uint64, currentGasUsed *uint64) bool {
+	if currentGasUsed == nil {
+		return true
+	}
+	if t.minimize {
+		return gasUsed < *currentGasUsed
+	}
+	return gasUsed > *currentGasUsed
+}
@@ -0,0 +1,213 @@
+package fuzzing
+
+import (
+	"sync"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/fuzzing/executiontracer"
+	"golang.org/x/exp/slices"
+)
+
+// GasTestCaseProvider is a provider for gas tests. Gas tests track the gas used by calls to target functions specified
+// by a config.FuzzingConfig, and report the call sequence which maximizes (or minimizes) the gas used by such a call.
+type GasTestCaseProvider struct {
+	// fuzzer describes the Fuzzer which this provider is attached to.
+	fuzzer *Fuzzer
+
+	// testCases is a map of contract-method IDs to gas test cases.
+	testCases map[contracts.ContractMethodID]*GasTestCase
+
+	// testCasesLock is used for thread-synchronization when updating testCases
+	testCasesLock sync.Mutex
+}
+
+// attachGasTestCaseProvider attaches a new GasTestCaseProvider to the Fuzzer and returns it.
+func attachGasTestCaseProvider(fuzzer *Fuzzer) *GasTestCaseProvider {
+	// If there are no target functions, then there is no reason to attach a test case provider and subscribe to events
+	if len(fuzzer.config.Fuzzing.Testing.GasTesting.TargetFunctionSignatures) == 0 {
+		return nil
+	}
+
+	// Create a test case provider
+	t := &GasTestCaseProvider{
+		fuzzer: fuzzer,
+	}
+
+	// Subscribe the provider to relevant events the fuzzer emits.
+	fuzzer.Events.FuzzerStarting.Subscribe(t.onFuzzerStarting)
+	fuzzer.Events.FuzzerStopping.Subscribe(t.onFuzzerStopping)
+	fuzzer.Events.WorkerCreated.Subscribe(t.onWorkerCreated)
+
+	// Add the provider's call sequence test function to the fuzzer.
+	fuzzer.Hooks.CallSequenceTestFuncs = append(fuzzer.Hooks.CallSequenceTestFuncs, t.callSequencePostCallTest)
+	return t
+}
+
+// lastCallGasUsed obtains the gas used by the last call in the provided call sequence, if it successfully called a
+// method we have a gas test case for.
+// Returns the test case for the called method and the gas used by the call, or a nil test case if the last call did not
+// target a tracked method or did not succeed. Returns an error if one occurs.
+func (t *GasTestCaseProvider) lastCallGasUsed(callSequence calls.CallSequence) (*GasTestCase, uint64, error) {
+	// If we have an empty call sequence, there is no call to measure
+	if len(callSequence) == 0 {
+		return nil, 0, nil
+	}
+
+	// Obtain the contract and method from the last call made in our sequence
+	lastCall := callSequence[len(callSequence)-1]
+	if lastCall.Contract == nil || lastCall.ChainReference == nil {
+		return nil, 0, nil
+	}
+	lastCallMethod, err := lastCall.Method()
+	if err != nil {
+		return nil, 0, err
+	}
+	methodId := contracts.GetContractMethodID(lastCall.Contract, lastCallMethod)
+
+	// Obtain the test case for this method, if we are tracking it.
+	t.testCasesLock.Lock()
+	testCase, testCaseExists := t.testCases[methodId]
+	t.testCasesLock.Unlock()
+	if !testCaseExists {
+		return nil, 0, nil
+	}
+
+	// Calls which failed do not execute the method in full, so we do not consider their gas usage.
+	lastMessageResults := lastCall.ChainReference.MessageResults()
+	if lastMessageResults.ExecutionResult.Failed() {
+		return nil, 0, nil
+	}
+	return testCase, lastMessageResults.Receipt.GasUsed, nil
+}
+
+// onFuzzerStarting is the event handler triggered when the Fuzzer is starting a fuzzing campaign. It creates test cases
+// in a "not started" state for every target function discovered in the contract definitions known to the Fuzzer.
+func (t *GasTestCaseProvider) onFuzzerStarting(event FuzzerStartingEvent) error {
+	// Reset our state
+	t.testCases = make(map[contracts.ContractMethodID]*GasTestCase)
+
+	// Create a test case for every target function.
+	gasTestingConfig := t.fuzzer.config.Fuzzing.Testing.GasTesting
+	for _, contract := range t.fuzzer.ContractDefinitions() {
+		// If we're not testing all contracts, verify the current contract is one we specified in our target contracts
+		if !t.fuzzer.config.Fuzzing.Testing.TestAllContracts && !slices.Contains(t.fuzzer.config.Fuzzing.TargetContracts, contract.Name()) {
+			continue
+		}
+
+		for _, method := range contract.CompiledContract().Abi.Methods {
+			// Verify the method is one of our target functions
+			if !slices.Contains(gasTestingConfig.TargetFunctionSignatures, contract.Name()+"."+method.Sig) {
+				continue
+			}
+
+			// Create local variables to avoid pointer types in the loop being overridden.
+			contract := contract
+			method := method
+
+			// Create our gas test case
+			gasTestCase := &GasTestCase{
+				status:         TestCaseStatusNotStarted,
+				targetContract: contract,
+				targetMethod:   method,
+				minimize:       gasTestingConfig.Minimize,
+				callSequence:   nil,
+				gasUsed:        nil,
+			}
+
+			// Add to our test cases and register them with the fuzzer
+			methodId := contracts.GetContractMethodID(contract, &method)
+			t.testCases[methodId] = gasTestCase
+			t.fuzzer.RegisterTestCase(gasTestCase)
+		}
+	}
+	return nil
+}
+
+// onFuzzerStopping is the event handler triggered when the Fuzzer is stopping the fuzzing campaign and all workers
+// have been destroyed. It sets test cases in "running" states to "passed".
+func (t *GasTestCaseProvider) onFuzzerStopping(event FuzzerStoppingEvent) error {
+	// Loop through each test case and set any tests with a running status to a passed status.
+	for _, testCase := range t.testCases {
+		if testCase.status == TestCaseStatusRunning {
+			testCase.status = TestCaseStatusPassed
+		}
+	}
+	return nil
+}
+
+// onWorkerCreated is the event handler triggered when a FuzzerWorker is created by the Fuzzer. It subscribes to
+// relevant worker events.
+func (t *GasTestCaseProvider) onWorkerCreated(event FuzzerWorkerCreatedEvent) error {
+	// Subscribe to relevant worker events.
+	event.Worker.Events.ContractAdded.Subscribe(t.onWorkerDeployedContractAdded)
+	return nil
+}
+
+// onWorkerDeployedContractAdded is the event handler triggered when a FuzzerWorker detects a new contract deployment
+// on its underlying chain. Any test cases previously made for methods of the deployed contract which are in a
+// "not started" state are put into a "running" state, as they are now potentially reachable for testing.
+func (t *GasTestCaseProvider) onWorkerDeployedContractAdded(event FuzzerWorkerContractAddedEvent) error {
+	// If we don't have a contract definition, we can't run tests against the contract.
+	if event.ContractDefinition == nil {
+		return nil
+	}
+
+	// Loop through all methods and find ones for which we have tests
+	for _, method := range event.ContractDefinition.CompiledContract().Abi.Methods {
+		// Obtain an identifier for this pair
+		methodId := contracts.GetContractMethodID(event.ContractDefinition, &method)
+
+		// If we have any tests in a not-started state, we can signal a running state now.
+		t.testCasesLock.Lock()
+		testCase, testCaseExists := t.testCases[methodId]
+		t.testCasesLock.Unlock()
+		if testCaseExists && testCase.Status() == TestCaseStatusNotStarted {
+			testCase.status = TestCaseStatusRunning
+		}
+	}
+	return nil
+}
+
+// callSequencePostCallTest provides is a CallSequenceTestFunc that performs post-call testing logic for the attached Fuzzer
+// and any underlying FuzzerWorker. It is called after every call made in a call sequence. It checks whether the last
+// call targeted a tracked method and improved upon the gas used by previous calls to it.
+func (t *GasTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+	// Create a list of shrink call sequence verifiers, which we populate if we want a call sequence shrunk.
+	shrinkRequests := make([]ShrinkCallSequenceRequest, 0)
+
+	// Obtain the test case and gas used for the last call, and check if it improved upon our current optimum.
+	testCase, newGasUsed, err := t.lastCallGasUsed(callSequence)
+	if err != nil || testCase == nil {
+		return shrinkRequests, err
+	}
+	if !testCase.improvesGasUsed(newGasUsed, testCase.GasUsed()) {
+		return shrinkRequests, nil
+	}
+
+	// If we improved upon the gas used, we provide a shrink verifier which will update the call sequence for each
+	// shrunken sequence provided that it still ends in a call to the method which uses at least as optimal an amount
+	// of gas.
+	shrinkRequest := newOptimizationShrinkRequest(newGasUsed,
+		func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence, trace bool) (uint64, *executiontracer.ExecutionTrace, bool, error) {
+			shrunkenTestCase, shrunkenGasUsed, err := t.lastCallGasUsed(shrunkenCallSequence)
+			return shrunkenGasUsed, nil, err == nil && shrunkenTestCase == testCase, err
+		},
+		func(gasUsed uint64, other uint64) bool {
+			return testCase.improvesGasUsed(gasUsed, &other)
+		},
+		func(shrunkenCallSequence calls.CallSequence, gasUsed uint64, executionTrace *executiontracer.ExecutionTrace) {
+			// Update our gas used and call sequence, unless another worker found a better one in the meantime.
+			testCase.gasUsedLock.Lock()
+			defer testCase.gasUsedLock.Unlock()
+			if testCase.improvesGasUsed(gasUsed, testCase.gasUsed) {
+				testCase.gasUsed = &gasUsed
+				testCase.callSequence = &shrunkenCallSequence
+			}
+		},
+	)
+
+	// Add our shrink request to our list.
+	shrinkRequests = append(shrinkRequests, shrinkRequest)
+	return shrinkRequests, nil
+}
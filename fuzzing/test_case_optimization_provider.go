@@ -293,60 +293,29 @@ func (t *OptimizationTestCaseProvider) callSequencePostCallTest(worker *FuzzerWo
 		//  testing API.
 		if newValue.Cmp(testCase.value) == 1 {
 			// Create a request to shrink this call sequence.
-			shrinkRequest := ShrinkCallSequenceRequest{
-				VerifierFunction: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence) (bool, error) {
-					// First verify the contract to the optimization test is still deployed to call upon.
+			shrinkRequest := newOptimizationShrinkRequest(newValue,
+				func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence, trace bool) (*big.Int, *executiontracer.ExecutionTrace, bool, error) {
+					// First verify the contract to the optimization test is still deployed to call upon. If it isn't,
+					// this shrunk sequence likely messed up deployment, so we report it as an invalid solution.
 					_, optimizationTestContractDeployed := worker.deployedContracts[workerOptimizationTestMethod.Address]
 					if !optimizationTestContractDeployed {
-						// If the contract isn't available, this shrunk sequence likely messed up deployment, so we
-						// report it as an invalid solution.
-						return false, nil
+						return nil, nil, false, nil
 					}
-
-					// Then the shrink verifier ensures that the maximum value has either stayed the same or, hopefully,
-					// increased.
-					shrunkenSequenceNewValue, _, err := t.runOptimizationTest(worker, &workerOptimizationTestMethod, false)
-
-					// If the shrunken value is greater than new value, then set new value to the shrunken one so that it
-					// can be tracked correctly in the finished callback
-					if err == nil && shrunkenSequenceNewValue.Cmp(newValue) == 1 {
-						newValue = new(big.Int).Set(shrunkenSequenceNewValue)
-					}
-
-					return shrunkenSequenceNewValue.Cmp(newValue) >= 0, err
+					value, executionTrace, err := t.runOptimizationTest(worker, &workerOptimizationTestMethod, trace)
+					return value, executionTrace, err == nil, err
 				},
-				FinishedCallback: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence, verboseTracing bool) error {
-					// When we're finished shrinking, attach an execution trace to the last call. If verboseTracing is true, attach to all calls.
-					if len(shrunkenCallSequence) > 0 {
-						_, err = calls.ExecuteCallSequenceWithExecutionTracer(worker.chain, worker.fuzzer.contractDefinitions, shrunkenCallSequence, verboseTracing)
-						if err != nil {
-							return err
-						}
-					}
-
-					// Execute the property test a final time, this time obtaining an execution trace
-					shrunkenSequenceNewValue, executionTrace, err := t.runOptimizationTest(worker, &workerOptimizationTestMethod, true)
-					if err != nil {
-						return err
-					}
-
-					// If, for some reason, the shrunken sequence lowers the new max value, do not save anything and exit
-					if shrunkenSequenceNewValue.Cmp(newValue) < 0 {
-						return fmt.Errorf("optimized call sequence failed to maximize value")
-					}
-
-					// Update our value with lock
+				func(value *big.Int, other *big.Int) bool {
+					return value.Cmp(other) == 1
+				},
+				func(shrunkenCallSequence calls.CallSequence, value *big.Int, executionTrace *executiontracer.ExecutionTrace) {
+					// Update our value, call sequence and trace with lock
 					testCase.valueLock.Lock()
-					testCase.value = new(big.Int).Set(shrunkenSequenceNewValue)
-					testCase.valueLock.Unlock()
-
-					// Update call sequence and trace
+					defer testCase.valueLock.Unlock()
+					testCase.value = new(big.Int).Set(value)
 					testCase.callSequence = &shrunkenCallSequence
 					testCase.optimizationTestTrace = executionTrace
-					return nil
 				},
-				RecordResultInCorpus: true,
-			}
+			)
 
 			// Add our shrink request to our list.
 			shrinkRequests = append(shrinkRequests, shrinkRequest)
@@ -355,3 +324,56 @@ func (t *OptimizationTestCaseProvider) callSequencePostCallTest(worker *FuzzerWo
 
 	return shrinkRequests, nil
 }
+
+// newOptimizationShrinkRequest creates a ShrinkCallSequenceRequest for a call sequence which improved upon the optimal
+// value tracked by a test case, reaching newValue. The measure function obtains the value for a call sequence once it
+// was executed by the provided worker, optionally with an execution trace, returning false if it cannot be measured.
+// The improves function indicates whether a value is strictly better than another. Shrunken call sequences are accepted
+// if they maintain or improve upon the value, and once shrinking finishes, the record function is called with the
+// shrunken call sequence, its value and its execution trace.
+// Returns the ShrinkCallSequenceRequest.
+func newOptimizationShrinkRequest[T any](
+	newValue T,
+	measure func(worker *FuzzerWorker, callSequence calls.CallSequence, trace bool) (T, *executiontracer.ExecutionTrace, bool, error),
+	improves func(value T, other T) bool,
+	record func(shrunkenCallSequence calls.CallSequence, value T, executionTrace *executiontracer.ExecutionTrace),
+) ShrinkCallSequenceRequest {
+	return ShrinkCallSequenceRequest{
+		VerifierFunction: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence) (bool, error) {
+			shrunkenValue, _, ok, err := measure(worker, shrunkenCallSequence, false)
+			if err != nil || !ok {
+				return false, err
+			}
+
+			// If the shrunken value improves upon the new value, then set new value to the shrunken one so that it can be
+			// tracked correctly in the finished callback.
+			if improves(shrunkenValue, newValue) {
+				newValue = shrunkenValue
+			}
+			return !improves(newValue, shrunkenValue), nil
+		},
+		FinishedCallback: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence, verboseTracing bool) error {
+			// When we're finished shrinking, attach an execution trace to the last call. If verboseTracing is true, attach to all calls.
+			if len(shrunkenCallSequence) > 0 {
+				_, err := calls.ExecuteCallSequenceWithExecutionTracer(worker.chain, worker.fuzzer.contractDefinitions, shrunkenCallSequence, verboseTracing)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Measure the value a final time, this time obtaining an execution trace.
+			shrunkenValue, executionTrace, ok, err := measure(worker, shrunkenCallSequence, true)
+			if err != nil {
+				return err
+			}
+
+			// If, for some reason, the shrunken sequence no longer reaches the new value, do not save anything and exit
+			if !ok || improves(newValue, shrunkenValue) {
+				return fmt.Errorf("optimized call sequence failed to maintain the optimized value")
+			}
+			record(shrunkenCallSequence, shrunkenValue, executionTrace)
+			return nil
+		},
+		RecordResultInCorpus: true,
+	}
+}
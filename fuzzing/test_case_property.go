@@ -49,6 +49,9 @@ func (t *PropertyTestCase) LogMessage() *logging.LogBuffer {
 	if t.Status() == TestCaseStatusFailed {
 		buffer.Append(colors.RedBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset, "\n")
 		buffer.Append(fmt.Sprintf("Test for method \"%s.%s\" failed after the following call sequence:\n", t.targetContract.Name(), t.targetMethod.Sig))
+		if cheatCodesUsed := t.CallSequence().CheatCodesUsed(); len(cheatCodesUsed) > 0 {
+			buffer.Append(colors.Bold, "[Cheat Codes]", colors.Reset, fmt.Sprintf(" used: %s\n", strings.Join(cheatCodesUsed, ", ")))
+		}
 		buffer.Append(colors.Bold, "[Call Sequence]", colors.Reset, "\n")
 		buffer.Append(t.CallSequence().Log().Elements()...)
 
@@ -60,6 +63,12 @@ func (t *PropertyTestCase) LogMessage() *logging.LogBuffer {
 		return buffer
 	}
 
+	// If the test was skipped, return a message distinguishing it from passed tests.
+	if t.Status() == TestCaseStatusSkipped {
+		buffer.Append(colors.YellowBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset)
+		return buffer
+	}
+
 	buffer.Append(colors.GreenBold, fmt.Sprintf("[%s] ", t.Status()), colors.Bold, t.Name(), colors.Reset)
 	return buffer
 }
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/crytic/medusa/chain"
 	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/fuzzing/contracts"
 	"github.com/crytic/medusa/fuzzing/executiontracer"
@@ -69,14 +70,15 @@ func attachPropertyTestCaseProvider(fuzzer *Fuzzer) *PropertyTestCaseProvider {
 // checkPropertyTestFailed executes a given property test method to see if it returns a failed status. This is used to
 // facilitate testing of property test methods after every call the Fuzzer makes when testing call sequences.
 // A boolean indicating whether an execution trace should be captured and returned is provided to the method.
-// Returns a boolean indicating if the property test failed, an optional execution trace for the property test call,
-// or an error if one occurred.
-func (t *PropertyTestCaseProvider) checkPropertyTestFailed(worker *FuzzerWorker, propertyTestMethod *contracts.DeployedContractMethod, trace bool) (bool, *executiontracer.ExecutionTrace, error) {
+// Returns a boolean indicating if the property test failed, a boolean indicating if the property test requested to be
+// skipped through the skip cheat code, an optional execution trace for the property test call, or an error if one
+// occurred.
+func (t *PropertyTestCaseProvider) checkPropertyTestFailed(worker *FuzzerWorker, propertyTestMethod *contracts.DeployedContractMethod, trace bool) (bool, bool, *executiontracer.ExecutionTrace, error) {
 	// Generate our ABI input data for the call. In this case, property test methods take no arguments, so the
 	// variadic argument list here is empty.
 	data, err := propertyTestMethod.Contract.CompiledContract().Abi.Pack(propertyTestMethod.Method.Name)
 	if err != nil {
-		return false, nil, err
+		return false, false, nil, err
 	}
 
 	// Create a call targeting our property test method
@@ -93,33 +95,36 @@ func (t *PropertyTestCaseProvider) checkPropertyTestFailed(worker *FuzzerWorker,
 		executionResult, err = worker.Chain().CallContract(msg.ToCoreMessage(), nil)
 	}
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to call property test method: %v", err)
+		return false, false, nil, fmt.Errorf("failed to call property test method: %v", err)
 	}
 
-	// If our property test method call failed, we flag a failed test.
+	// If our property test method call failed, we flag a failed test, unless it reverted through the skip cheat code.
 	if executionResult.Failed() {
-		return true, executionTrace, nil
+		if chain.IsSkipRevertData(executionResult.Revert()) {
+			return false, true, executionTrace, nil
+		}
+		return true, false, executionTrace, nil
 	}
 
 	// Decode our ABI outputs
 	retVals, err := propertyTestMethod.Method.Outputs.Unpack(executionResult.Return())
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to decode property test method return value: %v", err)
+		return false, false, nil, fmt.Errorf("failed to decode property test method return value: %v", err)
 	}
 
 	// We should have one return value.
 	if len(retVals) != 1 {
-		return false, nil, fmt.Errorf("detected an unexpected number of return values from property test '%s'", propertyTestMethod.Method.Name)
+		return false, false, nil, fmt.Errorf("detected an unexpected number of return values from property test '%s'", propertyTestMethod.Method.Name)
 	}
 
 	// The one return value should be a bool
 	propertyTestMethodPassed, ok := retVals[0].(bool)
 	if !ok {
-		return false, nil, fmt.Errorf("failed to parse property test method success status from return value '%s'", propertyTestMethod.Method.Name)
+		return false, false, nil, fmt.Errorf("failed to parse property test method success status from return value '%s'", propertyTestMethod.Method.Name)
 	}
 
 	// Return our property test results
-	return !propertyTestMethodPassed, executionTrace, nil
+	return !propertyTestMethodPassed, false, executionTrace, nil
 }
 
 // onFuzzerStarting is the event handler triggered when the Fuzzer is starting a fuzzing campaign. It creates test cases
@@ -279,18 +284,27 @@ func (t *PropertyTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorker
 		testCase := t.testCases[propertyTestMethodId]
 		t.testCasesLock.Unlock()
 
-		// If the test case already failed, skip it
-		if testCase.Status() == TestCaseStatusFailed {
+		// If the test case already failed or was skipped, skip it
+		if testCase.Status() == TestCaseStatusFailed || testCase.Status() == TestCaseStatusSkipped {
 			continue
 		}
 
 		// Test our property test method (create a local copy to avoid loop overwriting the method)
 		workerPropertyTestMethod := workerPropertyTestMethod
-		failedPropertyTest, _, err := t.checkPropertyTestFailed(worker, &workerPropertyTestMethod, false)
+		failedPropertyTest, skippedPropertyTest, _, err := t.checkPropertyTestFailed(worker, &workerPropertyTestMethod, false)
 		if err != nil {
 			return nil, err
 		}
 
+		// If the property test requested to be skipped, we mark it as skipped and report it finalized.
+		if skippedPropertyTest {
+			t.testCasesLock.Lock()
+			testCase.status = TestCaseStatusSkipped
+			t.testCasesLock.Unlock()
+			worker.Fuzzer().ReportTestCaseFinished(testCase)
+			continue
+		}
+
 		// If we failed a test, we update our state immediately. We provide a shrink verifier which will update
 		// the call sequence for each shrunken sequence provided that fails the property test.
 		if failedPropertyTest {
@@ -307,7 +321,7 @@ func (t *PropertyTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorker
 
 					// Then the shrink verifier simply ensures the previously failed property test fails
 					// for the shrunk sequence as well.
-					shrunkenSequenceFailedTest, _, err := t.checkPropertyTestFailed(worker, &workerPropertyTestMethod, false)
+					shrunkenSequenceFailedTest, _, _, err := t.checkPropertyTestFailed(worker, &workerPropertyTestMethod, false)
 					return shrunkenSequenceFailedTest, err
 				},
 				FinishedCallback: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence, verboseTracing bool) error {
@@ -320,7 +334,7 @@ func (t *PropertyTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorker
 					}
 
 					// Execute the property test a final time, this time obtaining an execution trace
-					shrunkenSequenceFailedTest, executionTrace, err := t.checkPropertyTestFailed(worker, &workerPropertyTestMethod, true)
+					shrunkenSequenceFailedTest, _, executionTrace, err := t.checkPropertyTestFailed(worker, &workerPropertyTestMethod, true)
 					if err != nil {
 						return err
 					}
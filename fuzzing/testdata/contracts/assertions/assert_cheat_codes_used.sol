@@ -0,0 +1,20 @@
+// This contract uses cheat codes prior to an assertion failure, so the failing test message reports them.
+interface CheatCodes {
+    function warp(uint256) external;
+    function prank(address) external;
+    function deal(address, uint256) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+    function useCheatCodesAndFail() public {
+        cheats.warp(1000);
+        cheats.prank(address(0x1234));
+        cheats.deal(address(this), 1 ether);
+
+        // ASSERTION: We always fail after using cheat codes.
+        assert(false);
+    }
+}
@@ -0,0 +1,18 @@
+// This contract ensures the fuzzer reports an assertion failure when a method reverts with a custom error which is
+// configured to be treated as a failing case, while other custom errors are still ignored.
+contract TestContract {
+    error InvariantBroken(uint256 value);
+    error Unauthorized();
+
+    function failCustomError(uint value) public {
+        // This should trigger, as the custom error is configured as a failing case.
+        if (value % 2 == 1) {
+            revert InvariantBroken(value);
+        }
+    }
+
+    function failOtherCustomError(uint value) public {
+        // This should not trigger, as the custom error is not configured as a failing case.
+        revert Unauthorized();
+    }
+}
@@ -0,0 +1,17 @@
+// This contract ensures call sequences can be shrunk by multiple workers in parallel. The assertion fails only once
+// step() was called three times, so the shrunk sequence should contain exactly those calls, followed by check().
+contract TestContract {
+    uint256 steps;
+
+    function step() public {
+        steps++;
+    }
+
+    function noop(uint256 value) public {
+    }
+
+    function check() public {
+        // ASSERTION: We fail once step() has been called three times.
+        assert(steps < 3);
+    }
+}
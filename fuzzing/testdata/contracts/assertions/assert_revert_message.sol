@@ -0,0 +1,13 @@
+// This contract ensures the fuzzer reports an assertion failure when a method reverts with a reason string which
+// matches the configured regular expression, while other reason strings are still ignored.
+contract TestContract {
+    function failRevertMessage(uint value) public {
+        // This should trigger, as the reason string matches the configured regular expression.
+        require(value % 2 == 0, "INVARIANT_VALUE_EVEN");
+    }
+
+    function failOtherRevertMessage(uint value) public {
+        // This should not trigger, as the reason string does not match the configured regular expression.
+        require(false, "NOT_AUTHORIZED");
+    }
+}
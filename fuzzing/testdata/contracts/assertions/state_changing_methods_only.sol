@@ -0,0 +1,20 @@
+// This contract ensures pure/view methods are never called by the fuzzer when testing view methods is disabled.
+contract TestContract {
+    uint256 x;
+
+    function setX(uint256 value) public {
+        x = value;
+    }
+
+    function incrementX() public {
+        x++;
+    }
+
+    function getX() public view returns (uint256) {
+        return x;
+    }
+
+    function addOne(uint256 value) public pure returns (uint256) {
+        return value + 1;
+    }
+}
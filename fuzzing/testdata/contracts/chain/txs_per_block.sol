@@ -0,0 +1,13 @@
+// This contract is used to test that multiple generated calls can be included in the same block.
+contract TestContract {
+    uint256 lastBlockNumber;
+    uint256 callsInBlock;
+
+    function recordCall() public {
+        if (block.number != lastBlockNumber) {
+            lastBlockNumber = block.number;
+            callsInBlock = 0;
+        }
+        callsInBlock++;
+    }
+}
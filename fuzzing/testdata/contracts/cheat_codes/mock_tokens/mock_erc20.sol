@@ -0,0 +1,59 @@
+// This test ensures that mock ERC20 tokens are pre-deployed and can be minted and transferred.
+interface CheatCodes {
+    function getMockERC20Tokens() external returns (address[] memory);
+}
+
+interface IMockERC20 {
+    function totalSupply() external view returns (uint256);
+    function balanceOf(address) external view returns (uint256);
+    function allowance(address, address) external view returns (uint256);
+    function transfer(address, uint256) external returns (bool);
+    function approve(address, uint256) external returns (bool);
+    function transferFrom(address, address, uint256) external returns (bool);
+    function mint(address, uint256) external;
+}
+
+// Spender is used to test transfers on behalf of another account with an allowance.
+contract Spender {
+    function spend(IMockERC20 token, address from, address to, uint256 amount) public {
+        token.transferFrom(from, to, amount);
+    }
+}
+
+contract TestContract {
+    Spender spender = new Spender();
+
+    function test(uint256 amount, address recipient) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Verify our tokens are deployed.
+        address[] memory tokens = cheats.getMockERC20Tokens();
+        assert(tokens.length == 2);
+        IMockERC20 token = IMockERC20(tokens[1]);
+
+        // Mint a fuzzed balance to ourselves.
+        amount = amount % 1e30;
+        uint256 supplyBefore = token.totalSupply();
+        token.mint(address(this), amount);
+        assert(token.totalSupply() == supplyBefore + amount);
+        assert(token.balanceOf(address(this)) == amount);
+
+        // Transfer half of it directly.
+        if (recipient == address(this) || recipient == address(spender)) {
+            recipient = address(0x1234);
+        }
+        uint256 recipientBefore = token.balanceOf(recipient);
+        uint256 half = amount / 2;
+        assert(token.transfer(recipient, half));
+        assert(token.balanceOf(address(this)) == amount - half);
+        assert(token.balanceOf(recipient) == recipientBefore + half);
+
+        // Transfer the rest using an allowance.
+        assert(token.approve(address(spender), amount - half));
+        spender.spend(token, address(this), recipient, amount - half);
+        assert(token.balanceOf(address(this)) == 0);
+        assert(token.balanceOf(recipient) == recipientBefore + amount);
+        assert(token.allowance(address(this), address(spender)) == 0);
+    }
+}
@@ -0,0 +1,45 @@
+// This test ensures that calls to mock ERC20 tokens made after an expectation cheat code is invoked are not counted as
+// the next call the expectation applies to.
+interface CheatCodes {
+    function getMockERC20Tokens() external returns (address[] memory);
+    function expectRevert() external;
+    function expectReturn(bytes calldata) external;
+}
+
+interface IMockERC20 {
+    function balanceOf(address) external view returns (uint256);
+    function mint(address, uint256) external;
+}
+
+contract Target {
+    function double(uint256 x) public pure returns (uint256) {
+        return x * 2;
+    }
+
+    function alwaysRevert() public pure {
+        revert("reverted");
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function test(uint256 x) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        IMockERC20 token = IMockERC20(cheats.getMockERC20Tokens()[0]);
+        x = x % 1000;
+
+        // The token calls succeed, so if they were counted as the next call, the expected revert would be unmet.
+        cheats.expectRevert();
+        token.mint(address(this), x);
+        token.balanceOf(address(this));
+        target.alwaysRevert();
+
+        // The token call returns different data, so if it was counted as the next call, the expected return would be
+        // unmet.
+        cheats.expectReturn(abi.encode(x * 2));
+        token.balanceOf(address(0x1234));
+        target.double(x);
+    }
+}
@@ -0,0 +1,59 @@
+// This test ensures that pranks apply to calls made to mock ERC20 tokens, and that a prank set for the next call is
+// consumed by a call to a token.
+interface CheatCodes {
+    function getMockERC20Tokens() external returns (address[] memory);
+    function prank(address) external;
+    function startPrank(address) external;
+    function stopPrank() external;
+}
+
+interface IMockERC20 {
+    function balanceOf(address) external view returns (uint256);
+    function allowance(address, address) external view returns (uint256);
+    function transfer(address, uint256) external returns (bool);
+    function approve(address, uint256) external returns (bool);
+    function mint(address, uint256) external;
+}
+
+// Recorder is used to test which sender the next call is made with.
+contract Recorder {
+    address public lastSender;
+
+    function record() public {
+        lastSender = msg.sender;
+    }
+}
+
+contract TestContract {
+    Recorder recorder = new Recorder();
+    address alice = address(0xa11ce);
+    address bob = address(0xb0b);
+
+    function test(uint256 amount) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        IMockERC20 token = IMockERC20(cheats.getMockERC20Tokens()[0]);
+
+        // Mint a fuzzed balance to alice, then transfer it to bob as alice.
+        amount = amount % 1e30;
+        token.mint(alice, amount);
+        uint256 aliceBefore = token.balanceOf(alice);
+        uint256 bobBefore = token.balanceOf(bob);
+        cheats.prank(alice);
+        assert(token.transfer(bob, amount));
+        assert(token.balanceOf(alice) == aliceBefore - amount);
+        assert(token.balanceOf(bob) == bobBefore + amount);
+
+        // The prank was consumed by the token call, so the next call is made with our own address.
+        recorder.record();
+        assert(recorder.lastSender() == address(this));
+
+        // A started prank applies to every token call until it is stopped.
+        cheats.startPrank(bob);
+        assert(token.approve(address(this), amount));
+        assert(token.approve(alice, amount));
+        cheats.stopPrank();
+        assert(token.allowance(bob, address(this)) == amount);
+        assert(token.allowance(bob, alice) == amount);
+    }
+}
@@ -0,0 +1,57 @@
+// This test ensures that addresses can be converted to and parsed from EIP-55 checksummed strings with cheat codes
+interface CheatCodes {
+    function toChecksumString(address) external returns (string memory);
+    function parseAddress(string calldata, bool) external returns (address);
+}
+
+contract TestContract {
+    CheatCodes cheats;
+
+    constructor() {
+        cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    }
+
+    function testToChecksumString() public {
+        address test = 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed;
+        string memory expectedString = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed";
+
+        // Call cheats.toChecksumString
+        string memory result = cheats.toChecksumString(test);
+        assert(keccak256(abi.encodePacked(result)) == keccak256(abi.encodePacked(expectedString)));
+    }
+
+    function testRoundTrip(address test) public {
+        // Any checksummed string we produce should parse with a checksum required.
+        assert(cheats.parseAddress(cheats.toChecksumString(test), true) == test);
+    }
+
+    function testValidChecksum() public {
+        address expectedAddress = 0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359;
+
+        // A correctly checksummed string should parse with a checksum required.
+        assert(cheats.parseAddress("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", true) == expectedAddress);
+
+        // An incorrectly checksummed string should still parse when no checksum is required.
+        assert(cheats.parseAddress("0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", false) == expectedAddress);
+    }
+
+    function testInvalidChecksum() public {
+        // A string with its casing altered should revert when a checksum is required.
+        try cheats.parseAddress("0xFB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", true) returns (address) {
+            assert(false);
+        } catch {
+        }
+
+        // A string without a checksum should revert when a checksum is required.
+        try cheats.parseAddress("0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", true) returns (address) {
+            assert(false);
+        } catch {
+        }
+
+        // A string which is not a full length address should revert when a checksum is required.
+        try cheats.parseAddress("0x10000", true) returns (address) {
+            assert(false);
+        } catch {
+        }
+    }
+}
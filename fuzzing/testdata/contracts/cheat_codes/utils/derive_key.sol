@@ -0,0 +1,37 @@
+// This test ensures that keys derived from a mnemonic match those derived by wallets, and that they can be used with
+// the addr, sign and rememberKey cheat codes.
+interface CheatCodes {
+    function deriveKey(string calldata, uint32) external returns (uint256);
+    function rememberKey(uint256) external returns (address);
+    function addr(uint256) external returns (address);
+    function sign(uint256, bytes32) external returns (uint8, bytes32, bytes32);
+    function sign(address, bytes32) external returns (uint8, bytes32, bytes32);
+}
+
+contract TestContract {
+    string constant MNEMONIC = "test test test test test test test test test test test junk";
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The derived keys should match the well-known keys for this mnemonic.
+        uint256 key0 = cheats.deriveKey(MNEMONIC, 0);
+        uint256 key1 = cheats.deriveKey(MNEMONIC, 1);
+        assert(key0 == 0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80);
+        assert(key1 == 0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d);
+        assert(cheats.addr(key0) == 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266);
+        assert(cheats.addr(key1) == 0x70997970C51812dc3A010C7d01b50e0d17dc79C8);
+
+        // ASSERTION: Signatures made with a derived key should recover to its address.
+        bytes32 digest = keccak256("Data To Sign");
+        (uint8 v, bytes32 r, bytes32 s) = cheats.sign(key0, digest);
+        assert(ecrecover(digest, v, r, s) == 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266);
+
+        // ASSERTION: A remembered key should return its address, and be usable to sign by that address.
+        address signer = cheats.rememberKey(key1);
+        assert(signer == 0x70997970C51812dc3A010C7d01b50e0d17dc79C8);
+        (v, r, s) = cheats.sign(signer, digest);
+        assert(ecrecover(digest, v, r, s) == signer);
+    }
+}
@@ -0,0 +1,50 @@
+// This test ensures that environment variables can be read and parsed, and that defaults are used for unset variables.
+interface CheatCodes {
+    function envUint(string calldata) external returns (uint256);
+    function envAddress(string calldata) external returns (address);
+    function envString(string calldata) external returns (string memory);
+    function envBytes(string calldata) external returns (bytes memory);
+    function envOr(string calldata, uint256) external returns (uint256);
+    function envOr(string calldata, address) external returns (address);
+    function envOr(string calldata, string calldata) external returns (string memory);
+    function envOr(string calldata, bytes calldata) external returns (bytes memory);
+}
+
+contract TestContract {
+    CheatCodes cheats;
+
+    constructor() {
+        cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    }
+
+    function testEnv() public {
+        // ASSERTION: Set variables should be parsed into their requested types.
+        assert(cheats.envUint("MEDUSA_TEST_ENV_UINT") == 1337);
+        assert(cheats.envAddress("MEDUSA_TEST_ENV_ADDRESS") == 0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        assert(keccak256(bytes(cheats.envString("MEDUSA_TEST_ENV_STRING"))) == keccak256("medusa"));
+        assert(keccak256(cheats.envBytes("MEDUSA_TEST_ENV_BYTES")) == keccak256(hex"deadbeef"));
+
+        // ASSERTION: Set variables should take precedence over defaults.
+        assert(cheats.envOr("MEDUSA_TEST_ENV_UINT", uint256(7)) == 1337);
+
+        // ASSERTION: Unset variables should fall back to defaults.
+        assert(cheats.envOr("MEDUSA_TEST_ENV_UNSET", uint256(7)) == 7);
+        assert(cheats.envOr("MEDUSA_TEST_ENV_UNSET", address(0x1234)) == address(0x1234));
+        string memory defaultString = "default";
+        bytes memory defaultBytes = hex"cafe";
+        assert(keccak256(bytes(cheats.envOr("MEDUSA_TEST_ENV_UNSET", defaultString))) == keccak256(bytes(defaultString)));
+        assert(keccak256(cheats.envOr("MEDUSA_TEST_ENV_UNSET", defaultBytes)) == keccak256(defaultBytes));
+
+        // ASSERTION: Reading an unset variable without a default should revert.
+        try cheats.envUint("MEDUSA_TEST_ENV_UNSET") returns (uint256) {
+            assert(false);
+        } catch {
+        }
+
+        // ASSERTION: Reading a variable which cannot be parsed should revert.
+        try cheats.envUint("MEDUSA_TEST_ENV_STRING") returns (uint256) {
+            assert(false);
+        } catch {
+        }
+    }
+}
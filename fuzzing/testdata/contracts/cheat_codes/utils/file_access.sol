@@ -0,0 +1,40 @@
+// This test ensures that files within the file access root can be written and read, and that paths outside it are
+// rejected.
+interface CheatCodes {
+    function readFile(string calldata) external returns (string memory);
+    function writeFile(string calldata, string calldata) external;
+    function readLine(string calldata) external returns (string memory);
+}
+
+contract TestContract {
+    CheatCodes cheats;
+
+    constructor() {
+        cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    }
+
+    function testFileAccess() public {
+        // Write a fixture, which also resets any lines previously read from it.
+        cheats.writeFile("fixture.txt", "first\nsecond\n");
+
+        // ASSERTION: The whole file should be read back.
+        assert(keccak256(bytes(cheats.readFile("fixture.txt"))) == keccak256("first\nsecond\n"));
+
+        // ASSERTION: Lines should be read in order, with an empty string returned once all lines are read.
+        assert(keccak256(bytes(cheats.readLine("fixture.txt"))) == keccak256("first"));
+        assert(keccak256(bytes(cheats.readLine("fixture.txt"))) == keccak256("second"));
+        assert(bytes(cheats.readLine("fixture.txt")).length == 0);
+
+        // ASSERTION: Reading a file which does not exist should revert.
+        try cheats.readFile("missing.txt") returns (string memory) {
+            assert(false);
+        } catch {
+        }
+
+        // ASSERTION: Accessing a path outside of the file access root should revert.
+        try cheats.writeFile("../outside.txt", "data") {
+            assert(false);
+        } catch {
+        }
+    }
+}
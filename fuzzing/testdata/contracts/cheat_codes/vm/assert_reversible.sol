@@ -0,0 +1,58 @@
+// This test ensures that reversible calls pass assertReversible, and that their effects are discarded afterwards.
+interface CheatCodes {
+    function assertReversible(bytes4) external;
+    function store(address, bytes32, bytes32) external;
+}
+
+contract Vault {
+    uint256 public balance;
+    mapping(address => uint256) public deposits;
+
+    function deposit(uint256 amount) public {
+        balance += amount;
+        deposits[msg.sender] += amount;
+    }
+
+    function reset() public {
+        // Reverted calls are reversible, as the EVM already discards their changes.
+        balance = 0;
+        revert();
+    }
+}
+
+contract TestContract {
+    Vault vault = new Vault();
+    uint256 x;
+
+    function test(uint256 amount) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Deposit some amount which should persist.
+        vault.deposit(1);
+        uint256 balance = vault.balance();
+        uint256 deposited = vault.deposits(address(this));
+
+        // ASSERTION: Storage written by the call is restored once it exits.
+        cheats.assertReversible(Vault.deposit.selector);
+        vault.deposit(amount);
+        assert(vault.balance() == balance);
+        assert(vault.deposits(address(this)) == deposited);
+
+        // ASSERTION: Storage written by cheat codes during the call is also restored.
+        cheats.assertReversible(this.storeValue.selector);
+        this.storeValue(amount);
+        assert(x == 0);
+
+        // ASSERTION: A call which reverts leaves no effects behind.
+        cheats.assertReversible(Vault.reset.selector);
+        (bool success, ) = address(vault).call(abi.encodeWithSelector(Vault.reset.selector));
+        assert(!success);
+        assert(vault.balance() == balance);
+    }
+
+    function storeValue(uint256 value) public {
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        cheats.store(address(this), bytes32(uint256(1)), bytes32(value | 1));
+    }
+}
@@ -0,0 +1,39 @@
+// This test ensures that calls which invalidate the snapshot taken by assertReversible, by reverting to an earlier
+// snapshot or restoring an earlier named state, cause an assertion failure rather than a crash.
+interface CheatCodes {
+    function assertReversible(bytes4) external;
+    function snapshot() external returns (uint256);
+    function revertTo(uint256) external returns (bool);
+    function saveState(string calldata) external;
+    function restoreState(string calldata) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    uint256 x;
+
+    function revertToSnapshot(uint256 snapshot) public {
+        x = 1;
+        cheats.revertTo(snapshot);
+    }
+
+    function restoreNamedState() public {
+        x = 1;
+        cheats.restoreState("earlier");
+    }
+
+    function assertReversibleButRevertsToEarlierSnapshot() public {
+        // ASSERTION: The call reverts to a snapshot taken before the call, so this should fail.
+        uint256 snapshot = cheats.snapshot();
+        cheats.assertReversible(this.revertToSnapshot.selector);
+        this.revertToSnapshot(snapshot);
+    }
+
+    function assertReversibleButRestoresEarlierState() public {
+        // ASSERTION: The call restores a state saved before the call, so this should fail.
+        cheats.saveState("earlier");
+        cheats.assertReversible(this.restoreNamedState.selector);
+        this.restoreNamedState();
+    }
+}
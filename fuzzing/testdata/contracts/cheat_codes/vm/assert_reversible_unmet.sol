@@ -0,0 +1,39 @@
+// This test ensures that calls which leave residual state after reverting, or which do not invoke the asserted selector,
+// cause an assertion failure.
+interface CheatCodes {
+    function assertReversible(bytes4) external;
+    function warp(uint256) external;
+}
+
+contract TestContract {
+    uint256 x;
+
+    function setValueAndWarp(uint256 value) public {
+        // Warping is not undone by reverting to a snapshot, so it leaves residual state behind.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        x = value;
+        cheats.warp(block.timestamp + 1);
+    }
+
+    function setValue(uint256 value) public {
+        x = value;
+    }
+
+    function assertReversibleButResidual() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The block timestamp remains changed after reverting, so this should fail.
+        cheats.assertReversible(this.setValueAndWarp.selector);
+        this.setValueAndWarp(1);
+    }
+
+    function assertReversibleButOtherSelector() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The next call does not invoke the asserted selector, so this should fail.
+        cheats.assertReversible(this.setValueAndWarp.selector);
+        this.setValue(1);
+    }
+}
@@ -0,0 +1,42 @@
+// This test ensures that the assertion cheat codes do not fail when their comparisons hold.
+interface CheatCodes {
+    function assertEq(uint256, uint256) external;
+    function assertEq(int256, int256) external;
+    function assertEq(address, address) external;
+    function assertEq(bool, bool) external;
+    function assertEq(bytes32, bytes32) external;
+    function assertGt(uint256, uint256) external;
+    function assertGt(int256, int256) external;
+    function assertLt(uint256, uint256) external;
+    function assertLt(int256, int256) external;
+    function assertGe(uint256, uint256) external;
+    function assertGe(int256, int256) external;
+    function assertLe(uint256, uint256) external;
+    function assertLe(int256, int256) external;
+    function assertTrue(bool) external;
+}
+
+contract TestContract {
+    function test(uint128 x, int128 y) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Test equality of each supported type.
+        cheats.assertEq(uint256(x), uint256(x));
+        cheats.assertEq(int256(y), int256(y));
+        cheats.assertEq(address(this), address(this));
+        cheats.assertEq(x % 2 == 0, x % 2 == 0);
+        cheats.assertEq(keccak256(abi.encode(x)), keccak256(abi.encode(x)));
+
+        // Test the ordering of integers.
+        cheats.assertGt(uint256(x) + 1, uint256(x));
+        cheats.assertGt(int256(y) + 1, int256(y));
+        cheats.assertLt(uint256(x), uint256(x) + 1);
+        cheats.assertLt(int256(y) - 1, int256(y));
+        cheats.assertGe(uint256(x), uint256(x));
+        cheats.assertGe(int256(y), int256(y) - 1);
+        cheats.assertLe(uint256(x), uint256(x));
+        cheats.assertLe(int256(y) - 1, int256(y));
+        cheats.assertTrue(x == x);
+    }
+}
@@ -0,0 +1,39 @@
+// This test ensures that the assertion cheat codes cause an assertion failure when their comparisons do not hold, and
+// describe the compared values when they do.
+interface CheatCodes {
+    function assertEq(uint256, uint256) external;
+    function assertEq(address, address) external;
+    function assertEq(bytes32, bytes32) external;
+    function assertGt(int256, int256) external;
+    function assertTrue(bool) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+    function assertEqButNotEqual() public {
+        // ASSERTION: The values are not equal, so this should fail.
+        cheats.assertEq(3, 4);
+    }
+
+    function assertEqAddressButNotEqual() public {
+        // ASSERTION: The addresses are not equal, so this should fail.
+        cheats.assertEq(address(0x1234), address(0x5678));
+    }
+
+    function assertEqBytes32ButNotEqual() public {
+        // ASSERTION: The values are not equal, so this should fail.
+        cheats.assertEq(bytes32(uint256(1)), bytes32(uint256(2)));
+    }
+
+    function assertGtButNotGreater() public {
+        // ASSERTION: The first value is not greater than the second, so this should fail.
+        cheats.assertGt(-2, 5);
+    }
+
+    function assertTrueButFalse() public {
+        // ASSERTION: The condition is false, so this should fail, even though the revert is caught.
+        try cheats.assertTrue(false) {} catch {}
+    }
+}
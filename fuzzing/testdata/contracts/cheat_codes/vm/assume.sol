@@ -0,0 +1,17 @@
+// This test ensures that calls whose assumptions fail are discarded rather than tested.
+interface CheatCodes {
+    function assume(bool) external;
+}
+
+contract TestContract {
+    function testAssume(uint256 x) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Discard any inputs which are not even.
+        cheats.assume(x % 2 == 0);
+
+        // ASSERTION: Only even inputs should reach this point.
+        assert(x % 2 == 0);
+    }
+}
@@ -0,0 +1,29 @@
+// This test ensures that calls whose assumptions fail, but which catch the resulting revert, remain in the call sequence
+// so the state changes they made are reproduced when the sequence is replayed.
+interface CheatCodes {
+    function assume(bool) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+    bool flagged;
+
+    function requireEven(uint256 x) external {
+        cheats.assume(x % 2 == 0);
+    }
+
+    function flagOdd(uint256 x) public {
+        // The state change only persists when the assumption failed, so this call is always discarded when it does.
+        try this.requireEven(x) {
+        } catch {
+            flagged = true;
+        }
+    }
+
+    function checkNotFlagged() public {
+        // ASSERTION: Once a discarded call flagged the contract, this should fail.
+        assert(!flagged);
+    }
+}
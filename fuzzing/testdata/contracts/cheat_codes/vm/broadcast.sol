@@ -0,0 +1,52 @@
+// This test ensures that the broadcast cheat codes used by scripts can be called, and set the sender if provided.
+interface CheatCodes {
+    function broadcast() external;
+    function broadcast(address) external;
+    function startBroadcast() external;
+    function startBroadcast(address) external;
+    function stopBroadcast() external;
+}
+
+contract Target {
+    function senderAndOrigin() public view returns (address, address) {
+        return (msg.sender, tx.origin);
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        address broadcaster = address(7);
+        address sender;
+        address origin;
+
+        // Broadcasting without an address does nothing.
+        cheats.broadcast();
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this));
+        cheats.startBroadcast();
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this));
+        cheats.stopBroadcast();
+
+        // Broadcasting with an address sets the sender and origin of the next call.
+        cheats.broadcast(broadcaster);
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == broadcaster && origin == broadcaster);
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this) && origin == tx.origin);
+
+        // Starting a broadcast with an address sets them for every call until it is stopped.
+        cheats.startBroadcast(broadcaster);
+        for (uint256 i = 0; i < 2; i++) {
+            (sender, origin) = target.senderAndOrigin();
+            assert(sender == broadcaster && origin == broadcaster);
+        }
+        cheats.stopBroadcast();
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this) && origin == tx.origin);
+    }
+}
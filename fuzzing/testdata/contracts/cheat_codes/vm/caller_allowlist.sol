@@ -0,0 +1,38 @@
+// This test ensures that callers in the caller allowlist can invoke cheat codes, while cheat code invocations from
+// callers which are not in it revert.
+interface CheatCodes {
+    function warp(uint256) external;
+    function toString(address) external returns (string memory);
+}
+
+contract Untrusted {
+    function warp(CheatCodes cheats, uint256 timestamp) external {
+        cheats.warp(timestamp);
+    }
+}
+
+contract TestContract {
+    Untrusted untrusted = new Untrusted();
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // This contract is in the caller allowlist, so calling a cheat code should succeed.
+        uint256 originalTimestamp = block.timestamp;
+        cheats.warp(originalTimestamp + 1);
+        assert(block.timestamp == originalTimestamp + 1);
+
+        // The contract it deployed is not in the caller allowlist, so calling a cheat code from it should revert with
+        // a message indicating so.
+        string memory expectedReason = string.concat(
+            "cheat code caller ", cheats.toString(address(untrusted)), " is not in the caller allowlist"
+        );
+        try untrusted.warp(cheats, originalTimestamp + 2) {
+            assert(false);
+        } catch Error(string memory reason) {
+            assert(keccak256(bytes(reason)) == keccak256(bytes(expectedReason)));
+        }
+        assert(block.timestamp == originalTimestamp + 1);
+    }
+}
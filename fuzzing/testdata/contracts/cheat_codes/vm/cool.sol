@@ -0,0 +1,45 @@
+// This test ensures that accounts and their storage slots are charged as cold accesses after being cooled.
+interface CheatCodes {
+    function cool(address) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    uint x = 123;
+
+    function loadCost() internal returns (uint cost) {
+        uint gasBefore = gasleft();
+        assembly {
+            pop(sload(x.slot))
+        }
+        cost = gasBefore - gasleft();
+    }
+
+    function balanceCost(address account) internal returns (uint cost) {
+        uint gasBefore = gasleft();
+        assembly {
+            pop(balance(account))
+        }
+        cost = gasBefore - gasleft();
+    }
+
+    function test() public {
+        // Warm our storage slot and an account, and measure the cost of accessing them while warm.
+        address account = address(0x1234);
+        loadCost();
+        balanceCost(account);
+        uint warmLoadCost = loadCost();
+        uint warmBalanceCost = balanceCost(account);
+
+        // Cool our own account, and verify our storage slot is charged as cold once, then warm again.
+        cheats.cool(address(this));
+        assert(loadCost() == warmLoadCost + 2000);
+        assert(loadCost() == warmLoadCost);
+
+        // Cool the other account, and verify it is charged as cold once, then warm again.
+        cheats.cool(account);
+        assert(balanceCost(account) == warmBalanceCost + 2500);
+        assert(balanceCost(account) == warmBalanceCost);
+    }
+}
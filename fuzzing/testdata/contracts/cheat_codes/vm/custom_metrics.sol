@@ -0,0 +1,20 @@
+// This test ensures that the counters and gauges recorded by cheat codes are aggregated across calls by the fuzzer.
+interface CheatCodes {
+    function incrementCounter(string calldata) external;
+    function setGauge(string calldata, uint256) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+    function takePath(uint256 value) public {
+        // Record which path was taken, along with the last value provided.
+        if (value % 2 == 0) {
+            cheats.incrementCounter("pathA");
+        } else {
+            cheats.incrementCounter("pathB");
+        }
+        cheats.setGauge("lastValue", value);
+    }
+}
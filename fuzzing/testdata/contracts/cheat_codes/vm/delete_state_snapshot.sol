@@ -0,0 +1,56 @@
+// This test ensures that snapshots can be reverted to repeatedly, that snapshots can be reverted to and deleted, or
+// deleted outright, and that many snapshots can be taken and released within a single transaction.
+interface CheatCodes {
+    function snapshot() external returns (uint256);
+    function revertTo(uint256) external returns (bool);
+    function revertToStateAndDelete(uint256) external returns (bool);
+    function deleteStateSnapshot(uint256) external returns (bool);
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    uint x = 1;
+
+    function test() public {
+        // Take a snapshot, and verify we can revert to it more than once.
+        uint256 snapshot = cheats.snapshot();
+        x = 2;
+        assert(cheats.revertTo(snapshot));
+        assert(x == 1);
+        x = 3;
+        assert(cheats.revertTo(snapshot));
+        assert(x == 1);
+
+        // Revert to the snapshot and delete it, verify it can no longer be reverted to or deleted.
+        x = 4;
+        assert(cheats.revertToStateAndDelete(snapshot));
+        assert(x == 1);
+        assert(!cheats.revertTo(snapshot));
+        assert(!cheats.deleteStateSnapshot(snapshot));
+
+        // Take a snapshot and delete it, verify it can no longer be reverted to.
+        snapshot = cheats.snapshot();
+        x = 5;
+        assert(cheats.deleteStateSnapshot(snapshot));
+        assert(!cheats.revertToStateAndDelete(snapshot));
+        assert(x == 5);
+
+        // Reverting to a snapshot releases snapshots taken after it.
+        snapshot = cheats.snapshot();
+        uint256 laterSnapshot = cheats.snapshot();
+        assert(cheats.revertTo(snapshot));
+        assert(!cheats.revertTo(laterSnapshot));
+
+        // Take and release many snapshots.
+        for (uint i = 0; i < 2000; i++) {
+            assert(cheats.revertToStateAndDelete(cheats.snapshot()));
+        }
+    }
+
+    function testSnapshotsFromPreviousTransactions(uint256 snapshot) public {
+        // Snapshots do not persist across transactions.
+        assert(!cheats.revertTo(snapshot));
+        assert(!cheats.deleteStateSnapshot(snapshot));
+    }
+}
@@ -0,0 +1,24 @@
+// This test ensures that cheat codes disabled in the chain configuration are unavailable, while others still work.
+interface CheatCodes {
+    function etch(address, bytes calldata) external;
+    function warp(uint256) external;
+}
+
+contract TestContract {
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Calling the disabled cheat code should revert with a message indicating it is disabled.
+        address acc = address(777);
+        try cheats.etch(acc, address(this).code) {
+            assert(false);
+        } catch Error(string memory reason) {
+            assert(keccak256(bytes(reason)) == keccak256("etch is disabled in the chain configuration"));
+        }
+        assert(acc.code.length == 0);
+
+        // Calling a cheat code which was not disabled should still succeed.
+        cheats.warp(block.timestamp + 1);
+    }
+}
@@ -0,0 +1,80 @@
+// This test ensures that expected logs can be set with cheat codes, and are matched against the next call's logs.
+interface CheatCodes {
+    function expectEmit() external;
+    function expectEmit(address) external;
+    function expectEmit(bool, bool, bool, bool) external;
+    function expectEmit(bool, bool, bool, bool, address) external;
+}
+
+contract Token {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+    event Checkpoint(uint256 indexed id, bytes32 indexed tag) anonymous;
+
+    function transfer(address to, uint256 amount) public {
+        emit Transfer(msg.sender, to, amount);
+    }
+
+    function transferAndApprove(address to, uint256 amount) public {
+        emit Transfer(msg.sender, to, amount);
+        emit Approval(msg.sender, to, amount);
+    }
+
+    function checkpoint(uint256 id, bytes32 tag) public {
+        emit Checkpoint(id, tag);
+    }
+}
+
+contract Router {
+    Token token;
+
+    constructor(Token _token) {
+        token = _token;
+    }
+
+    function forwardTransfer(address to, uint256 amount) public {
+        // Logs emitted by deeper calls count towards the call made to this contract.
+        token.transfer(to, amount);
+    }
+}
+
+contract TestContract {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+    event Checkpoint(uint256 indexed id, bytes32 indexed tag) anonymous;
+
+    Token token = new Token();
+    Router router = new Router(token);
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Expect an exact log.
+        cheats.expectEmit();
+        emit Transfer(address(this), address(0x1234), 100);
+        token.transfer(address(0x1234), 100);
+
+        // Expect a log from a specific emitter, ignoring the second topic and data.
+        cheats.expectEmit(true, false, false, false, address(token));
+        emit Transfer(address(this), address(0x9999), 1);
+        token.transfer(address(0x1234), 100);
+
+        // Multiple expected logs should be matched in order.
+        cheats.expectEmit(true, true, false, true);
+        emit Transfer(address(this), address(0x1234), 5);
+        cheats.expectEmit(address(token));
+        emit Approval(address(this), address(0x1234), 5);
+        token.transferAndApprove(address(0x1234), 5);
+
+        // Logs emitted by nested calls should be matched.
+        cheats.expectEmit();
+        emit Transfer(address(router), address(0x1234), 7);
+        router.forwardTransfer(address(0x1234), 7);
+
+        // Anonymous events should be matched by their indexed arguments.
+        cheats.expectEmit(true, true, false, true);
+        emit Checkpoint(1, bytes32("tag"));
+        token.checkpoint(1, bytes32("tag"));
+    }
+}
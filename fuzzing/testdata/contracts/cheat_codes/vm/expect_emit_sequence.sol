@@ -0,0 +1,32 @@
+// This test ensures that a call emitting exactly the expected sequence of logs satisfies expectEmitSequence.
+interface CheatCodes {
+    function expectEmitSequence() external;
+}
+
+contract Token {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+
+    function approveAndTransfer(address to, uint256 amount) public {
+        emit Approval(msg.sender, to, amount);
+        emit Transfer(msg.sender, to, amount);
+    }
+}
+
+contract TestContract {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+
+    Token token = new Token();
+
+    function testExpectEmitSequence(address to, uint256 amount) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call emits exactly the expected logs in the same order, so this should not fail.
+        cheats.expectEmitSequence();
+        emit Approval(address(this), to, amount);
+        emit Transfer(address(this), to, amount);
+        token.approveAndTransfer(to, amount);
+    }
+}
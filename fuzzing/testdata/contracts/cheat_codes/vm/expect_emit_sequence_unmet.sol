@@ -0,0 +1,49 @@
+// This test ensures that a call which does not emit exactly the expected sequence of logs causes an assertion failure.
+interface CheatCodes {
+    function expectEmitSequence() external;
+}
+
+contract Token {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+
+    function transferAndApprove(address to, uint256 amount) public {
+        emit Transfer(msg.sender, to, amount);
+        emit Approval(msg.sender, to, amount);
+    }
+
+    function approveAndTransferTwice(address to, uint256 amount) public {
+        emit Approval(msg.sender, to, amount);
+        emit Transfer(msg.sender, to, amount);
+        emit Transfer(msg.sender, to, amount);
+    }
+}
+
+contract TestContract {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+
+    Token token = new Token();
+
+    function expectEmitSequenceButOutOfOrder() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call emits the expected logs in a different order, so this should fail.
+        cheats.expectEmitSequence();
+        emit Approval(address(this), address(0x1234), 1);
+        emit Transfer(address(this), address(0x1234), 1);
+        token.transferAndApprove(address(0x1234), 1);
+    }
+
+    function expectEmitSequenceButExtraLog() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call emits an additional log after the expected ones, so this should fail.
+        cheats.expectEmitSequence();
+        emit Approval(address(this), address(0x1234), 1);
+        emit Transfer(address(this), address(0x1234), 1);
+        token.approveAndTransferTwice(address(0x1234), 1);
+    }
+}
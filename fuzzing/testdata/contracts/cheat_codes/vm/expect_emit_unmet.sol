@@ -0,0 +1,47 @@
+// This test ensures that an expected log which is not emitted causes an assertion failure.
+interface CheatCodes {
+    function expectEmit() external;
+}
+
+contract Token {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+
+    function transfer(address to, uint256 amount) public {
+        emit Transfer(msg.sender, to, amount);
+    }
+
+    function approve(address spender, uint256 amount) public {
+        emit Approval(msg.sender, spender, amount);
+    }
+}
+
+contract TestContract {
+    event Transfer(address indexed from, address indexed to, uint256 amount);
+    event Approval(address indexed owner, address indexed spender, uint256 amount);
+
+    Token token = new Token();
+
+    function expectEmitButMismatch() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call emits a log with a different amount, so the expectation is unmet and this should fail.
+        cheats.expectEmit();
+        emit Transfer(address(this), address(0x1234), 100);
+        token.transfer(address(0x1234), 99);
+    }
+
+    function expectEmitButOutOfOrder() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The logs are emitted across different calls, so the second expected log is unmet.
+        cheats.expectEmit();
+        emit Transfer(address(this), address(0x1234), 1);
+        cheats.expectEmit();
+        emit Approval(address(this), address(0x1234), 1);
+        token.transfer(address(0x1234), 1);
+        token.approve(address(0x1234), 1);
+    }
+}
@@ -0,0 +1,37 @@
+// This test ensures that an expected nonce increase can be set with cheat codes, and that deployments which increase
+// the nonce of the account pass.
+interface CheatCodes {
+    function expectNonceIncrease(address) external;
+    function prank(address) external;
+}
+
+contract Child {
+}
+
+contract Target {
+    function deploy() public returns (address) {
+        return address(new Child());
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // The target deploys a contract, increasing its nonce, so the expectation is met.
+        cheats.expectNonceIncrease(address(target));
+        target.deploy();
+
+        // Deploying a contract from this account increases our own nonce, so the expectation is met.
+        cheats.expectNonceIncrease(address(this));
+        new Child();
+
+        // Calls to the cheat code contract are not counted as the next call.
+        cheats.expectNonceIncrease(address(target));
+        cheats.prank(address(0x1234));
+        target.deploy();
+    }
+}
@@ -0,0 +1,39 @@
+// This test ensures that an expected nonce increase which does not occur causes an assertion failure.
+interface CheatCodes {
+    function expectNonceIncrease(address) external;
+}
+
+contract Child {
+}
+
+contract Target {
+    function noop() public {
+    }
+
+    function deployAndRevert() public {
+        new Child();
+        revert("reverted");
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function expectNonceIncreaseButNoop() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call does not deploy anything, so the expectation is unmet and this should fail.
+        cheats.expectNonceIncrease(address(target));
+        target.noop();
+    }
+
+    function expectNonceIncreaseButRevert() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The deployment is rolled back by the revert, so the expectation is unmet and this should fail.
+        cheats.expectNonceIncrease(address(target));
+        try target.deployAndRevert() {} catch {}
+    }
+}
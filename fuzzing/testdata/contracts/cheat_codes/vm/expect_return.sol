@@ -0,0 +1,39 @@
+// This test ensures that expected return data can be set with cheat codes, and that matching return data passes.
+interface CheatCodes {
+    function expectReturn(bytes calldata) external;
+    function prank(address) external;
+}
+
+contract Target {
+    function double(uint256 x) public pure returns (uint256) {
+        return x * 2;
+    }
+
+    function greet() public pure returns (string memory) {
+        return "hello";
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function test(uint256 x) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        x = x % 1000;
+
+        // The return data matches its ABI encoding, so the expectation is met.
+        cheats.expectReturn(abi.encode(x * 2));
+        uint256 result = target.double(x);
+        assert(result == x * 2);
+
+        // Dynamic return data is compared in its encoded form.
+        cheats.expectReturn(abi.encode("hello"));
+        target.greet();
+
+        // Calls to the cheat code contract are not counted as the next call.
+        cheats.expectReturn(abi.encode(x * 2));
+        cheats.prank(address(0x1234));
+        target.double(x);
+    }
+}
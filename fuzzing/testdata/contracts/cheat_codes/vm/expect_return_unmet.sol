@@ -0,0 +1,36 @@
+// This test ensures that an expected return which does not occur causes an assertion failure.
+interface CheatCodes {
+    function expectReturn(bytes calldata) external;
+}
+
+contract Target {
+    function double(uint256 x) public pure returns (uint256) {
+        return x * 2;
+    }
+
+    function alwaysRevert() public pure returns (uint256) {
+        revert("reverted");
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function expectReturnButMismatch() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call returns different data, so the expectation is unmet and this should fail.
+        cheats.expectReturn(abi.encode(uint256(3)));
+        target.double(1);
+    }
+
+    function expectReturnButRevert() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call reverts, so the expectation is unmet and this should fail.
+        cheats.expectReturn(abi.encode(uint256(0)));
+        try target.alwaysRevert() returns (uint256) {} catch {}
+    }
+}
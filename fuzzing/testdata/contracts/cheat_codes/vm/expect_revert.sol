@@ -0,0 +1,97 @@
+// This test ensures that expected reverts can be set with cheat codes, and that matching reverts are suppressed.
+interface CheatCodes {
+    function expectRevert() external;
+    function expectRevert(bytes4) external;
+    function expectRevert(bytes calldata) external;
+    function prank(address) external;
+}
+
+contract Target {
+    error Unauthorized(address caller);
+
+    uint256 public value;
+    address public owner = address(0x1234);
+
+    function setValue(uint256 newValue) public {
+        value = newValue;
+    }
+
+    function revertWithReason() public {
+        value = 1;
+        require(false, "reason");
+    }
+
+    function revertWithCustomError() public {
+        value = 1;
+        revert Unauthorized(msg.sender);
+    }
+
+    function onlyOwner() public {
+        if (msg.sender != owner) {
+            revert Unauthorized(msg.sender);
+        }
+    }
+}
+
+contract Intermediary {
+    Target target;
+
+    constructor(Target _target) {
+        target = _target;
+    }
+
+    function forwardRevert() public {
+        // Reverts from a deeper call bubble up through this call.
+        target.revertWithReason();
+    }
+
+    function expectRevertInternally() public {
+        // Expected reverts are tracked per call frame, so this should not interfere with the caller's expectation.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        cheats.expectRevert(bytes("reason"));
+        target.revertWithReason();
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+    Intermediary intermediary = new Intermediary(target);
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Any revert should be accepted, and the reverted state changes should not persist.
+        cheats.expectRevert();
+        target.revertWithReason();
+        assert(target.value() == 0);
+
+        // A revert reason string should be matched.
+        cheats.expectRevert(bytes("reason"));
+        target.revertWithReason();
+
+        // A custom error selector should be matched.
+        cheats.expectRevert(Target.Unauthorized.selector);
+        target.revertWithCustomError();
+
+        // Full custom error data should be matched.
+        cheats.expectRevert(abi.encodeWithSelector(Target.Unauthorized.selector, address(this)));
+        target.revertWithCustomError();
+
+        // Calls to other cheat codes should not count as the expected call.
+        cheats.expectRevert(Target.Unauthorized.selector);
+        cheats.prank(address(0x5678));
+        target.onlyOwner();
+
+        // Reverts which bubble up from deeper calls should be matched.
+        cheats.expectRevert(bytes("reason"));
+        intermediary.forwardRevert();
+
+        // Expected reverts in nested call frames should be handled independently.
+        intermediary.expectRevertInternally();
+
+        // The expectation should only apply to a single call.
+        target.setValue(7);
+        assert(target.value() == 7);
+    }
+}
@@ -0,0 +1,36 @@
+// This test ensures that an expected revert which does not occur causes an assertion failure.
+interface CheatCodes {
+    function expectRevert(bytes calldata) external;
+}
+
+contract Target {
+    function revertWithReason(bool shouldRevert) public pure {
+        require(!shouldRevert, "reason");
+    }
+
+    function revertWithOtherReason() public pure {
+        revert("other reason");
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+
+    function expectRevertButSucceed() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call does not revert, so the expectation is unmet and this should fail.
+        cheats.expectRevert(bytes("reason"));
+        target.revertWithReason(false);
+    }
+
+    function expectRevertButMismatch() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The call reverts with a different reason, so the expectation is unmet and this should fail.
+        cheats.expectRevert(bytes("reason"));
+        target.revertWithOtherReason();
+    }
+}
@@ -0,0 +1,28 @@
+// This test ensures that the number of committed blocks can be obtained with cheat codes
+interface CheatCodes {
+    function getBlockCount() external returns (uint256);
+}
+
+contract TestContract {
+    uint256 lastBlockNumber;
+    uint256 lastBlockCount;
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // The genesis block and deployment blocks should always be committed.
+        uint256 blockCount = cheats.getBlockCount();
+        assert(blockCount > 0);
+
+        // The block count should never decrease, and should increase when the sequence advances to a new block.
+        assert(blockCount >= lastBlockCount);
+        if (lastBlockNumber != 0 && block.number > lastBlockNumber) {
+            assert(blockCount > lastBlockCount);
+        }
+
+        // Record the current block state for the next call.
+        lastBlockNumber = block.number;
+        lastBlockCount = blockCount;
+    }
+}
@@ -0,0 +1,21 @@
+// This test ensures that the current block number can be obtained with cheat codes
+interface CheatCodes {
+    function roll(uint256) external;
+    function getBlockNumber() external returns (uint256);
+}
+
+contract TestContract {
+    function test(uint32 delta) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // The block number should match the current block.
+        assert(cheats.getBlockNumber() == block.number);
+
+        // Roll relative to the current block number and verify the change is reflected.
+        uint256 blockNumber = cheats.getBlockNumber() + delta;
+        cheats.roll(blockNumber);
+        assert(cheats.getBlockNumber() == blockNumber);
+        assert(block.number == blockNumber);
+    }
+}
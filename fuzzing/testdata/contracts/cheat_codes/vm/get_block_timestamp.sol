@@ -0,0 +1,21 @@
+// This test ensures that the current block timestamp can be obtained with cheat codes
+interface CheatCodes {
+    function warp(uint256) external;
+    function getBlockTimestamp() external returns (uint256);
+}
+
+contract TestContract {
+    function test(uint32 delta) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // The block timestamp should match the current block.
+        assert(cheats.getBlockTimestamp() == block.timestamp);
+
+        // Warp relative to the current block timestamp and verify the change is reflected.
+        uint256 blockTimestamp = cheats.getBlockTimestamp() + delta;
+        cheats.warp(blockTimestamp);
+        assert(cheats.getBlockTimestamp() == blockTimestamp);
+        assert(block.timestamp == blockTimestamp);
+    }
+}
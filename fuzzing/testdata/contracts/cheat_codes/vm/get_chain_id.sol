@@ -0,0 +1,23 @@
+// This test ensures that the chainId can be obtained with cheat codes, reflecting prior chainId cheat codes
+interface CheatCodes {
+    function chainId(uint256) external;
+    function getChainId() external returns (uint256);
+}
+
+contract TestContract {
+    function test(uint256 x) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // The chain id obtained should match the one seen by the EVM.
+        assert(cheats.getChainId() == block.chainid);
+
+        // Change value and verify.
+        cheats.chainId(777123);
+        assert(cheats.getChainId() == 777123);
+        assert(cheats.getChainId() == block.chainid);
+        cheats.chainId(x);
+        assert(cheats.getChainId() == x);
+        assert(cheats.getChainId() == block.chainid);
+    }
+}
@@ -0,0 +1,20 @@
+// This test ensures that the addresses of contracts deployed during setup can be queried by name.
+interface CheatCodes {
+    function getDeployedAddress(string calldata) external returns (address);
+}
+
+contract TestContract {
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: This contract was deployed as a target contract, so its address should be returned.
+        assert(cheats.getDeployedAddress("TestContract") == address(this));
+
+        // ASSERTION: Querying a contract which was not deployed should revert.
+        try cheats.getDeployedAddress("MissingContract") returns (address) {
+            assert(false);
+        } catch {
+        }
+    }
+}
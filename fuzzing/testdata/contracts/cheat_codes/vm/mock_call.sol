@@ -0,0 +1,71 @@
+// This test ensures that calls can be mocked with cheat codes, returning mocked data instead of executing the target.
+interface CheatCodes {
+    function mockCall(address, bytes calldata, bytes calldata) external;
+    function mockCall(address, uint256, bytes calldata, bytes calldata) external;
+    function clearMockedCalls() external;
+}
+
+contract Oracle {
+    function price() public pure returns (uint256) {
+        return 1;
+    }
+
+    function priceOf(address asset) public pure returns (uint256) {
+        return uint160(asset);
+    }
+
+    function pay() public payable returns (uint256) {
+        return msg.value;
+    }
+}
+
+interface IMissing {
+    function value() external returns (uint256);
+}
+
+contract TestContract {
+    Oracle oracle = new Oracle();
+
+    function test(address asset) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // ASSERTION: The mocked data should be returned instead of executing the oracle.
+        cheats.mockCall(address(oracle), abi.encodeWithSelector(Oracle.price.selector), abi.encode(uint256(42)));
+        assert(oracle.price() == 42);
+
+        // ASSERTION: Mocking only the selector should match calls with any arguments, while more specific calldata
+        // should take precedence.
+        cheats.mockCall(address(oracle), abi.encodeWithSelector(Oracle.priceOf.selector), abi.encode(uint256(7)));
+        cheats.mockCall(address(oracle), abi.encodeWithSelector(Oracle.priceOf.selector, address(0)), abi.encode(uint256(8)));
+        assert(oracle.priceOf(asset) == (asset == address(0) ? 8 : 7));
+
+        // ASSERTION: Mocks with a value should only match calls with that value.
+        cheats.mockCall(address(oracle), 5, abi.encodeWithSelector(Oracle.pay.selector), abi.encode(uint256(100)));
+        assert(oracle.pay{value: 0}() == 0);
+
+        // ASSERTION: Calls to addresses without code can be mocked.
+        IMissing missing = IMissing(address(0x1234));
+        cheats.mockCall(address(missing), abi.encodeWithSelector(IMissing.value.selector), abi.encode(uint256(3)));
+        assert(missing.value() == 3);
+
+        // ASSERTION: Clearing mocked calls should restore the original behaviour.
+        cheats.clearMockedCalls();
+        assert(oracle.price() == 1);
+    }
+
+    function testRevertedMock() public {
+        // ASSERTION: Mocks made in a call which reverts should be reverted too.
+        try this.mockAndRevert() {
+            assert(false);
+        } catch {
+            assert(oracle.price() == 1);
+        }
+    }
+
+    function mockAndRevert() public {
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        cheats.mockCall(address(oracle), abi.encodeWithSelector(Oracle.price.selector), abi.encode(uint256(42)));
+        revert();
+    }
+}
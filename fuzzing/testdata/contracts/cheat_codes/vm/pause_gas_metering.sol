@@ -0,0 +1,55 @@
+// This test ensures that gas is not charged while gas metering is paused, and is charged again once it is resumed.
+interface CheatCodes {
+    function pauseGasMetering() external;
+    function resumeGasMetering() external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    uint[] values;
+
+    function expensive() public {
+        for (uint i = 0; i < 10; i++) {
+            values.push(i);
+        }
+    }
+
+    function pauseAndRevert() public {
+        cheats.pauseGasMetering();
+        revert();
+    }
+
+    function resumeAndSpend() public {
+        cheats.resumeGasMetering();
+        expensive();
+    }
+
+    function test() public {
+        // Pause gas metering, and verify no gas is charged for storage writes or calls.
+        cheats.pauseGasMetering();
+        uint gasBefore = gasleft();
+        expensive();
+        this.expensive();
+        assert(gasleft() == gasBefore);
+
+        // Resume gas metering, and verify gas is charged again, without regaining any which was not charged.
+        cheats.resumeGasMetering();
+        uint gasAfterResume = gasleft();
+        assert(gasAfterResume <= gasBefore);
+        expensive();
+        assert(gasAfterResume - gasleft() > 10 * 20000);
+
+        // Pause gas metering in a call which reverts, and verify the pause is reverted with it.
+        try this.pauseAndRevert() {} catch {}
+        uint gasAfterRevertedPause = gasleft();
+        expensive();
+        assert(gasAfterRevertedPause - gasleft() > 10 * 20000);
+
+        // Pause gas metering, then resume it in a call, and verify we are charged for the gas it used once resumed.
+        cheats.pauseGasMetering();
+        uint gasBeforeCall = gasleft();
+        this.resumeAndSpend();
+        assert(gasBeforeCall - gasleft() > 10 * 20000);
+    }
+}
@@ -0,0 +1,90 @@
+// This test ensures that pranks only apply to delegatecalls when the delegateCall flag is not false, and that pranked
+// delegatecalls execute in the context of the pranked address when the flag is true.
+interface CheatCodes {
+    function prank(address) external;
+    function prank(address, bool) external;
+    function startPrank(address, bool) external;
+    function stopPrank() external;
+    function load(address, bytes32) external returns (bytes32);
+}
+
+contract Implementation {
+    uint256 value;
+
+    function sender() public view returns (address) {
+        return msg.sender;
+    }
+
+    function self() public view returns (address) {
+        return address(this);
+    }
+
+    function setValue(uint256 newValue) public {
+        value = newValue;
+    }
+}
+
+contract TestContract {
+    Implementation implementation = new Implementation();
+
+    function delegate(bytes memory data) internal returns (bytes memory) {
+        (bool success, bytes memory returnData) = address(implementation).delegatecall(data);
+        assert(success);
+        return returnData;
+    }
+
+    function delegateSender() internal returns (address) {
+        return abi.decode(delegate(abi.encodeCall(Implementation.sender, ())), (address));
+    }
+
+    function delegateSelf() internal returns (address) {
+        return abi.decode(delegate(abi.encodeCall(Implementation.self, ())), (address));
+    }
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        address prankSender = address(7);
+
+        // Without the delegateCall flag, the next delegatecall is pranked, but executes in our context.
+        cheats.prank(prankSender);
+        assert(delegateSender() == prankSender);
+        cheats.prank(prankSender);
+        assert(delegateSelf() == address(this));
+
+        // With the delegateCall flag set, the next delegatecall is pranked, and executes in the context of the sender.
+        cheats.prank(prankSender, true);
+        assert(delegateSender() == prankSender);
+        assert(delegateSender() == msg.sender);
+        cheats.prank(prankSender, true);
+        assert(delegateSelf() == prankSender);
+        assert(delegateSelf() == address(this));
+
+        // Storage written by a pranked delegatecall is written to the sender's account rather than ours (where the
+        // same slot holds our implementation address).
+        cheats.prank(prankSender, true);
+        delegate(abi.encodeCall(Implementation.setValue, (42)));
+        assert(uint256(cheats.load(prankSender, bytes32(0))) == 42);
+        assert(uint256(cheats.load(address(this), bytes32(0))) == uint256(uint160(address(implementation))));
+
+        // Without it, delegatecalls are skipped and the next regular call is pranked instead.
+        cheats.prank(prankSender, false);
+        assert(delegateSender() == msg.sender);
+        assert(implementation.sender() == prankSender);
+        assert(implementation.sender() == address(this));
+
+        // The same applies to pranks started with startPrank.
+        cheats.startPrank(prankSender, true);
+        assert(delegateSender() == prankSender);
+        assert(delegateSelf() == prankSender);
+        assert(implementation.sender() == prankSender);
+        assert(implementation.self() == address(implementation));
+        cheats.stopPrank();
+        cheats.startPrank(prankSender, false);
+        assert(delegateSender() == msg.sender);
+        assert(implementation.sender() == prankSender);
+        cheats.stopPrank();
+        assert(delegateSender() == msg.sender);
+        assert(delegateSelf() == address(this));
+    }
+}
@@ -0,0 +1,83 @@
+// This test ensures that the tx.origin can be set alongside the msg.sender with cheat codes.
+// It tests prank (spoof msg.sender and tx.origin on next call), and startPrank (spoof them on every call until stopped)
+interface CheatCodes {
+    function prank(address, address) external;
+    function startPrank(address) external;
+    function startPrank(address, address) external;
+    function stopPrank() external;
+}
+
+contract Target {
+    Target nested;
+
+    function setNested(Target _nested) public {
+        nested = _nested;
+    }
+
+    function senderAndOrigin() public view returns (address, address) {
+        return (msg.sender, tx.origin);
+    }
+
+    function nestedOrigin() public view returns (address) {
+        return nested.origin();
+    }
+
+    function origin() public view returns (address) {
+        return tx.origin;
+    }
+}
+
+contract TestContract {
+    Target target = new Target();
+    Target nestedTarget = new Target();
+
+    constructor() {
+        target.setNested(nestedTarget);
+    }
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        address prankSender = address(7);
+        address prankOrigin = address(8);
+        address originalOrigin = tx.origin;
+        address sender;
+        address origin;
+
+        // Prank only the next call, and verify the origin is also observed by calls it makes.
+        cheats.prank(prankSender, prankOrigin);
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == prankSender);
+        assert(origin == prankOrigin);
+        assert(tx.origin == originalOrigin);
+        cheats.prank(prankSender, prankOrigin);
+        assert(target.nestedOrigin() == prankOrigin);
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this));
+        assert(origin == originalOrigin);
+
+        // Start a prank which only patches the sender, and verify it applies to every call until stopped.
+        cheats.startPrank(prankSender);
+        for (uint256 i = 0; i < 2; i++) {
+            (sender, origin) = target.senderAndOrigin();
+            assert(sender == prankSender);
+            assert(origin == originalOrigin);
+        }
+        cheats.stopPrank();
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this));
+
+        // Start a prank which patches both, and verify it applies to every call until stopped.
+        cheats.startPrank(prankSender, prankOrigin);
+        for (uint256 i = 0; i < 2; i++) {
+            (sender, origin) = target.senderAndOrigin();
+            assert(sender == prankSender);
+            assert(origin == prankOrigin);
+        }
+        assert(tx.origin == originalOrigin);
+        cheats.stopPrank();
+        (sender, origin) = target.senderAndOrigin();
+        assert(sender == address(this));
+        assert(origin == originalOrigin);
+    }
+}
@@ -0,0 +1,91 @@
+// This test ensures that we can save named states of the testchain and restore an earlier one using the saveState and
+// restoreState cheatcodes, that a state can be restored more than once, and that restoring a state which was
+// invalidated reverts rather than failing the transaction.
+pragma solidity ^0.8.0;
+
+interface CheatCodes {
+    function deal(address, uint256) external;
+
+    function snapshot() external returns (uint256);
+
+    function revertTo(uint256) external returns (bool);
+
+    function saveState(string calldata) external;
+
+    function restoreState(string calldata) external;
+}
+
+contract TestContract {
+    uint256 value;
+
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+    function test() public {
+        // Save our first state
+        value = 10;
+        cheats.deal(address(this), 5 ether);
+        cheats.saveState("first");
+
+        // Change state and save our second state
+        value = 20;
+        cheats.deal(address(this), 50 ether);
+        cheats.saveState("second");
+
+        // Change state again
+        value = 30;
+        cheats.deal(address(this), 500 ether);
+        assert(value == 30);
+        assert(address(this).balance == 500 ether);
+
+        // Restore the earlier state and ensure the changes from both later states have been reset
+        cheats.restoreState("first");
+        assert(value == 10);
+        assert(address(this).balance == 5 ether);
+
+        // Restore the same state again, after changing it
+        value = 40;
+        cheats.restoreState("first");
+        assert(value == 10);
+
+        // The later state was invalidated by restoring the earlier one, so it can no longer be restored.
+        try cheats.restoreState("second") {
+            assert(false);
+        } catch Error(string memory reason) {
+            assert(keccak256(bytes(reason)) == keccak256(bytes("restoreState: the state saved with the name \"second\" was invalidated by an earlier revert")));
+        }
+
+        // States which were never saved cannot be restored.
+        try cheats.restoreState("unknown") {
+            assert(false);
+        } catch Error(string memory reason) {
+            assert(keccak256(bytes(reason)) == keccak256(bytes("restoreState: no state was saved with the name \"unknown\"")));
+        }
+    }
+
+    function testRestoreAfterRevertTo() public {
+        // Save a named state after taking a snapshot, then revert to the snapshot.
+        value = 1;
+        uint256 snapshot = cheats.snapshot();
+        value = 2;
+        cheats.saveState("later");
+        assert(cheats.revertTo(snapshot));
+        assert(value == 1);
+
+        // The named state was taken after the snapshot, so reverting to it invalidated the named state.
+        try cheats.restoreState("later") {
+            assert(false);
+        } catch Error(string memory reason) {
+            assert(keccak256(bytes(reason)) == keccak256(bytes("restoreState: the state saved with the name \"later\" was invalidated by an earlier revert")));
+        }
+
+        // A named state saved before the snapshot can still be restored after reverting to it.
+        cheats.saveState("earlier");
+        snapshot = cheats.snapshot();
+        value = 3;
+        assert(cheats.revertTo(snapshot));
+        value = 4;
+        cheats.restoreState("earlier");
+        assert(value == 1);
+    }
+}
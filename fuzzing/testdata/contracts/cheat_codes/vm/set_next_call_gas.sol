@@ -0,0 +1,45 @@
+// This test ensures that the gas provided to the next call can be set with cheat codes.
+interface CheatCodes {
+    function setNextCallGas(uint256) external;
+}
+
+contract CallbackReceiver {
+    uint256 public gasAtEntry;
+    uint256[8] values;
+
+    function onCallback() public {
+        // Record the gas we were provided, then perform storage writes which require far more than a small stipend.
+        gasAtEntry = gasleft();
+        for (uint256 i = 0; i < values.length; i++) {
+            values[i] = i + 1;
+        }
+    }
+}
+
+contract TestContract {
+    CallbackReceiver receiver = new CallbackReceiver();
+
+    function test() public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+
+        // Provide the callback too little gas to complete, and verify it fails.
+        cheats.setNextCallGas(30000);
+        try receiver.onCallback() {
+            assert(false);
+        } catch {
+        }
+
+        // Provide the callback an exact amount of gas which is sufficient, and verify it succeeds with that gas.
+        uint256 callGas = 500000;
+        cheats.setNextCallGas(callGas);
+        receiver.onCallback();
+        uint256 gasAtEntry = receiver.gasAtEntry();
+        assert(gasAtEntry <= callGas);
+        assert(gasAtEntry > callGas - 1000);
+
+        // The cheat code should only apply to a single call, so the next call should receive the usual gas.
+        receiver.onCallback();
+        assert(receiver.gasAtEntry() > callGas);
+    }
+}
@@ -0,0 +1,56 @@
+// This test ensures that the storage slots changed while recording a state diff are returned by cheat codes
+interface CheatCodes {
+    struct StorageDiff {
+        address account;
+        bytes32 slot;
+        bytes32 previousValue;
+        bytes32 newValue;
+    }
+
+    function startStateDiffRecording() external;
+    function stopAndReturnStateDiff() external returns (StorageDiff[] memory);
+    function store(address, bytes32, bytes32) external;
+}
+
+contract Reverter {
+    uint z;
+
+    function writeAndRevert() public {
+        z = 1;
+        revert();
+    }
+}
+
+contract TestContract {
+    uint x = 123;
+    uint y = 0;
+    Reverter reverter = new Reverter();
+
+    function test(uint value) public {
+        // Obtain our cheat code contract reference.
+        CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+        uint originalX = x;
+        value = originalX + (value % 100) + 1;
+
+        // Record a change to x, a reverted write, and a cheat code storage write to y.
+        cheats.startStateDiffRecording();
+        x = value;
+        try reverter.writeAndRevert() {} catch {}
+        cheats.store(address(this), bytes32(uint(1)), bytes32(uint(456)));
+        CheatCodes.StorageDiff[] memory diffs = cheats.stopAndReturnStateDiff();
+
+        // Verify only the changes to x and y were returned, in the order they were first written.
+        assert(diffs.length == 2);
+        assert(diffs[0].account == address(this));
+        assert(diffs[0].slot == bytes32(uint(0)));
+        assert(diffs[0].previousValue == bytes32(originalX));
+        assert(diffs[0].newValue == bytes32(value));
+        assert(diffs[1].account == address(this));
+        assert(diffs[1].slot == bytes32(uint(1)));
+        assert(diffs[1].previousValue == bytes32(uint(0)));
+        assert(diffs[1].newValue == bytes32(uint(456)));
+
+        // Restore y so re-running this method does not change its previous value.
+        y = 0;
+    }
+}
@@ -0,0 +1,18 @@
+// This test provides a contract which changes its own storage with cheat codes, which should be undone once the fuzzer
+// reverts to the base block between call sequences.
+interface CheatCodes {
+    function store(address, bytes32, bytes32) external;
+    function warp(uint256) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    uint x;
+
+    function setX(uint value) public {
+        cheats.store(address(this), bytes32(uint(1)), bytes32(value));
+        cheats.warp(block.timestamp + value % 1000);
+        assert(x == value);
+    }
+}
@@ -0,0 +1,42 @@
+// This test ensures that transient storage can be get and set with cheat codes, that changes are reverted with the
+// caller, and that transient storage is cleared between transactions.
+interface CheatCodes {
+    function loadTransient(address, bytes32) external returns (bytes32);
+    function storeTransient(address, bytes32, bytes32) external;
+}
+
+contract TestContract {
+    // Obtain our cheat code contract reference.
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    uint x = 123;
+
+    function test(uint value) public {
+        // Store into a transient slot, load it, verify it.
+        cheats.storeTransient(address(this), bytes32(uint(1)), bytes32(value));
+        assert(cheats.loadTransient(address(this), bytes32(uint(1))) == bytes32(value));
+
+        // Other transient slots should not be affected.
+        assert(cheats.loadTransient(address(this), bytes32(uint(2))) == bytes32(0));
+
+        // The persistent storage slot sharing its key (x) should not be affected.
+        assert(x == 123);
+
+        // Store into the slot within a call which reverts, verify the change was reverted.
+        try this.storeAndRevert(bytes32(uint(1)), ~bytes32(value)) {
+            assert(false);
+        } catch {
+        }
+        assert(cheats.loadTransient(address(this), bytes32(uint(1))) == bytes32(value));
+    }
+
+    function storeAndRevert(bytes32 slot, bytes32 value) public {
+        require(msg.sender == address(this));
+        cheats.storeTransient(address(this), slot, value);
+        revert();
+    }
+
+    function testClearedBetweenTransactions() public {
+        // Transient storage set by a previous transaction should have been cleared.
+        assert(cheats.loadTransient(address(this), bytes32(uint(1))) == bytes32(0));
+    }
+}
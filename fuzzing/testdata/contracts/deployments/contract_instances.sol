@@ -0,0 +1,8 @@
+// This contract is deployed several times, to verify each instance is deployed at a distinct address and called.
+contract TestContract {
+    uint256 touches;
+
+    function touch() public {
+        touches++;
+    }
+}
@@ -0,0 +1,24 @@
+// This test ensures that when dynamic deployers are configured, only the dynamically deployed contracts they deployed
+// are tested.
+contract InnerDeployment {
+    function property_inner_deployment() public view returns (bool) {
+        // PROPERTY: Fail immediately (this should only fail for the instance deployed by AllowedFactory).
+        return false;
+    }
+}
+
+contract AllowedFactory {
+    InnerDeployment public inner;
+
+    constructor() {
+        inner = new InnerDeployment();
+    }
+}
+
+contract OtherFactory {
+    InnerDeployment public inner;
+
+    constructor() {
+        inner = new InnerDeployment();
+    }
+}
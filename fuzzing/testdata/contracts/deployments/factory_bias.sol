@@ -0,0 +1,50 @@
+// This test ensures that factory methods are called more often when the factory call bias is enabled.
+contract Instance {
+    uint256 public id;
+
+    constructor(uint256 _id) {
+        id = _id;
+    }
+}
+
+contract Factory {
+    uint256 x;
+    uint256 instanceCount;
+
+    function create() public returns (address) {
+        instanceCount++;
+        return address(new Instance(instanceCount));
+    }
+
+    function setA(uint256 value) public {
+        x = value;
+    }
+
+    function setB(uint256 value) public {
+        x = value + 1;
+    }
+
+    function setC(uint256 value) public {
+        x = value + 2;
+    }
+
+    function setD(uint256 value) public {
+        x = value + 3;
+    }
+
+    function setE(uint256 value) public {
+        x = value + 4;
+    }
+
+    function setF(uint256 value) public {
+        x = value + 5;
+    }
+
+    function setG(uint256 value) public {
+        x = value + 6;
+    }
+
+    function setH(uint256 value) public {
+        x = value + 7;
+    }
+}
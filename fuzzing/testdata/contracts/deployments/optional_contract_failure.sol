@@ -0,0 +1,22 @@
+// This test ensures that the failed deployment of an optional contract is skipped, and fuzzing proceeds with the others.
+contract FailingContract {
+    constructor() {
+        revert("this contract always fails to deploy");
+    }
+
+    function doNothing() public {
+    }
+}
+
+contract TestContract {
+    uint256 x;
+
+    function setX(uint256 value) public {
+        x = value;
+    }
+
+    function property_never_specific_x() public view returns (bool) {
+        // PROPERTY: x should never be 7 (this should fail, as TestContract is still deployed and fuzzed).
+        return x != 7;
+    }
+}
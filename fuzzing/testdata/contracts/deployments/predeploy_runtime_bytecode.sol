@@ -0,0 +1,10 @@
+// This contract ensures the fuzzer sets the runtime bytecode and storage of predeployed contracts in the genesis block.
+// The contract predeployed at 0x1234 returns the value of its first storage slot when called.
+contract TestContract {
+    function testPredeploy() public {
+        (bool success, bytes memory data) = address(0x1234).staticcall("");
+
+        // ASSERTION: The predeployed contract should not return the value set in its storage
+        assert(!(success && data.length == 32 && abi.decode(data, (uint256)) == 42));
+    }
+}
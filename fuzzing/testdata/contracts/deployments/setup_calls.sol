@@ -0,0 +1,16 @@
+// This test ensures that configured setup calls are executed after deployment, before fuzzing begins.
+contract TestContract {
+    bool initialized;
+    uint256 x;
+
+    function initialize(uint256 value) public {
+        require(!initialized, "already initialized");
+        initialized = true;
+        x = value;
+    }
+
+    function property_initialized() public view returns (bool) {
+        // PROPERTY: the setup call should have initialized the contract with the configured value (this should pass).
+        return initialized && x == 7;
+    }
+}
@@ -0,0 +1,23 @@
+// This contract guards all of its methods behind a flag set on construction. If it is not set, every call to it reverts,
+// so the fuzzer should warn that the target contract is never reached.
+contract TestContract {
+    bool open;
+    uint256 value;
+
+    constructor(bool _open) {
+        open = _open;
+    }
+
+    modifier whenOpen() {
+        require(open, "closed");
+        _;
+    }
+
+    function set(uint256 x) public whenOpen {
+        value = x;
+    }
+
+    function increment() public whenOpen {
+        value++;
+    }
+}
@@ -0,0 +1,28 @@
+// This contract ensures the fuzzer detects an ERC20 token whose balances no longer sum up to its total supply.
+contract TestContract {
+    uint256 public totalSupply;
+    mapping(address => uint256) public balanceOf;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+
+    constructor() {
+        totalSupply = 1000;
+        balanceOf[msg.sender] = 1000;
+        emit Transfer(address(0), msg.sender, 1000);
+    }
+
+    function transfer(address to, uint256 amount) public returns (bool) {
+        require(balanceOf[msg.sender] >= amount);
+        balanceOf[msg.sender] -= amount;
+        balanceOf[to] += amount;
+        emit Transfer(msg.sender, to, amount);
+        return true;
+    }
+
+    function mint(uint256 amount) public {
+        // BUG: the total supply is not updated when minting.
+        require(amount > 0 && amount < 1e30);
+        balanceOf[msg.sender] += amount;
+        emit Transfer(address(0), msg.sender, amount);
+    }
+}
@@ -0,0 +1,12 @@
+// This contract ensures the fuzzer's execution tracing can record the storage slots changed by each call
+contract TestContract {
+    uint x;
+
+    function setX(uint value) public {
+        x = (value % 100) + 1;
+    }
+
+    function checkX() public {
+        assert(x == 0);
+    }
+}
@@ -0,0 +1,16 @@
+// This contract is used to test that gas testing finds the call sequence which maximizes or minimizes the gas used by
+// a target function.
+contract TestContract {
+    uint256[] values;
+
+    function store(uint8 count) public {
+        // The gas used by this function grows with the number of values stored.
+        for (uint256 i = 0; i < count; i++) {
+            values.push(i);
+        }
+    }
+
+    function reset() public {
+        delete values;
+    }
+}
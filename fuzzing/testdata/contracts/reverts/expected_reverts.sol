@@ -0,0 +1,12 @@
+// This contract ensures reverts configured as expected are not counted as reverted calls.
+contract TestContract {
+    error ExpectedError();
+
+    function revertWithReason() public {
+        revert("expected revert");
+    }
+
+    function revertWithCustomError() public {
+        revert ExpectedError();
+    }
+}
@@ -0,0 +1,23 @@
+// This contract has multiple methods which must be called in sequence to fail an assertion, so campaigns run with the
+// same random seed must select methods and generate arguments in the same order to produce the same results.
+contract TestContract {
+    uint256 x;
+    uint256 y;
+
+    function setX(uint256 value) public {
+        x = value % 100;
+    }
+
+    function setY(uint256 value) public {
+        y = value % 100;
+    }
+
+    function addXToY() public {
+        y += x;
+    }
+
+    function checkY() public {
+        // ASSERTION: y should never exceed 150
+        assert(y <= 150);
+    }
+}
@@ -0,0 +1,26 @@
+// This contract depends on host state which persists across campaigns, so campaigns run with the same random seed
+// produce different results. The first campaign to deploy it records that it ran in a file, and only it fails the
+// assertion.
+interface CheatCodes {
+    function readFile(string calldata) external returns (string memory);
+    function writeFile(string calldata, string calldata) external;
+}
+
+contract TestContract {
+    CheatCodes cheats = CheatCodes(0x7109709ECfa91a80626fF3989D68f67F5b1DD12D);
+    bool firstCampaign;
+
+    constructor() {
+        try cheats.readFile("campaigns.txt") returns (string memory) {
+            firstCampaign = false;
+        } catch {
+            firstCampaign = true;
+            cheats.writeFile("campaigns.txt", "1");
+        }
+    }
+
+    function check() public {
+        // ASSERTION: only the first campaign fails
+        assert(!firstCampaign);
+    }
+}
@@ -0,0 +1,20 @@
+// This contract verifies that the chain advances by a base block time with bounded jitter for each block it advances.
+contract TestContract {
+    uint lastNumber;
+    uint lastTimestamp;
+
+    function step() public {
+        // The fuzzer is configured with a block time of 12 seconds with up to 3 seconds of jitter per jump.
+        if (lastTimestamp != 0) {
+            uint blocks = block.number - lastNumber;
+            uint elapsed = block.timestamp - lastTimestamp;
+            if (blocks == 0) {
+                assert(elapsed == 0);
+            } else {
+                assert(elapsed >= blocks * 12 - 3 && elapsed <= blocks * 12 + 3);
+            }
+        }
+        lastNumber = block.number;
+        lastTimestamp = block.timestamp;
+    }
+}
@@ -0,0 +1,11 @@
+// This contract is used to test the values generated for calls to payable methods, and records how much it received.
+contract TestContract {
+    uint256 received;
+
+    function deposit() public payable {
+        received += msg.value;
+    }
+
+    function noop() public {
+    }
+}
@@ -0,0 +1,12 @@
+// This contract verifies the fuzzer only provides function arguments within configured integer ranges.
+contract TestContract {
+    function setPercentage(uint256 percentage) public {
+        // ASSERTION: the percentage should always be within the configured range.
+        assert(percentage <= 100);
+    }
+
+    function setOffset(int8 offset) public {
+        // ASSERTION: the offset should always be within the configured range.
+        assert(offset >= -5 && offset <= 5);
+    }
+}
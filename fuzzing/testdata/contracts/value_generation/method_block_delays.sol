@@ -0,0 +1,25 @@
+// This contract verifies that calls to methods with configured block delays advance the chain within those delays.
+contract TestContract {
+    uint lastNumber;
+    uint lastTimestamp;
+
+    function advance() public {
+        // This method is configured to always advance a single block, by 1000 to 2000 seconds.
+        if (lastTimestamp != 0) {
+            assert(block.number == lastNumber + 1);
+            assert(block.timestamp >= lastTimestamp + 1000 && block.timestamp <= lastTimestamp + 2000);
+        }
+        lastNumber = block.number;
+        lastTimestamp = block.timestamp;
+    }
+
+    function stay() public {
+        // This method is configured to never advance the chain, so it is included in the previous call's block.
+        if (lastTimestamp != 0) {
+            assert(block.number == lastNumber);
+            assert(block.timestamp == lastTimestamp);
+        }
+        lastNumber = block.number;
+        lastTimestamp = block.timestamp;
+    }
+}
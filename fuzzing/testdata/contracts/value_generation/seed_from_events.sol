@@ -0,0 +1,20 @@
+// This contract only reveals the value needed to fail its assertion through an emitted event, so the fuzzer must add
+// event values to its value set to solve it.
+contract TestContract {
+    uint256 secret;
+
+    event Revealed(uint256 value);
+
+    constructor() {
+        secret = uint256(keccak256(abi.encodePacked(block.timestamp, address(this))));
+    }
+
+    function reveal() public {
+        emit Revealed(secret);
+    }
+
+    function guess(uint256 value) public {
+        // ASSERTION: the secret should never be guessed.
+        assert(value != secret);
+    }
+}
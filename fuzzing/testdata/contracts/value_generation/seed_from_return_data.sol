@@ -0,0 +1,26 @@
+// This contract only reveals the value needed to fail its assertion through a nested return value, so the fuzzer must
+// add flattened return values to its value set to solve it.
+contract TestContract {
+    struct Reveal {
+        bool revealed;
+        uint256[] values;
+    }
+
+    uint256 secret;
+
+    constructor() {
+        secret = uint256(keccak256(abi.encodePacked(block.timestamp, address(this))));
+    }
+
+    function reveal() public view returns (Reveal memory) {
+        uint256[] memory values = new uint256[](2);
+        values[0] = 7;
+        values[1] = secret;
+        return Reveal(true, values);
+    }
+
+    function guess(uint256 value) public {
+        // ASSERTION: the secret should never be guessed.
+        assert(value != secret);
+    }
+}
@@ -0,0 +1,25 @@
+// This contract provides methods with a variety of argument types, used to verify that call sequences generated with
+// a recorded sequence seed are regenerated identically when the seed is replayed.
+contract TestContract {
+    uint256 x;
+    address lastAddress;
+    bytes lastData;
+    string lastString;
+
+    function setX(uint256 value) public {
+        x = value;
+    }
+
+    function setAddress(address value) public {
+        lastAddress = value;
+    }
+
+    function setData(bytes memory data, string memory str) public {
+        lastData = data;
+        lastString = str;
+    }
+
+    function fund() public payable {
+        x += msg.value;
+    }
+}
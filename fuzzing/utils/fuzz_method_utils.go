@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"sort"
 	"strings"
 
 	compilationTypes "github.com/crytic/medusa/compilation/types"
@@ -37,9 +38,17 @@ func IsPropertyTest(method abi.Method, prefixes []string) bool {
 	return false
 }
 
-// BinTestByType sorts a contract's methods by whether they are assertion, property, or optimization tests.
+// BinTestByType sorts a contract's methods by whether they are assertion, property, or optimization tests. Methods
+// are ordered by their signature within each category, so the result is deterministic.
 func BinTestByType(contract *compilationTypes.CompiledContract, propertyTestPrefixes, optimizationTestPrefixes []string, testViewMethods bool) (assertionTests, propertyTests, optimizationTests []abi.Method) {
+	methods := make([]abi.Method, 0, len(contract.Abi.Methods))
 	for _, method := range contract.Abi.Methods {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Sig < methods[j].Sig
+	})
+	for _, method := range methods {
 		if IsPropertyTest(method, propertyTestPrefixes) {
 			propertyTests = append(propertyTests, method)
 		} else if IsOptimizationTest(method, optimizationTestPrefixes) {
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"github.com/crytic/medusa/logging"
 	"math/big"
+	"math/rand"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/crytic/medusa/utils"
 	"github.com/crytic/medusa/utils/reflectionutils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -104,6 +106,21 @@ func GenerateAbiValue(generator ValueGenerator, inputType *abi.Type) any {
 // MutateAbiValue takes an ABI packable input value, alongside its type definition and a value generator, to mutate
 // existing ABI input values.
 func MutateAbiValue(generator ValueGenerator, mutator ValueMutator, inputType *abi.Type, value any) (any, error) {
+	return mutateAbiValue(generator, mutator, inputType, value, "", nil, nil)
+}
+
+// MutateAbiValueWithFieldProbabilities takes an ABI packable input value, alongside its type definition and a value
+// generator, to mutate existing ABI input values as MutateAbiValue does. Additionally, each struct/tuple field whose
+// path has a provided probability is only mutated with that probability, and is otherwise left unchanged. Field paths
+// are the dot-separated names of the fields leading to a field, relative to the provided value (e.g. "inner.amount").
+// Elements of arrays and slices share the field path of their array. Fields without a probability are always mutated.
+func MutateAbiValueWithFieldProbabilities(generator ValueGenerator, mutator ValueMutator, inputType *abi.Type, value any, fieldMutationProbabilities map[string]float64, randomProvider *rand.Rand) (any, error) {
+	return mutateAbiValue(generator, mutator, inputType, value, "", fieldMutationProbabilities, randomProvider)
+}
+
+// mutateAbiValue mutates an ABI packable input value as MutateAbiValueWithFieldProbabilities does, where fieldPath
+// describes the field path of the provided value. If fieldMutationProbabilities is nil, all fields are mutated.
+func mutateAbiValue(generator ValueGenerator, mutator ValueMutator, inputType *abi.Type, value any, fieldPath string, fieldMutationProbabilities map[string]float64, randomProvider *rand.Rand) (any, error) {
 	// Switch on the type of value and mutate it recursively.
 	switch inputType.T {
 	case abi.AddressTy:
@@ -226,7 +243,7 @@ func MutateAbiValue(generator ValueGenerator, mutator ValueMutator, inputType *a
 				generatedElement := GenerateAbiValue(generator, inputType.Elem)
 				reflectedElement.Set(reflect.ValueOf(generatedElement))
 			} else {
-				mutatedElement, err := MutateAbiValue(generator, mutator, inputType.Elem, mutatedValues[i])
+				mutatedElement, err := mutateAbiValue(generator, mutator, inputType.Elem, mutatedValues[i], fieldPath, fieldMutationProbabilities, randomProvider)
 				if err != nil {
 					return nil, fmt.Errorf("could not mutate array input as the value generator encountered an error: %v", err)
 				}
@@ -256,7 +273,7 @@ func MutateAbiValue(generator ValueGenerator, mutator ValueMutator, inputType *a
 				generatedElement := GenerateAbiValue(generator, inputType.Elem)
 				reflectedElement.Set(reflect.ValueOf(generatedElement))
 			} else {
-				mutatedElement, err := MutateAbiValue(generator, mutator, inputType.Elem, mutatedValues[i])
+				mutatedElement, err := mutateAbiValue(generator, mutator, inputType.Elem, mutatedValues[i], fieldPath, fieldMutationProbabilities, randomProvider)
 				if err != nil {
 					return nil, fmt.Errorf("could not mutate slice input as the value generator encountered an error: %v", err)
 				}
@@ -269,9 +286,18 @@ func MutateAbiValue(generator ValueGenerator, mutator ValueMutator, inputType *a
 		// Note: We create a copy, as existing tuples may not be assignable.
 		tuple := reflectionutils.CopyReflectedType(reflect.ValueOf(value))
 		for i := 0; i < len(inputType.TupleElems); i++ {
+			// Determine the path of this field. If it has a mutation probability, we may leave it unchanged.
+			tupleFieldPath := inputType.TupleRawNames[i]
+			if fieldPath != "" {
+				tupleFieldPath = fieldPath + "." + tupleFieldPath
+			}
+			if probability, ok := fieldMutationProbabilities[tupleFieldPath]; ok && randomProvider.Float64() >= probability {
+				continue
+			}
+
 			field := tuple.Field(i)
 			fieldValue := reflectionutils.GetField(field)
-			mutatedValue, err := MutateAbiValue(generator, mutator, inputType.TupleElems[i], fieldValue)
+			mutatedValue, err := mutateAbiValue(generator, mutator, inputType.TupleElems[i], fieldValue, tupleFieldPath, fieldMutationProbabilities, randomProvider)
 			if err != nil {
 				return nil, fmt.Errorf("could not mutate struct/tuple input as the value generator encountered an error: %v", err)
 			}
@@ -283,6 +309,78 @@ func MutateAbiValue(generator ValueGenerator, mutator ValueMutator, inputType *a
 	}
 }
 
+// ConstrainAbiIntegerValue takes an ABI packable integer input value alongside its type definition, and constrains it
+// to the provided inclusive range. Values outside the range are wrapped around into it. The range is first narrowed
+// to the bounds of the integer type, so the resulting value is always representable by it.
+// Returns the constrained value, or an error if the type or value provided is not an integer, or the range does not
+// intersect with the bounds of the type.
+func ConstrainAbiIntegerValue(inputType *abi.Type, value any, min *big.Int, max *big.Int) (any, error) {
+	// Verify we were provided an integer type.
+	if inputType.T != abi.UintTy && inputType.T != abi.IntTy {
+		return nil, fmt.Errorf("could not constrain argument as the type is not an integer: %v", inputType)
+	}
+
+	// Obtain the value as a big integer.
+	var b *big.Int
+	switch v := value.(type) {
+	case uint8:
+		b = new(big.Int).SetUint64(uint64(v))
+	case uint16:
+		b = new(big.Int).SetUint64(uint64(v))
+	case uint32:
+		b = new(big.Int).SetUint64(uint64(v))
+	case uint64:
+		b = new(big.Int).SetUint64(v)
+	case int8:
+		b = new(big.Int).SetInt64(int64(v))
+	case int16:
+		b = new(big.Int).SetInt64(int64(v))
+	case int32:
+		b = new(big.Int).SetInt64(int64(v))
+	case int64:
+		b = new(big.Int).SetInt64(v)
+	case *big.Int:
+		b = new(big.Int).Set(v)
+	default:
+		return nil, fmt.Errorf("could not constrain %v input as the value provided is not of an integer type", inputType)
+	}
+
+	// Narrow our range to the bounds of the type and constrain the value to it.
+	typeMin, typeMax := utils.GetIntegerConstraints(inputType.T == abi.IntTy, inputType.Size)
+	if min.Cmp(typeMin) < 0 {
+		min = typeMin
+	}
+	if max.Cmp(typeMax) > 0 {
+		max = typeMax
+	}
+	if min.Cmp(max) > 0 {
+		return nil, fmt.Errorf("could not constrain %v input as the range provided does not intersect with its bounds", inputType)
+	}
+	b = utils.ConstrainIntegerToBounds(b, min, max)
+
+	// Convert the value back to its original type.
+	switch value.(type) {
+	case uint8:
+		return uint8(b.Uint64()), nil
+	case uint16:
+		return uint16(b.Uint64()), nil
+	case uint32:
+		return uint32(b.Uint64()), nil
+	case uint64:
+		return b.Uint64(), nil
+	case int8:
+		return int8(b.Int64()), nil
+	case int16:
+		return int16(b.Int64()), nil
+	case int32:
+		return int32(b.Int64()), nil
+	case int64:
+		return b.Int64(), nil
+	default:
+		return b, nil
+	}
+}
+
 // EncodeJSONArgumentsToMap encodes provided go-ethereum ABI packable input values into a generic JSON type values
 // (e.g. []any, map[string]any, etc).
 // Returns the encoded values, or an error if one occurs.
@@ -668,17 +766,23 @@ func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
 
 // DecodeJSONArgumentsFromMap decodes JSON values into a provided values of the given types, or returns an error of one occurs.
 // The values provided must be generic JSON types (e.g. []any, map[string]any, etc) which will be transformed into
-// a go-ethereum ABI packable values.
-func DecodeJSONArgumentsFromMap(inputs abi.Arguments, values map[string]any, deployedContractAddr map[string]common.Address) ([]any, error) {
+// a go-ethereum ABI packable values. If lenient is true, arguments or struct fields which are not provided are filled
+// with their zero value and a warning is logged, rather than returning an error.
+func DecodeJSONArgumentsFromMap(inputs abi.Arguments, values map[string]any, deployedContractAddr map[string]common.Address, lenient bool) ([]any, error) {
 	// Create a variable to store decoded arguments, fill it with the respective decoded arguments.
 	var decodedArgs = make([]any, len(inputs))
 	for i, input := range inputs {
 		value, ok := values[input.Name]
 		if !ok {
+			if lenient {
+				logging.GlobalLogger.Warn(fmt.Sprintf("Value not provided for argument %v, using its zero value", input.Name))
+				decodedArgs[i] = zeroJSONArgument(&input.Type)
+				continue
+			}
 			err := fmt.Errorf("value not not provided for argument: name: %v", input.Name)
 			return nil, err
 		}
-		arg, err := decodeJSONArgument(&input.Type, value, deployedContractAddr)
+		arg, err := decodeJSONArgument(&input.Type, value, deployedContractAddr, lenient)
 		if err != nil {
 			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
@@ -703,7 +807,7 @@ func DecodeJSONArgumentsFromSlice(inputs abi.Arguments, values []any, deployedCo
 	// Create a variable to store decoded arguments, fill it with the respective decoded arguments.
 	var decodedArgs = make([]any, len(inputs))
 	for i, input := range inputs {
-		arg, err := decodeJSONArgument(&input.Type, values[i], deployedContractAddr)
+		arg, err := decodeJSONArgument(&input.Type, values[i], deployedContractAddr, false)
 		if err != nil {
 			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
@@ -717,8 +821,9 @@ func DecodeJSONArgumentsFromSlice(inputs abi.Arguments, values []any, deployedCo
 
 // decodeJSONArgument decodes JSON value into a provided value of a given type, or returns an error of one occurs.
 // The value provided must be a generic JSON type (e.g. []any, map[string]any, etc) which will be transformed into
-// a go-ethereum ABI packable value.
-func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map[string]common.Address) (any, error) {
+// a go-ethereum ABI packable value. If lenient is true, struct fields which are not provided are filled with their
+// zero value and a warning is logged, rather than returning an error.
+func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map[string]common.Address, lenient bool) (any, error) {
 	var v any
 	switch inputType.T {
 	case abi.AddressTy:
@@ -839,7 +944,7 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 		// This needs to be an array type, not a slice. But arrays can't be dynamically defined without reflection.
 		array := reflect.Indirect(reflect.New(inputType.GetType()))
 		for i, e := range arr {
-			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr)
+			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr, lenient)
 			if err != nil {
 				return nil, err
 			}
@@ -854,7 +959,7 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 		// Element type of slice is dynamic therefore it needs to be created with reflection.
 		slice := reflect.MakeSlice(inputType.GetType(), len(arr), len(arr))
 		for i, e := range arr {
-			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr)
+			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr, lenient)
 			if err != nil {
 				return nil, err
 			}
@@ -873,10 +978,15 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 			fieldName := inputType.TupleRawNames[i]
 			fieldValue, ok := object[fieldName]
 			if !ok {
-				return nil, fmt.Errorf("value for struct field %s not provided", fieldName)
+				if !lenient {
+					return nil, fmt.Errorf("value for struct field %s not provided", fieldName)
+				}
+				logging.GlobalLogger.Warn(fmt.Sprintf("Value for struct field %s not provided, using its zero value", fieldName))
+				reflectionutils.SetField(field, zeroJSONArgument(eleType))
+				continue
 			}
-			eleValue, err := decodeJSONArgument(eleType, fieldValue, deployedContractAddr)
-			if !ok {
+			eleValue, err := decodeJSONArgument(eleType, fieldValue, deployedContractAddr, lenient)
+			if err != nil {
 				return nil, fmt.Errorf("can not parse struct field %s, error: %s", fieldName, err)
 			}
 			reflectionutils.SetField(field, eleValue)
@@ -889,3 +999,29 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 
 	return v, nil
 }
+
+// zeroJSONArgument obtains the zero value for a given type, in the same go-ethereum ABI packable form that
+// decodeJSONArgument would produce.
+func zeroJSONArgument(inputType *abi.Type) any {
+	switch inputType.T {
+	case abi.UintTy, abi.IntTy:
+		// Integers which are not 8, 16, 32, or 64 bits are represented as big integers, whose zero value would
+		// otherwise be nil.
+		if inputType.Size != 8 && inputType.Size != 16 && inputType.Size != 32 && inputType.Size != 64 {
+			return big.NewInt(0)
+		}
+	case abi.ArrayTy:
+		array := reflect.Indirect(reflect.New(inputType.GetType()))
+		for i := 0; i < array.Len(); i++ {
+			array.Index(i).Set(reflect.ValueOf(zeroJSONArgument(inputType.Elem)))
+		}
+		return array.Interface()
+	case abi.TupleTy:
+		st := reflect.Indirect(reflect.New(inputType.GetType()))
+		for i, eleType := range inputType.TupleElems {
+			reflectionutils.SetField(st.Field(i), zeroJSONArgument(eleType))
+		}
+		return st.Interface()
+	}
+	return reflect.Zero(inputType.GetType()).Interface()
+}
@@ -2,12 +2,14 @@ package valuegeneration
 
 import (
 	"fmt"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -228,7 +230,7 @@ func TestABIRoundtripEncodingAllTypes(t *testing.T) {
 			assert.NoError(t, err)
 
 			// Decode the generated value
-			decodedValue, err := decodeJSONArgument(&arg.Type, encodedValue, nil)
+			decodedValue, err := decodeJSONArgument(&arg.Type, encodedValue, nil, false)
 			assert.NoError(t, err)
 
 			// Re-encode the generated value for this argument
@@ -295,6 +297,99 @@ func TestABIGenerationAndMutation(t *testing.T) {
 	}
 }
 
+// TestMutateAbiValueWithFieldProbabilities runs tests to ensure that struct fields with a mutation probability of zero
+// are never mutated, including those in nested structs, while other fields continue to be mutated.
+func TestMutateAbiValueWithFieldProbabilities(t *testing.T) {
+	// Create a value generator which mutates integers and addresses frequently.
+	mutationalGeneratorConfig := &MutationalValueGeneratorConfig{
+		MinMutationRounds:            1,
+		MaxMutationRounds:            1,
+		GenerateRandomAddressBias:    0.5,
+		GenerateRandomIntegerBias:    0.5,
+		MutateAddressProbability:     1,
+		MutateIntegerProbability:     1,
+		MutateIntegerGenerateNewBias: 0.5,
+		RandomValueGeneratorConfig: &RandomValueGeneratorConfig{
+			GenerateRandomArrayMaxSize:  100,
+			GenerateRandomBytesMaxSize:  100,
+			GenerateRandomStringMaxSize: 100,
+		},
+	}
+	randomProvider := rand.New(rand.NewSource(time.Now().UnixNano()))
+	mutationalGenerator := NewMutationalValueGenerator(mutationalGeneratorConfig, NewValueSet(), randomProvider)
+
+	// Define a struct type with a nested struct, pinning a top level field and a nested field.
+	structType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "amount", Type: "uint256"},
+		{Name: "owner", Type: "address"},
+		{Name: "inner", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "x", Type: "uint256"},
+			{Name: "y", Type: "uint256"},
+		}},
+	})
+	assert.NoError(t, err)
+	fieldMutationProbabilities := map[string]float64{
+		"owner":   0,
+		"inner.x": 0,
+	}
+
+	// Mutate generated values repeatedly, verifying pinned fields never change while the others do.
+	amountMutated, innerYMutated := false, false
+	for i := 0; i < 1_000; i++ {
+		value := GenerateAbiValue(mutationalGenerator, &structType)
+		mutatedValue, err := MutateAbiValueWithFieldProbabilities(mutationalGenerator, mutationalGenerator, &structType, value, fieldMutationProbabilities, randomProvider)
+		assert.NoError(t, err)
+
+		original, mutated := reflect.ValueOf(value), reflect.ValueOf(mutatedValue)
+		assert.EqualValues(t, original.Field(1).Interface(), mutated.Field(1).Interface())
+		assert.Zero(t, original.Field(2).Field(0).Interface().(*big.Int).Cmp(mutated.Field(2).Field(0).Interface().(*big.Int)))
+		if original.Field(0).Interface().(*big.Int).Cmp(mutated.Field(0).Interface().(*big.Int)) != 0 {
+			amountMutated = true
+		}
+		if original.Field(2).Field(1).Interface().(*big.Int).Cmp(mutated.Field(2).Field(1).Interface().(*big.Int)) != 0 {
+			innerYMutated = true
+		}
+	}
+	assert.True(t, amountMutated)
+	assert.True(t, innerYMutated)
+}
+
+// TestGenerateAddressZeroAddressProbability runs tests to ensure that when a zero address probability is configured,
+// the MutationalValueGenerator generates the zero address at the configured frequency, even if the ValueSet contains it.
+func TestGenerateAddressZeroAddressProbability(t *testing.T) {
+	// Create a value set which contains the zero address alongside other addresses.
+	valueSet := NewValueSet()
+	valueSet.AddAddress(common.Address{})
+	valueSet.AddAddress(common.HexToAddress("0x1234"))
+	valueSet.AddAddress(common.HexToAddress("0x5678"))
+
+	for _, zeroAddressProbability := range []float32{0, 0.2, 1} {
+		zeroAddressProbability := zeroAddressProbability
+		mutationalGeneratorConfig := &MutationalValueGeneratorConfig{
+			GenerateRandomAddressBias: 0.05,
+			ZeroAddressProbability:    &zeroAddressProbability,
+			RandomValueGeneratorConfig: &RandomValueGeneratorConfig{
+				GenerateRandomArrayMaxSize:  100,
+				GenerateRandomBytesMaxSize:  100,
+				GenerateRandomStringMaxSize: 100,
+			},
+		}
+		mutationalGenerator := NewMutationalValueGenerator(mutationalGeneratorConfig, valueSet, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+		// Generate addresses and count how many are the zero address.
+		const generatedCount = 20_000
+		zeroAddressCount := 0
+		for i := 0; i < generatedCount; i++ {
+			if mutationalGenerator.GenerateAddress() == (common.Address{}) {
+				zeroAddressCount++
+			}
+		}
+
+		// Verify the zero address frequency matches the configured probability.
+		assert.InDelta(t, zeroAddressProbability, float64(zeroAddressCount)/generatedCount, 0.02)
+	}
+}
+
 // TestEncodeABIArgumentToString runs tests to ensure that  a provided go-ethereum ABI packable input value of a given
 // type is encoded to string in the specific format, depending on the input's type.
 func TestEncodeABIArgumentToString(t *testing.T) {
@@ -324,3 +419,41 @@ func TestEncodeABIArgumentToString(t *testing.T) {
 		}
 	}
 }
+
+// TestDecodeJSONArgumentsFromMapLenient ensures that struct fields missing from JSON values cause decoding to fail in
+// strict mode, while lenient mode fills them with their zero value.
+func TestDecodeJSONArgumentsFromMapLenient(t *testing.T) {
+	// Define a constructor argument which is a struct, and JSON values which omit one of its fields.
+	structType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "amount", Type: "uint256"},
+		{Name: "owner", Type: "address"},
+		{Name: "limits", Type: "uint256[2]"},
+	})
+	assert.NoError(t, err)
+	inputs := abi.Arguments{{Name: "config", Type: structType}}
+	values := map[string]any{
+		"config": map[string]any{
+			"amount": "5",
+			"limits": []any{"1", "2"},
+		},
+	}
+
+	// Strict mode should fail on the missing field.
+	_, err = DecodeJSONArgumentsFromMap(inputs, values, nil, false)
+	assert.ErrorContains(t, err, "owner")
+
+	// Lenient mode should zero-fill the missing field while decoding the provided ones.
+	decoded, err := DecodeJSONArgumentsFromMap(inputs, values, nil, true)
+	assert.NoError(t, err)
+	decodedStruct := reflect.ValueOf(decoded[0])
+	assert.EqualValues(t, big.NewInt(5), decodedStruct.Field(0).Interface())
+	assert.EqualValues(t, common.Address{}, decodedStruct.Field(1).Interface())
+
+	// Lenient mode should also zero-fill missing arguments, and all decoded values should be packable.
+	decoded, err = DecodeJSONArgumentsFromMap(inputs, map[string]any{}, nil, true)
+	assert.NoError(t, err)
+	decodedStruct = reflect.ValueOf(decoded[0])
+	assert.EqualValues(t, big.NewInt(0), decodedStruct.Field(0).Interface())
+	_, err = inputs.Pack(decoded...)
+	assert.NoError(t, err)
+}
@@ -47,6 +47,11 @@ type MutationalValueGeneratorConfig struct {
 	// entirely random, rather than mutated. Value range is [0.0, 1.0].
 	GenerateRandomBytesBias float32
 
+	// ZeroAddressProbability defines the probability in which an address generated by the value generator is the zero
+	// address. If set, the zero address is otherwise never generated, even if it is in the ValueSet. If nil, the zero
+	// address is treated like any other address. Value range is [0.0, 1.0].
+	ZeroAddressProbability *float32
+
 	// MutateAddressProbability defines the probability in which an existing address value will be mutated by
 	// the value generator. Value range is [0.0, 1.0].
 	MutateAddressProbability float32
@@ -378,16 +383,31 @@ func (g *MutationalValueGenerator) mutateStringInternal(s *string) string {
 
 // GenerateAddress obtains an existing address from its underlying value set or generates a random one.
 func (g *MutationalValueGenerator) GenerateAddress() common.Address {
+	// If a zero address probability is configured, decide whether to generate the zero address first. Otherwise, it
+	// is excluded from the addresses we generate.
+	excludeZeroAddress := false
+	if g.config.ZeroAddressProbability != nil {
+		if g.randomProvider.Float32() < *g.config.ZeroAddressProbability {
+			return common.Address{}
+		}
+		excludeZeroAddress = true
+	}
+
 	// If our bias directs us to, use the random generator instead
 	randomGeneratorDecision := g.randomProvider.Float32()
 	if randomGeneratorDecision < g.config.GenerateRandomAddressBias {
-		return g.RandomValueGenerator.GenerateAddress()
+		return g.generateRandomAddress(excludeZeroAddress)
 	}
 
 	// Obtain our addresses from our value set. If we have none, generate a random one instead.
 	addresses := g.valueSet.Addresses()
+	if excludeZeroAddress {
+		addresses = slices.DeleteFunc(addresses, func(address common.Address) bool {
+			return address == (common.Address{})
+		})
+	}
 	if len(addresses) == 0 {
-		return g.RandomValueGenerator.GenerateAddress()
+		return g.generateRandomAddress(excludeZeroAddress)
 	}
 
 	// Select a random address from our set of addresses.
@@ -395,6 +415,16 @@ func (g *MutationalValueGenerator) GenerateAddress() common.Address {
 	return address
 }
 
+// generateRandomAddress generates an entirely random address using the underlying RandomValueGenerator. If
+// excludeZeroAddress is true, the zero address is never returned.
+func (g *MutationalValueGenerator) generateRandomAddress(excludeZeroAddress bool) common.Address {
+	address := g.RandomValueGenerator.GenerateAddress()
+	for excludeZeroAddress && address == (common.Address{}) {
+		address = g.RandomValueGenerator.GenerateAddress()
+	}
+	return address
+}
+
 // MutateAddress takes an address input and sometimes returns a mutated value based off the input.
 func (g *MutationalValueGenerator) MutateAddress(addr common.Address) common.Address {
 	// Determine whether to perform mutations against this input or just return it as-is.
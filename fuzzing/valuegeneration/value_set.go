@@ -1,6 +1,7 @@
 package valuegeneration
 
 import (
+	"bytes"
 	"encoding/hex"
 	"github.com/crytic/medusa/utils/reflectionutils"
 	"hash"
@@ -10,18 +11,29 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/sha3"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // ValueSet represents potential values of significance within the source code to be used in fuzz tests.
+// Each set of values is additionally tracked in insertion order, so values are returned in a deterministic order and
+// random selections made from them can be reproduced.
 type ValueSet struct {
 	// addresses represents a set of common.Address to use in fuzz tests. A mapping is used to avoid duplicates.
 	addresses map[common.Address]any
+	// addressesOrdered represents the addresses in the set, in the order they were added.
+	addressesOrdered []common.Address
 	// integers represents a set of integers to use in fuzz tests. A mapping is used to avoid duplicates.
 	integers map[string]*big.Int
+	// integersOrdered represents the integers in the set, in the order they were added.
+	integersOrdered []*big.Int
 	// strings represents a set of strings to use in fuzz tests. A mapping is used to avoid duplicates.
 	strings map[string]any
+	// stringsOrdered represents the strings in the set, in the order they were added.
+	stringsOrdered []string
 	// bytes represents a set of bytes to use in fuzz tests. A mapping is used to avoid duplicates.
 	bytes map[string][]byte
+	// bytesOrdered represents the byte sequences in the set, in the order they were added.
+	bytesOrdered [][]byte
 	// hashProvider represents a hash provider used to create keys for some data.
 	hashProvider hash.Hash
 }
@@ -41,28 +53,29 @@ func NewValueSet() *ValueSet {
 // Clone creates a copy of the current ValueSet.
 func (vs *ValueSet) Clone() *ValueSet {
 	baseValueSet := &ValueSet{
-		addresses:    maps.Clone(vs.addresses),
-		integers:     maps.Clone(vs.integers),
-		strings:      maps.Clone(vs.strings),
-		bytes:        maps.Clone(vs.bytes),
-		hashProvider: sha3.NewLegacyKeccak256(),
+		addresses:        maps.Clone(vs.addresses),
+		addressesOrdered: slices.Clone(vs.addressesOrdered),
+		integers:         maps.Clone(vs.integers),
+		integersOrdered:  slices.Clone(vs.integersOrdered),
+		strings:          maps.Clone(vs.strings),
+		stringsOrdered:   slices.Clone(vs.stringsOrdered),
+		bytes:            maps.Clone(vs.bytes),
+		bytesOrdered:     slices.Clone(vs.bytesOrdered),
+		hashProvider:     sha3.NewLegacyKeccak256(),
 	}
 	return baseValueSet
 }
 
 // Addresses returns a list of addresses contained within the set.
 func (vs *ValueSet) Addresses() []common.Address {
-	res := make([]common.Address, len(vs.addresses))
-	count := 0
-	for k := range vs.addresses {
-		res[count] = k
-		count++
-	}
-	return res
+	return slices.Clone(vs.addressesOrdered)
 }
 
 // AddAddress adds an address item to the ValueSet.
 func (vs *ValueSet) AddAddress(a common.Address) {
+	if _, exists := vs.addresses[a]; !exists {
+		vs.addressesOrdered = append(vs.addressesOrdered, a)
+	}
 	vs.addresses[a] = nil
 }
 
@@ -74,23 +87,26 @@ func (vs *ValueSet) ContainsAddress(a common.Address) bool {
 
 // RemoveAddress removes an address item from the ValueSet.
 func (vs *ValueSet) RemoveAddress(a common.Address) {
+	if _, exists := vs.addresses[a]; exists {
+		vs.addressesOrdered = slices.DeleteFunc(vs.addressesOrdered, func(item common.Address) bool {
+			return item == a
+		})
+	}
 	delete(vs.addresses, a)
 }
 
 // Integers returns a list of integers contained within the set.
 func (vs *ValueSet) Integers() []*big.Int {
-	res := make([]*big.Int, len(vs.integers))
-	count := 0
-	for _, v := range vs.integers {
-		res[count] = v
-		count++
-	}
-	return res
+	return slices.Clone(vs.integersOrdered)
 }
 
 // AddInteger adds an integer item to the ValueSet.
 func (vs *ValueSet) AddInteger(b *big.Int) {
-	vs.integers[b.String()] = b
+	key := b.String()
+	if _, exists := vs.integers[key]; !exists {
+		vs.integersOrdered = append(vs.integersOrdered, b)
+	}
+	vs.integers[key] = b
 }
 
 // ContainsInteger checks if an integer is contained in the ValueSet.
@@ -101,22 +117,25 @@ func (vs *ValueSet) ContainsInteger(b *big.Int) bool {
 
 // RemoveInteger removes an integer item from the ValueSet.
 func (vs *ValueSet) RemoveInteger(b *big.Int) {
-	delete(vs.integers, b.String())
+	key := b.String()
+	if _, exists := vs.integers[key]; exists {
+		vs.integersOrdered = slices.DeleteFunc(vs.integersOrdered, func(item *big.Int) bool {
+			return item.Cmp(b) == 0
+		})
+	}
+	delete(vs.integers, key)
 }
 
 // Strings returns a list of strings contained within the set.
 func (vs *ValueSet) Strings() []string {
-	res := make([]string, len(vs.strings))
-	count := 0
-	for k := range vs.strings {
-		res[count] = k
-		count++
-	}
-	return res
+	return slices.Clone(vs.stringsOrdered)
 }
 
 // AddString adds a string item to the ValueSet.
 func (vs *ValueSet) AddString(s string) {
+	if _, exists := vs.strings[s]; !exists {
+		vs.stringsOrdered = append(vs.stringsOrdered, s)
+	}
 	vs.strings[s] = nil
 }
 
@@ -128,18 +147,17 @@ func (vs *ValueSet) ContainsString(s string) bool {
 
 // RemoveString removes a string item from the ValueSet.
 func (vs *ValueSet) RemoveString(s string) {
+	if _, exists := vs.strings[s]; exists {
+		vs.stringsOrdered = slices.DeleteFunc(vs.stringsOrdered, func(item string) bool {
+			return item == s
+		})
+	}
 	delete(vs.strings, s)
 }
 
 // Bytes returns a list of bytes contained within the set.
 func (vs *ValueSet) Bytes() [][]byte {
-	res := make([][]byte, len(vs.bytes))
-	count := 0
-	for _, v := range vs.bytes {
-		res[count] = v
-		count++
-	}
-	return res
+	return slices.Clone(vs.bytesOrdered)
 }
 
 // AddBytes adds a byte sequence to the ValueSet.
@@ -150,6 +168,9 @@ func (vs *ValueSet) AddBytes(b []byte) {
 	vs.hashProvider.Reset()
 
 	// Add our hash to our "set" (map)
+	if _, exists := vs.bytes[hashStr]; !exists {
+		vs.bytesOrdered = append(vs.bytesOrdered, b)
+	}
 	vs.bytes[hashStr] = b
 }
 
@@ -172,6 +193,11 @@ func (vs *ValueSet) RemoveBytes(b []byte) {
 	hashStr := hex.EncodeToString(vs.hashProvider.Sum(nil))
 	vs.hashProvider.Reset()
 
+	if _, exists := vs.bytes[hashStr]; exists {
+		vs.bytesOrdered = slices.DeleteFunc(vs.bytesOrdered, func(item []byte) bool {
+			return bytes.Equal(item, b)
+		})
+	}
 	delete(vs.bytes, hashStr)
 }
 
@@ -223,3 +249,38 @@ func (vs *ValueSet) Add(values []any) {
 		}
 	}
 }
+
+// AddFlattened adds one or more values, as Add does. Values which are arrays, slices, or structs (other than bytes and
+// fixed bytes) are flattened, so each of their primitive leaf values is added instead.
+func (vs *ValueSet) AddFlattened(values []any) {
+	for _, value := range values {
+		vs.addFlattenedValue(reflect.ValueOf(value))
+	}
+}
+
+// addFlattenedValue adds the provided reflected value, recursing into the elements of arrays and slices, and the fields
+// of structs, until primitive values are reached.
+func (vs *ValueSet) addFlattenedValue(value reflect.Value) {
+	if !value.IsValid() {
+		return
+	}
+	switch value.Kind() {
+	case reflect.Array, reflect.Slice:
+		// Bytes and fixed bytes are primitive values, so we add them as they are.
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			vs.Add([]any{value.Interface()})
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			vs.addFlattenedValue(value.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				vs.addFlattenedValue(value.Field(i))
+			}
+		}
+	default:
+		vs.Add([]any{value.Interface()})
+	}
+}
@@ -0,0 +1,53 @@
+package valuegeneration
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValueSetAddFlattened tests that nested arrays, slices, and structs (as decoded from ABI data) are flattened into
+// their leaf values when added to a ValueSet, while bytes and fixed bytes are added as whole values.
+func TestValueSetAddFlattened(t *testing.T) {
+	// Define a struct resembling a decoded ABI tuple.
+	type tuple struct {
+		Account common.Address
+		Amounts []*big.Int
+		Ids     [2]uint64
+		Data    []byte
+		Tag     [4]byte
+		Name    string
+	}
+	address := common.HexToAddress("0x1234")
+	values := []any{
+		[]tuple{{
+			Account: address,
+			Amounts: []*big.Int{big.NewInt(100), big.NewInt(200)},
+			Ids:     [2]uint64{7, 8},
+			Data:    []byte{0xde, 0xad},
+			Tag:     [4]byte{0xbe, 0xef, 0xca, 0xfe},
+			Name:    "medusa",
+		}},
+		true,
+	}
+
+	// Add the values and verify each leaf value was added.
+	valueSet := NewValueSet()
+	valueSet.AddFlattened(values)
+	assert.True(t, valueSet.ContainsAddress(address))
+	for _, integer := range []int64{100, 200, 7, 8, 1} {
+		assert.True(t, valueSet.ContainsInteger(big.NewInt(integer)))
+	}
+	assert.True(t, valueSet.ContainsBytes([]byte{0xde, 0xad}))
+	assert.True(t, valueSet.ContainsBytes([]byte{0xbe, 0xef, 0xca, 0xfe}))
+	assert.True(t, valueSet.ContainsString("medusa"))
+
+	// Verify the same values are not flattened by Add, which only accepts primitive values.
+	valueSet = NewValueSet()
+	valueSet.Add(values)
+	assert.False(t, valueSet.ContainsAddress(address))
+	assert.False(t, valueSet.ContainsInteger(big.NewInt(100)))
+	assert.True(t, valueSet.ContainsInteger(big.NewInt(1)))
+}
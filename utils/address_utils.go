@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/hex"
+	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -30,6 +31,22 @@ func HexStringToAddress(addressHexString string) (common.Address, error) {
 	return address, nil
 }
 
+// ChecksummedHexStringToAddress converts an EIP-55 checksummed hex string (with the "0x" prefix) to a common.Address.
+// Returns the parsed address, or an error if the string is malformed or does not carry a valid checksum.
+func ChecksummedHexStringToAddress(addressHexString string) (common.Address, error) {
+	// Verify the string is a full length, "0x" prefixed address.
+	if !strings.HasPrefix(addressHexString, "0x") || !common.IsHexAddress(addressHexString) {
+		return common.Address{}, fmt.Errorf("malformed address string %q", addressHexString)
+	}
+
+	// The casing of the string must match the checksummed representation of the address exactly.
+	address := common.HexToAddress(addressHexString)
+	if address.Hex() != addressHexString {
+		return common.Address{}, fmt.Errorf("invalid checksum for address string %q, expected %q", addressHexString, address.Hex())
+	}
+	return address, nil
+}
+
 // HexStringsToAddresses converts hex strings (with or without the "0x" prefix) to common.Address objects. Returns the
 // parsed address, or an error if one occurs during conversion.
 func HexStringsToAddresses(addressHexStrings []string) ([]common.Address, error) {
@@ -2,8 +2,16 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
 )
 
 // GetPrivateKey will return a private key object given a byte slice. Only slices between lengths 1 and 32 (inclusive)
@@ -22,3 +30,66 @@ func GetPrivateKey(b []byte) (*ecdsa.PrivateKey, error) {
 	privateKey, err := crypto.ToECDSA(paddedPrivateKey[:])
 	return privateKey, errors.WithStack(err)
 }
+
+// DerivePrivateKey derives the private key at the provided index of the default Ethereum derivation path
+// (m/44'/60'/0'/0/index) for a BIP-39 mnemonic, as wallets do. The mnemonic is used without a passphrase.
+// Returns the derived private key, or an error if one occurs.
+func DerivePrivateKey(mnemonic string, index uint32) (*ecdsa.PrivateKey, error) {
+	// Obtain the BIP-39 seed for the mnemonic.
+	words := strings.Fields(mnemonic)
+	if len(words) == 0 {
+		return nil, errors.New("empty mnemonic")
+	}
+	seed := pbkdf2.Key([]byte(strings.Join(words, " ")), []byte("mnemonic"), 2048, 64, sha512.New)
+
+	// Obtain the BIP-32 master key, then derive each child key along the path.
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	digest := mac.Sum(nil)
+	privateKey, err := GetPrivateKey(digest[:32])
+	if err != nil {
+		return nil, err
+	}
+	chainCode := digest[32:]
+
+	const hardened = uint32(1) << 31
+	for _, childIndex := range []uint32{44 + hardened, 60 + hardened, hardened, 0, index} {
+		privateKey, chainCode, err = deriveChildPrivateKey(privateKey, chainCode, childIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return privateKey, nil
+}
+
+// deriveChildPrivateKey derives the BIP-32 child private key at the provided index from a parent private key and
+// chain code. Indexes of 2^31 and above describe hardened children.
+// Returns the child private key and chain code, or an error if the index yields an invalid key.
+func deriveChildPrivateKey(parentKey *ecdsa.PrivateKey, chainCode []byte, index uint32) (*ecdsa.PrivateKey, []byte, error) {
+	// Hardened children are derived from the parent private key, others from the parent public key.
+	var data []byte
+	if index >= 1<<31 {
+		data = append([]byte{0x00}, crypto.FromECDSA(parentKey)...)
+	} else {
+		data = crypto.CompressPubkey(&parentKey.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	digest := mac.Sum(nil)
+
+	// The child key is the parent key tweaked by the left half of the digest, modulo the curve order.
+	curveOrder := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(digest[:32])
+	if tweak.Cmp(curveOrder) >= 0 {
+		return nil, nil, errors.Errorf("invalid child key at index %d", index)
+	}
+	childKey := tweak.Add(tweak, parentKey.D)
+	childKey.Mod(childKey, curveOrder)
+	privateKey, err := crypto.ToECDSA(math.PaddedBigBytes(childKey, 32))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return privateKey, digest[32:], nil
+}